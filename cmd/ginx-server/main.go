@@ -36,6 +36,7 @@ var rootCmd = &cobra.Command{
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&ConfigFile, "config", "f", "conf.toml", "server configuration file")
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(usersCmd)
 }
 
 func main() {
@@ -43,21 +44,7 @@ func main() {
 }
 
 func NewServer(ctx context.Context, author, version, buildTime, configFile string) (*ginx.Server, error) {
-	// read config file
-	appConf, err := conf.ReadFrom(configFile)
-	if err != nil {
-		return nil, err
-	}
-
-	appConf.Meta = conf.MetaInfo{
-		AppName:   AppName,
-		Author:    author,
-		Version:   version,
-		BuildTime: buildTime,
-	}
-
-	// revise configuration
-	appConf, err = conf.Revise(appConf)
+	appConf, err := loadConfig(author, version, buildTime, configFile)
 	if err != nil {
 		return nil, err
 	}
@@ -80,3 +67,22 @@ func NewServer(ctx context.Context, author, version, buildTime, configFile strin
 	}
 	return app, nil
 }
+
+// loadConfig reads configuration from file and revises it with meta info
+func loadConfig(author, version, buildTime, configFile string) (conf.App, error) {
+	// read config file
+	appConf, err := conf.ReadFrom(configFile)
+	if err != nil {
+		return conf.App{}, err
+	}
+
+	appConf.Meta = conf.MetaInfo{
+		AppName:   AppName,
+		Author:    author,
+		Version:   version,
+		BuildTime: buildTime,
+	}
+
+	// revise configuration
+	return conf.Revise(appConf)
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/handler"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/internal/server"
+	"github.com/ginx-contribs/ginx-server/pkg/logh"
+	"github.com/spf13/cobra"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var usersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "import and export users in bulk",
+}
+
+var importOptions struct {
+	File   string
+	Format string
+	DryRun bool
+	Invite bool
+}
+
+var usersImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "import users from csv or json lines file, and print the report in json",
+	Long: `import users from csv or json lines file, and print the report in json.
+all rows are validated first, then valid rows are inserted in batches, each batch in a transaction.
+csv must have a header with username and email, optional columns are nickname, password and password_hash.
+json lines have the same keys, except that password_hash is passwordHash. unknown columns and keys are ignored.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := importOptions.Format
+		if format == "" {
+			format = strings.TrimPrefix(filepath.Ext(importOptions.File), ".")
+		}
+		if format != types.FormatCSV && format != types.FormatJSONL {
+			return fmt.Errorf("unsupported format %q, only csv and jsonl are allowed", format)
+		}
+		file, err := os.Open(importOptions.File)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		bulkHandler, closeAll, err := newBulkHandler(cmd, importOptions.Invite)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			logh.NoError("close connections failed", closeAll())
+		}()
+
+		option := types.ImportOptions{Format: format, DryRun: importOptions.DryRun, Invite: importOptions.Invite}
+		report, err := bulkHandler.Import(cmd.Context(), file, option, 0)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	},
+}
+
+var exportOptions struct {
+	Format string
+	Output string
+}
+
+var usersExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "export all users to csv or json lines file, which could be imported again",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if exportOptions.Format != types.FormatCSV && exportOptions.Format != types.FormatJSONL {
+			return fmt.Errorf("unsupported format %q, only csv and jsonl are allowed", exportOptions.Format)
+		}
+		bulkHandler, closeAll, err := newBulkHandler(cmd, false)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			logh.NoError("close connections failed", closeAll())
+		}()
+
+		output := exportOptions.Output
+		if output == "" {
+			output = "users." + exportOptions.Format
+		}
+		var writer io.Writer = cmd.OutOrStdout()
+		if output != "-" {
+			file, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			writer = file
+		}
+
+		exported, err := bulkHandler.Export(cmd.Context(), writer, exportOptions.Format)
+		if err != nil {
+			return err
+		}
+		if output != "-" {
+			fmt.Fprintf(cmd.OutOrStdout(), "%d users exported to %s\n", exported, output)
+		}
+		return nil
+	},
+}
+
+func init() {
+	usersImportCmd.Flags().StringVar(&importOptions.File, "file", "", "file of users to import")
+	usersImportCmd.Flags().StringVar(&importOptions.Format, "format", "", "csv | jsonl, inferred from file extension if empty")
+	usersImportCmd.Flags().BoolVar(&importOptions.DryRun, "dry-run", false, "only validate rows without importing")
+	usersImportCmd.Flags().BoolVar(&importOptions.Invite, "invite", false, "send invitation email to each imported user by the running server")
+	usersImportCmd.MarkFlagRequired("file")
+
+	usersExportCmd.Flags().StringVar(&exportOptions.Format, "format", types.FormatCSV, "csv | jsonl")
+	usersExportCmd.Flags().StringVarP(&exportOptions.Output, "output", "o", "", "output file, defaults to users.<format>, - means stdout")
+
+	usersCmd.AddCommand(usersImportCmd, usersExportCmd)
+}
+
+// newBulkHandler connects to datasource with the configuration, logs are written as the server does
+func newBulkHandler(cmd *cobra.Command, invite bool) (handler.BulkHandler, func() error, error) {
+	appConf, err := loadConfig(Author, Version, BuildTime, ConfigFile)
+	if err != nil {
+		return handler.BulkHandler{}, nil, err
+	}
+	logger, err := logh.NewLogger(appConf.Log)
+	if err != nil {
+		return handler.BulkHandler{}, nil, err
+	}
+	slog.SetDefault(logger.Slog())
+
+	bulkHandler, closeAll, err := server.NewBulkHandler(cmd.Context(), &appConf, logger, invite)
+	if err != nil {
+		return handler.BulkHandler{}, nil, errors.Join(err, logger.Close())
+	}
+	return bulkHandler, func() error {
+		return errors.Join(closeAll(), logger.Close())
+	}, nil
+}
@@ -0,0 +1,399 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ginx-contribs/ginx-server/ent/auditlog"
+	"github.com/ginx-contribs/ginx-server/ent/predicate"
+)
+
+// AuditLogUpdate is the builder for updating AuditLog entities.
+type AuditLogUpdate struct {
+	config
+	hooks     []Hook
+	mutation  *AuditLogMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// Where appends a list predicates to the AuditLogUpdate builder.
+func (alu *AuditLogUpdate) Where(ps ...predicate.AuditLog) *AuditLogUpdate {
+	alu.mutation.Where(ps...)
+	return alu
+}
+
+// SetActor sets the "actor" field.
+func (alu *AuditLogUpdate) SetActor(s string) *AuditLogUpdate {
+	alu.mutation.SetActor(s)
+	return alu
+}
+
+// SetNillableActor sets the "actor" field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableActor(s *string) *AuditLogUpdate {
+	if s != nil {
+		alu.SetActor(*s)
+	}
+	return alu
+}
+
+// SetAction sets the "action" field.
+func (alu *AuditLogUpdate) SetAction(s string) *AuditLogUpdate {
+	alu.mutation.SetAction(s)
+	return alu
+}
+
+// SetNillableAction sets the "action" field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableAction(s *string) *AuditLogUpdate {
+	if s != nil {
+		alu.SetAction(*s)
+	}
+	return alu
+}
+
+// SetTarget sets the "target" field.
+func (alu *AuditLogUpdate) SetTarget(s string) *AuditLogUpdate {
+	alu.mutation.SetTarget(s)
+	return alu
+}
+
+// SetNillableTarget sets the "target" field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableTarget(s *string) *AuditLogUpdate {
+	if s != nil {
+		alu.SetTarget(*s)
+	}
+	return alu
+}
+
+// SetDetail sets the "detail" field.
+func (alu *AuditLogUpdate) SetDetail(s string) *AuditLogUpdate {
+	alu.mutation.SetDetail(s)
+	return alu
+}
+
+// SetNillableDetail sets the "detail" field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableDetail(s *string) *AuditLogUpdate {
+	if s != nil {
+		alu.SetDetail(*s)
+	}
+	return alu
+}
+
+// ClearDetail clears the value of the "detail" field.
+func (alu *AuditLogUpdate) ClearDetail() *AuditLogUpdate {
+	alu.mutation.ClearDetail()
+	return alu
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (alu *AuditLogUpdate) SetCreatedAt(i int64) *AuditLogUpdate {
+	alu.mutation.ResetCreatedAt()
+	alu.mutation.SetCreatedAt(i)
+	return alu
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableCreatedAt(i *int64) *AuditLogUpdate {
+	if i != nil {
+		alu.SetCreatedAt(*i)
+	}
+	return alu
+}
+
+// AddCreatedAt adds i to the "created_at" field.
+func (alu *AuditLogUpdate) AddCreatedAt(i int64) *AuditLogUpdate {
+	alu.mutation.AddCreatedAt(i)
+	return alu
+}
+
+// Mutation returns the AuditLogMutation object of the builder.
+func (alu *AuditLogUpdate) Mutation() *AuditLogMutation {
+	return alu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (alu *AuditLogUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, alu.sqlSave, alu.mutation, alu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (alu *AuditLogUpdate) SaveX(ctx context.Context) int {
+	affected, err := alu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (alu *AuditLogUpdate) Exec(ctx context.Context) error {
+	_, err := alu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (alu *AuditLogUpdate) ExecX(ctx context.Context) {
+	if err := alu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (alu *AuditLogUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *AuditLogUpdate {
+	alu.modifiers = append(alu.modifiers, modifiers...)
+	return alu
+}
+
+func (alu *AuditLogUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(auditlog.Table, auditlog.Columns, sqlgraph.NewFieldSpec(auditlog.FieldID, field.TypeInt))
+	if ps := alu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := alu.mutation.Actor(); ok {
+		_spec.SetField(auditlog.FieldActor, field.TypeString, value)
+	}
+	if value, ok := alu.mutation.Action(); ok {
+		_spec.SetField(auditlog.FieldAction, field.TypeString, value)
+	}
+	if value, ok := alu.mutation.Target(); ok {
+		_spec.SetField(auditlog.FieldTarget, field.TypeString, value)
+	}
+	if value, ok := alu.mutation.Detail(); ok {
+		_spec.SetField(auditlog.FieldDetail, field.TypeString, value)
+	}
+	if alu.mutation.DetailCleared() {
+		_spec.ClearField(auditlog.FieldDetail, field.TypeString)
+	}
+	if value, ok := alu.mutation.CreatedAt(); ok {
+		_spec.SetField(auditlog.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := alu.mutation.AddedCreatedAt(); ok {
+		_spec.AddField(auditlog.FieldCreatedAt, field.TypeInt64, value)
+	}
+	_spec.AddModifiers(alu.modifiers...)
+	if n, err = sqlgraph.UpdateNodes(ctx, alu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{auditlog.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	alu.mutation.done = true
+	return n, nil
+}
+
+// AuditLogUpdateOne is the builder for updating a single AuditLog entity.
+type AuditLogUpdateOne struct {
+	config
+	fields    []string
+	hooks     []Hook
+	mutation  *AuditLogMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// SetActor sets the "actor" field.
+func (aluo *AuditLogUpdateOne) SetActor(s string) *AuditLogUpdateOne {
+	aluo.mutation.SetActor(s)
+	return aluo
+}
+
+// SetNillableActor sets the "actor" field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableActor(s *string) *AuditLogUpdateOne {
+	if s != nil {
+		aluo.SetActor(*s)
+	}
+	return aluo
+}
+
+// SetAction sets the "action" field.
+func (aluo *AuditLogUpdateOne) SetAction(s string) *AuditLogUpdateOne {
+	aluo.mutation.SetAction(s)
+	return aluo
+}
+
+// SetNillableAction sets the "action" field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableAction(s *string) *AuditLogUpdateOne {
+	if s != nil {
+		aluo.SetAction(*s)
+	}
+	return aluo
+}
+
+// SetTarget sets the "target" field.
+func (aluo *AuditLogUpdateOne) SetTarget(s string) *AuditLogUpdateOne {
+	aluo.mutation.SetTarget(s)
+	return aluo
+}
+
+// SetNillableTarget sets the "target" field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableTarget(s *string) *AuditLogUpdateOne {
+	if s != nil {
+		aluo.SetTarget(*s)
+	}
+	return aluo
+}
+
+// SetDetail sets the "detail" field.
+func (aluo *AuditLogUpdateOne) SetDetail(s string) *AuditLogUpdateOne {
+	aluo.mutation.SetDetail(s)
+	return aluo
+}
+
+// SetNillableDetail sets the "detail" field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableDetail(s *string) *AuditLogUpdateOne {
+	if s != nil {
+		aluo.SetDetail(*s)
+	}
+	return aluo
+}
+
+// ClearDetail clears the value of the "detail" field.
+func (aluo *AuditLogUpdateOne) ClearDetail() *AuditLogUpdateOne {
+	aluo.mutation.ClearDetail()
+	return aluo
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (aluo *AuditLogUpdateOne) SetCreatedAt(i int64) *AuditLogUpdateOne {
+	aluo.mutation.ResetCreatedAt()
+	aluo.mutation.SetCreatedAt(i)
+	return aluo
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableCreatedAt(i *int64) *AuditLogUpdateOne {
+	if i != nil {
+		aluo.SetCreatedAt(*i)
+	}
+	return aluo
+}
+
+// AddCreatedAt adds i to the "created_at" field.
+func (aluo *AuditLogUpdateOne) AddCreatedAt(i int64) *AuditLogUpdateOne {
+	aluo.mutation.AddCreatedAt(i)
+	return aluo
+}
+
+// Mutation returns the AuditLogMutation object of the builder.
+func (aluo *AuditLogUpdateOne) Mutation() *AuditLogMutation {
+	return aluo.mutation
+}
+
+// Where appends a list predicates to the AuditLogUpdate builder.
+func (aluo *AuditLogUpdateOne) Where(ps ...predicate.AuditLog) *AuditLogUpdateOne {
+	aluo.mutation.Where(ps...)
+	return aluo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (aluo *AuditLogUpdateOne) Select(field string, fields ...string) *AuditLogUpdateOne {
+	aluo.fields = append([]string{field}, fields...)
+	return aluo
+}
+
+// Save executes the query and returns the updated AuditLog entity.
+func (aluo *AuditLogUpdateOne) Save(ctx context.Context) (*AuditLog, error) {
+	return withHooks(ctx, aluo.sqlSave, aluo.mutation, aluo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (aluo *AuditLogUpdateOne) SaveX(ctx context.Context) *AuditLog {
+	node, err := aluo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (aluo *AuditLogUpdateOne) Exec(ctx context.Context) error {
+	_, err := aluo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aluo *AuditLogUpdateOne) ExecX(ctx context.Context) {
+	if err := aluo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (aluo *AuditLogUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *AuditLogUpdateOne {
+	aluo.modifiers = append(aluo.modifiers, modifiers...)
+	return aluo
+}
+
+func (aluo *AuditLogUpdateOne) sqlSave(ctx context.Context) (_node *AuditLog, err error) {
+	_spec := sqlgraph.NewUpdateSpec(auditlog.Table, auditlog.Columns, sqlgraph.NewFieldSpec(auditlog.FieldID, field.TypeInt))
+	id, ok := aluo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "AuditLog.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := aluo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, auditlog.FieldID)
+		for _, f := range fields {
+			if !auditlog.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != auditlog.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := aluo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := aluo.mutation.Actor(); ok {
+		_spec.SetField(auditlog.FieldActor, field.TypeString, value)
+	}
+	if value, ok := aluo.mutation.Action(); ok {
+		_spec.SetField(auditlog.FieldAction, field.TypeString, value)
+	}
+	if value, ok := aluo.mutation.Target(); ok {
+		_spec.SetField(auditlog.FieldTarget, field.TypeString, value)
+	}
+	if value, ok := aluo.mutation.Detail(); ok {
+		_spec.SetField(auditlog.FieldDetail, field.TypeString, value)
+	}
+	if aluo.mutation.DetailCleared() {
+		_spec.ClearField(auditlog.FieldDetail, field.TypeString)
+	}
+	if value, ok := aluo.mutation.CreatedAt(); ok {
+		_spec.SetField(auditlog.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := aluo.mutation.AddedCreatedAt(); ok {
+		_spec.AddField(auditlog.FieldCreatedAt, field.TypeInt64, value)
+	}
+	_spec.AddModifiers(aluo.modifiers...)
+	_node = &AuditLog{config: aluo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, aluo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{auditlog.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	aluo.mutation.done = true
+	return _node, nil
+}
@@ -12,6 +12,16 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/ginx-contribs/ginx-server/ent/auditlog"
+	"github.com/ginx-contribs/ginx-server/ent/identity"
+	"github.com/ginx-contribs/ginx-server/ent/loginhistory"
+	"github.com/ginx-contribs/ginx-server/ent/oauthclient"
+	"github.com/ginx-contribs/ginx-server/ent/passwordhistory"
+	"github.com/ginx-contribs/ginx-server/ent/permission"
+	"github.com/ginx-contribs/ginx-server/ent/personaltoken"
+	"github.com/ginx-contribs/ginx-server/ent/recoverycode"
+	"github.com/ginx-contribs/ginx-server/ent/role"
+	"github.com/ginx-contribs/ginx-server/ent/session"
 	"github.com/ginx-contribs/ginx-server/ent/user"
 )
 
@@ -73,7 +83,17 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			user.Table: user.ValidColumn,
+			auditlog.Table:        auditlog.ValidColumn,
+			identity.Table:        identity.ValidColumn,
+			loginhistory.Table:    loginhistory.ValidColumn,
+			oauthclient.Table:     oauthclient.ValidColumn,
+			passwordhistory.Table: passwordhistory.ValidColumn,
+			permission.Table:      permission.ValidColumn,
+			personaltoken.Table:   personaltoken.ValidColumn,
+			recoverycode.Table:    recoverycode.ValidColumn,
+			role.Table:            role.ValidColumn,
+			session.Table:         session.ValidColumn,
+			user.Table:            user.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
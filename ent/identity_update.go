@@ -0,0 +1,532 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ginx-contribs/ginx-server/ent/identity"
+	"github.com/ginx-contribs/ginx-server/ent/predicate"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+)
+
+// IdentityUpdate is the builder for updating Identity entities.
+type IdentityUpdate struct {
+	config
+	hooks     []Hook
+	mutation  *IdentityMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// Where appends a list predicates to the IdentityUpdate builder.
+func (iu *IdentityUpdate) Where(ps ...predicate.Identity) *IdentityUpdate {
+	iu.mutation.Where(ps...)
+	return iu
+}
+
+// SetUserID sets the "user_id" field.
+func (iu *IdentityUpdate) SetUserID(i int) *IdentityUpdate {
+	iu.mutation.SetUserID(i)
+	return iu
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (iu *IdentityUpdate) SetNillableUserID(i *int) *IdentityUpdate {
+	if i != nil {
+		iu.SetUserID(*i)
+	}
+	return iu
+}
+
+// SetProvider sets the "provider" field.
+func (iu *IdentityUpdate) SetProvider(s string) *IdentityUpdate {
+	iu.mutation.SetProvider(s)
+	return iu
+}
+
+// SetNillableProvider sets the "provider" field if the given value is not nil.
+func (iu *IdentityUpdate) SetNillableProvider(s *string) *IdentityUpdate {
+	if s != nil {
+		iu.SetProvider(*s)
+	}
+	return iu
+}
+
+// SetSubject sets the "subject" field.
+func (iu *IdentityUpdate) SetSubject(s string) *IdentityUpdate {
+	iu.mutation.SetSubject(s)
+	return iu
+}
+
+// SetNillableSubject sets the "subject" field if the given value is not nil.
+func (iu *IdentityUpdate) SetNillableSubject(s *string) *IdentityUpdate {
+	if s != nil {
+		iu.SetSubject(*s)
+	}
+	return iu
+}
+
+// SetEmail sets the "email" field.
+func (iu *IdentityUpdate) SetEmail(s string) *IdentityUpdate {
+	iu.mutation.SetEmail(s)
+	return iu
+}
+
+// SetNillableEmail sets the "email" field if the given value is not nil.
+func (iu *IdentityUpdate) SetNillableEmail(s *string) *IdentityUpdate {
+	if s != nil {
+		iu.SetEmail(*s)
+	}
+	return iu
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (iu *IdentityUpdate) SetCreatedAt(i int64) *IdentityUpdate {
+	iu.mutation.ResetCreatedAt()
+	iu.mutation.SetCreatedAt(i)
+	return iu
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (iu *IdentityUpdate) SetNillableCreatedAt(i *int64) *IdentityUpdate {
+	if i != nil {
+		iu.SetCreatedAt(*i)
+	}
+	return iu
+}
+
+// AddCreatedAt adds i to the "created_at" field.
+func (iu *IdentityUpdate) AddCreatedAt(i int64) *IdentityUpdate {
+	iu.mutation.AddCreatedAt(i)
+	return iu
+}
+
+// SetLastLoginAt sets the "last_login_at" field.
+func (iu *IdentityUpdate) SetLastLoginAt(i int64) *IdentityUpdate {
+	iu.mutation.ResetLastLoginAt()
+	iu.mutation.SetLastLoginAt(i)
+	return iu
+}
+
+// SetNillableLastLoginAt sets the "last_login_at" field if the given value is not nil.
+func (iu *IdentityUpdate) SetNillableLastLoginAt(i *int64) *IdentityUpdate {
+	if i != nil {
+		iu.SetLastLoginAt(*i)
+	}
+	return iu
+}
+
+// AddLastLoginAt adds i to the "last_login_at" field.
+func (iu *IdentityUpdate) AddLastLoginAt(i int64) *IdentityUpdate {
+	iu.mutation.AddLastLoginAt(i)
+	return iu
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (iu *IdentityUpdate) SetUser(u *User) *IdentityUpdate {
+	return iu.SetUserID(u.ID)
+}
+
+// Mutation returns the IdentityMutation object of the builder.
+func (iu *IdentityUpdate) Mutation() *IdentityMutation {
+	return iu.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (iu *IdentityUpdate) ClearUser() *IdentityUpdate {
+	iu.mutation.ClearUser()
+	return iu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (iu *IdentityUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, iu.sqlSave, iu.mutation, iu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (iu *IdentityUpdate) SaveX(ctx context.Context) int {
+	affected, err := iu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (iu *IdentityUpdate) Exec(ctx context.Context) error {
+	_, err := iu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (iu *IdentityUpdate) ExecX(ctx context.Context) {
+	if err := iu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (iu *IdentityUpdate) check() error {
+	if iu.mutation.UserCleared() && len(iu.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Identity.user"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (iu *IdentityUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *IdentityUpdate {
+	iu.modifiers = append(iu.modifiers, modifiers...)
+	return iu
+}
+
+func (iu *IdentityUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := iu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(identity.Table, identity.Columns, sqlgraph.NewFieldSpec(identity.FieldID, field.TypeInt))
+	if ps := iu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := iu.mutation.Provider(); ok {
+		_spec.SetField(identity.FieldProvider, field.TypeString, value)
+	}
+	if value, ok := iu.mutation.Subject(); ok {
+		_spec.SetField(identity.FieldSubject, field.TypeString, value)
+	}
+	if value, ok := iu.mutation.Email(); ok {
+		_spec.SetField(identity.FieldEmail, field.TypeString, value)
+	}
+	if value, ok := iu.mutation.CreatedAt(); ok {
+		_spec.SetField(identity.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := iu.mutation.AddedCreatedAt(); ok {
+		_spec.AddField(identity.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := iu.mutation.LastLoginAt(); ok {
+		_spec.SetField(identity.FieldLastLoginAt, field.TypeInt64, value)
+	}
+	if value, ok := iu.mutation.AddedLastLoginAt(); ok {
+		_spec.AddField(identity.FieldLastLoginAt, field.TypeInt64, value)
+	}
+	if iu.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   identity.UserTable,
+			Columns: []string{identity.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := iu.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   identity.UserTable,
+			Columns: []string{identity.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(iu.modifiers...)
+	if n, err = sqlgraph.UpdateNodes(ctx, iu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{identity.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	iu.mutation.done = true
+	return n, nil
+}
+
+// IdentityUpdateOne is the builder for updating a single Identity entity.
+type IdentityUpdateOne struct {
+	config
+	fields    []string
+	hooks     []Hook
+	mutation  *IdentityMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// SetUserID sets the "user_id" field.
+func (iuo *IdentityUpdateOne) SetUserID(i int) *IdentityUpdateOne {
+	iuo.mutation.SetUserID(i)
+	return iuo
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (iuo *IdentityUpdateOne) SetNillableUserID(i *int) *IdentityUpdateOne {
+	if i != nil {
+		iuo.SetUserID(*i)
+	}
+	return iuo
+}
+
+// SetProvider sets the "provider" field.
+func (iuo *IdentityUpdateOne) SetProvider(s string) *IdentityUpdateOne {
+	iuo.mutation.SetProvider(s)
+	return iuo
+}
+
+// SetNillableProvider sets the "provider" field if the given value is not nil.
+func (iuo *IdentityUpdateOne) SetNillableProvider(s *string) *IdentityUpdateOne {
+	if s != nil {
+		iuo.SetProvider(*s)
+	}
+	return iuo
+}
+
+// SetSubject sets the "subject" field.
+func (iuo *IdentityUpdateOne) SetSubject(s string) *IdentityUpdateOne {
+	iuo.mutation.SetSubject(s)
+	return iuo
+}
+
+// SetNillableSubject sets the "subject" field if the given value is not nil.
+func (iuo *IdentityUpdateOne) SetNillableSubject(s *string) *IdentityUpdateOne {
+	if s != nil {
+		iuo.SetSubject(*s)
+	}
+	return iuo
+}
+
+// SetEmail sets the "email" field.
+func (iuo *IdentityUpdateOne) SetEmail(s string) *IdentityUpdateOne {
+	iuo.mutation.SetEmail(s)
+	return iuo
+}
+
+// SetNillableEmail sets the "email" field if the given value is not nil.
+func (iuo *IdentityUpdateOne) SetNillableEmail(s *string) *IdentityUpdateOne {
+	if s != nil {
+		iuo.SetEmail(*s)
+	}
+	return iuo
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (iuo *IdentityUpdateOne) SetCreatedAt(i int64) *IdentityUpdateOne {
+	iuo.mutation.ResetCreatedAt()
+	iuo.mutation.SetCreatedAt(i)
+	return iuo
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (iuo *IdentityUpdateOne) SetNillableCreatedAt(i *int64) *IdentityUpdateOne {
+	if i != nil {
+		iuo.SetCreatedAt(*i)
+	}
+	return iuo
+}
+
+// AddCreatedAt adds i to the "created_at" field.
+func (iuo *IdentityUpdateOne) AddCreatedAt(i int64) *IdentityUpdateOne {
+	iuo.mutation.AddCreatedAt(i)
+	return iuo
+}
+
+// SetLastLoginAt sets the "last_login_at" field.
+func (iuo *IdentityUpdateOne) SetLastLoginAt(i int64) *IdentityUpdateOne {
+	iuo.mutation.ResetLastLoginAt()
+	iuo.mutation.SetLastLoginAt(i)
+	return iuo
+}
+
+// SetNillableLastLoginAt sets the "last_login_at" field if the given value is not nil.
+func (iuo *IdentityUpdateOne) SetNillableLastLoginAt(i *int64) *IdentityUpdateOne {
+	if i != nil {
+		iuo.SetLastLoginAt(*i)
+	}
+	return iuo
+}
+
+// AddLastLoginAt adds i to the "last_login_at" field.
+func (iuo *IdentityUpdateOne) AddLastLoginAt(i int64) *IdentityUpdateOne {
+	iuo.mutation.AddLastLoginAt(i)
+	return iuo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (iuo *IdentityUpdateOne) SetUser(u *User) *IdentityUpdateOne {
+	return iuo.SetUserID(u.ID)
+}
+
+// Mutation returns the IdentityMutation object of the builder.
+func (iuo *IdentityUpdateOne) Mutation() *IdentityMutation {
+	return iuo.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (iuo *IdentityUpdateOne) ClearUser() *IdentityUpdateOne {
+	iuo.mutation.ClearUser()
+	return iuo
+}
+
+// Where appends a list predicates to the IdentityUpdate builder.
+func (iuo *IdentityUpdateOne) Where(ps ...predicate.Identity) *IdentityUpdateOne {
+	iuo.mutation.Where(ps...)
+	return iuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (iuo *IdentityUpdateOne) Select(field string, fields ...string) *IdentityUpdateOne {
+	iuo.fields = append([]string{field}, fields...)
+	return iuo
+}
+
+// Save executes the query and returns the updated Identity entity.
+func (iuo *IdentityUpdateOne) Save(ctx context.Context) (*Identity, error) {
+	return withHooks(ctx, iuo.sqlSave, iuo.mutation, iuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (iuo *IdentityUpdateOne) SaveX(ctx context.Context) *Identity {
+	node, err := iuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (iuo *IdentityUpdateOne) Exec(ctx context.Context) error {
+	_, err := iuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (iuo *IdentityUpdateOne) ExecX(ctx context.Context) {
+	if err := iuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (iuo *IdentityUpdateOne) check() error {
+	if iuo.mutation.UserCleared() && len(iuo.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Identity.user"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (iuo *IdentityUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *IdentityUpdateOne {
+	iuo.modifiers = append(iuo.modifiers, modifiers...)
+	return iuo
+}
+
+func (iuo *IdentityUpdateOne) sqlSave(ctx context.Context) (_node *Identity, err error) {
+	if err := iuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(identity.Table, identity.Columns, sqlgraph.NewFieldSpec(identity.FieldID, field.TypeInt))
+	id, ok := iuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Identity.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := iuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, identity.FieldID)
+		for _, f := range fields {
+			if !identity.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != identity.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := iuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := iuo.mutation.Provider(); ok {
+		_spec.SetField(identity.FieldProvider, field.TypeString, value)
+	}
+	if value, ok := iuo.mutation.Subject(); ok {
+		_spec.SetField(identity.FieldSubject, field.TypeString, value)
+	}
+	if value, ok := iuo.mutation.Email(); ok {
+		_spec.SetField(identity.FieldEmail, field.TypeString, value)
+	}
+	if value, ok := iuo.mutation.CreatedAt(); ok {
+		_spec.SetField(identity.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := iuo.mutation.AddedCreatedAt(); ok {
+		_spec.AddField(identity.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := iuo.mutation.LastLoginAt(); ok {
+		_spec.SetField(identity.FieldLastLoginAt, field.TypeInt64, value)
+	}
+	if value, ok := iuo.mutation.AddedLastLoginAt(); ok {
+		_spec.AddField(identity.FieldLastLoginAt, field.TypeInt64, value)
+	}
+	if iuo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   identity.UserTable,
+			Columns: []string{identity.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := iuo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   identity.UserTable,
+			Columns: []string{identity.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(iuo.modifiers...)
+	_node = &Identity{config: iuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, iuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{identity.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	iuo.mutation.done = true
+	return _node, nil
+}
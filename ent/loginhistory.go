@@ -0,0 +1,176 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/ginx-contribs/ginx-server/ent/loginhistory"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+)
+
+// successful logins of users, they are kept after sessions ended
+type LoginHistory struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// UserID holds the value of the "user_id" field.
+	UserID int `json:"user_id,omitempty"`
+	// id of the session created by login
+	Sid string `json:"sid,omitempty"`
+	// UserAgent holds the value of the "user_agent" field.
+	UserAgent string `json:"user_agent,omitempty"`
+	// IP holds the value of the "ip" field.
+	IP string `json:"ip,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt int64 `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the LoginHistoryQuery when eager-loading is set.
+	Edges        LoginHistoryEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// LoginHistoryEdges holds the relations/edges for other nodes in the graph.
+type LoginHistoryEdges struct {
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e LoginHistoryEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*LoginHistory) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case loginhistory.FieldID, loginhistory.FieldUserID, loginhistory.FieldCreatedAt:
+			values[i] = new(sql.NullInt64)
+		case loginhistory.FieldSid, loginhistory.FieldUserAgent, loginhistory.FieldIP:
+			values[i] = new(sql.NullString)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the LoginHistory fields.
+func (lh *LoginHistory) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case loginhistory.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			lh.ID = int(value.Int64)
+		case loginhistory.FieldUserID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value.Valid {
+				lh.UserID = int(value.Int64)
+			}
+		case loginhistory.FieldSid:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field sid", values[i])
+			} else if value.Valid {
+				lh.Sid = value.String
+			}
+		case loginhistory.FieldUserAgent:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field user_agent", values[i])
+			} else if value.Valid {
+				lh.UserAgent = value.String
+			}
+		case loginhistory.FieldIP:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field ip", values[i])
+			} else if value.Valid {
+				lh.IP = value.String
+			}
+		case loginhistory.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				lh.CreatedAt = value.Int64
+			}
+		default:
+			lh.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the LoginHistory.
+// This includes values selected through modifiers, order, etc.
+func (lh *LoginHistory) Value(name string) (ent.Value, error) {
+	return lh.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the LoginHistory entity.
+func (lh *LoginHistory) QueryUser() *UserQuery {
+	return NewLoginHistoryClient(lh.config).QueryUser(lh)
+}
+
+// Update returns a builder for updating this LoginHistory.
+// Note that you need to call LoginHistory.Unwrap() before calling this method if this LoginHistory
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (lh *LoginHistory) Update() *LoginHistoryUpdateOne {
+	return NewLoginHistoryClient(lh.config).UpdateOne(lh)
+}
+
+// Unwrap unwraps the LoginHistory entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (lh *LoginHistory) Unwrap() *LoginHistory {
+	_tx, ok := lh.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: LoginHistory is not a transactional entity")
+	}
+	lh.config.driver = _tx.drv
+	return lh
+}
+
+// String implements the fmt.Stringer.
+func (lh *LoginHistory) String() string {
+	var builder strings.Builder
+	builder.WriteString("LoginHistory(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", lh.ID))
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", lh.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("sid=")
+	builder.WriteString(lh.Sid)
+	builder.WriteString(", ")
+	builder.WriteString("user_agent=")
+	builder.WriteString(lh.UserAgent)
+	builder.WriteString(", ")
+	builder.WriteString("ip=")
+	builder.WriteString(lh.IP)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(fmt.Sprintf("%v", lh.CreatedAt))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// LoginHistories is a parsable slice of LoginHistory.
+type LoginHistories []*LoginHistory
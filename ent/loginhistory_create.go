@@ -0,0 +1,750 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ginx-contribs/ginx-server/ent/loginhistory"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+)
+
+// LoginHistoryCreate is the builder for creating a LoginHistory entity.
+type LoginHistoryCreate struct {
+	config
+	mutation *LoginHistoryMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetUserID sets the "user_id" field.
+func (lhc *LoginHistoryCreate) SetUserID(i int) *LoginHistoryCreate {
+	lhc.mutation.SetUserID(i)
+	return lhc
+}
+
+// SetSid sets the "sid" field.
+func (lhc *LoginHistoryCreate) SetSid(s string) *LoginHistoryCreate {
+	lhc.mutation.SetSid(s)
+	return lhc
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (lhc *LoginHistoryCreate) SetUserAgent(s string) *LoginHistoryCreate {
+	lhc.mutation.SetUserAgent(s)
+	return lhc
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (lhc *LoginHistoryCreate) SetNillableUserAgent(s *string) *LoginHistoryCreate {
+	if s != nil {
+		lhc.SetUserAgent(*s)
+	}
+	return lhc
+}
+
+// SetIP sets the "ip" field.
+func (lhc *LoginHistoryCreate) SetIP(s string) *LoginHistoryCreate {
+	lhc.mutation.SetIP(s)
+	return lhc
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (lhc *LoginHistoryCreate) SetNillableIP(s *string) *LoginHistoryCreate {
+	if s != nil {
+		lhc.SetIP(*s)
+	}
+	return lhc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (lhc *LoginHistoryCreate) SetCreatedAt(i int64) *LoginHistoryCreate {
+	lhc.mutation.SetCreatedAt(i)
+	return lhc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (lhc *LoginHistoryCreate) SetNillableCreatedAt(i *int64) *LoginHistoryCreate {
+	if i != nil {
+		lhc.SetCreatedAt(*i)
+	}
+	return lhc
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (lhc *LoginHistoryCreate) SetUser(u *User) *LoginHistoryCreate {
+	return lhc.SetUserID(u.ID)
+}
+
+// Mutation returns the LoginHistoryMutation object of the builder.
+func (lhc *LoginHistoryCreate) Mutation() *LoginHistoryMutation {
+	return lhc.mutation
+}
+
+// Save creates the LoginHistory in the database.
+func (lhc *LoginHistoryCreate) Save(ctx context.Context) (*LoginHistory, error) {
+	lhc.defaults()
+	return withHooks(ctx, lhc.sqlSave, lhc.mutation, lhc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (lhc *LoginHistoryCreate) SaveX(ctx context.Context) *LoginHistory {
+	v, err := lhc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (lhc *LoginHistoryCreate) Exec(ctx context.Context) error {
+	_, err := lhc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (lhc *LoginHistoryCreate) ExecX(ctx context.Context) {
+	if err := lhc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (lhc *LoginHistoryCreate) defaults() {
+	if _, ok := lhc.mutation.UserAgent(); !ok {
+		v := loginhistory.DefaultUserAgent
+		lhc.mutation.SetUserAgent(v)
+	}
+	if _, ok := lhc.mutation.IP(); !ok {
+		v := loginhistory.DefaultIP
+		lhc.mutation.SetIP(v)
+	}
+	if _, ok := lhc.mutation.CreatedAt(); !ok {
+		v := loginhistory.DefaultCreatedAt()
+		lhc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (lhc *LoginHistoryCreate) check() error {
+	if _, ok := lhc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "LoginHistory.user_id"`)}
+	}
+	if _, ok := lhc.mutation.Sid(); !ok {
+		return &ValidationError{Name: "sid", err: errors.New(`ent: missing required field "LoginHistory.sid"`)}
+	}
+	if _, ok := lhc.mutation.UserAgent(); !ok {
+		return &ValidationError{Name: "user_agent", err: errors.New(`ent: missing required field "LoginHistory.user_agent"`)}
+	}
+	if _, ok := lhc.mutation.IP(); !ok {
+		return &ValidationError{Name: "ip", err: errors.New(`ent: missing required field "LoginHistory.ip"`)}
+	}
+	if _, ok := lhc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "LoginHistory.created_at"`)}
+	}
+	if len(lhc.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "LoginHistory.user"`)}
+	}
+	return nil
+}
+
+func (lhc *LoginHistoryCreate) sqlSave(ctx context.Context) (*LoginHistory, error) {
+	if err := lhc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := lhc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, lhc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	lhc.mutation.id = &_node.ID
+	lhc.mutation.done = true
+	return _node, nil
+}
+
+func (lhc *LoginHistoryCreate) createSpec() (*LoginHistory, *sqlgraph.CreateSpec) {
+	var (
+		_node = &LoginHistory{config: lhc.config}
+		_spec = sqlgraph.NewCreateSpec(loginhistory.Table, sqlgraph.NewFieldSpec(loginhistory.FieldID, field.TypeInt))
+	)
+	_spec.OnConflict = lhc.conflict
+	if value, ok := lhc.mutation.Sid(); ok {
+		_spec.SetField(loginhistory.FieldSid, field.TypeString, value)
+		_node.Sid = value
+	}
+	if value, ok := lhc.mutation.UserAgent(); ok {
+		_spec.SetField(loginhistory.FieldUserAgent, field.TypeString, value)
+		_node.UserAgent = value
+	}
+	if value, ok := lhc.mutation.IP(); ok {
+		_spec.SetField(loginhistory.FieldIP, field.TypeString, value)
+		_node.IP = value
+	}
+	if value, ok := lhc.mutation.CreatedAt(); ok {
+		_spec.SetField(loginhistory.FieldCreatedAt, field.TypeInt64, value)
+		_node.CreatedAt = value
+	}
+	if nodes := lhc.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   loginhistory.UserTable,
+			Columns: []string{loginhistory.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.UserID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.LoginHistory.Create().
+//		SetUserID(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.LoginHistoryUpsert) {
+//			SetUserID(v+v).
+//		}).
+//		Exec(ctx)
+func (lhc *LoginHistoryCreate) OnConflict(opts ...sql.ConflictOption) *LoginHistoryUpsertOne {
+	lhc.conflict = opts
+	return &LoginHistoryUpsertOne{
+		create: lhc,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.LoginHistory.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (lhc *LoginHistoryCreate) OnConflictColumns(columns ...string) *LoginHistoryUpsertOne {
+	lhc.conflict = append(lhc.conflict, sql.ConflictColumns(columns...))
+	return &LoginHistoryUpsertOne{
+		create: lhc,
+	}
+}
+
+type (
+	// LoginHistoryUpsertOne is the builder for "upsert"-ing
+	//  one LoginHistory node.
+	LoginHistoryUpsertOne struct {
+		create *LoginHistoryCreate
+	}
+
+	// LoginHistoryUpsert is the "OnConflict" setter.
+	LoginHistoryUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetUserID sets the "user_id" field.
+func (u *LoginHistoryUpsert) SetUserID(v int) *LoginHistoryUpsert {
+	u.Set(loginhistory.FieldUserID, v)
+	return u
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *LoginHistoryUpsert) UpdateUserID() *LoginHistoryUpsert {
+	u.SetExcluded(loginhistory.FieldUserID)
+	return u
+}
+
+// SetSid sets the "sid" field.
+func (u *LoginHistoryUpsert) SetSid(v string) *LoginHistoryUpsert {
+	u.Set(loginhistory.FieldSid, v)
+	return u
+}
+
+// UpdateSid sets the "sid" field to the value that was provided on create.
+func (u *LoginHistoryUpsert) UpdateSid() *LoginHistoryUpsert {
+	u.SetExcluded(loginhistory.FieldSid)
+	return u
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (u *LoginHistoryUpsert) SetUserAgent(v string) *LoginHistoryUpsert {
+	u.Set(loginhistory.FieldUserAgent, v)
+	return u
+}
+
+// UpdateUserAgent sets the "user_agent" field to the value that was provided on create.
+func (u *LoginHistoryUpsert) UpdateUserAgent() *LoginHistoryUpsert {
+	u.SetExcluded(loginhistory.FieldUserAgent)
+	return u
+}
+
+// SetIP sets the "ip" field.
+func (u *LoginHistoryUpsert) SetIP(v string) *LoginHistoryUpsert {
+	u.Set(loginhistory.FieldIP, v)
+	return u
+}
+
+// UpdateIP sets the "ip" field to the value that was provided on create.
+func (u *LoginHistoryUpsert) UpdateIP() *LoginHistoryUpsert {
+	u.SetExcluded(loginhistory.FieldIP)
+	return u
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *LoginHistoryUpsert) SetCreatedAt(v int64) *LoginHistoryUpsert {
+	u.Set(loginhistory.FieldCreatedAt, v)
+	return u
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *LoginHistoryUpsert) UpdateCreatedAt() *LoginHistoryUpsert {
+	u.SetExcluded(loginhistory.FieldCreatedAt)
+	return u
+}
+
+// AddCreatedAt adds v to the "created_at" field.
+func (u *LoginHistoryUpsert) AddCreatedAt(v int64) *LoginHistoryUpsert {
+	u.Add(loginhistory.FieldCreatedAt, v)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.LoginHistory.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *LoginHistoryUpsertOne) UpdateNewValues() *LoginHistoryUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.LoginHistory.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *LoginHistoryUpsertOne) Ignore() *LoginHistoryUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *LoginHistoryUpsertOne) DoNothing() *LoginHistoryUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the LoginHistoryCreate.OnConflict
+// documentation for more info.
+func (u *LoginHistoryUpsertOne) Update(set func(*LoginHistoryUpsert)) *LoginHistoryUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&LoginHistoryUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUserID sets the "user_id" field.
+func (u *LoginHistoryUpsertOne) SetUserID(v int) *LoginHistoryUpsertOne {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *LoginHistoryUpsertOne) UpdateUserID() *LoginHistoryUpsertOne {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetSid sets the "sid" field.
+func (u *LoginHistoryUpsertOne) SetSid(v string) *LoginHistoryUpsertOne {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.SetSid(v)
+	})
+}
+
+// UpdateSid sets the "sid" field to the value that was provided on create.
+func (u *LoginHistoryUpsertOne) UpdateSid() *LoginHistoryUpsertOne {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.UpdateSid()
+	})
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (u *LoginHistoryUpsertOne) SetUserAgent(v string) *LoginHistoryUpsertOne {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.SetUserAgent(v)
+	})
+}
+
+// UpdateUserAgent sets the "user_agent" field to the value that was provided on create.
+func (u *LoginHistoryUpsertOne) UpdateUserAgent() *LoginHistoryUpsertOne {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.UpdateUserAgent()
+	})
+}
+
+// SetIP sets the "ip" field.
+func (u *LoginHistoryUpsertOne) SetIP(v string) *LoginHistoryUpsertOne {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.SetIP(v)
+	})
+}
+
+// UpdateIP sets the "ip" field to the value that was provided on create.
+func (u *LoginHistoryUpsertOne) UpdateIP() *LoginHistoryUpsertOne {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.UpdateIP()
+	})
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *LoginHistoryUpsertOne) SetCreatedAt(v int64) *LoginHistoryUpsertOne {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.SetCreatedAt(v)
+	})
+}
+
+// AddCreatedAt adds v to the "created_at" field.
+func (u *LoginHistoryUpsertOne) AddCreatedAt(v int64) *LoginHistoryUpsertOne {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.AddCreatedAt(v)
+	})
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *LoginHistoryUpsertOne) UpdateCreatedAt() *LoginHistoryUpsertOne {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.UpdateCreatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *LoginHistoryUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for LoginHistoryCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *LoginHistoryUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *LoginHistoryUpsertOne) ID(ctx context.Context) (id int, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *LoginHistoryUpsertOne) IDX(ctx context.Context) int {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// LoginHistoryCreateBulk is the builder for creating many LoginHistory entities in bulk.
+type LoginHistoryCreateBulk struct {
+	config
+	err      error
+	builders []*LoginHistoryCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the LoginHistory entities in the database.
+func (lhcb *LoginHistoryCreateBulk) Save(ctx context.Context) ([]*LoginHistory, error) {
+	if lhcb.err != nil {
+		return nil, lhcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(lhcb.builders))
+	nodes := make([]*LoginHistory, len(lhcb.builders))
+	mutators := make([]Mutator, len(lhcb.builders))
+	for i := range lhcb.builders {
+		func(i int, root context.Context) {
+			builder := lhcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*LoginHistoryMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, lhcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = lhcb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, lhcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, lhcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (lhcb *LoginHistoryCreateBulk) SaveX(ctx context.Context) []*LoginHistory {
+	v, err := lhcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (lhcb *LoginHistoryCreateBulk) Exec(ctx context.Context) error {
+	_, err := lhcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (lhcb *LoginHistoryCreateBulk) ExecX(ctx context.Context) {
+	if err := lhcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.LoginHistory.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.LoginHistoryUpsert) {
+//			SetUserID(v+v).
+//		}).
+//		Exec(ctx)
+func (lhcb *LoginHistoryCreateBulk) OnConflict(opts ...sql.ConflictOption) *LoginHistoryUpsertBulk {
+	lhcb.conflict = opts
+	return &LoginHistoryUpsertBulk{
+		create: lhcb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.LoginHistory.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (lhcb *LoginHistoryCreateBulk) OnConflictColumns(columns ...string) *LoginHistoryUpsertBulk {
+	lhcb.conflict = append(lhcb.conflict, sql.ConflictColumns(columns...))
+	return &LoginHistoryUpsertBulk{
+		create: lhcb,
+	}
+}
+
+// LoginHistoryUpsertBulk is the builder for "upsert"-ing
+// a bulk of LoginHistory nodes.
+type LoginHistoryUpsertBulk struct {
+	create *LoginHistoryCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.LoginHistory.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *LoginHistoryUpsertBulk) UpdateNewValues() *LoginHistoryUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.LoginHistory.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *LoginHistoryUpsertBulk) Ignore() *LoginHistoryUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *LoginHistoryUpsertBulk) DoNothing() *LoginHistoryUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the LoginHistoryCreateBulk.OnConflict
+// documentation for more info.
+func (u *LoginHistoryUpsertBulk) Update(set func(*LoginHistoryUpsert)) *LoginHistoryUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&LoginHistoryUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUserID sets the "user_id" field.
+func (u *LoginHistoryUpsertBulk) SetUserID(v int) *LoginHistoryUpsertBulk {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *LoginHistoryUpsertBulk) UpdateUserID() *LoginHistoryUpsertBulk {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetSid sets the "sid" field.
+func (u *LoginHistoryUpsertBulk) SetSid(v string) *LoginHistoryUpsertBulk {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.SetSid(v)
+	})
+}
+
+// UpdateSid sets the "sid" field to the value that was provided on create.
+func (u *LoginHistoryUpsertBulk) UpdateSid() *LoginHistoryUpsertBulk {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.UpdateSid()
+	})
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (u *LoginHistoryUpsertBulk) SetUserAgent(v string) *LoginHistoryUpsertBulk {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.SetUserAgent(v)
+	})
+}
+
+// UpdateUserAgent sets the "user_agent" field to the value that was provided on create.
+func (u *LoginHistoryUpsertBulk) UpdateUserAgent() *LoginHistoryUpsertBulk {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.UpdateUserAgent()
+	})
+}
+
+// SetIP sets the "ip" field.
+func (u *LoginHistoryUpsertBulk) SetIP(v string) *LoginHistoryUpsertBulk {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.SetIP(v)
+	})
+}
+
+// UpdateIP sets the "ip" field to the value that was provided on create.
+func (u *LoginHistoryUpsertBulk) UpdateIP() *LoginHistoryUpsertBulk {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.UpdateIP()
+	})
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *LoginHistoryUpsertBulk) SetCreatedAt(v int64) *LoginHistoryUpsertBulk {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.SetCreatedAt(v)
+	})
+}
+
+// AddCreatedAt adds v to the "created_at" field.
+func (u *LoginHistoryUpsertBulk) AddCreatedAt(v int64) *LoginHistoryUpsertBulk {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.AddCreatedAt(v)
+	})
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *LoginHistoryUpsertBulk) UpdateCreatedAt() *LoginHistoryUpsertBulk {
+	return u.Update(func(s *LoginHistoryUpsert) {
+		s.UpdateCreatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *LoginHistoryUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the LoginHistoryCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for LoginHistoryCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *LoginHistoryUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
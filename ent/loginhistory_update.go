@@ -0,0 +1,478 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ginx-contribs/ginx-server/ent/loginhistory"
+	"github.com/ginx-contribs/ginx-server/ent/predicate"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+)
+
+// LoginHistoryUpdate is the builder for updating LoginHistory entities.
+type LoginHistoryUpdate struct {
+	config
+	hooks     []Hook
+	mutation  *LoginHistoryMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// Where appends a list predicates to the LoginHistoryUpdate builder.
+func (lhu *LoginHistoryUpdate) Where(ps ...predicate.LoginHistory) *LoginHistoryUpdate {
+	lhu.mutation.Where(ps...)
+	return lhu
+}
+
+// SetUserID sets the "user_id" field.
+func (lhu *LoginHistoryUpdate) SetUserID(i int) *LoginHistoryUpdate {
+	lhu.mutation.SetUserID(i)
+	return lhu
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (lhu *LoginHistoryUpdate) SetNillableUserID(i *int) *LoginHistoryUpdate {
+	if i != nil {
+		lhu.SetUserID(*i)
+	}
+	return lhu
+}
+
+// SetSid sets the "sid" field.
+func (lhu *LoginHistoryUpdate) SetSid(s string) *LoginHistoryUpdate {
+	lhu.mutation.SetSid(s)
+	return lhu
+}
+
+// SetNillableSid sets the "sid" field if the given value is not nil.
+func (lhu *LoginHistoryUpdate) SetNillableSid(s *string) *LoginHistoryUpdate {
+	if s != nil {
+		lhu.SetSid(*s)
+	}
+	return lhu
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (lhu *LoginHistoryUpdate) SetUserAgent(s string) *LoginHistoryUpdate {
+	lhu.mutation.SetUserAgent(s)
+	return lhu
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (lhu *LoginHistoryUpdate) SetNillableUserAgent(s *string) *LoginHistoryUpdate {
+	if s != nil {
+		lhu.SetUserAgent(*s)
+	}
+	return lhu
+}
+
+// SetIP sets the "ip" field.
+func (lhu *LoginHistoryUpdate) SetIP(s string) *LoginHistoryUpdate {
+	lhu.mutation.SetIP(s)
+	return lhu
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (lhu *LoginHistoryUpdate) SetNillableIP(s *string) *LoginHistoryUpdate {
+	if s != nil {
+		lhu.SetIP(*s)
+	}
+	return lhu
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (lhu *LoginHistoryUpdate) SetCreatedAt(i int64) *LoginHistoryUpdate {
+	lhu.mutation.ResetCreatedAt()
+	lhu.mutation.SetCreatedAt(i)
+	return lhu
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (lhu *LoginHistoryUpdate) SetNillableCreatedAt(i *int64) *LoginHistoryUpdate {
+	if i != nil {
+		lhu.SetCreatedAt(*i)
+	}
+	return lhu
+}
+
+// AddCreatedAt adds i to the "created_at" field.
+func (lhu *LoginHistoryUpdate) AddCreatedAt(i int64) *LoginHistoryUpdate {
+	lhu.mutation.AddCreatedAt(i)
+	return lhu
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (lhu *LoginHistoryUpdate) SetUser(u *User) *LoginHistoryUpdate {
+	return lhu.SetUserID(u.ID)
+}
+
+// Mutation returns the LoginHistoryMutation object of the builder.
+func (lhu *LoginHistoryUpdate) Mutation() *LoginHistoryMutation {
+	return lhu.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (lhu *LoginHistoryUpdate) ClearUser() *LoginHistoryUpdate {
+	lhu.mutation.ClearUser()
+	return lhu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (lhu *LoginHistoryUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, lhu.sqlSave, lhu.mutation, lhu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (lhu *LoginHistoryUpdate) SaveX(ctx context.Context) int {
+	affected, err := lhu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (lhu *LoginHistoryUpdate) Exec(ctx context.Context) error {
+	_, err := lhu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (lhu *LoginHistoryUpdate) ExecX(ctx context.Context) {
+	if err := lhu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (lhu *LoginHistoryUpdate) check() error {
+	if lhu.mutation.UserCleared() && len(lhu.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "LoginHistory.user"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (lhu *LoginHistoryUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *LoginHistoryUpdate {
+	lhu.modifiers = append(lhu.modifiers, modifiers...)
+	return lhu
+}
+
+func (lhu *LoginHistoryUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := lhu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(loginhistory.Table, loginhistory.Columns, sqlgraph.NewFieldSpec(loginhistory.FieldID, field.TypeInt))
+	if ps := lhu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := lhu.mutation.Sid(); ok {
+		_spec.SetField(loginhistory.FieldSid, field.TypeString, value)
+	}
+	if value, ok := lhu.mutation.UserAgent(); ok {
+		_spec.SetField(loginhistory.FieldUserAgent, field.TypeString, value)
+	}
+	if value, ok := lhu.mutation.IP(); ok {
+		_spec.SetField(loginhistory.FieldIP, field.TypeString, value)
+	}
+	if value, ok := lhu.mutation.CreatedAt(); ok {
+		_spec.SetField(loginhistory.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := lhu.mutation.AddedCreatedAt(); ok {
+		_spec.AddField(loginhistory.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if lhu.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   loginhistory.UserTable,
+			Columns: []string{loginhistory.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := lhu.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   loginhistory.UserTable,
+			Columns: []string{loginhistory.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(lhu.modifiers...)
+	if n, err = sqlgraph.UpdateNodes(ctx, lhu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{loginhistory.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	lhu.mutation.done = true
+	return n, nil
+}
+
+// LoginHistoryUpdateOne is the builder for updating a single LoginHistory entity.
+type LoginHistoryUpdateOne struct {
+	config
+	fields    []string
+	hooks     []Hook
+	mutation  *LoginHistoryMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// SetUserID sets the "user_id" field.
+func (lhuo *LoginHistoryUpdateOne) SetUserID(i int) *LoginHistoryUpdateOne {
+	lhuo.mutation.SetUserID(i)
+	return lhuo
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (lhuo *LoginHistoryUpdateOne) SetNillableUserID(i *int) *LoginHistoryUpdateOne {
+	if i != nil {
+		lhuo.SetUserID(*i)
+	}
+	return lhuo
+}
+
+// SetSid sets the "sid" field.
+func (lhuo *LoginHistoryUpdateOne) SetSid(s string) *LoginHistoryUpdateOne {
+	lhuo.mutation.SetSid(s)
+	return lhuo
+}
+
+// SetNillableSid sets the "sid" field if the given value is not nil.
+func (lhuo *LoginHistoryUpdateOne) SetNillableSid(s *string) *LoginHistoryUpdateOne {
+	if s != nil {
+		lhuo.SetSid(*s)
+	}
+	return lhuo
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (lhuo *LoginHistoryUpdateOne) SetUserAgent(s string) *LoginHistoryUpdateOne {
+	lhuo.mutation.SetUserAgent(s)
+	return lhuo
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (lhuo *LoginHistoryUpdateOne) SetNillableUserAgent(s *string) *LoginHistoryUpdateOne {
+	if s != nil {
+		lhuo.SetUserAgent(*s)
+	}
+	return lhuo
+}
+
+// SetIP sets the "ip" field.
+func (lhuo *LoginHistoryUpdateOne) SetIP(s string) *LoginHistoryUpdateOne {
+	lhuo.mutation.SetIP(s)
+	return lhuo
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (lhuo *LoginHistoryUpdateOne) SetNillableIP(s *string) *LoginHistoryUpdateOne {
+	if s != nil {
+		lhuo.SetIP(*s)
+	}
+	return lhuo
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (lhuo *LoginHistoryUpdateOne) SetCreatedAt(i int64) *LoginHistoryUpdateOne {
+	lhuo.mutation.ResetCreatedAt()
+	lhuo.mutation.SetCreatedAt(i)
+	return lhuo
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (lhuo *LoginHistoryUpdateOne) SetNillableCreatedAt(i *int64) *LoginHistoryUpdateOne {
+	if i != nil {
+		lhuo.SetCreatedAt(*i)
+	}
+	return lhuo
+}
+
+// AddCreatedAt adds i to the "created_at" field.
+func (lhuo *LoginHistoryUpdateOne) AddCreatedAt(i int64) *LoginHistoryUpdateOne {
+	lhuo.mutation.AddCreatedAt(i)
+	return lhuo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (lhuo *LoginHistoryUpdateOne) SetUser(u *User) *LoginHistoryUpdateOne {
+	return lhuo.SetUserID(u.ID)
+}
+
+// Mutation returns the LoginHistoryMutation object of the builder.
+func (lhuo *LoginHistoryUpdateOne) Mutation() *LoginHistoryMutation {
+	return lhuo.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (lhuo *LoginHistoryUpdateOne) ClearUser() *LoginHistoryUpdateOne {
+	lhuo.mutation.ClearUser()
+	return lhuo
+}
+
+// Where appends a list predicates to the LoginHistoryUpdate builder.
+func (lhuo *LoginHistoryUpdateOne) Where(ps ...predicate.LoginHistory) *LoginHistoryUpdateOne {
+	lhuo.mutation.Where(ps...)
+	return lhuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (lhuo *LoginHistoryUpdateOne) Select(field string, fields ...string) *LoginHistoryUpdateOne {
+	lhuo.fields = append([]string{field}, fields...)
+	return lhuo
+}
+
+// Save executes the query and returns the updated LoginHistory entity.
+func (lhuo *LoginHistoryUpdateOne) Save(ctx context.Context) (*LoginHistory, error) {
+	return withHooks(ctx, lhuo.sqlSave, lhuo.mutation, lhuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (lhuo *LoginHistoryUpdateOne) SaveX(ctx context.Context) *LoginHistory {
+	node, err := lhuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (lhuo *LoginHistoryUpdateOne) Exec(ctx context.Context) error {
+	_, err := lhuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (lhuo *LoginHistoryUpdateOne) ExecX(ctx context.Context) {
+	if err := lhuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (lhuo *LoginHistoryUpdateOne) check() error {
+	if lhuo.mutation.UserCleared() && len(lhuo.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "LoginHistory.user"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (lhuo *LoginHistoryUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *LoginHistoryUpdateOne {
+	lhuo.modifiers = append(lhuo.modifiers, modifiers...)
+	return lhuo
+}
+
+func (lhuo *LoginHistoryUpdateOne) sqlSave(ctx context.Context) (_node *LoginHistory, err error) {
+	if err := lhuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(loginhistory.Table, loginhistory.Columns, sqlgraph.NewFieldSpec(loginhistory.FieldID, field.TypeInt))
+	id, ok := lhuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "LoginHistory.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := lhuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, loginhistory.FieldID)
+		for _, f := range fields {
+			if !loginhistory.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != loginhistory.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := lhuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := lhuo.mutation.Sid(); ok {
+		_spec.SetField(loginhistory.FieldSid, field.TypeString, value)
+	}
+	if value, ok := lhuo.mutation.UserAgent(); ok {
+		_spec.SetField(loginhistory.FieldUserAgent, field.TypeString, value)
+	}
+	if value, ok := lhuo.mutation.IP(); ok {
+		_spec.SetField(loginhistory.FieldIP, field.TypeString, value)
+	}
+	if value, ok := lhuo.mutation.CreatedAt(); ok {
+		_spec.SetField(loginhistory.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := lhuo.mutation.AddedCreatedAt(); ok {
+		_spec.AddField(loginhistory.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if lhuo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   loginhistory.UserTable,
+			Columns: []string{loginhistory.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := lhuo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   loginhistory.UserTable,
+			Columns: []string{loginhistory.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(lhuo.modifiers...)
+	_node = &LoginHistory{config: lhuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, lhuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{loginhistory.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	lhuo.mutation.done = true
+	return _node, nil
+}
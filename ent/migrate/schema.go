@@ -9,13 +9,302 @@ import (
 )
 
 var (
+	// AuditLogsColumns holds the columns for the "audit_logs" table.
+	AuditLogsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "actor", Type: field.TypeString, Comment: "uid of the user who performed the operation"},
+		{Name: "action", Type: field.TypeString, Comment: "operation name, such as user.create"},
+		{Name: "target", Type: field.TypeString, Comment: "uid of the user who the operation is performed on"},
+		{Name: "detail", Type: field.TypeString, Nullable: true, Comment: "additional information about the operation"},
+		{Name: "created_at", Type: field.TypeInt64},
+	}
+	// AuditLogsTable holds the schema information for the "audit_logs" table.
+	AuditLogsTable = &schema.Table{
+		Name:       "audit_logs",
+		Comment:    "audit log table of administrative operations",
+		Columns:    AuditLogsColumns,
+		PrimaryKey: []*schema.Column{AuditLogsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "auditlog_actor",
+				Unique:  false,
+				Columns: []*schema.Column{AuditLogsColumns[1]},
+			},
+			{
+				Name:    "auditlog_target",
+				Unique:  false,
+				Columns: []*schema.Column{AuditLogsColumns[3]},
+			},
+		},
+	}
+	// IdentitiesColumns holds the columns for the "identities" table.
+	IdentitiesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "provider", Type: field.TypeString, Comment: "name of the configured login provider"},
+		{Name: "subject", Type: field.TypeString, Comment: "unique user id at the provider"},
+		{Name: "email", Type: field.TypeString, Comment: "email reported by the provider", Default: ""},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "last_login_at", Type: field.TypeInt64},
+		{Name: "user_id", Type: field.TypeInt},
+	}
+	// IdentitiesTable holds the schema information for the "identities" table.
+	IdentitiesTable = &schema.Table{
+		Name:       "identities",
+		Comment:    "third-party login identity table",
+		Columns:    IdentitiesColumns,
+		PrimaryKey: []*schema.Column{IdentitiesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "identities_users_identities",
+				Columns:    []*schema.Column{IdentitiesColumns[6]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "identity_provider_subject",
+				Unique:  true,
+				Columns: []*schema.Column{IdentitiesColumns[1], IdentitiesColumns[2]},
+			},
+			{
+				Name:    "identity_user_id_provider",
+				Unique:  true,
+				Columns: []*schema.Column{IdentitiesColumns[6], IdentitiesColumns[1]},
+			},
+		},
+	}
+	// LoginHistoriesColumns holds the columns for the "login_histories" table.
+	LoginHistoriesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "sid", Type: field.TypeString, Comment: "id of the session created by login"},
+		{Name: "user_agent", Type: field.TypeString, Default: ""},
+		{Name: "ip", Type: field.TypeString, Default: ""},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "user_id", Type: field.TypeInt},
+	}
+	// LoginHistoriesTable holds the schema information for the "login_histories" table.
+	LoginHistoriesTable = &schema.Table{
+		Name:       "login_histories",
+		Comment:    "successful logins of users, they are kept after sessions ended",
+		Columns:    LoginHistoriesColumns,
+		PrimaryKey: []*schema.Column{LoginHistoriesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "login_histories_users_login_histories",
+				Columns:    []*schema.Column{LoginHistoriesColumns[5]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "loginhistory_user_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{LoginHistoriesColumns[5], LoginHistoriesColumns[4]},
+			},
+		},
+	}
+	// OauthClientsColumns holds the columns for the "oauth_clients" table.
+	OauthClientsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "client_id", Type: field.TypeString, Unique: true},
+		{Name: "secret_hash", Type: field.TypeString, Comment: "sha256 hash of the client secret, empty for public clients", Default: ""},
+		{Name: "name", Type: field.TypeString},
+		{Name: "redirect_uris", Type: field.TypeJSON, Comment: "registered redirect uris, they must be matched exactly"},
+		{Name: "scopes", Type: field.TypeJSON, Comment: "scopes the client is allowed to request"},
+		{Name: "grant_types", Type: field.TypeJSON, Comment: "grant types the client is allowed to use"},
+		{Name: "public", Type: field.TypeBool, Comment: "public client could not keep secret, such as spa and native app", Default: false},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "updated_at", Type: field.TypeInt64},
+		{Name: "user_id", Type: field.TypeInt, Comment: "owner of the client"},
+	}
+	// OauthClientsTable holds the schema information for the "oauth_clients" table.
+	OauthClientsTable = &schema.Table{
+		Name:       "oauth_clients",
+		Comment:    "oauth2 client application table",
+		Columns:    OauthClientsColumns,
+		PrimaryKey: []*schema.Column{OauthClientsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "oauth_clients_users_oauth_clients",
+				Columns:    []*schema.Column{OauthClientsColumns[10]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// PasswordHistoriesColumns holds the columns for the "password_histories" table.
+	PasswordHistoriesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "password", Type: field.TypeString, Comment: "previous password hash in PHC string format"},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "user_id", Type: field.TypeInt},
+	}
+	// PasswordHistoriesTable holds the schema information for the "password_histories" table.
+	PasswordHistoriesTable = &schema.Table{
+		Name:       "password_histories",
+		Comment:    "previous passwords of users, they could not be reused",
+		Columns:    PasswordHistoriesColumns,
+		PrimaryKey: []*schema.Column{PasswordHistoriesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "password_histories_users_password_histories",
+				Columns:    []*schema.Column{PasswordHistoriesColumns[3]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "passwordhistory_user_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{PasswordHistoriesColumns[3], PasswordHistoriesColumns[2]},
+			},
+		},
+	}
+	// PermissionsColumns holds the columns for the "permissions" table.
+	PermissionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "name", Type: field.TypeString, Unique: true, Comment: "permission name in resource:action format, such as user:list"},
+		{Name: "description", Type: field.TypeString, Nullable: true},
+	}
+	// PermissionsTable holds the schema information for the "permissions" table.
+	PermissionsTable = &schema.Table{
+		Name:       "permissions",
+		Comment:    "permission table",
+		Columns:    PermissionsColumns,
+		PrimaryKey: []*schema.Column{PermissionsColumns[0]},
+	}
+	// PersonalTokensColumns holds the columns for the "personal_tokens" table.
+	PersonalTokensColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "key_id", Type: field.TypeString, Unique: true, Comment: "public id embedded in the key, used to look up the key"},
+		{Name: "secret_hash", Type: field.TypeString, Comment: "sha256 hash of the key secret"},
+		{Name: "name", Type: field.TypeString},
+		{Name: "scopes", Type: field.TypeJSON, Nullable: true, Comment: "scopes granted to the key"},
+		{Name: "expires_at", Type: field.TypeInt64, Comment: "0 means never expire", Default: 0},
+		{Name: "last_used_at", Type: field.TypeInt64, Comment: "0 means never used", Default: 0},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "user_id", Type: field.TypeInt},
+	}
+	// PersonalTokensTable holds the schema information for the "personal_tokens" table.
+	PersonalTokensTable = &schema.Table{
+		Name:       "personal_tokens",
+		Comment:    "personal access token table",
+		Columns:    PersonalTokensColumns,
+		PrimaryKey: []*schema.Column{PersonalTokensColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "personal_tokens_users_personal_tokens",
+				Columns:    []*schema.Column{PersonalTokensColumns[8]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// RecoveryCodesColumns holds the columns for the "recovery_codes" table.
+	RecoveryCodesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "code_hash", Type: field.TypeString, Comment: "sha256 hash of the recovery code"},
+		{Name: "used_at", Type: field.TypeInt64, Comment: "code could be used only once, 0 means unused", Default: 0},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "user_id", Type: field.TypeInt},
+	}
+	// RecoveryCodesTable holds the schema information for the "recovery_codes" table.
+	RecoveryCodesTable = &schema.Table{
+		Name:       "recovery_codes",
+		Comment:    "two-factor authentication recovery code table",
+		Columns:    RecoveryCodesColumns,
+		PrimaryKey: []*schema.Column{RecoveryCodesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "recovery_codes_users_recovery_codes",
+				Columns:    []*schema.Column{RecoveryCodesColumns[4]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "recoverycode_user_id_code_hash",
+				Unique:  true,
+				Columns: []*schema.Column{RecoveryCodesColumns[4], RecoveryCodesColumns[1]},
+			},
+		},
+	}
+	// RolesColumns holds the columns for the "roles" table.
+	RolesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "name", Type: field.TypeString, Unique: true},
+		{Name: "description", Type: field.TypeString, Nullable: true},
+		{Name: "builtin", Type: field.TypeBool, Comment: "builtin role is seeded by server, it could not be deleted", Default: false},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "updated_at", Type: field.TypeInt64},
+	}
+	// RolesTable holds the schema information for the "roles" table.
+	RolesTable = &schema.Table{
+		Name:       "roles",
+		Comment:    "role table",
+		Columns:    RolesColumns,
+		PrimaryKey: []*schema.Column{RolesColumns[0]},
+	}
+	// SessionsColumns holds the columns for the "sessions" table.
+	SessionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "sid", Type: field.TypeString, Unique: true, Comment: "session id carried in token payload"},
+		{Name: "access_id", Type: field.TypeString, Comment: "id of the latest access token"},
+		{Name: "refresh_id", Type: field.TypeString, Nullable: true, Comment: "id of the refresh token, empty if not remembered"},
+		{Name: "user_agent", Type: field.TypeString, Default: ""},
+		{Name: "ip", Type: field.TypeString, Default: ""},
+		{Name: "remember", Type: field.TypeBool, Default: false},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "last_seen_at", Type: field.TypeInt64},
+		{Name: "expires_at", Type: field.TypeInt64, Comment: "session could not be refreshed after expiration"},
+		{Name: "user_id", Type: field.TypeInt},
+	}
+	// SessionsTable holds the schema information for the "sessions" table.
+	SessionsTable = &schema.Table{
+		Name:       "sessions",
+		Comment:    "user login session table",
+		Columns:    SessionsColumns,
+		PrimaryKey: []*schema.Column{SessionsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "sessions_users_sessions",
+				Columns:    []*schema.Column{SessionsColumns[10]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "session_user_id_expires_at",
+				Unique:  false,
+				Columns: []*schema.Column{SessionsColumns[10], SessionsColumns[9]},
+			},
+		},
+	}
 	// UsersColumns holds the columns for the "users" table.
 	UsersColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "deleted_at", Type: field.TypeInt64, Comment: "time when the row was soft deleted, 0 means not deleted", Default: 0},
 		{Name: "uid", Type: field.TypeString, Unique: true},
 		{Name: "username", Type: field.TypeString, Unique: true},
 		{Name: "email", Type: field.TypeString, Unique: true},
-		{Name: "password", Type: field.TypeString},
+		{Name: "email_verified_at", Type: field.TypeInt64, Comment: "time when the email was verified, 0 means unverified", Default: 0},
+		{Name: "password", Type: field.TypeString, Comment: "password hash in PHC string format"},
+		{Name: "totp_secret", Type: field.TypeString, Nullable: true, Comment: "base32 encoded totp secret, it is pending until 2fa enabled"},
+		{Name: "totp_enabled", Type: field.TypeBool, Comment: "whether two-factor authentication is enabled", Default: false},
+		{Name: "nickname", Type: field.TypeString, Nullable: true},
+		{Name: "avatar_url", Type: field.TypeString, Nullable: true},
+		{Name: "bio", Type: field.TypeString, Nullable: true},
+		{Name: "avatar_key", Type: field.TypeString, Nullable: true, Comment: "storage key of uploaded avatar, keys of each size are suffixed with _<size>"},
+		{Name: "locale", Type: field.TypeString, Nullable: true, Comment: "preferred language in BCP 47, such as en-US"},
+		{Name: "timezone", Type: field.TypeString, Nullable: true, Comment: "IANA time zone name, such as Asia/Shanghai"},
+		{Name: "metadata", Type: field.TypeJSON, Nullable: true, Comment: "arbitrary metadata maintained by the user"},
+		{Name: "status", Type: field.TypeEnum, Comment: "only active user could log in, disabled user is set by administrator, pending deletion user is purged after purge_at unless logs in again", Enums: []string{"active", "disabled", "pending_deletion", "deleted"}, Default: "active"},
+		{Name: "purge_at", Type: field.TypeInt64, Comment: "time when the pending deletion user will be purged, 0 means not scheduled", Default: 0},
 		{Name: "created_at", Type: field.TypeInt64},
 		{Name: "updated_at", Type: field.TypeInt64},
 	}
@@ -25,13 +314,103 @@ var (
 		Comment:    "user info table",
 		Columns:    UsersColumns,
 		PrimaryKey: []*schema.Column{UsersColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "user_status_purge_at",
+				Unique:  false,
+				Columns: []*schema.Column{UsersColumns[16], UsersColumns[17]},
+			},
+		},
+	}
+	// RolePermissionsColumns holds the columns for the "role_permissions" table.
+	RolePermissionsColumns = []*schema.Column{
+		{Name: "role_id", Type: field.TypeInt},
+		{Name: "permission_id", Type: field.TypeInt},
+	}
+	// RolePermissionsTable holds the schema information for the "role_permissions" table.
+	RolePermissionsTable = &schema.Table{
+		Name:       "role_permissions",
+		Columns:    RolePermissionsColumns,
+		PrimaryKey: []*schema.Column{RolePermissionsColumns[0], RolePermissionsColumns[1]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "role_permissions_role_id",
+				Columns:    []*schema.Column{RolePermissionsColumns[0]},
+				RefColumns: []*schema.Column{RolesColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "role_permissions_permission_id",
+				Columns:    []*schema.Column{RolePermissionsColumns[1]},
+				RefColumns: []*schema.Column{PermissionsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// UserRolesColumns holds the columns for the "user_roles" table.
+	UserRolesColumns = []*schema.Column{
+		{Name: "user_id", Type: field.TypeInt},
+		{Name: "role_id", Type: field.TypeInt},
+	}
+	// UserRolesTable holds the schema information for the "user_roles" table.
+	UserRolesTable = &schema.Table{
+		Name:       "user_roles",
+		Columns:    UserRolesColumns,
+		PrimaryKey: []*schema.Column{UserRolesColumns[0], UserRolesColumns[1]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "user_roles_user_id",
+				Columns:    []*schema.Column{UserRolesColumns[0]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "user_roles_role_id",
+				Columns:    []*schema.Column{UserRolesColumns[1]},
+				RefColumns: []*schema.Column{RolesColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
 	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		AuditLogsTable,
+		IdentitiesTable,
+		LoginHistoriesTable,
+		OauthClientsTable,
+		PasswordHistoriesTable,
+		PermissionsTable,
+		PersonalTokensTable,
+		RecoveryCodesTable,
+		RolesTable,
+		SessionsTable,
 		UsersTable,
+		RolePermissionsTable,
+		UserRolesTable,
 	}
 )
 
 func init() {
+	AuditLogsTable.Annotation = &entsql.Annotation{}
+	IdentitiesTable.ForeignKeys[0].RefTable = UsersTable
+	IdentitiesTable.Annotation = &entsql.Annotation{}
+	LoginHistoriesTable.ForeignKeys[0].RefTable = UsersTable
+	LoginHistoriesTable.Annotation = &entsql.Annotation{}
+	OauthClientsTable.ForeignKeys[0].RefTable = UsersTable
+	OauthClientsTable.Annotation = &entsql.Annotation{}
+	PasswordHistoriesTable.ForeignKeys[0].RefTable = UsersTable
+	PasswordHistoriesTable.Annotation = &entsql.Annotation{}
+	PermissionsTable.Annotation = &entsql.Annotation{}
+	PersonalTokensTable.ForeignKeys[0].RefTable = UsersTable
+	PersonalTokensTable.Annotation = &entsql.Annotation{}
+	RecoveryCodesTable.ForeignKeys[0].RefTable = UsersTable
+	RecoveryCodesTable.Annotation = &entsql.Annotation{}
+	RolesTable.Annotation = &entsql.Annotation{}
+	SessionsTable.ForeignKeys[0].RefTable = UsersTable
+	SessionsTable.Annotation = &entsql.Annotation{}
 	UsersTable.Annotation = &entsql.Annotation{}
+	RolePermissionsTable.ForeignKeys[0].RefTable = RolesTable
+	RolePermissionsTable.ForeignKeys[1].RefTable = PermissionsTable
+	UserRolesTable.ForeignKeys[0].RefTable = UsersTable
+	UserRolesTable.ForeignKeys[1].RefTable = RolesTable
 }
@@ -0,0 +1,1059 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ginx-contribs/ginx-server/ent/oauthclient"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+)
+
+// OAuthClientCreate is the builder for creating a OAuthClient entity.
+type OAuthClientCreate struct {
+	config
+	mutation *OAuthClientMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetClientID sets the "client_id" field.
+func (occ *OAuthClientCreate) SetClientID(s string) *OAuthClientCreate {
+	occ.mutation.SetClientID(s)
+	return occ
+}
+
+// SetNillableClientID sets the "client_id" field if the given value is not nil.
+func (occ *OAuthClientCreate) SetNillableClientID(s *string) *OAuthClientCreate {
+	if s != nil {
+		occ.SetClientID(*s)
+	}
+	return occ
+}
+
+// SetSecretHash sets the "secret_hash" field.
+func (occ *OAuthClientCreate) SetSecretHash(s string) *OAuthClientCreate {
+	occ.mutation.SetSecretHash(s)
+	return occ
+}
+
+// SetNillableSecretHash sets the "secret_hash" field if the given value is not nil.
+func (occ *OAuthClientCreate) SetNillableSecretHash(s *string) *OAuthClientCreate {
+	if s != nil {
+		occ.SetSecretHash(*s)
+	}
+	return occ
+}
+
+// SetName sets the "name" field.
+func (occ *OAuthClientCreate) SetName(s string) *OAuthClientCreate {
+	occ.mutation.SetName(s)
+	return occ
+}
+
+// SetRedirectUris sets the "redirect_uris" field.
+func (occ *OAuthClientCreate) SetRedirectUris(s []string) *OAuthClientCreate {
+	occ.mutation.SetRedirectUris(s)
+	return occ
+}
+
+// SetScopes sets the "scopes" field.
+func (occ *OAuthClientCreate) SetScopes(s []string) *OAuthClientCreate {
+	occ.mutation.SetScopes(s)
+	return occ
+}
+
+// SetGrantTypes sets the "grant_types" field.
+func (occ *OAuthClientCreate) SetGrantTypes(s []string) *OAuthClientCreate {
+	occ.mutation.SetGrantTypes(s)
+	return occ
+}
+
+// SetPublic sets the "public" field.
+func (occ *OAuthClientCreate) SetPublic(b bool) *OAuthClientCreate {
+	occ.mutation.SetPublic(b)
+	return occ
+}
+
+// SetNillablePublic sets the "public" field if the given value is not nil.
+func (occ *OAuthClientCreate) SetNillablePublic(b *bool) *OAuthClientCreate {
+	if b != nil {
+		occ.SetPublic(*b)
+	}
+	return occ
+}
+
+// SetUserID sets the "user_id" field.
+func (occ *OAuthClientCreate) SetUserID(i int) *OAuthClientCreate {
+	occ.mutation.SetUserID(i)
+	return occ
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (occ *OAuthClientCreate) SetCreatedAt(i int64) *OAuthClientCreate {
+	occ.mutation.SetCreatedAt(i)
+	return occ
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (occ *OAuthClientCreate) SetNillableCreatedAt(i *int64) *OAuthClientCreate {
+	if i != nil {
+		occ.SetCreatedAt(*i)
+	}
+	return occ
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (occ *OAuthClientCreate) SetUpdatedAt(i int64) *OAuthClientCreate {
+	occ.mutation.SetUpdatedAt(i)
+	return occ
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (occ *OAuthClientCreate) SetNillableUpdatedAt(i *int64) *OAuthClientCreate {
+	if i != nil {
+		occ.SetUpdatedAt(*i)
+	}
+	return occ
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (occ *OAuthClientCreate) SetUser(u *User) *OAuthClientCreate {
+	return occ.SetUserID(u.ID)
+}
+
+// Mutation returns the OAuthClientMutation object of the builder.
+func (occ *OAuthClientCreate) Mutation() *OAuthClientMutation {
+	return occ.mutation
+}
+
+// Save creates the OAuthClient in the database.
+func (occ *OAuthClientCreate) Save(ctx context.Context) (*OAuthClient, error) {
+	occ.defaults()
+	return withHooks(ctx, occ.sqlSave, occ.mutation, occ.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (occ *OAuthClientCreate) SaveX(ctx context.Context) *OAuthClient {
+	v, err := occ.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (occ *OAuthClientCreate) Exec(ctx context.Context) error {
+	_, err := occ.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (occ *OAuthClientCreate) ExecX(ctx context.Context) {
+	if err := occ.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (occ *OAuthClientCreate) defaults() {
+	if _, ok := occ.mutation.ClientID(); !ok {
+		v := oauthclient.DefaultClientID()
+		occ.mutation.SetClientID(v)
+	}
+	if _, ok := occ.mutation.SecretHash(); !ok {
+		v := oauthclient.DefaultSecretHash
+		occ.mutation.SetSecretHash(v)
+	}
+	if _, ok := occ.mutation.Public(); !ok {
+		v := oauthclient.DefaultPublic
+		occ.mutation.SetPublic(v)
+	}
+	if _, ok := occ.mutation.CreatedAt(); !ok {
+		v := oauthclient.DefaultCreatedAt()
+		occ.mutation.SetCreatedAt(v)
+	}
+	if _, ok := occ.mutation.UpdatedAt(); !ok {
+		v := oauthclient.DefaultUpdatedAt()
+		occ.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (occ *OAuthClientCreate) check() error {
+	if _, ok := occ.mutation.ClientID(); !ok {
+		return &ValidationError{Name: "client_id", err: errors.New(`ent: missing required field "OAuthClient.client_id"`)}
+	}
+	if _, ok := occ.mutation.SecretHash(); !ok {
+		return &ValidationError{Name: "secret_hash", err: errors.New(`ent: missing required field "OAuthClient.secret_hash"`)}
+	}
+	if _, ok := occ.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "OAuthClient.name"`)}
+	}
+	if _, ok := occ.mutation.RedirectUris(); !ok {
+		return &ValidationError{Name: "redirect_uris", err: errors.New(`ent: missing required field "OAuthClient.redirect_uris"`)}
+	}
+	if _, ok := occ.mutation.Scopes(); !ok {
+		return &ValidationError{Name: "scopes", err: errors.New(`ent: missing required field "OAuthClient.scopes"`)}
+	}
+	if _, ok := occ.mutation.GrantTypes(); !ok {
+		return &ValidationError{Name: "grant_types", err: errors.New(`ent: missing required field "OAuthClient.grant_types"`)}
+	}
+	if _, ok := occ.mutation.Public(); !ok {
+		return &ValidationError{Name: "public", err: errors.New(`ent: missing required field "OAuthClient.public"`)}
+	}
+	if _, ok := occ.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "OAuthClient.user_id"`)}
+	}
+	if _, ok := occ.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "OAuthClient.created_at"`)}
+	}
+	if _, ok := occ.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "OAuthClient.updated_at"`)}
+	}
+	if len(occ.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "OAuthClient.user"`)}
+	}
+	return nil
+}
+
+func (occ *OAuthClientCreate) sqlSave(ctx context.Context) (*OAuthClient, error) {
+	if err := occ.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := occ.createSpec()
+	if err := sqlgraph.CreateNode(ctx, occ.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	occ.mutation.id = &_node.ID
+	occ.mutation.done = true
+	return _node, nil
+}
+
+func (occ *OAuthClientCreate) createSpec() (*OAuthClient, *sqlgraph.CreateSpec) {
+	var (
+		_node = &OAuthClient{config: occ.config}
+		_spec = sqlgraph.NewCreateSpec(oauthclient.Table, sqlgraph.NewFieldSpec(oauthclient.FieldID, field.TypeInt))
+	)
+	_spec.OnConflict = occ.conflict
+	if value, ok := occ.mutation.ClientID(); ok {
+		_spec.SetField(oauthclient.FieldClientID, field.TypeString, value)
+		_node.ClientID = value
+	}
+	if value, ok := occ.mutation.SecretHash(); ok {
+		_spec.SetField(oauthclient.FieldSecretHash, field.TypeString, value)
+		_node.SecretHash = value
+	}
+	if value, ok := occ.mutation.Name(); ok {
+		_spec.SetField(oauthclient.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := occ.mutation.RedirectUris(); ok {
+		_spec.SetField(oauthclient.FieldRedirectUris, field.TypeJSON, value)
+		_node.RedirectUris = value
+	}
+	if value, ok := occ.mutation.Scopes(); ok {
+		_spec.SetField(oauthclient.FieldScopes, field.TypeJSON, value)
+		_node.Scopes = value
+	}
+	if value, ok := occ.mutation.GrantTypes(); ok {
+		_spec.SetField(oauthclient.FieldGrantTypes, field.TypeJSON, value)
+		_node.GrantTypes = value
+	}
+	if value, ok := occ.mutation.Public(); ok {
+		_spec.SetField(oauthclient.FieldPublic, field.TypeBool, value)
+		_node.Public = value
+	}
+	if value, ok := occ.mutation.CreatedAt(); ok {
+		_spec.SetField(oauthclient.FieldCreatedAt, field.TypeInt64, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := occ.mutation.UpdatedAt(); ok {
+		_spec.SetField(oauthclient.FieldUpdatedAt, field.TypeInt64, value)
+		_node.UpdatedAt = value
+	}
+	if nodes := occ.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   oauthclient.UserTable,
+			Columns: []string{oauthclient.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.UserID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.OAuthClient.Create().
+//		SetClientID(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.OAuthClientUpsert) {
+//			SetClientID(v+v).
+//		}).
+//		Exec(ctx)
+func (occ *OAuthClientCreate) OnConflict(opts ...sql.ConflictOption) *OAuthClientUpsertOne {
+	occ.conflict = opts
+	return &OAuthClientUpsertOne{
+		create: occ,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.OAuthClient.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (occ *OAuthClientCreate) OnConflictColumns(columns ...string) *OAuthClientUpsertOne {
+	occ.conflict = append(occ.conflict, sql.ConflictColumns(columns...))
+	return &OAuthClientUpsertOne{
+		create: occ,
+	}
+}
+
+type (
+	// OAuthClientUpsertOne is the builder for "upsert"-ing
+	//  one OAuthClient node.
+	OAuthClientUpsertOne struct {
+		create *OAuthClientCreate
+	}
+
+	// OAuthClientUpsert is the "OnConflict" setter.
+	OAuthClientUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetClientID sets the "client_id" field.
+func (u *OAuthClientUpsert) SetClientID(v string) *OAuthClientUpsert {
+	u.Set(oauthclient.FieldClientID, v)
+	return u
+}
+
+// UpdateClientID sets the "client_id" field to the value that was provided on create.
+func (u *OAuthClientUpsert) UpdateClientID() *OAuthClientUpsert {
+	u.SetExcluded(oauthclient.FieldClientID)
+	return u
+}
+
+// SetSecretHash sets the "secret_hash" field.
+func (u *OAuthClientUpsert) SetSecretHash(v string) *OAuthClientUpsert {
+	u.Set(oauthclient.FieldSecretHash, v)
+	return u
+}
+
+// UpdateSecretHash sets the "secret_hash" field to the value that was provided on create.
+func (u *OAuthClientUpsert) UpdateSecretHash() *OAuthClientUpsert {
+	u.SetExcluded(oauthclient.FieldSecretHash)
+	return u
+}
+
+// SetName sets the "name" field.
+func (u *OAuthClientUpsert) SetName(v string) *OAuthClientUpsert {
+	u.Set(oauthclient.FieldName, v)
+	return u
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *OAuthClientUpsert) UpdateName() *OAuthClientUpsert {
+	u.SetExcluded(oauthclient.FieldName)
+	return u
+}
+
+// SetRedirectUris sets the "redirect_uris" field.
+func (u *OAuthClientUpsert) SetRedirectUris(v []string) *OAuthClientUpsert {
+	u.Set(oauthclient.FieldRedirectUris, v)
+	return u
+}
+
+// UpdateRedirectUris sets the "redirect_uris" field to the value that was provided on create.
+func (u *OAuthClientUpsert) UpdateRedirectUris() *OAuthClientUpsert {
+	u.SetExcluded(oauthclient.FieldRedirectUris)
+	return u
+}
+
+// SetScopes sets the "scopes" field.
+func (u *OAuthClientUpsert) SetScopes(v []string) *OAuthClientUpsert {
+	u.Set(oauthclient.FieldScopes, v)
+	return u
+}
+
+// UpdateScopes sets the "scopes" field to the value that was provided on create.
+func (u *OAuthClientUpsert) UpdateScopes() *OAuthClientUpsert {
+	u.SetExcluded(oauthclient.FieldScopes)
+	return u
+}
+
+// SetGrantTypes sets the "grant_types" field.
+func (u *OAuthClientUpsert) SetGrantTypes(v []string) *OAuthClientUpsert {
+	u.Set(oauthclient.FieldGrantTypes, v)
+	return u
+}
+
+// UpdateGrantTypes sets the "grant_types" field to the value that was provided on create.
+func (u *OAuthClientUpsert) UpdateGrantTypes() *OAuthClientUpsert {
+	u.SetExcluded(oauthclient.FieldGrantTypes)
+	return u
+}
+
+// SetPublic sets the "public" field.
+func (u *OAuthClientUpsert) SetPublic(v bool) *OAuthClientUpsert {
+	u.Set(oauthclient.FieldPublic, v)
+	return u
+}
+
+// UpdatePublic sets the "public" field to the value that was provided on create.
+func (u *OAuthClientUpsert) UpdatePublic() *OAuthClientUpsert {
+	u.SetExcluded(oauthclient.FieldPublic)
+	return u
+}
+
+// SetUserID sets the "user_id" field.
+func (u *OAuthClientUpsert) SetUserID(v int) *OAuthClientUpsert {
+	u.Set(oauthclient.FieldUserID, v)
+	return u
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *OAuthClientUpsert) UpdateUserID() *OAuthClientUpsert {
+	u.SetExcluded(oauthclient.FieldUserID)
+	return u
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *OAuthClientUpsert) SetCreatedAt(v int64) *OAuthClientUpsert {
+	u.Set(oauthclient.FieldCreatedAt, v)
+	return u
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *OAuthClientUpsert) UpdateCreatedAt() *OAuthClientUpsert {
+	u.SetExcluded(oauthclient.FieldCreatedAt)
+	return u
+}
+
+// AddCreatedAt adds v to the "created_at" field.
+func (u *OAuthClientUpsert) AddCreatedAt(v int64) *OAuthClientUpsert {
+	u.Add(oauthclient.FieldCreatedAt, v)
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *OAuthClientUpsert) SetUpdatedAt(v int64) *OAuthClientUpsert {
+	u.Set(oauthclient.FieldUpdatedAt, v)
+	return u
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *OAuthClientUpsert) UpdateUpdatedAt() *OAuthClientUpsert {
+	u.SetExcluded(oauthclient.FieldUpdatedAt)
+	return u
+}
+
+// AddUpdatedAt adds v to the "updated_at" field.
+func (u *OAuthClientUpsert) AddUpdatedAt(v int64) *OAuthClientUpsert {
+	u.Add(oauthclient.FieldUpdatedAt, v)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.OAuthClient.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *OAuthClientUpsertOne) UpdateNewValues() *OAuthClientUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.OAuthClient.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *OAuthClientUpsertOne) Ignore() *OAuthClientUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *OAuthClientUpsertOne) DoNothing() *OAuthClientUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the OAuthClientCreate.OnConflict
+// documentation for more info.
+func (u *OAuthClientUpsertOne) Update(set func(*OAuthClientUpsert)) *OAuthClientUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&OAuthClientUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetClientID sets the "client_id" field.
+func (u *OAuthClientUpsertOne) SetClientID(v string) *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetClientID(v)
+	})
+}
+
+// UpdateClientID sets the "client_id" field to the value that was provided on create.
+func (u *OAuthClientUpsertOne) UpdateClientID() *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdateClientID()
+	})
+}
+
+// SetSecretHash sets the "secret_hash" field.
+func (u *OAuthClientUpsertOne) SetSecretHash(v string) *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetSecretHash(v)
+	})
+}
+
+// UpdateSecretHash sets the "secret_hash" field to the value that was provided on create.
+func (u *OAuthClientUpsertOne) UpdateSecretHash() *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdateSecretHash()
+	})
+}
+
+// SetName sets the "name" field.
+func (u *OAuthClientUpsertOne) SetName(v string) *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetName(v)
+	})
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *OAuthClientUpsertOne) UpdateName() *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdateName()
+	})
+}
+
+// SetRedirectUris sets the "redirect_uris" field.
+func (u *OAuthClientUpsertOne) SetRedirectUris(v []string) *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetRedirectUris(v)
+	})
+}
+
+// UpdateRedirectUris sets the "redirect_uris" field to the value that was provided on create.
+func (u *OAuthClientUpsertOne) UpdateRedirectUris() *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdateRedirectUris()
+	})
+}
+
+// SetScopes sets the "scopes" field.
+func (u *OAuthClientUpsertOne) SetScopes(v []string) *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetScopes(v)
+	})
+}
+
+// UpdateScopes sets the "scopes" field to the value that was provided on create.
+func (u *OAuthClientUpsertOne) UpdateScopes() *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdateScopes()
+	})
+}
+
+// SetGrantTypes sets the "grant_types" field.
+func (u *OAuthClientUpsertOne) SetGrantTypes(v []string) *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetGrantTypes(v)
+	})
+}
+
+// UpdateGrantTypes sets the "grant_types" field to the value that was provided on create.
+func (u *OAuthClientUpsertOne) UpdateGrantTypes() *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdateGrantTypes()
+	})
+}
+
+// SetPublic sets the "public" field.
+func (u *OAuthClientUpsertOne) SetPublic(v bool) *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetPublic(v)
+	})
+}
+
+// UpdatePublic sets the "public" field to the value that was provided on create.
+func (u *OAuthClientUpsertOne) UpdatePublic() *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdatePublic()
+	})
+}
+
+// SetUserID sets the "user_id" field.
+func (u *OAuthClientUpsertOne) SetUserID(v int) *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *OAuthClientUpsertOne) UpdateUserID() *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *OAuthClientUpsertOne) SetCreatedAt(v int64) *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetCreatedAt(v)
+	})
+}
+
+// AddCreatedAt adds v to the "created_at" field.
+func (u *OAuthClientUpsertOne) AddCreatedAt(v int64) *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.AddCreatedAt(v)
+	})
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *OAuthClientUpsertOne) UpdateCreatedAt() *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdateCreatedAt()
+	})
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *OAuthClientUpsertOne) SetUpdatedAt(v int64) *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// AddUpdatedAt adds v to the "updated_at" field.
+func (u *OAuthClientUpsertOne) AddUpdatedAt(v int64) *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.AddUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *OAuthClientUpsertOne) UpdateUpdatedAt() *OAuthClientUpsertOne {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *OAuthClientUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for OAuthClientCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *OAuthClientUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *OAuthClientUpsertOne) ID(ctx context.Context) (id int, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *OAuthClientUpsertOne) IDX(ctx context.Context) int {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// OAuthClientCreateBulk is the builder for creating many OAuthClient entities in bulk.
+type OAuthClientCreateBulk struct {
+	config
+	err      error
+	builders []*OAuthClientCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the OAuthClient entities in the database.
+func (occb *OAuthClientCreateBulk) Save(ctx context.Context) ([]*OAuthClient, error) {
+	if occb.err != nil {
+		return nil, occb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(occb.builders))
+	nodes := make([]*OAuthClient, len(occb.builders))
+	mutators := make([]Mutator, len(occb.builders))
+	for i := range occb.builders {
+		func(i int, root context.Context) {
+			builder := occb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*OAuthClientMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, occb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = occb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, occb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, occb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (occb *OAuthClientCreateBulk) SaveX(ctx context.Context) []*OAuthClient {
+	v, err := occb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (occb *OAuthClientCreateBulk) Exec(ctx context.Context) error {
+	_, err := occb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (occb *OAuthClientCreateBulk) ExecX(ctx context.Context) {
+	if err := occb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.OAuthClient.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.OAuthClientUpsert) {
+//			SetClientID(v+v).
+//		}).
+//		Exec(ctx)
+func (occb *OAuthClientCreateBulk) OnConflict(opts ...sql.ConflictOption) *OAuthClientUpsertBulk {
+	occb.conflict = opts
+	return &OAuthClientUpsertBulk{
+		create: occb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.OAuthClient.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (occb *OAuthClientCreateBulk) OnConflictColumns(columns ...string) *OAuthClientUpsertBulk {
+	occb.conflict = append(occb.conflict, sql.ConflictColumns(columns...))
+	return &OAuthClientUpsertBulk{
+		create: occb,
+	}
+}
+
+// OAuthClientUpsertBulk is the builder for "upsert"-ing
+// a bulk of OAuthClient nodes.
+type OAuthClientUpsertBulk struct {
+	create *OAuthClientCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.OAuthClient.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *OAuthClientUpsertBulk) UpdateNewValues() *OAuthClientUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.OAuthClient.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *OAuthClientUpsertBulk) Ignore() *OAuthClientUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *OAuthClientUpsertBulk) DoNothing() *OAuthClientUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the OAuthClientCreateBulk.OnConflict
+// documentation for more info.
+func (u *OAuthClientUpsertBulk) Update(set func(*OAuthClientUpsert)) *OAuthClientUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&OAuthClientUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetClientID sets the "client_id" field.
+func (u *OAuthClientUpsertBulk) SetClientID(v string) *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetClientID(v)
+	})
+}
+
+// UpdateClientID sets the "client_id" field to the value that was provided on create.
+func (u *OAuthClientUpsertBulk) UpdateClientID() *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdateClientID()
+	})
+}
+
+// SetSecretHash sets the "secret_hash" field.
+func (u *OAuthClientUpsertBulk) SetSecretHash(v string) *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetSecretHash(v)
+	})
+}
+
+// UpdateSecretHash sets the "secret_hash" field to the value that was provided on create.
+func (u *OAuthClientUpsertBulk) UpdateSecretHash() *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdateSecretHash()
+	})
+}
+
+// SetName sets the "name" field.
+func (u *OAuthClientUpsertBulk) SetName(v string) *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetName(v)
+	})
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *OAuthClientUpsertBulk) UpdateName() *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdateName()
+	})
+}
+
+// SetRedirectUris sets the "redirect_uris" field.
+func (u *OAuthClientUpsertBulk) SetRedirectUris(v []string) *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetRedirectUris(v)
+	})
+}
+
+// UpdateRedirectUris sets the "redirect_uris" field to the value that was provided on create.
+func (u *OAuthClientUpsertBulk) UpdateRedirectUris() *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdateRedirectUris()
+	})
+}
+
+// SetScopes sets the "scopes" field.
+func (u *OAuthClientUpsertBulk) SetScopes(v []string) *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetScopes(v)
+	})
+}
+
+// UpdateScopes sets the "scopes" field to the value that was provided on create.
+func (u *OAuthClientUpsertBulk) UpdateScopes() *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdateScopes()
+	})
+}
+
+// SetGrantTypes sets the "grant_types" field.
+func (u *OAuthClientUpsertBulk) SetGrantTypes(v []string) *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetGrantTypes(v)
+	})
+}
+
+// UpdateGrantTypes sets the "grant_types" field to the value that was provided on create.
+func (u *OAuthClientUpsertBulk) UpdateGrantTypes() *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdateGrantTypes()
+	})
+}
+
+// SetPublic sets the "public" field.
+func (u *OAuthClientUpsertBulk) SetPublic(v bool) *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetPublic(v)
+	})
+}
+
+// UpdatePublic sets the "public" field to the value that was provided on create.
+func (u *OAuthClientUpsertBulk) UpdatePublic() *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdatePublic()
+	})
+}
+
+// SetUserID sets the "user_id" field.
+func (u *OAuthClientUpsertBulk) SetUserID(v int) *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *OAuthClientUpsertBulk) UpdateUserID() *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *OAuthClientUpsertBulk) SetCreatedAt(v int64) *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetCreatedAt(v)
+	})
+}
+
+// AddCreatedAt adds v to the "created_at" field.
+func (u *OAuthClientUpsertBulk) AddCreatedAt(v int64) *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.AddCreatedAt(v)
+	})
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *OAuthClientUpsertBulk) UpdateCreatedAt() *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdateCreatedAt()
+	})
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *OAuthClientUpsertBulk) SetUpdatedAt(v int64) *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// AddUpdatedAt adds v to the "updated_at" field.
+func (u *OAuthClientUpsertBulk) AddUpdatedAt(v int64) *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.AddUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *OAuthClientUpsertBulk) UpdateUpdatedAt() *OAuthClientUpsertBulk {
+	return u.Update(func(s *OAuthClientUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *OAuthClientUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the OAuthClientCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for OAuthClientCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *OAuthClientUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
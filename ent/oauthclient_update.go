@@ -0,0 +1,689 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/ginx-contribs/ginx-server/ent/oauthclient"
+	"github.com/ginx-contribs/ginx-server/ent/predicate"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+)
+
+// OAuthClientUpdate is the builder for updating OAuthClient entities.
+type OAuthClientUpdate struct {
+	config
+	hooks     []Hook
+	mutation  *OAuthClientMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// Where appends a list predicates to the OAuthClientUpdate builder.
+func (ocu *OAuthClientUpdate) Where(ps ...predicate.OAuthClient) *OAuthClientUpdate {
+	ocu.mutation.Where(ps...)
+	return ocu
+}
+
+// SetClientID sets the "client_id" field.
+func (ocu *OAuthClientUpdate) SetClientID(s string) *OAuthClientUpdate {
+	ocu.mutation.SetClientID(s)
+	return ocu
+}
+
+// SetNillableClientID sets the "client_id" field if the given value is not nil.
+func (ocu *OAuthClientUpdate) SetNillableClientID(s *string) *OAuthClientUpdate {
+	if s != nil {
+		ocu.SetClientID(*s)
+	}
+	return ocu
+}
+
+// SetSecretHash sets the "secret_hash" field.
+func (ocu *OAuthClientUpdate) SetSecretHash(s string) *OAuthClientUpdate {
+	ocu.mutation.SetSecretHash(s)
+	return ocu
+}
+
+// SetNillableSecretHash sets the "secret_hash" field if the given value is not nil.
+func (ocu *OAuthClientUpdate) SetNillableSecretHash(s *string) *OAuthClientUpdate {
+	if s != nil {
+		ocu.SetSecretHash(*s)
+	}
+	return ocu
+}
+
+// SetName sets the "name" field.
+func (ocu *OAuthClientUpdate) SetName(s string) *OAuthClientUpdate {
+	ocu.mutation.SetName(s)
+	return ocu
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (ocu *OAuthClientUpdate) SetNillableName(s *string) *OAuthClientUpdate {
+	if s != nil {
+		ocu.SetName(*s)
+	}
+	return ocu
+}
+
+// SetRedirectUris sets the "redirect_uris" field.
+func (ocu *OAuthClientUpdate) SetRedirectUris(s []string) *OAuthClientUpdate {
+	ocu.mutation.SetRedirectUris(s)
+	return ocu
+}
+
+// AppendRedirectUris appends s to the "redirect_uris" field.
+func (ocu *OAuthClientUpdate) AppendRedirectUris(s []string) *OAuthClientUpdate {
+	ocu.mutation.AppendRedirectUris(s)
+	return ocu
+}
+
+// SetScopes sets the "scopes" field.
+func (ocu *OAuthClientUpdate) SetScopes(s []string) *OAuthClientUpdate {
+	ocu.mutation.SetScopes(s)
+	return ocu
+}
+
+// AppendScopes appends s to the "scopes" field.
+func (ocu *OAuthClientUpdate) AppendScopes(s []string) *OAuthClientUpdate {
+	ocu.mutation.AppendScopes(s)
+	return ocu
+}
+
+// SetGrantTypes sets the "grant_types" field.
+func (ocu *OAuthClientUpdate) SetGrantTypes(s []string) *OAuthClientUpdate {
+	ocu.mutation.SetGrantTypes(s)
+	return ocu
+}
+
+// AppendGrantTypes appends s to the "grant_types" field.
+func (ocu *OAuthClientUpdate) AppendGrantTypes(s []string) *OAuthClientUpdate {
+	ocu.mutation.AppendGrantTypes(s)
+	return ocu
+}
+
+// SetPublic sets the "public" field.
+func (ocu *OAuthClientUpdate) SetPublic(b bool) *OAuthClientUpdate {
+	ocu.mutation.SetPublic(b)
+	return ocu
+}
+
+// SetNillablePublic sets the "public" field if the given value is not nil.
+func (ocu *OAuthClientUpdate) SetNillablePublic(b *bool) *OAuthClientUpdate {
+	if b != nil {
+		ocu.SetPublic(*b)
+	}
+	return ocu
+}
+
+// SetUserID sets the "user_id" field.
+func (ocu *OAuthClientUpdate) SetUserID(i int) *OAuthClientUpdate {
+	ocu.mutation.SetUserID(i)
+	return ocu
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (ocu *OAuthClientUpdate) SetNillableUserID(i *int) *OAuthClientUpdate {
+	if i != nil {
+		ocu.SetUserID(*i)
+	}
+	return ocu
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (ocu *OAuthClientUpdate) SetCreatedAt(i int64) *OAuthClientUpdate {
+	ocu.mutation.ResetCreatedAt()
+	ocu.mutation.SetCreatedAt(i)
+	return ocu
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (ocu *OAuthClientUpdate) SetNillableCreatedAt(i *int64) *OAuthClientUpdate {
+	if i != nil {
+		ocu.SetCreatedAt(*i)
+	}
+	return ocu
+}
+
+// AddCreatedAt adds i to the "created_at" field.
+func (ocu *OAuthClientUpdate) AddCreatedAt(i int64) *OAuthClientUpdate {
+	ocu.mutation.AddCreatedAt(i)
+	return ocu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ocu *OAuthClientUpdate) SetUpdatedAt(i int64) *OAuthClientUpdate {
+	ocu.mutation.ResetUpdatedAt()
+	ocu.mutation.SetUpdatedAt(i)
+	return ocu
+}
+
+// AddUpdatedAt adds i to the "updated_at" field.
+func (ocu *OAuthClientUpdate) AddUpdatedAt(i int64) *OAuthClientUpdate {
+	ocu.mutation.AddUpdatedAt(i)
+	return ocu
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (ocu *OAuthClientUpdate) SetUser(u *User) *OAuthClientUpdate {
+	return ocu.SetUserID(u.ID)
+}
+
+// Mutation returns the OAuthClientMutation object of the builder.
+func (ocu *OAuthClientUpdate) Mutation() *OAuthClientMutation {
+	return ocu.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (ocu *OAuthClientUpdate) ClearUser() *OAuthClientUpdate {
+	ocu.mutation.ClearUser()
+	return ocu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (ocu *OAuthClientUpdate) Save(ctx context.Context) (int, error) {
+	ocu.defaults()
+	return withHooks(ctx, ocu.sqlSave, ocu.mutation, ocu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ocu *OAuthClientUpdate) SaveX(ctx context.Context) int {
+	affected, err := ocu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (ocu *OAuthClientUpdate) Exec(ctx context.Context) error {
+	_, err := ocu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ocu *OAuthClientUpdate) ExecX(ctx context.Context) {
+	if err := ocu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ocu *OAuthClientUpdate) defaults() {
+	if _, ok := ocu.mutation.UpdatedAt(); !ok {
+		v := oauthclient.UpdateDefaultUpdatedAt()
+		ocu.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ocu *OAuthClientUpdate) check() error {
+	if ocu.mutation.UserCleared() && len(ocu.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "OAuthClient.user"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (ocu *OAuthClientUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *OAuthClientUpdate {
+	ocu.modifiers = append(ocu.modifiers, modifiers...)
+	return ocu
+}
+
+func (ocu *OAuthClientUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := ocu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(oauthclient.Table, oauthclient.Columns, sqlgraph.NewFieldSpec(oauthclient.FieldID, field.TypeInt))
+	if ps := ocu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ocu.mutation.ClientID(); ok {
+		_spec.SetField(oauthclient.FieldClientID, field.TypeString, value)
+	}
+	if value, ok := ocu.mutation.SecretHash(); ok {
+		_spec.SetField(oauthclient.FieldSecretHash, field.TypeString, value)
+	}
+	if value, ok := ocu.mutation.Name(); ok {
+		_spec.SetField(oauthclient.FieldName, field.TypeString, value)
+	}
+	if value, ok := ocu.mutation.RedirectUris(); ok {
+		_spec.SetField(oauthclient.FieldRedirectUris, field.TypeJSON, value)
+	}
+	if value, ok := ocu.mutation.AppendedRedirectUris(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, oauthclient.FieldRedirectUris, value)
+		})
+	}
+	if value, ok := ocu.mutation.Scopes(); ok {
+		_spec.SetField(oauthclient.FieldScopes, field.TypeJSON, value)
+	}
+	if value, ok := ocu.mutation.AppendedScopes(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, oauthclient.FieldScopes, value)
+		})
+	}
+	if value, ok := ocu.mutation.GrantTypes(); ok {
+		_spec.SetField(oauthclient.FieldGrantTypes, field.TypeJSON, value)
+	}
+	if value, ok := ocu.mutation.AppendedGrantTypes(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, oauthclient.FieldGrantTypes, value)
+		})
+	}
+	if value, ok := ocu.mutation.Public(); ok {
+		_spec.SetField(oauthclient.FieldPublic, field.TypeBool, value)
+	}
+	if value, ok := ocu.mutation.CreatedAt(); ok {
+		_spec.SetField(oauthclient.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := ocu.mutation.AddedCreatedAt(); ok {
+		_spec.AddField(oauthclient.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := ocu.mutation.UpdatedAt(); ok {
+		_spec.SetField(oauthclient.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if value, ok := ocu.mutation.AddedUpdatedAt(); ok {
+		_spec.AddField(oauthclient.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if ocu.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   oauthclient.UserTable,
+			Columns: []string{oauthclient.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ocu.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   oauthclient.UserTable,
+			Columns: []string{oauthclient.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(ocu.modifiers...)
+	if n, err = sqlgraph.UpdateNodes(ctx, ocu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{oauthclient.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	ocu.mutation.done = true
+	return n, nil
+}
+
+// OAuthClientUpdateOne is the builder for updating a single OAuthClient entity.
+type OAuthClientUpdateOne struct {
+	config
+	fields    []string
+	hooks     []Hook
+	mutation  *OAuthClientMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// SetClientID sets the "client_id" field.
+func (ocuo *OAuthClientUpdateOne) SetClientID(s string) *OAuthClientUpdateOne {
+	ocuo.mutation.SetClientID(s)
+	return ocuo
+}
+
+// SetNillableClientID sets the "client_id" field if the given value is not nil.
+func (ocuo *OAuthClientUpdateOne) SetNillableClientID(s *string) *OAuthClientUpdateOne {
+	if s != nil {
+		ocuo.SetClientID(*s)
+	}
+	return ocuo
+}
+
+// SetSecretHash sets the "secret_hash" field.
+func (ocuo *OAuthClientUpdateOne) SetSecretHash(s string) *OAuthClientUpdateOne {
+	ocuo.mutation.SetSecretHash(s)
+	return ocuo
+}
+
+// SetNillableSecretHash sets the "secret_hash" field if the given value is not nil.
+func (ocuo *OAuthClientUpdateOne) SetNillableSecretHash(s *string) *OAuthClientUpdateOne {
+	if s != nil {
+		ocuo.SetSecretHash(*s)
+	}
+	return ocuo
+}
+
+// SetName sets the "name" field.
+func (ocuo *OAuthClientUpdateOne) SetName(s string) *OAuthClientUpdateOne {
+	ocuo.mutation.SetName(s)
+	return ocuo
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (ocuo *OAuthClientUpdateOne) SetNillableName(s *string) *OAuthClientUpdateOne {
+	if s != nil {
+		ocuo.SetName(*s)
+	}
+	return ocuo
+}
+
+// SetRedirectUris sets the "redirect_uris" field.
+func (ocuo *OAuthClientUpdateOne) SetRedirectUris(s []string) *OAuthClientUpdateOne {
+	ocuo.mutation.SetRedirectUris(s)
+	return ocuo
+}
+
+// AppendRedirectUris appends s to the "redirect_uris" field.
+func (ocuo *OAuthClientUpdateOne) AppendRedirectUris(s []string) *OAuthClientUpdateOne {
+	ocuo.mutation.AppendRedirectUris(s)
+	return ocuo
+}
+
+// SetScopes sets the "scopes" field.
+func (ocuo *OAuthClientUpdateOne) SetScopes(s []string) *OAuthClientUpdateOne {
+	ocuo.mutation.SetScopes(s)
+	return ocuo
+}
+
+// AppendScopes appends s to the "scopes" field.
+func (ocuo *OAuthClientUpdateOne) AppendScopes(s []string) *OAuthClientUpdateOne {
+	ocuo.mutation.AppendScopes(s)
+	return ocuo
+}
+
+// SetGrantTypes sets the "grant_types" field.
+func (ocuo *OAuthClientUpdateOne) SetGrantTypes(s []string) *OAuthClientUpdateOne {
+	ocuo.mutation.SetGrantTypes(s)
+	return ocuo
+}
+
+// AppendGrantTypes appends s to the "grant_types" field.
+func (ocuo *OAuthClientUpdateOne) AppendGrantTypes(s []string) *OAuthClientUpdateOne {
+	ocuo.mutation.AppendGrantTypes(s)
+	return ocuo
+}
+
+// SetPublic sets the "public" field.
+func (ocuo *OAuthClientUpdateOne) SetPublic(b bool) *OAuthClientUpdateOne {
+	ocuo.mutation.SetPublic(b)
+	return ocuo
+}
+
+// SetNillablePublic sets the "public" field if the given value is not nil.
+func (ocuo *OAuthClientUpdateOne) SetNillablePublic(b *bool) *OAuthClientUpdateOne {
+	if b != nil {
+		ocuo.SetPublic(*b)
+	}
+	return ocuo
+}
+
+// SetUserID sets the "user_id" field.
+func (ocuo *OAuthClientUpdateOne) SetUserID(i int) *OAuthClientUpdateOne {
+	ocuo.mutation.SetUserID(i)
+	return ocuo
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (ocuo *OAuthClientUpdateOne) SetNillableUserID(i *int) *OAuthClientUpdateOne {
+	if i != nil {
+		ocuo.SetUserID(*i)
+	}
+	return ocuo
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (ocuo *OAuthClientUpdateOne) SetCreatedAt(i int64) *OAuthClientUpdateOne {
+	ocuo.mutation.ResetCreatedAt()
+	ocuo.mutation.SetCreatedAt(i)
+	return ocuo
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (ocuo *OAuthClientUpdateOne) SetNillableCreatedAt(i *int64) *OAuthClientUpdateOne {
+	if i != nil {
+		ocuo.SetCreatedAt(*i)
+	}
+	return ocuo
+}
+
+// AddCreatedAt adds i to the "created_at" field.
+func (ocuo *OAuthClientUpdateOne) AddCreatedAt(i int64) *OAuthClientUpdateOne {
+	ocuo.mutation.AddCreatedAt(i)
+	return ocuo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ocuo *OAuthClientUpdateOne) SetUpdatedAt(i int64) *OAuthClientUpdateOne {
+	ocuo.mutation.ResetUpdatedAt()
+	ocuo.mutation.SetUpdatedAt(i)
+	return ocuo
+}
+
+// AddUpdatedAt adds i to the "updated_at" field.
+func (ocuo *OAuthClientUpdateOne) AddUpdatedAt(i int64) *OAuthClientUpdateOne {
+	ocuo.mutation.AddUpdatedAt(i)
+	return ocuo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (ocuo *OAuthClientUpdateOne) SetUser(u *User) *OAuthClientUpdateOne {
+	return ocuo.SetUserID(u.ID)
+}
+
+// Mutation returns the OAuthClientMutation object of the builder.
+func (ocuo *OAuthClientUpdateOne) Mutation() *OAuthClientMutation {
+	return ocuo.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (ocuo *OAuthClientUpdateOne) ClearUser() *OAuthClientUpdateOne {
+	ocuo.mutation.ClearUser()
+	return ocuo
+}
+
+// Where appends a list predicates to the OAuthClientUpdate builder.
+func (ocuo *OAuthClientUpdateOne) Where(ps ...predicate.OAuthClient) *OAuthClientUpdateOne {
+	ocuo.mutation.Where(ps...)
+	return ocuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ocuo *OAuthClientUpdateOne) Select(field string, fields ...string) *OAuthClientUpdateOne {
+	ocuo.fields = append([]string{field}, fields...)
+	return ocuo
+}
+
+// Save executes the query and returns the updated OAuthClient entity.
+func (ocuo *OAuthClientUpdateOne) Save(ctx context.Context) (*OAuthClient, error) {
+	ocuo.defaults()
+	return withHooks(ctx, ocuo.sqlSave, ocuo.mutation, ocuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ocuo *OAuthClientUpdateOne) SaveX(ctx context.Context) *OAuthClient {
+	node, err := ocuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ocuo *OAuthClientUpdateOne) Exec(ctx context.Context) error {
+	_, err := ocuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ocuo *OAuthClientUpdateOne) ExecX(ctx context.Context) {
+	if err := ocuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ocuo *OAuthClientUpdateOne) defaults() {
+	if _, ok := ocuo.mutation.UpdatedAt(); !ok {
+		v := oauthclient.UpdateDefaultUpdatedAt()
+		ocuo.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ocuo *OAuthClientUpdateOne) check() error {
+	if ocuo.mutation.UserCleared() && len(ocuo.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "OAuthClient.user"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (ocuo *OAuthClientUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *OAuthClientUpdateOne {
+	ocuo.modifiers = append(ocuo.modifiers, modifiers...)
+	return ocuo
+}
+
+func (ocuo *OAuthClientUpdateOne) sqlSave(ctx context.Context) (_node *OAuthClient, err error) {
+	if err := ocuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(oauthclient.Table, oauthclient.Columns, sqlgraph.NewFieldSpec(oauthclient.FieldID, field.TypeInt))
+	id, ok := ocuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "OAuthClient.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ocuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, oauthclient.FieldID)
+		for _, f := range fields {
+			if !oauthclient.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != oauthclient.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ocuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ocuo.mutation.ClientID(); ok {
+		_spec.SetField(oauthclient.FieldClientID, field.TypeString, value)
+	}
+	if value, ok := ocuo.mutation.SecretHash(); ok {
+		_spec.SetField(oauthclient.FieldSecretHash, field.TypeString, value)
+	}
+	if value, ok := ocuo.mutation.Name(); ok {
+		_spec.SetField(oauthclient.FieldName, field.TypeString, value)
+	}
+	if value, ok := ocuo.mutation.RedirectUris(); ok {
+		_spec.SetField(oauthclient.FieldRedirectUris, field.TypeJSON, value)
+	}
+	if value, ok := ocuo.mutation.AppendedRedirectUris(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, oauthclient.FieldRedirectUris, value)
+		})
+	}
+	if value, ok := ocuo.mutation.Scopes(); ok {
+		_spec.SetField(oauthclient.FieldScopes, field.TypeJSON, value)
+	}
+	if value, ok := ocuo.mutation.AppendedScopes(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, oauthclient.FieldScopes, value)
+		})
+	}
+	if value, ok := ocuo.mutation.GrantTypes(); ok {
+		_spec.SetField(oauthclient.FieldGrantTypes, field.TypeJSON, value)
+	}
+	if value, ok := ocuo.mutation.AppendedGrantTypes(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, oauthclient.FieldGrantTypes, value)
+		})
+	}
+	if value, ok := ocuo.mutation.Public(); ok {
+		_spec.SetField(oauthclient.FieldPublic, field.TypeBool, value)
+	}
+	if value, ok := ocuo.mutation.CreatedAt(); ok {
+		_spec.SetField(oauthclient.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := ocuo.mutation.AddedCreatedAt(); ok {
+		_spec.AddField(oauthclient.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := ocuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(oauthclient.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if value, ok := ocuo.mutation.AddedUpdatedAt(); ok {
+		_spec.AddField(oauthclient.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if ocuo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   oauthclient.UserTable,
+			Columns: []string{oauthclient.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ocuo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   oauthclient.UserTable,
+			Columns: []string{oauthclient.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(ocuo.modifiers...)
+	_node = &OAuthClient{config: ocuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ocuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{oauthclient.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	ocuo.mutation.done = true
+	return _node, nil
+}
@@ -4,8 +4,27 @@ package ent
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
+	"entgo.io/ent/dialect/sql"
+	"github.com/ginx-contribs/ginx-server/ent/auditlog"
+	"github.com/ginx-contribs/ginx-server/ent/identity"
+	"github.com/ginx-contribs/ginx-server/ent/loginhistory"
+	"github.com/ginx-contribs/ginx-server/ent/oauthclient"
+	"github.com/ginx-contribs/ginx-server/ent/passwordhistory"
+	"github.com/ginx-contribs/ginx-server/ent/permission"
+	"github.com/ginx-contribs/ginx-server/ent/personaltoken"
+	"github.com/ginx-contribs/ginx-server/ent/recoverycode"
+	"github.com/ginx-contribs/ginx-server/ent/role"
+	"github.com/ginx-contribs/ginx-server/ent/session"
 	"github.com/ginx-contribs/ginx-server/ent/user"
 )
 
@@ -55,6 +74,1674 @@ func (o OrderDirection) reverse() OrderDirection {
 
 const errInvalidPagination = "INVALID_PAGINATION"
 
+var (
+	// ErrInvalidCursor is returned when a cursor is malformed, forged, or issued for another ordering.
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrInvalidCursorArgs is returned when cursor pagination arguments are invalid.
+	ErrInvalidCursorArgs = errors.New("invalid cursor pagination arguments")
+)
+
+// Cursor is the position of a row in an ordered list, it is opaque to clients after encoding.
+type Cursor struct {
+	Node      string         `json:"n"`
+	Field     string         `json:"f"`
+	Direction OrderDirection `json:"d"`
+	ID        string         `json:"i"`
+	Value     string         `json:"v,omitempty"`
+}
+
+// CursorSigner encodes cursors into opaque strings signed with HMAC-SHA256, so that clients can not forge them.
+type CursorSigner struct {
+	key []byte
+}
+
+// NewCursorSigner returns a CursorSigner with the signing key.
+func NewCursorSigner(key []byte) *CursorSigner {
+	return &CursorSigner{key: key}
+}
+
+// Encode returns the signed string of cursor.
+func (s *CursorSigner) Encode(cursor Cursor) (string, error) {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded)), nil
+}
+
+// Decode verifies the signature of token and returns the cursor in it.
+func (s *CursorSigner) Decode(token string) (Cursor, error) {
+	var cursor Cursor
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return cursor, ErrInvalidCursor
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, s.sign(encoded)) {
+		return cursor, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return cursor, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+func (s *CursorSigner) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// CursorArgs are arguments of cursor pagination, exactly one of First and Last must be given.
+// First returns rows after the After cursor, Last returns rows before the Before cursor, both cursors could be used together.
+type CursorArgs struct {
+	After  string
+	First  int
+	Before string
+	Last   int
+	// Field to order by, defaults to id, rows with the same value are ordered by id.
+	Field string
+	// Direction of ordering, defaults to DESC.
+	Direction OrderDirection
+}
+
+// PageInfo describes the page of cursor pagination.
+type PageInfo struct {
+	HasNextPage     bool   `json:"hasNextPage"`
+	HasPreviousPage bool   `json:"hasPreviousPage"`
+	StartCursor     string `json:"startCursor"`
+	EndCursor       string `json:"endCursor"`
+}
+
+// cursorField is a field that could be used to order rows in cursor pagination.
+type cursorField[T any] struct {
+	value func(T) string
+	parse func(string) (any, error)
+}
+
+func parseIntCursor(s string) (any, error) {
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func parseUintCursor(s string) (any, error) {
+	return strconv.ParseUint(s, 10, 64)
+}
+
+func parseFloatCursor(s string) (any, error) {
+	return strconv.ParseFloat(s, 64)
+}
+
+func parseStringCursor(s string) (any, error) {
+	return s, nil
+}
+
+// cursorWindow is the validated arguments of cursor pagination.
+type cursorWindow struct {
+	node      string
+	idField   string
+	field     string
+	direction OrderDirection
+	limit     int
+	backward  bool
+	after     *Cursor
+	before    *Cursor
+}
+
+func (a CursorArgs) window(signer *CursorSigner, node, idField string) (*cursorWindow, error) {
+	if a.First < 0 || a.Last < 0 || (a.First > 0) == (a.Last > 0) {
+		return nil, fmt.Errorf("%w: exactly one of first and last must be positive", ErrInvalidCursorArgs)
+	}
+	w := &cursorWindow{node: node, idField: idField, field: a.Field, direction: a.Direction, limit: a.First}
+	if w.field == "" {
+		w.field = idField
+	}
+	if w.direction == "" {
+		w.direction = OrderDirectionDesc
+	} else if err := w.direction.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursorArgs, err)
+	}
+	if a.Last > 0 {
+		w.limit, w.backward = a.Last, true
+	}
+	var err error
+	if w.after, err = w.decode(signer, a.After); err != nil {
+		return nil, err
+	}
+	if w.before, err = w.decode(signer, a.Before); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// decode returns the cursor in token, cursors issued for other nodes or orderings are rejected.
+func (w *cursorWindow) decode(signer *CursorSigner, token string) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	cursor, err := signer.Decode(token)
+	if err != nil {
+		return nil, err
+	}
+	if cursor.Node != w.node || cursor.Field != w.field || cursor.Direction != w.direction {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// predicates returns predicates that select rows between the after and before cursors.
+func (w *cursorWindow) predicates(parseID, parseValue func(string) (any, error)) ([]func(*sql.Selector), error) {
+	var ps []func(*sql.Selector)
+	for i, cursor := range []*Cursor{w.after, w.before} {
+		if cursor == nil {
+			continue
+		}
+		id, err := parseID(cursor.ID)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		var value any
+		if w.field != w.idField {
+			if value, err = parseValue(cursor.Value); err != nil {
+				return nil, ErrInvalidCursor
+			}
+		}
+		ps = append(ps, w.predicate(i == 0, id, value))
+	}
+	return ps, nil
+}
+
+// predicate selects rows after or before the position (value, id) in the ordering of window.
+func (w *cursorWindow) predicate(after bool, id, value any) func(*sql.Selector) {
+	cmp := sql.LT
+	if (w.direction == OrderDirectionAsc) == after {
+		cmp = sql.GT
+	}
+	return func(s *sql.Selector) {
+		if w.field == w.idField {
+			s.Where(cmp(s.C(w.idField), id))
+			return
+		}
+		s.Where(sql.Or(
+			cmp(s.C(w.field), value),
+			sql.And(sql.EQ(s.C(w.field), value), cmp(s.C(w.idField), id)),
+		))
+	}
+}
+
+// order returns the ordering of query, it is reversed for backward pagination.
+func (w *cursorWindow) order() func(*sql.Selector) {
+	direction := w.direction
+	if w.backward {
+		direction = direction.reverse()
+	}
+	by := sql.Desc
+	if direction == OrderDirectionAsc {
+		by = sql.Asc
+	}
+	return func(s *sql.Selector) {
+		if w.field != w.idField {
+			s.OrderBy(by(s.C(w.field)))
+		}
+		s.OrderBy(by(s.C(w.idField)))
+	}
+}
+
+// cursorPage trims the rows fetched with limit+1 and builds the page info with signed cursors.
+func cursorPage[T any](signer *CursorSigner, w *cursorWindow, nodes []T, id, field cursorField[T]) ([]T, PageInfo, error) {
+	var info PageInfo
+	hasMore := len(nodes) > w.limit
+	if hasMore {
+		nodes = nodes[:w.limit]
+	}
+	if w.backward {
+		slices.Reverse(nodes)
+		info.HasPreviousPage, info.HasNextPage = hasMore, w.before != nil
+	} else {
+		info.HasNextPage, info.HasPreviousPage = hasMore, w.after != nil
+	}
+	if len(nodes) == 0 {
+		return nodes, info, nil
+	}
+
+	encode := func(node T) (string, error) {
+		cursor := Cursor{Node: w.node, Field: w.field, Direction: w.direction, ID: id.value(node)}
+		if w.field != w.idField {
+			cursor.Value = field.value(node)
+		}
+		return signer.Encode(cursor)
+	}
+	var err error
+	if info.StartCursor, err = encode(nodes[0]); err != nil {
+		return nil, info, err
+	}
+	if info.EndCursor, err = encode(nodes[len(nodes)-1]); err != nil {
+		return nil, info, err
+	}
+	return nodes, info, nil
+}
+
+type AuditLogPager struct {
+	Order  auditlog.OrderOption
+	Filter func(*AuditLogQuery) (*AuditLogQuery, error)
+}
+
+// AuditLogPaginateOption enables pagination customization.
+type AuditLogPaginateOption func(*AuditLogPager)
+
+// DefaultAuditLogOrder is the default ordering of AuditLog.
+var DefaultAuditLogOrder = Desc(auditlog.FieldID)
+
+func newAuditLogPager(opts []AuditLogPaginateOption) (*AuditLogPager, error) {
+	pager := &AuditLogPager{}
+	for _, opt := range opts {
+		opt(pager)
+	}
+	if pager.Order == nil {
+		pager.Order = DefaultAuditLogOrder
+	}
+	return pager, nil
+}
+
+func (p *AuditLogPager) ApplyFilter(query *AuditLogQuery) (*AuditLogQuery, error) {
+	if p.Filter != nil {
+		return p.Filter(query)
+	}
+	return query, nil
+}
+
+// AuditLogPageList is AuditLog PageList result.
+type AuditLogPageList struct {
+	List        []*AuditLog  `json:"list"`
+	PageDetails *PageDetails `json:"pageDetails"`
+}
+
+func (al *AuditLogQuery) Page(
+	ctx context.Context, pageNum uint64, pageSize uint64, opts ...AuditLogPaginateOption,
+) (*AuditLogPageList, error) {
+
+	pager, err := newAuditLogPager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if al, err = pager.ApplyFilter(al); err != nil {
+		return nil, err
+	}
+
+	ret := &AuditLogPageList{}
+
+	ret.PageDetails = &PageDetails{
+		Page: pageNum,
+		Size: pageSize,
+	}
+
+	query := al.Clone()
+	query.ctx.Fields = nil
+	count, err := query.Count(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret.PageDetails.Total = uint64(count)
+
+	if pager.Order != nil {
+		al = al.Order(pager.Order)
+	} else {
+		al = al.Order(DefaultAuditLogOrder)
+	}
+
+	al = al.Offset(int((pageNum - 1) * pageSize)).Limit(int(pageSize))
+	list, err := al.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret.List = list
+
+	return ret, nil
+}
+
+// AuditLogConnection is AuditLog cursor pagination result.
+type AuditLogConnection struct {
+	List     []*AuditLog `json:"list"`
+	PageInfo PageInfo    `json:"pageInfo"`
+}
+
+// auditlogCursorFields are fields that AuditLog could be ordered by in cursor pagination.
+var auditlogCursorFields = map[string]cursorField[*AuditLog]{
+	auditlog.FieldID:        {value: func(n *AuditLog) string { return fmt.Sprint(n.ID) }, parse: parseIntCursor},
+	auditlog.FieldActor:     {value: func(n *AuditLog) string { return fmt.Sprint(n.Actor) }, parse: parseStringCursor},
+	auditlog.FieldAction:    {value: func(n *AuditLog) string { return fmt.Sprint(n.Action) }, parse: parseStringCursor},
+	auditlog.FieldTarget:    {value: func(n *AuditLog) string { return fmt.Sprint(n.Target) }, parse: parseStringCursor},
+	auditlog.FieldDetail:    {value: func(n *AuditLog) string { return fmt.Sprint(n.Detail) }, parse: parseStringCursor},
+	auditlog.FieldCreatedAt: {value: func(n *AuditLog) string { return fmt.Sprint(n.CreatedAt) }, parse: parseIntCursor},
+}
+
+// Paginate returns a page of AuditLog by cursor, rows are ordered by args.Field then id, and the Order of pager is ignored.
+// Cursors in args must be issued by the same signer with the same ordering.
+func (al *AuditLogQuery) Paginate(
+	ctx context.Context, signer *CursorSigner, args CursorArgs, opts ...AuditLogPaginateOption,
+) (*AuditLogConnection, error) {
+
+	pager, err := newAuditLogPager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if al, err = pager.ApplyFilter(al); err != nil {
+		return nil, err
+	}
+
+	window, err := args.window(signer, "AuditLog", auditlog.FieldID)
+	if err != nil {
+		return nil, err
+	}
+	field, ok := auditlogCursorFields[window.field]
+	if !ok {
+		return nil, fmt.Errorf("%w: AuditLog can not be ordered by %q", ErrInvalidCursorArgs, window.field)
+	}
+	id := auditlogCursorFields[auditlog.FieldID]
+
+	predicates, err := window.predicates(id.parse, field.parse)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range predicates {
+		al = al.Where(p)
+	}
+
+	list, err := al.Order(window.order()).Limit(window.limit + 1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, pageInfo, err := cursorPage(signer, window, list, id, field)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLogConnection{List: list, PageInfo: pageInfo}, nil
+}
+
+type IdentityPager struct {
+	Order  identity.OrderOption
+	Filter func(*IdentityQuery) (*IdentityQuery, error)
+}
+
+// IdentityPaginateOption enables pagination customization.
+type IdentityPaginateOption func(*IdentityPager)
+
+// DefaultIdentityOrder is the default ordering of Identity.
+var DefaultIdentityOrder = Desc(identity.FieldID)
+
+func newIdentityPager(opts []IdentityPaginateOption) (*IdentityPager, error) {
+	pager := &IdentityPager{}
+	for _, opt := range opts {
+		opt(pager)
+	}
+	if pager.Order == nil {
+		pager.Order = DefaultIdentityOrder
+	}
+	return pager, nil
+}
+
+func (p *IdentityPager) ApplyFilter(query *IdentityQuery) (*IdentityQuery, error) {
+	if p.Filter != nil {
+		return p.Filter(query)
+	}
+	return query, nil
+}
+
+// IdentityPageList is Identity PageList result.
+type IdentityPageList struct {
+	List        []*Identity  `json:"list"`
+	PageDetails *PageDetails `json:"pageDetails"`
+}
+
+func (i *IdentityQuery) Page(
+	ctx context.Context, pageNum uint64, pageSize uint64, opts ...IdentityPaginateOption,
+) (*IdentityPageList, error) {
+
+	pager, err := newIdentityPager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if i, err = pager.ApplyFilter(i); err != nil {
+		return nil, err
+	}
+
+	ret := &IdentityPageList{}
+
+	ret.PageDetails = &PageDetails{
+		Page: pageNum,
+		Size: pageSize,
+	}
+
+	query := i.Clone()
+	query.ctx.Fields = nil
+	count, err := query.Count(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret.PageDetails.Total = uint64(count)
+
+	if pager.Order != nil {
+		i = i.Order(pager.Order)
+	} else {
+		i = i.Order(DefaultIdentityOrder)
+	}
+
+	i = i.Offset(int((pageNum - 1) * pageSize)).Limit(int(pageSize))
+	list, err := i.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret.List = list
+
+	return ret, nil
+}
+
+// IdentityConnection is Identity cursor pagination result.
+type IdentityConnection struct {
+	List     []*Identity `json:"list"`
+	PageInfo PageInfo    `json:"pageInfo"`
+}
+
+// identityCursorFields are fields that Identity could be ordered by in cursor pagination.
+var identityCursorFields = map[string]cursorField[*Identity]{
+	identity.FieldID:          {value: func(n *Identity) string { return fmt.Sprint(n.ID) }, parse: parseIntCursor},
+	identity.FieldUserID:      {value: func(n *Identity) string { return fmt.Sprint(n.UserID) }, parse: parseIntCursor},
+	identity.FieldProvider:    {value: func(n *Identity) string { return fmt.Sprint(n.Provider) }, parse: parseStringCursor},
+	identity.FieldSubject:     {value: func(n *Identity) string { return fmt.Sprint(n.Subject) }, parse: parseStringCursor},
+	identity.FieldEmail:       {value: func(n *Identity) string { return fmt.Sprint(n.Email) }, parse: parseStringCursor},
+	identity.FieldCreatedAt:   {value: func(n *Identity) string { return fmt.Sprint(n.CreatedAt) }, parse: parseIntCursor},
+	identity.FieldLastLoginAt: {value: func(n *Identity) string { return fmt.Sprint(n.LastLoginAt) }, parse: parseIntCursor},
+}
+
+// Paginate returns a page of Identity by cursor, rows are ordered by args.Field then id, and the Order of pager is ignored.
+// Cursors in args must be issued by the same signer with the same ordering.
+func (i *IdentityQuery) Paginate(
+	ctx context.Context, signer *CursorSigner, args CursorArgs, opts ...IdentityPaginateOption,
+) (*IdentityConnection, error) {
+
+	pager, err := newIdentityPager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if i, err = pager.ApplyFilter(i); err != nil {
+		return nil, err
+	}
+
+	window, err := args.window(signer, "Identity", identity.FieldID)
+	if err != nil {
+		return nil, err
+	}
+	field, ok := identityCursorFields[window.field]
+	if !ok {
+		return nil, fmt.Errorf("%w: Identity can not be ordered by %q", ErrInvalidCursorArgs, window.field)
+	}
+	id := identityCursorFields[identity.FieldID]
+
+	predicates, err := window.predicates(id.parse, field.parse)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range predicates {
+		i = i.Where(p)
+	}
+
+	list, err := i.Order(window.order()).Limit(window.limit + 1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, pageInfo, err := cursorPage(signer, window, list, id, field)
+	if err != nil {
+		return nil, err
+	}
+	return &IdentityConnection{List: list, PageInfo: pageInfo}, nil
+}
+
+type LoginHistoryPager struct {
+	Order  loginhistory.OrderOption
+	Filter func(*LoginHistoryQuery) (*LoginHistoryQuery, error)
+}
+
+// LoginHistoryPaginateOption enables pagination customization.
+type LoginHistoryPaginateOption func(*LoginHistoryPager)
+
+// DefaultLoginHistoryOrder is the default ordering of LoginHistory.
+var DefaultLoginHistoryOrder = Desc(loginhistory.FieldID)
+
+func newLoginHistoryPager(opts []LoginHistoryPaginateOption) (*LoginHistoryPager, error) {
+	pager := &LoginHistoryPager{}
+	for _, opt := range opts {
+		opt(pager)
+	}
+	if pager.Order == nil {
+		pager.Order = DefaultLoginHistoryOrder
+	}
+	return pager, nil
+}
+
+func (p *LoginHistoryPager) ApplyFilter(query *LoginHistoryQuery) (*LoginHistoryQuery, error) {
+	if p.Filter != nil {
+		return p.Filter(query)
+	}
+	return query, nil
+}
+
+// LoginHistoryPageList is LoginHistory PageList result.
+type LoginHistoryPageList struct {
+	List        []*LoginHistory `json:"list"`
+	PageDetails *PageDetails    `json:"pageDetails"`
+}
+
+func (lh *LoginHistoryQuery) Page(
+	ctx context.Context, pageNum uint64, pageSize uint64, opts ...LoginHistoryPaginateOption,
+) (*LoginHistoryPageList, error) {
+
+	pager, err := newLoginHistoryPager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if lh, err = pager.ApplyFilter(lh); err != nil {
+		return nil, err
+	}
+
+	ret := &LoginHistoryPageList{}
+
+	ret.PageDetails = &PageDetails{
+		Page: pageNum,
+		Size: pageSize,
+	}
+
+	query := lh.Clone()
+	query.ctx.Fields = nil
+	count, err := query.Count(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret.PageDetails.Total = uint64(count)
+
+	if pager.Order != nil {
+		lh = lh.Order(pager.Order)
+	} else {
+		lh = lh.Order(DefaultLoginHistoryOrder)
+	}
+
+	lh = lh.Offset(int((pageNum - 1) * pageSize)).Limit(int(pageSize))
+	list, err := lh.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret.List = list
+
+	return ret, nil
+}
+
+// LoginHistoryConnection is LoginHistory cursor pagination result.
+type LoginHistoryConnection struct {
+	List     []*LoginHistory `json:"list"`
+	PageInfo PageInfo        `json:"pageInfo"`
+}
+
+// loginhistoryCursorFields are fields that LoginHistory could be ordered by in cursor pagination.
+var loginhistoryCursorFields = map[string]cursorField[*LoginHistory]{
+	loginhistory.FieldID:        {value: func(n *LoginHistory) string { return fmt.Sprint(n.ID) }, parse: parseIntCursor},
+	loginhistory.FieldUserID:    {value: func(n *LoginHistory) string { return fmt.Sprint(n.UserID) }, parse: parseIntCursor},
+	loginhistory.FieldSid:       {value: func(n *LoginHistory) string { return fmt.Sprint(n.Sid) }, parse: parseStringCursor},
+	loginhistory.FieldUserAgent: {value: func(n *LoginHistory) string { return fmt.Sprint(n.UserAgent) }, parse: parseStringCursor},
+	loginhistory.FieldIP:        {value: func(n *LoginHistory) string { return fmt.Sprint(n.IP) }, parse: parseStringCursor},
+	loginhistory.FieldCreatedAt: {value: func(n *LoginHistory) string { return fmt.Sprint(n.CreatedAt) }, parse: parseIntCursor},
+}
+
+// Paginate returns a page of LoginHistory by cursor, rows are ordered by args.Field then id, and the Order of pager is ignored.
+// Cursors in args must be issued by the same signer with the same ordering.
+func (lh *LoginHistoryQuery) Paginate(
+	ctx context.Context, signer *CursorSigner, args CursorArgs, opts ...LoginHistoryPaginateOption,
+) (*LoginHistoryConnection, error) {
+
+	pager, err := newLoginHistoryPager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if lh, err = pager.ApplyFilter(lh); err != nil {
+		return nil, err
+	}
+
+	window, err := args.window(signer, "LoginHistory", loginhistory.FieldID)
+	if err != nil {
+		return nil, err
+	}
+	field, ok := loginhistoryCursorFields[window.field]
+	if !ok {
+		return nil, fmt.Errorf("%w: LoginHistory can not be ordered by %q", ErrInvalidCursorArgs, window.field)
+	}
+	id := loginhistoryCursorFields[loginhistory.FieldID]
+
+	predicates, err := window.predicates(id.parse, field.parse)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range predicates {
+		lh = lh.Where(p)
+	}
+
+	list, err := lh.Order(window.order()).Limit(window.limit + 1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, pageInfo, err := cursorPage(signer, window, list, id, field)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginHistoryConnection{List: list, PageInfo: pageInfo}, nil
+}
+
+type OAuthClientPager struct {
+	Order  oauthclient.OrderOption
+	Filter func(*OAuthClientQuery) (*OAuthClientQuery, error)
+}
+
+// OAuthClientPaginateOption enables pagination customization.
+type OAuthClientPaginateOption func(*OAuthClientPager)
+
+// DefaultOAuthClientOrder is the default ordering of OAuthClient.
+var DefaultOAuthClientOrder = Desc(oauthclient.FieldID)
+
+func newOAuthClientPager(opts []OAuthClientPaginateOption) (*OAuthClientPager, error) {
+	pager := &OAuthClientPager{}
+	for _, opt := range opts {
+		opt(pager)
+	}
+	if pager.Order == nil {
+		pager.Order = DefaultOAuthClientOrder
+	}
+	return pager, nil
+}
+
+func (p *OAuthClientPager) ApplyFilter(query *OAuthClientQuery) (*OAuthClientQuery, error) {
+	if p.Filter != nil {
+		return p.Filter(query)
+	}
+	return query, nil
+}
+
+// OAuthClientPageList is OAuthClient PageList result.
+type OAuthClientPageList struct {
+	List        []*OAuthClient `json:"list"`
+	PageDetails *PageDetails   `json:"pageDetails"`
+}
+
+func (oc *OAuthClientQuery) Page(
+	ctx context.Context, pageNum uint64, pageSize uint64, opts ...OAuthClientPaginateOption,
+) (*OAuthClientPageList, error) {
+
+	pager, err := newOAuthClientPager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if oc, err = pager.ApplyFilter(oc); err != nil {
+		return nil, err
+	}
+
+	ret := &OAuthClientPageList{}
+
+	ret.PageDetails = &PageDetails{
+		Page: pageNum,
+		Size: pageSize,
+	}
+
+	query := oc.Clone()
+	query.ctx.Fields = nil
+	count, err := query.Count(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret.PageDetails.Total = uint64(count)
+
+	if pager.Order != nil {
+		oc = oc.Order(pager.Order)
+	} else {
+		oc = oc.Order(DefaultOAuthClientOrder)
+	}
+
+	oc = oc.Offset(int((pageNum - 1) * pageSize)).Limit(int(pageSize))
+	list, err := oc.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret.List = list
+
+	return ret, nil
+}
+
+// OAuthClientConnection is OAuthClient cursor pagination result.
+type OAuthClientConnection struct {
+	List     []*OAuthClient `json:"list"`
+	PageInfo PageInfo       `json:"pageInfo"`
+}
+
+// oauthclientCursorFields are fields that OAuthClient could be ordered by in cursor pagination.
+var oauthclientCursorFields = map[string]cursorField[*OAuthClient]{
+	oauthclient.FieldID:        {value: func(n *OAuthClient) string { return fmt.Sprint(n.ID) }, parse: parseIntCursor},
+	oauthclient.FieldClientID:  {value: func(n *OAuthClient) string { return fmt.Sprint(n.ClientID) }, parse: parseStringCursor},
+	oauthclient.FieldName:      {value: func(n *OAuthClient) string { return fmt.Sprint(n.Name) }, parse: parseStringCursor},
+	oauthclient.FieldUserID:    {value: func(n *OAuthClient) string { return fmt.Sprint(n.UserID) }, parse: parseIntCursor},
+	oauthclient.FieldCreatedAt: {value: func(n *OAuthClient) string { return fmt.Sprint(n.CreatedAt) }, parse: parseIntCursor},
+	oauthclient.FieldUpdatedAt: {value: func(n *OAuthClient) string { return fmt.Sprint(n.UpdatedAt) }, parse: parseIntCursor},
+}
+
+// Paginate returns a page of OAuthClient by cursor, rows are ordered by args.Field then id, and the Order of pager is ignored.
+// Cursors in args must be issued by the same signer with the same ordering.
+func (oc *OAuthClientQuery) Paginate(
+	ctx context.Context, signer *CursorSigner, args CursorArgs, opts ...OAuthClientPaginateOption,
+) (*OAuthClientConnection, error) {
+
+	pager, err := newOAuthClientPager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if oc, err = pager.ApplyFilter(oc); err != nil {
+		return nil, err
+	}
+
+	window, err := args.window(signer, "OAuthClient", oauthclient.FieldID)
+	if err != nil {
+		return nil, err
+	}
+	field, ok := oauthclientCursorFields[window.field]
+	if !ok {
+		return nil, fmt.Errorf("%w: OAuthClient can not be ordered by %q", ErrInvalidCursorArgs, window.field)
+	}
+	id := oauthclientCursorFields[oauthclient.FieldID]
+
+	predicates, err := window.predicates(id.parse, field.parse)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range predicates {
+		oc = oc.Where(p)
+	}
+
+	list, err := oc.Order(window.order()).Limit(window.limit + 1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, pageInfo, err := cursorPage(signer, window, list, id, field)
+	if err != nil {
+		return nil, err
+	}
+	return &OAuthClientConnection{List: list, PageInfo: pageInfo}, nil
+}
+
+type PasswordHistoryPager struct {
+	Order  passwordhistory.OrderOption
+	Filter func(*PasswordHistoryQuery) (*PasswordHistoryQuery, error)
+}
+
+// PasswordHistoryPaginateOption enables pagination customization.
+type PasswordHistoryPaginateOption func(*PasswordHistoryPager)
+
+// DefaultPasswordHistoryOrder is the default ordering of PasswordHistory.
+var DefaultPasswordHistoryOrder = Desc(passwordhistory.FieldID)
+
+func newPasswordHistoryPager(opts []PasswordHistoryPaginateOption) (*PasswordHistoryPager, error) {
+	pager := &PasswordHistoryPager{}
+	for _, opt := range opts {
+		opt(pager)
+	}
+	if pager.Order == nil {
+		pager.Order = DefaultPasswordHistoryOrder
+	}
+	return pager, nil
+}
+
+func (p *PasswordHistoryPager) ApplyFilter(query *PasswordHistoryQuery) (*PasswordHistoryQuery, error) {
+	if p.Filter != nil {
+		return p.Filter(query)
+	}
+	return query, nil
+}
+
+// PasswordHistoryPageList is PasswordHistory PageList result.
+type PasswordHistoryPageList struct {
+	List        []*PasswordHistory `json:"list"`
+	PageDetails *PageDetails       `json:"pageDetails"`
+}
+
+func (ph *PasswordHistoryQuery) Page(
+	ctx context.Context, pageNum uint64, pageSize uint64, opts ...PasswordHistoryPaginateOption,
+) (*PasswordHistoryPageList, error) {
+
+	pager, err := newPasswordHistoryPager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if ph, err = pager.ApplyFilter(ph); err != nil {
+		return nil, err
+	}
+
+	ret := &PasswordHistoryPageList{}
+
+	ret.PageDetails = &PageDetails{
+		Page: pageNum,
+		Size: pageSize,
+	}
+
+	query := ph.Clone()
+	query.ctx.Fields = nil
+	count, err := query.Count(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret.PageDetails.Total = uint64(count)
+
+	if pager.Order != nil {
+		ph = ph.Order(pager.Order)
+	} else {
+		ph = ph.Order(DefaultPasswordHistoryOrder)
+	}
+
+	ph = ph.Offset(int((pageNum - 1) * pageSize)).Limit(int(pageSize))
+	list, err := ph.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret.List = list
+
+	return ret, nil
+}
+
+// PasswordHistoryConnection is PasswordHistory cursor pagination result.
+type PasswordHistoryConnection struct {
+	List     []*PasswordHistory `json:"list"`
+	PageInfo PageInfo           `json:"pageInfo"`
+}
+
+// passwordhistoryCursorFields are fields that PasswordHistory could be ordered by in cursor pagination.
+var passwordhistoryCursorFields = map[string]cursorField[*PasswordHistory]{
+	passwordhistory.FieldID:        {value: func(n *PasswordHistory) string { return fmt.Sprint(n.ID) }, parse: parseIntCursor},
+	passwordhistory.FieldUserID:    {value: func(n *PasswordHistory) string { return fmt.Sprint(n.UserID) }, parse: parseIntCursor},
+	passwordhistory.FieldCreatedAt: {value: func(n *PasswordHistory) string { return fmt.Sprint(n.CreatedAt) }, parse: parseIntCursor},
+}
+
+// Paginate returns a page of PasswordHistory by cursor, rows are ordered by args.Field then id, and the Order of pager is ignored.
+// Cursors in args must be issued by the same signer with the same ordering.
+func (ph *PasswordHistoryQuery) Paginate(
+	ctx context.Context, signer *CursorSigner, args CursorArgs, opts ...PasswordHistoryPaginateOption,
+) (*PasswordHistoryConnection, error) {
+
+	pager, err := newPasswordHistoryPager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if ph, err = pager.ApplyFilter(ph); err != nil {
+		return nil, err
+	}
+
+	window, err := args.window(signer, "PasswordHistory", passwordhistory.FieldID)
+	if err != nil {
+		return nil, err
+	}
+	field, ok := passwordhistoryCursorFields[window.field]
+	if !ok {
+		return nil, fmt.Errorf("%w: PasswordHistory can not be ordered by %q", ErrInvalidCursorArgs, window.field)
+	}
+	id := passwordhistoryCursorFields[passwordhistory.FieldID]
+
+	predicates, err := window.predicates(id.parse, field.parse)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range predicates {
+		ph = ph.Where(p)
+	}
+
+	list, err := ph.Order(window.order()).Limit(window.limit + 1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, pageInfo, err := cursorPage(signer, window, list, id, field)
+	if err != nil {
+		return nil, err
+	}
+	return &PasswordHistoryConnection{List: list, PageInfo: pageInfo}, nil
+}
+
+type PermissionPager struct {
+	Order  permission.OrderOption
+	Filter func(*PermissionQuery) (*PermissionQuery, error)
+}
+
+// PermissionPaginateOption enables pagination customization.
+type PermissionPaginateOption func(*PermissionPager)
+
+// DefaultPermissionOrder is the default ordering of Permission.
+var DefaultPermissionOrder = Desc(permission.FieldID)
+
+func newPermissionPager(opts []PermissionPaginateOption) (*PermissionPager, error) {
+	pager := &PermissionPager{}
+	for _, opt := range opts {
+		opt(pager)
+	}
+	if pager.Order == nil {
+		pager.Order = DefaultPermissionOrder
+	}
+	return pager, nil
+}
+
+func (p *PermissionPager) ApplyFilter(query *PermissionQuery) (*PermissionQuery, error) {
+	if p.Filter != nil {
+		return p.Filter(query)
+	}
+	return query, nil
+}
+
+// PermissionPageList is Permission PageList result.
+type PermissionPageList struct {
+	List        []*Permission `json:"list"`
+	PageDetails *PageDetails  `json:"pageDetails"`
+}
+
+func (pe *PermissionQuery) Page(
+	ctx context.Context, pageNum uint64, pageSize uint64, opts ...PermissionPaginateOption,
+) (*PermissionPageList, error) {
+
+	pager, err := newPermissionPager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if pe, err = pager.ApplyFilter(pe); err != nil {
+		return nil, err
+	}
+
+	ret := &PermissionPageList{}
+
+	ret.PageDetails = &PageDetails{
+		Page: pageNum,
+		Size: pageSize,
+	}
+
+	query := pe.Clone()
+	query.ctx.Fields = nil
+	count, err := query.Count(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret.PageDetails.Total = uint64(count)
+
+	if pager.Order != nil {
+		pe = pe.Order(pager.Order)
+	} else {
+		pe = pe.Order(DefaultPermissionOrder)
+	}
+
+	pe = pe.Offset(int((pageNum - 1) * pageSize)).Limit(int(pageSize))
+	list, err := pe.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret.List = list
+
+	return ret, nil
+}
+
+// PermissionConnection is Permission cursor pagination result.
+type PermissionConnection struct {
+	List     []*Permission `json:"list"`
+	PageInfo PageInfo      `json:"pageInfo"`
+}
+
+// permissionCursorFields are fields that Permission could be ordered by in cursor pagination.
+var permissionCursorFields = map[string]cursorField[*Permission]{
+	permission.FieldID:          {value: func(n *Permission) string { return fmt.Sprint(n.ID) }, parse: parseIntCursor},
+	permission.FieldName:        {value: func(n *Permission) string { return fmt.Sprint(n.Name) }, parse: parseStringCursor},
+	permission.FieldDescription: {value: func(n *Permission) string { return fmt.Sprint(n.Description) }, parse: parseStringCursor},
+}
+
+// Paginate returns a page of Permission by cursor, rows are ordered by args.Field then id, and the Order of pager is ignored.
+// Cursors in args must be issued by the same signer with the same ordering.
+func (pe *PermissionQuery) Paginate(
+	ctx context.Context, signer *CursorSigner, args CursorArgs, opts ...PermissionPaginateOption,
+) (*PermissionConnection, error) {
+
+	pager, err := newPermissionPager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if pe, err = pager.ApplyFilter(pe); err != nil {
+		return nil, err
+	}
+
+	window, err := args.window(signer, "Permission", permission.FieldID)
+	if err != nil {
+		return nil, err
+	}
+	field, ok := permissionCursorFields[window.field]
+	if !ok {
+		return nil, fmt.Errorf("%w: Permission can not be ordered by %q", ErrInvalidCursorArgs, window.field)
+	}
+	id := permissionCursorFields[permission.FieldID]
+
+	predicates, err := window.predicates(id.parse, field.parse)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range predicates {
+		pe = pe.Where(p)
+	}
+
+	list, err := pe.Order(window.order()).Limit(window.limit + 1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, pageInfo, err := cursorPage(signer, window, list, id, field)
+	if err != nil {
+		return nil, err
+	}
+	return &PermissionConnection{List: list, PageInfo: pageInfo}, nil
+}
+
+type PersonalTokenPager struct {
+	Order  personaltoken.OrderOption
+	Filter func(*PersonalTokenQuery) (*PersonalTokenQuery, error)
+}
+
+// PersonalTokenPaginateOption enables pagination customization.
+type PersonalTokenPaginateOption func(*PersonalTokenPager)
+
+// DefaultPersonalTokenOrder is the default ordering of PersonalToken.
+var DefaultPersonalTokenOrder = Desc(personaltoken.FieldID)
+
+func newPersonalTokenPager(opts []PersonalTokenPaginateOption) (*PersonalTokenPager, error) {
+	pager := &PersonalTokenPager{}
+	for _, opt := range opts {
+		opt(pager)
+	}
+	if pager.Order == nil {
+		pager.Order = DefaultPersonalTokenOrder
+	}
+	return pager, nil
+}
+
+func (p *PersonalTokenPager) ApplyFilter(query *PersonalTokenQuery) (*PersonalTokenQuery, error) {
+	if p.Filter != nil {
+		return p.Filter(query)
+	}
+	return query, nil
+}
+
+// PersonalTokenPageList is PersonalToken PageList result.
+type PersonalTokenPageList struct {
+	List        []*PersonalToken `json:"list"`
+	PageDetails *PageDetails     `json:"pageDetails"`
+}
+
+func (pt *PersonalTokenQuery) Page(
+	ctx context.Context, pageNum uint64, pageSize uint64, opts ...PersonalTokenPaginateOption,
+) (*PersonalTokenPageList, error) {
+
+	pager, err := newPersonalTokenPager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if pt, err = pager.ApplyFilter(pt); err != nil {
+		return nil, err
+	}
+
+	ret := &PersonalTokenPageList{}
+
+	ret.PageDetails = &PageDetails{
+		Page: pageNum,
+		Size: pageSize,
+	}
+
+	query := pt.Clone()
+	query.ctx.Fields = nil
+	count, err := query.Count(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret.PageDetails.Total = uint64(count)
+
+	if pager.Order != nil {
+		pt = pt.Order(pager.Order)
+	} else {
+		pt = pt.Order(DefaultPersonalTokenOrder)
+	}
+
+	pt = pt.Offset(int((pageNum - 1) * pageSize)).Limit(int(pageSize))
+	list, err := pt.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret.List = list
+
+	return ret, nil
+}
+
+// PersonalTokenConnection is PersonalToken cursor pagination result.
+type PersonalTokenConnection struct {
+	List     []*PersonalToken `json:"list"`
+	PageInfo PageInfo         `json:"pageInfo"`
+}
+
+// personaltokenCursorFields are fields that PersonalToken could be ordered by in cursor pagination.
+var personaltokenCursorFields = map[string]cursorField[*PersonalToken]{
+	personaltoken.FieldID:         {value: func(n *PersonalToken) string { return fmt.Sprint(n.ID) }, parse: parseIntCursor},
+	personaltoken.FieldKeyID:      {value: func(n *PersonalToken) string { return fmt.Sprint(n.KeyID) }, parse: parseStringCursor},
+	personaltoken.FieldUserID:     {value: func(n *PersonalToken) string { return fmt.Sprint(n.UserID) }, parse: parseIntCursor},
+	personaltoken.FieldName:       {value: func(n *PersonalToken) string { return fmt.Sprint(n.Name) }, parse: parseStringCursor},
+	personaltoken.FieldExpiresAt:  {value: func(n *PersonalToken) string { return fmt.Sprint(n.ExpiresAt) }, parse: parseIntCursor},
+	personaltoken.FieldLastUsedAt: {value: func(n *PersonalToken) string { return fmt.Sprint(n.LastUsedAt) }, parse: parseIntCursor},
+	personaltoken.FieldCreatedAt:  {value: func(n *PersonalToken) string { return fmt.Sprint(n.CreatedAt) }, parse: parseIntCursor},
+}
+
+// Paginate returns a page of PersonalToken by cursor, rows are ordered by args.Field then id, and the Order of pager is ignored.
+// Cursors in args must be issued by the same signer with the same ordering.
+func (pt *PersonalTokenQuery) Paginate(
+	ctx context.Context, signer *CursorSigner, args CursorArgs, opts ...PersonalTokenPaginateOption,
+) (*PersonalTokenConnection, error) {
+
+	pager, err := newPersonalTokenPager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if pt, err = pager.ApplyFilter(pt); err != nil {
+		return nil, err
+	}
+
+	window, err := args.window(signer, "PersonalToken", personaltoken.FieldID)
+	if err != nil {
+		return nil, err
+	}
+	field, ok := personaltokenCursorFields[window.field]
+	if !ok {
+		return nil, fmt.Errorf("%w: PersonalToken can not be ordered by %q", ErrInvalidCursorArgs, window.field)
+	}
+	id := personaltokenCursorFields[personaltoken.FieldID]
+
+	predicates, err := window.predicates(id.parse, field.parse)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range predicates {
+		pt = pt.Where(p)
+	}
+
+	list, err := pt.Order(window.order()).Limit(window.limit + 1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, pageInfo, err := cursorPage(signer, window, list, id, field)
+	if err != nil {
+		return nil, err
+	}
+	return &PersonalTokenConnection{List: list, PageInfo: pageInfo}, nil
+}
+
+type RecoveryCodePager struct {
+	Order  recoverycode.OrderOption
+	Filter func(*RecoveryCodeQuery) (*RecoveryCodeQuery, error)
+}
+
+// RecoveryCodePaginateOption enables pagination customization.
+type RecoveryCodePaginateOption func(*RecoveryCodePager)
+
+// DefaultRecoveryCodeOrder is the default ordering of RecoveryCode.
+var DefaultRecoveryCodeOrder = Desc(recoverycode.FieldID)
+
+func newRecoveryCodePager(opts []RecoveryCodePaginateOption) (*RecoveryCodePager, error) {
+	pager := &RecoveryCodePager{}
+	for _, opt := range opts {
+		opt(pager)
+	}
+	if pager.Order == nil {
+		pager.Order = DefaultRecoveryCodeOrder
+	}
+	return pager, nil
+}
+
+func (p *RecoveryCodePager) ApplyFilter(query *RecoveryCodeQuery) (*RecoveryCodeQuery, error) {
+	if p.Filter != nil {
+		return p.Filter(query)
+	}
+	return query, nil
+}
+
+// RecoveryCodePageList is RecoveryCode PageList result.
+type RecoveryCodePageList struct {
+	List        []*RecoveryCode `json:"list"`
+	PageDetails *PageDetails    `json:"pageDetails"`
+}
+
+func (rc *RecoveryCodeQuery) Page(
+	ctx context.Context, pageNum uint64, pageSize uint64, opts ...RecoveryCodePaginateOption,
+) (*RecoveryCodePageList, error) {
+
+	pager, err := newRecoveryCodePager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if rc, err = pager.ApplyFilter(rc); err != nil {
+		return nil, err
+	}
+
+	ret := &RecoveryCodePageList{}
+
+	ret.PageDetails = &PageDetails{
+		Page: pageNum,
+		Size: pageSize,
+	}
+
+	query := rc.Clone()
+	query.ctx.Fields = nil
+	count, err := query.Count(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret.PageDetails.Total = uint64(count)
+
+	if pager.Order != nil {
+		rc = rc.Order(pager.Order)
+	} else {
+		rc = rc.Order(DefaultRecoveryCodeOrder)
+	}
+
+	rc = rc.Offset(int((pageNum - 1) * pageSize)).Limit(int(pageSize))
+	list, err := rc.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret.List = list
+
+	return ret, nil
+}
+
+// RecoveryCodeConnection is RecoveryCode cursor pagination result.
+type RecoveryCodeConnection struct {
+	List     []*RecoveryCode `json:"list"`
+	PageInfo PageInfo        `json:"pageInfo"`
+}
+
+// recoverycodeCursorFields are fields that RecoveryCode could be ordered by in cursor pagination.
+var recoverycodeCursorFields = map[string]cursorField[*RecoveryCode]{
+	recoverycode.FieldID:        {value: func(n *RecoveryCode) string { return fmt.Sprint(n.ID) }, parse: parseIntCursor},
+	recoverycode.FieldUserID:    {value: func(n *RecoveryCode) string { return fmt.Sprint(n.UserID) }, parse: parseIntCursor},
+	recoverycode.FieldUsedAt:    {value: func(n *RecoveryCode) string { return fmt.Sprint(n.UsedAt) }, parse: parseIntCursor},
+	recoverycode.FieldCreatedAt: {value: func(n *RecoveryCode) string { return fmt.Sprint(n.CreatedAt) }, parse: parseIntCursor},
+}
+
+// Paginate returns a page of RecoveryCode by cursor, rows are ordered by args.Field then id, and the Order of pager is ignored.
+// Cursors in args must be issued by the same signer with the same ordering.
+func (rc *RecoveryCodeQuery) Paginate(
+	ctx context.Context, signer *CursorSigner, args CursorArgs, opts ...RecoveryCodePaginateOption,
+) (*RecoveryCodeConnection, error) {
+
+	pager, err := newRecoveryCodePager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if rc, err = pager.ApplyFilter(rc); err != nil {
+		return nil, err
+	}
+
+	window, err := args.window(signer, "RecoveryCode", recoverycode.FieldID)
+	if err != nil {
+		return nil, err
+	}
+	field, ok := recoverycodeCursorFields[window.field]
+	if !ok {
+		return nil, fmt.Errorf("%w: RecoveryCode can not be ordered by %q", ErrInvalidCursorArgs, window.field)
+	}
+	id := recoverycodeCursorFields[recoverycode.FieldID]
+
+	predicates, err := window.predicates(id.parse, field.parse)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range predicates {
+		rc = rc.Where(p)
+	}
+
+	list, err := rc.Order(window.order()).Limit(window.limit + 1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, pageInfo, err := cursorPage(signer, window, list, id, field)
+	if err != nil {
+		return nil, err
+	}
+	return &RecoveryCodeConnection{List: list, PageInfo: pageInfo}, nil
+}
+
+type RolePager struct {
+	Order  role.OrderOption
+	Filter func(*RoleQuery) (*RoleQuery, error)
+}
+
+// RolePaginateOption enables pagination customization.
+type RolePaginateOption func(*RolePager)
+
+// DefaultRoleOrder is the default ordering of Role.
+var DefaultRoleOrder = Desc(role.FieldID)
+
+func newRolePager(opts []RolePaginateOption) (*RolePager, error) {
+	pager := &RolePager{}
+	for _, opt := range opts {
+		opt(pager)
+	}
+	if pager.Order == nil {
+		pager.Order = DefaultRoleOrder
+	}
+	return pager, nil
+}
+
+func (p *RolePager) ApplyFilter(query *RoleQuery) (*RoleQuery, error) {
+	if p.Filter != nil {
+		return p.Filter(query)
+	}
+	return query, nil
+}
+
+// RolePageList is Role PageList result.
+type RolePageList struct {
+	List        []*Role      `json:"list"`
+	PageDetails *PageDetails `json:"pageDetails"`
+}
+
+func (r *RoleQuery) Page(
+	ctx context.Context, pageNum uint64, pageSize uint64, opts ...RolePaginateOption,
+) (*RolePageList, error) {
+
+	pager, err := newRolePager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if r, err = pager.ApplyFilter(r); err != nil {
+		return nil, err
+	}
+
+	ret := &RolePageList{}
+
+	ret.PageDetails = &PageDetails{
+		Page: pageNum,
+		Size: pageSize,
+	}
+
+	query := r.Clone()
+	query.ctx.Fields = nil
+	count, err := query.Count(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret.PageDetails.Total = uint64(count)
+
+	if pager.Order != nil {
+		r = r.Order(pager.Order)
+	} else {
+		r = r.Order(DefaultRoleOrder)
+	}
+
+	r = r.Offset(int((pageNum - 1) * pageSize)).Limit(int(pageSize))
+	list, err := r.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret.List = list
+
+	return ret, nil
+}
+
+// RoleConnection is Role cursor pagination result.
+type RoleConnection struct {
+	List     []*Role  `json:"list"`
+	PageInfo PageInfo `json:"pageInfo"`
+}
+
+// roleCursorFields are fields that Role could be ordered by in cursor pagination.
+var roleCursorFields = map[string]cursorField[*Role]{
+	role.FieldID:          {value: func(n *Role) string { return fmt.Sprint(n.ID) }, parse: parseIntCursor},
+	role.FieldName:        {value: func(n *Role) string { return fmt.Sprint(n.Name) }, parse: parseStringCursor},
+	role.FieldDescription: {value: func(n *Role) string { return fmt.Sprint(n.Description) }, parse: parseStringCursor},
+	role.FieldCreatedAt:   {value: func(n *Role) string { return fmt.Sprint(n.CreatedAt) }, parse: parseIntCursor},
+	role.FieldUpdatedAt:   {value: func(n *Role) string { return fmt.Sprint(n.UpdatedAt) }, parse: parseIntCursor},
+}
+
+// Paginate returns a page of Role by cursor, rows are ordered by args.Field then id, and the Order of pager is ignored.
+// Cursors in args must be issued by the same signer with the same ordering.
+func (r *RoleQuery) Paginate(
+	ctx context.Context, signer *CursorSigner, args CursorArgs, opts ...RolePaginateOption,
+) (*RoleConnection, error) {
+
+	pager, err := newRolePager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if r, err = pager.ApplyFilter(r); err != nil {
+		return nil, err
+	}
+
+	window, err := args.window(signer, "Role", role.FieldID)
+	if err != nil {
+		return nil, err
+	}
+	field, ok := roleCursorFields[window.field]
+	if !ok {
+		return nil, fmt.Errorf("%w: Role can not be ordered by %q", ErrInvalidCursorArgs, window.field)
+	}
+	id := roleCursorFields[role.FieldID]
+
+	predicates, err := window.predicates(id.parse, field.parse)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range predicates {
+		r = r.Where(p)
+	}
+
+	list, err := r.Order(window.order()).Limit(window.limit + 1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, pageInfo, err := cursorPage(signer, window, list, id, field)
+	if err != nil {
+		return nil, err
+	}
+	return &RoleConnection{List: list, PageInfo: pageInfo}, nil
+}
+
+type SessionPager struct {
+	Order  session.OrderOption
+	Filter func(*SessionQuery) (*SessionQuery, error)
+}
+
+// SessionPaginateOption enables pagination customization.
+type SessionPaginateOption func(*SessionPager)
+
+// DefaultSessionOrder is the default ordering of Session.
+var DefaultSessionOrder = Desc(session.FieldID)
+
+func newSessionPager(opts []SessionPaginateOption) (*SessionPager, error) {
+	pager := &SessionPager{}
+	for _, opt := range opts {
+		opt(pager)
+	}
+	if pager.Order == nil {
+		pager.Order = DefaultSessionOrder
+	}
+	return pager, nil
+}
+
+func (p *SessionPager) ApplyFilter(query *SessionQuery) (*SessionQuery, error) {
+	if p.Filter != nil {
+		return p.Filter(query)
+	}
+	return query, nil
+}
+
+// SessionPageList is Session PageList result.
+type SessionPageList struct {
+	List        []*Session   `json:"list"`
+	PageDetails *PageDetails `json:"pageDetails"`
+}
+
+func (s *SessionQuery) Page(
+	ctx context.Context, pageNum uint64, pageSize uint64, opts ...SessionPaginateOption,
+) (*SessionPageList, error) {
+
+	pager, err := newSessionPager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if s, err = pager.ApplyFilter(s); err != nil {
+		return nil, err
+	}
+
+	ret := &SessionPageList{}
+
+	ret.PageDetails = &PageDetails{
+		Page: pageNum,
+		Size: pageSize,
+	}
+
+	query := s.Clone()
+	query.ctx.Fields = nil
+	count, err := query.Count(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret.PageDetails.Total = uint64(count)
+
+	if pager.Order != nil {
+		s = s.Order(pager.Order)
+	} else {
+		s = s.Order(DefaultSessionOrder)
+	}
+
+	s = s.Offset(int((pageNum - 1) * pageSize)).Limit(int(pageSize))
+	list, err := s.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret.List = list
+
+	return ret, nil
+}
+
+// SessionConnection is Session cursor pagination result.
+type SessionConnection struct {
+	List     []*Session `json:"list"`
+	PageInfo PageInfo   `json:"pageInfo"`
+}
+
+// sessionCursorFields are fields that Session could be ordered by in cursor pagination.
+var sessionCursorFields = map[string]cursorField[*Session]{
+	session.FieldID:         {value: func(n *Session) string { return fmt.Sprint(n.ID) }, parse: parseIntCursor},
+	session.FieldSid:        {value: func(n *Session) string { return fmt.Sprint(n.Sid) }, parse: parseStringCursor},
+	session.FieldUserID:     {value: func(n *Session) string { return fmt.Sprint(n.UserID) }, parse: parseIntCursor},
+	session.FieldAccessID:   {value: func(n *Session) string { return fmt.Sprint(n.AccessID) }, parse: parseStringCursor},
+	session.FieldRefreshID:  {value: func(n *Session) string { return fmt.Sprint(n.RefreshID) }, parse: parseStringCursor},
+	session.FieldUserAgent:  {value: func(n *Session) string { return fmt.Sprint(n.UserAgent) }, parse: parseStringCursor},
+	session.FieldIP:         {value: func(n *Session) string { return fmt.Sprint(n.IP) }, parse: parseStringCursor},
+	session.FieldCreatedAt:  {value: func(n *Session) string { return fmt.Sprint(n.CreatedAt) }, parse: parseIntCursor},
+	session.FieldLastSeenAt: {value: func(n *Session) string { return fmt.Sprint(n.LastSeenAt) }, parse: parseIntCursor},
+	session.FieldExpiresAt:  {value: func(n *Session) string { return fmt.Sprint(n.ExpiresAt) }, parse: parseIntCursor},
+}
+
+// Paginate returns a page of Session by cursor, rows are ordered by args.Field then id, and the Order of pager is ignored.
+// Cursors in args must be issued by the same signer with the same ordering.
+func (s *SessionQuery) Paginate(
+	ctx context.Context, signer *CursorSigner, args CursorArgs, opts ...SessionPaginateOption,
+) (*SessionConnection, error) {
+
+	pager, err := newSessionPager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if s, err = pager.ApplyFilter(s); err != nil {
+		return nil, err
+	}
+
+	window, err := args.window(signer, "Session", session.FieldID)
+	if err != nil {
+		return nil, err
+	}
+	field, ok := sessionCursorFields[window.field]
+	if !ok {
+		return nil, fmt.Errorf("%w: Session can not be ordered by %q", ErrInvalidCursorArgs, window.field)
+	}
+	id := sessionCursorFields[session.FieldID]
+
+	predicates, err := window.predicates(id.parse, field.parse)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range predicates {
+		s = s.Where(p)
+	}
+
+	list, err := s.Order(window.order()).Limit(window.limit + 1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, pageInfo, err := cursorPage(signer, window, list, id, field)
+	if err != nil {
+		return nil, err
+	}
+	return &SessionConnection{List: list, PageInfo: pageInfo}, nil
+}
+
 type UserPager struct {
 	Order  user.OrderOption
 	Filter func(*UserQuery) (*UserQuery, error)
@@ -135,3 +1822,74 @@ func (u *UserQuery) Page(
 
 	return ret, nil
 }
+
+// UserConnection is User cursor pagination result.
+type UserConnection struct {
+	List     []*User  `json:"list"`
+	PageInfo PageInfo `json:"pageInfo"`
+}
+
+// userCursorFields are fields that User could be ordered by in cursor pagination.
+var userCursorFields = map[string]cursorField[*User]{
+	user.FieldID:              {value: func(n *User) string { return fmt.Sprint(n.ID) }, parse: parseIntCursor},
+	user.FieldDeletedAt:       {value: func(n *User) string { return fmt.Sprint(n.DeletedAt) }, parse: parseIntCursor},
+	user.FieldUID:             {value: func(n *User) string { return fmt.Sprint(n.UID) }, parse: parseStringCursor},
+	user.FieldUsername:        {value: func(n *User) string { return fmt.Sprint(n.Username) }, parse: parseStringCursor},
+	user.FieldEmail:           {value: func(n *User) string { return fmt.Sprint(n.Email) }, parse: parseStringCursor},
+	user.FieldEmailVerifiedAt: {value: func(n *User) string { return fmt.Sprint(n.EmailVerifiedAt) }, parse: parseIntCursor},
+	user.FieldNickname:        {value: func(n *User) string { return fmt.Sprint(n.Nickname) }, parse: parseStringCursor},
+	user.FieldAvatarURL:       {value: func(n *User) string { return fmt.Sprint(n.AvatarURL) }, parse: parseStringCursor},
+	user.FieldBio:             {value: func(n *User) string { return fmt.Sprint(n.Bio) }, parse: parseStringCursor},
+	user.FieldAvatarKey:       {value: func(n *User) string { return fmt.Sprint(n.AvatarKey) }, parse: parseStringCursor},
+	user.FieldLocale:          {value: func(n *User) string { return fmt.Sprint(n.Locale) }, parse: parseStringCursor},
+	user.FieldTimezone:        {value: func(n *User) string { return fmt.Sprint(n.Timezone) }, parse: parseStringCursor},
+	user.FieldStatus:          {value: func(n *User) string { return fmt.Sprint(n.Status) }, parse: parseStringCursor},
+	user.FieldPurgeAt:         {value: func(n *User) string { return fmt.Sprint(n.PurgeAt) }, parse: parseIntCursor},
+	user.FieldCreatedAt:       {value: func(n *User) string { return fmt.Sprint(n.CreatedAt) }, parse: parseIntCursor},
+	user.FieldUpdatedAt:       {value: func(n *User) string { return fmt.Sprint(n.UpdatedAt) }, parse: parseIntCursor},
+}
+
+// Paginate returns a page of User by cursor, rows are ordered by args.Field then id, and the Order of pager is ignored.
+// Cursors in args must be issued by the same signer with the same ordering.
+func (u *UserQuery) Paginate(
+	ctx context.Context, signer *CursorSigner, args CursorArgs, opts ...UserPaginateOption,
+) (*UserConnection, error) {
+
+	pager, err := newUserPager(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if u, err = pager.ApplyFilter(u); err != nil {
+		return nil, err
+	}
+
+	window, err := args.window(signer, "User", user.FieldID)
+	if err != nil {
+		return nil, err
+	}
+	field, ok := userCursorFields[window.field]
+	if !ok {
+		return nil, fmt.Errorf("%w: User can not be ordered by %q", ErrInvalidCursorArgs, window.field)
+	}
+	id := userCursorFields[user.FieldID]
+
+	predicates, err := window.predicates(id.parse, field.parse)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range predicates {
+		u = u.Where(p)
+	}
+
+	list, err := u.Order(window.order()).Limit(window.limit + 1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, pageInfo, err := cursorPage(signer, window, list, id, field)
+	if err != nil {
+		return nil, err
+	}
+	return &UserConnection{List: list, PageInfo: pageInfo}, nil
+}
@@ -0,0 +1,239 @@
+package ent_test
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	_ "github.com/ginx-contribs/ent-sqlite"
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/ent/enttest"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+	"github.com/stretchr/testify/assert"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// newPaginationClient returns a client with 7 users, created_at of every two users are the same
+// to exercise ordering by id on ties.
+func newPaginationClient(t *testing.T) (*ent.Client, []*ent.User) {
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&_pragma=foreign_keys(1)", t.Name()))
+	t.Cleanup(func() { client.Close() })
+	var users []*ent.User
+	for i := range 7 {
+		created, err := client.User.Create().
+			SetUsername(fmt.Sprintf("u%d", 6-i)).
+			SetEmail(fmt.Sprintf("u%d@example.com", i)).
+			SetPassword("").
+			SetCreatedAt(int64(100 + i/2)).
+			Save(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		users = append(users, created)
+	}
+	return client, users
+}
+
+func ids(users []*ent.User) []int {
+	var result []int
+	for _, u := range users {
+		result = append(result, u.ID)
+	}
+	return result
+}
+
+// sortedIds returns ids of users ordered by the field then id in the direction
+func sortedIds(users []*ent.User, field string, direction ent.OrderDirection) []int {
+	sorted := slices.Clone(users)
+	slices.SortFunc(sorted, func(a, b *ent.User) int {
+		var c int
+		switch field {
+		case user.FieldCreatedAt:
+			c = cmp.Compare(a.CreatedAt, b.CreatedAt)
+		case user.FieldUsername:
+			c = cmp.Compare(a.Username, b.Username)
+		}
+		if c == 0 {
+			c = cmp.Compare(a.ID, b.ID)
+		}
+		if direction == ent.OrderDirectionDesc {
+			c = -c
+		}
+		return c
+	})
+	return ids(sorted)
+}
+
+func TestUserQuery_Paginate(t *testing.T) {
+	ctx := context.Background()
+	client, users := newPaginationClient(t)
+	signer := ent.NewCursorSigner([]byte("secret"))
+
+	samples := []struct {
+		field     string
+		direction ent.OrderDirection
+		expected  []int
+	}{
+		{"", "", sortedIds(users, user.FieldID, ent.OrderDirectionDesc)},
+		{user.FieldID, ent.OrderDirectionAsc, sortedIds(users, user.FieldID, ent.OrderDirectionAsc)},
+		{user.FieldCreatedAt, ent.OrderDirectionAsc, sortedIds(users, user.FieldCreatedAt, ent.OrderDirectionAsc)},
+		{user.FieldCreatedAt, ent.OrderDirectionDesc, sortedIds(users, user.FieldCreatedAt, ent.OrderDirectionDesc)},
+		{user.FieldUsername, ent.OrderDirectionAsc, sortedIds(users, user.FieldUsername, ent.OrderDirectionAsc)},
+	}
+	for _, sample := range samples {
+		name := sample.field + " " + string(sample.direction)
+
+		// walk forward by first and after
+		var forward []int
+		args := ent.CursorArgs{First: 3, Field: sample.field, Direction: sample.direction}
+		for page := 0; ; page++ {
+			conn, err := client.User.Query().Paginate(ctx, signer, args)
+			if !assert.NoError(t, err, name) {
+				break
+			}
+			assert.Equal(t, page > 0, conn.PageInfo.HasPreviousPage, name)
+			forward = append(forward, ids(conn.List)...)
+			if !conn.PageInfo.HasNextPage {
+				break
+			}
+			args.After = conn.PageInfo.EndCursor
+		}
+		assert.Equal(t, sample.expected, forward, name)
+
+		// walk backward by last and before, each page is still in the ordering
+		var backward []int
+		args = ent.CursorArgs{Last: 3, Field: sample.field, Direction: sample.direction}
+		for page := 0; ; page++ {
+			conn, err := client.User.Query().Paginate(ctx, signer, args)
+			if !assert.NoError(t, err, name) {
+				break
+			}
+			assert.Equal(t, page > 0, conn.PageInfo.HasNextPage, name)
+			backward = append(ids(conn.List), backward...)
+			if !conn.PageInfo.HasPreviousPage {
+				break
+			}
+			args.Before = conn.PageInfo.StartCursor
+		}
+		assert.Equal(t, sample.expected, backward, name)
+	}
+}
+
+func TestUserQuery_Paginate_Between(t *testing.T) {
+	ctx := context.Background()
+	client, users := newPaginationClient(t)
+	signer := ent.NewCursorSigner([]byte("secret"))
+	expected := sortedIds(users, user.FieldCreatedAt, ent.OrderDirectionAsc)
+
+	args := ent.CursorArgs{First: 1, Field: user.FieldCreatedAt, Direction: ent.OrderDirectionAsc}
+	first, err := client.User.Query().Paginate(ctx, signer, args)
+	if !assert.NoError(t, err) {
+		return
+	}
+	args.First = 6
+	last, err := client.User.Query().Paginate(ctx, signer, args)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// rows between the first and the sixth row
+	args = ent.CursorArgs{First: 10, After: first.PageInfo.EndCursor, Before: last.PageInfo.EndCursor, Field: args.Field, Direction: args.Direction}
+	conn, err := client.User.Query().Paginate(ctx, signer, args)
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected[1:5], ids(conn.List))
+		assert.False(t, conn.PageInfo.HasNextPage)
+		assert.True(t, conn.PageInfo.HasPreviousPage)
+	}
+
+	args.First, args.Last = 0, 2
+	conn, err = client.User.Query().Paginate(ctx, signer, args)
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected[3:5], ids(conn.List))
+		assert.True(t, conn.PageInfo.HasNextPage)
+		assert.True(t, conn.PageInfo.HasPreviousPage)
+	}
+
+	// empty page has no cursors
+	conn, err = client.User.Query().Where(user.UsernameEQ("nobody")).Paginate(ctx, signer, ent.CursorArgs{First: 1})
+	if assert.NoError(t, err) {
+		assert.Empty(t, conn.List)
+		assert.Empty(t, conn.PageInfo.StartCursor)
+		assert.Empty(t, conn.PageInfo.EndCursor)
+	}
+}
+
+func TestUserQuery_Paginate_InvalidCursor(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newPaginationClient(t)
+	signer := ent.NewCursorSigner([]byte("secret"))
+
+	conn, err := client.User.Query().Paginate(ctx, signer, ent.CursorArgs{First: 1, Field: user.FieldCreatedAt})
+	if !assert.NoError(t, err) {
+		return
+	}
+	cursor := conn.PageInfo.EndCursor
+	payload, signature, _ := strings.Cut(cursor, ".")
+	forge := func(c ent.Cursor) string {
+		forged, err := signer.Encode(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return forged
+	}
+	otherSigned, err := ent.NewCursorSigner([]byte("other")).Encode(ent.Cursor{Node: "User", Field: user.FieldCreatedAt, Direction: ent.OrderDirectionDesc, ID: "1", Value: "100"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	samples := []struct {
+		name   string
+		args   ent.CursorArgs
+		cursor string
+	}{
+		{"tampered payload", ent.CursorArgs{Field: user.FieldCreatedAt}, "A" + payload[1:] + "." + signature},
+		{"tampered signature", ent.CursorArgs{Field: user.FieldCreatedAt}, cursor + "x"},
+		{"missing signature", ent.CursorArgs{Field: user.FieldCreatedAt}, payload},
+		{"not base64", ent.CursorArgs{Field: user.FieldCreatedAt}, "!!!." + signature},
+		{"other signer", ent.CursorArgs{Field: user.FieldCreatedAt}, otherSigned},
+		{"other field", ent.CursorArgs{Field: user.FieldUsername}, cursor},
+		{"other field by default", ent.CursorArgs{}, cursor},
+		{"other direction", ent.CursorArgs{Field: user.FieldCreatedAt, Direction: ent.OrderDirectionAsc}, cursor},
+		{"other node", ent.CursorArgs{}, forge(ent.Cursor{Node: "Role", Field: user.FieldID, Direction: ent.OrderDirectionDesc, ID: "1"})},
+		{"invalid id", ent.CursorArgs{}, forge(ent.Cursor{Node: "User", Field: user.FieldID, Direction: ent.OrderDirectionDesc, ID: "x"})},
+		{"invalid value", ent.CursorArgs{Field: user.FieldCreatedAt}, forge(ent.Cursor{Node: "User", Field: user.FieldCreatedAt, Direction: ent.OrderDirectionDesc, ID: "1", Value: "x"})},
+	}
+	for _, sample := range samples {
+		args := sample.args
+		args.First, args.After = 1, sample.cursor
+		_, err := client.User.Query().Paginate(ctx, signer, args)
+		assert.ErrorIs(t, err, ent.ErrInvalidCursor, sample.name)
+
+		args.First, args.After, args.Last, args.Before = 0, "", 1, sample.cursor
+		_, err = client.User.Query().Paginate(ctx, signer, args)
+		assert.ErrorIs(t, err, ent.ErrInvalidCursor, sample.name)
+	}
+}
+
+func TestUserQuery_Paginate_InvalidArgs(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newPaginationClient(t)
+	signer := ent.NewCursorSigner([]byte("secret"))
+
+	samples := []struct {
+		name string
+		args ent.CursorArgs
+	}{
+		{"neither first nor last", ent.CursorArgs{}},
+		{"both first and last", ent.CursorArgs{First: 1, Last: 1}},
+		{"negative first", ent.CursorArgs{First: -1}},
+		{"negative last", ent.CursorArgs{First: 1, Last: -1}},
+		{"invalid direction", ent.CursorArgs{First: 1, Direction: "UP"}},
+		{"unknown field", ent.CursorArgs{First: 1, Field: "nothing"}},
+		{"sensitive field", ent.CursorArgs{First: 1, Field: user.FieldPassword}},
+	}
+	for _, sample := range samples {
+		_, err := client.User.Query().Paginate(ctx, signer, sample.args)
+		assert.ErrorIs(t, err, ent.ErrInvalidCursorArgs, sample.name)
+	}
+}
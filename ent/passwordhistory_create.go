@@ -0,0 +1,620 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ginx-contribs/ginx-server/ent/passwordhistory"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+)
+
+// PasswordHistoryCreate is the builder for creating a PasswordHistory entity.
+type PasswordHistoryCreate struct {
+	config
+	mutation *PasswordHistoryMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetUserID sets the "user_id" field.
+func (phc *PasswordHistoryCreate) SetUserID(i int) *PasswordHistoryCreate {
+	phc.mutation.SetUserID(i)
+	return phc
+}
+
+// SetPassword sets the "password" field.
+func (phc *PasswordHistoryCreate) SetPassword(s string) *PasswordHistoryCreate {
+	phc.mutation.SetPassword(s)
+	return phc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (phc *PasswordHistoryCreate) SetCreatedAt(i int64) *PasswordHistoryCreate {
+	phc.mutation.SetCreatedAt(i)
+	return phc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (phc *PasswordHistoryCreate) SetNillableCreatedAt(i *int64) *PasswordHistoryCreate {
+	if i != nil {
+		phc.SetCreatedAt(*i)
+	}
+	return phc
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (phc *PasswordHistoryCreate) SetUser(u *User) *PasswordHistoryCreate {
+	return phc.SetUserID(u.ID)
+}
+
+// Mutation returns the PasswordHistoryMutation object of the builder.
+func (phc *PasswordHistoryCreate) Mutation() *PasswordHistoryMutation {
+	return phc.mutation
+}
+
+// Save creates the PasswordHistory in the database.
+func (phc *PasswordHistoryCreate) Save(ctx context.Context) (*PasswordHistory, error) {
+	phc.defaults()
+	return withHooks(ctx, phc.sqlSave, phc.mutation, phc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (phc *PasswordHistoryCreate) SaveX(ctx context.Context) *PasswordHistory {
+	v, err := phc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (phc *PasswordHistoryCreate) Exec(ctx context.Context) error {
+	_, err := phc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (phc *PasswordHistoryCreate) ExecX(ctx context.Context) {
+	if err := phc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (phc *PasswordHistoryCreate) defaults() {
+	if _, ok := phc.mutation.CreatedAt(); !ok {
+		v := passwordhistory.DefaultCreatedAt()
+		phc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (phc *PasswordHistoryCreate) check() error {
+	if _, ok := phc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "PasswordHistory.user_id"`)}
+	}
+	if _, ok := phc.mutation.Password(); !ok {
+		return &ValidationError{Name: "password", err: errors.New(`ent: missing required field "PasswordHistory.password"`)}
+	}
+	if _, ok := phc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "PasswordHistory.created_at"`)}
+	}
+	if len(phc.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "PasswordHistory.user"`)}
+	}
+	return nil
+}
+
+func (phc *PasswordHistoryCreate) sqlSave(ctx context.Context) (*PasswordHistory, error) {
+	if err := phc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := phc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, phc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	phc.mutation.id = &_node.ID
+	phc.mutation.done = true
+	return _node, nil
+}
+
+func (phc *PasswordHistoryCreate) createSpec() (*PasswordHistory, *sqlgraph.CreateSpec) {
+	var (
+		_node = &PasswordHistory{config: phc.config}
+		_spec = sqlgraph.NewCreateSpec(passwordhistory.Table, sqlgraph.NewFieldSpec(passwordhistory.FieldID, field.TypeInt))
+	)
+	_spec.OnConflict = phc.conflict
+	if value, ok := phc.mutation.Password(); ok {
+		_spec.SetField(passwordhistory.FieldPassword, field.TypeString, value)
+		_node.Password = value
+	}
+	if value, ok := phc.mutation.CreatedAt(); ok {
+		_spec.SetField(passwordhistory.FieldCreatedAt, field.TypeInt64, value)
+		_node.CreatedAt = value
+	}
+	if nodes := phc.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   passwordhistory.UserTable,
+			Columns: []string{passwordhistory.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.UserID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.PasswordHistory.Create().
+//		SetUserID(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.PasswordHistoryUpsert) {
+//			SetUserID(v+v).
+//		}).
+//		Exec(ctx)
+func (phc *PasswordHistoryCreate) OnConflict(opts ...sql.ConflictOption) *PasswordHistoryUpsertOne {
+	phc.conflict = opts
+	return &PasswordHistoryUpsertOne{
+		create: phc,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.PasswordHistory.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (phc *PasswordHistoryCreate) OnConflictColumns(columns ...string) *PasswordHistoryUpsertOne {
+	phc.conflict = append(phc.conflict, sql.ConflictColumns(columns...))
+	return &PasswordHistoryUpsertOne{
+		create: phc,
+	}
+}
+
+type (
+	// PasswordHistoryUpsertOne is the builder for "upsert"-ing
+	//  one PasswordHistory node.
+	PasswordHistoryUpsertOne struct {
+		create *PasswordHistoryCreate
+	}
+
+	// PasswordHistoryUpsert is the "OnConflict" setter.
+	PasswordHistoryUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetUserID sets the "user_id" field.
+func (u *PasswordHistoryUpsert) SetUserID(v int) *PasswordHistoryUpsert {
+	u.Set(passwordhistory.FieldUserID, v)
+	return u
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *PasswordHistoryUpsert) UpdateUserID() *PasswordHistoryUpsert {
+	u.SetExcluded(passwordhistory.FieldUserID)
+	return u
+}
+
+// SetPassword sets the "password" field.
+func (u *PasswordHistoryUpsert) SetPassword(v string) *PasswordHistoryUpsert {
+	u.Set(passwordhistory.FieldPassword, v)
+	return u
+}
+
+// UpdatePassword sets the "password" field to the value that was provided on create.
+func (u *PasswordHistoryUpsert) UpdatePassword() *PasswordHistoryUpsert {
+	u.SetExcluded(passwordhistory.FieldPassword)
+	return u
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *PasswordHistoryUpsert) SetCreatedAt(v int64) *PasswordHistoryUpsert {
+	u.Set(passwordhistory.FieldCreatedAt, v)
+	return u
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *PasswordHistoryUpsert) UpdateCreatedAt() *PasswordHistoryUpsert {
+	u.SetExcluded(passwordhistory.FieldCreatedAt)
+	return u
+}
+
+// AddCreatedAt adds v to the "created_at" field.
+func (u *PasswordHistoryUpsert) AddCreatedAt(v int64) *PasswordHistoryUpsert {
+	u.Add(passwordhistory.FieldCreatedAt, v)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.PasswordHistory.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *PasswordHistoryUpsertOne) UpdateNewValues() *PasswordHistoryUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.PasswordHistory.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *PasswordHistoryUpsertOne) Ignore() *PasswordHistoryUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *PasswordHistoryUpsertOne) DoNothing() *PasswordHistoryUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the PasswordHistoryCreate.OnConflict
+// documentation for more info.
+func (u *PasswordHistoryUpsertOne) Update(set func(*PasswordHistoryUpsert)) *PasswordHistoryUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&PasswordHistoryUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUserID sets the "user_id" field.
+func (u *PasswordHistoryUpsertOne) SetUserID(v int) *PasswordHistoryUpsertOne {
+	return u.Update(func(s *PasswordHistoryUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *PasswordHistoryUpsertOne) UpdateUserID() *PasswordHistoryUpsertOne {
+	return u.Update(func(s *PasswordHistoryUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetPassword sets the "password" field.
+func (u *PasswordHistoryUpsertOne) SetPassword(v string) *PasswordHistoryUpsertOne {
+	return u.Update(func(s *PasswordHistoryUpsert) {
+		s.SetPassword(v)
+	})
+}
+
+// UpdatePassword sets the "password" field to the value that was provided on create.
+func (u *PasswordHistoryUpsertOne) UpdatePassword() *PasswordHistoryUpsertOne {
+	return u.Update(func(s *PasswordHistoryUpsert) {
+		s.UpdatePassword()
+	})
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *PasswordHistoryUpsertOne) SetCreatedAt(v int64) *PasswordHistoryUpsertOne {
+	return u.Update(func(s *PasswordHistoryUpsert) {
+		s.SetCreatedAt(v)
+	})
+}
+
+// AddCreatedAt adds v to the "created_at" field.
+func (u *PasswordHistoryUpsertOne) AddCreatedAt(v int64) *PasswordHistoryUpsertOne {
+	return u.Update(func(s *PasswordHistoryUpsert) {
+		s.AddCreatedAt(v)
+	})
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *PasswordHistoryUpsertOne) UpdateCreatedAt() *PasswordHistoryUpsertOne {
+	return u.Update(func(s *PasswordHistoryUpsert) {
+		s.UpdateCreatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *PasswordHistoryUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for PasswordHistoryCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *PasswordHistoryUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *PasswordHistoryUpsertOne) ID(ctx context.Context) (id int, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *PasswordHistoryUpsertOne) IDX(ctx context.Context) int {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// PasswordHistoryCreateBulk is the builder for creating many PasswordHistory entities in bulk.
+type PasswordHistoryCreateBulk struct {
+	config
+	err      error
+	builders []*PasswordHistoryCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the PasswordHistory entities in the database.
+func (phcb *PasswordHistoryCreateBulk) Save(ctx context.Context) ([]*PasswordHistory, error) {
+	if phcb.err != nil {
+		return nil, phcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(phcb.builders))
+	nodes := make([]*PasswordHistory, len(phcb.builders))
+	mutators := make([]Mutator, len(phcb.builders))
+	for i := range phcb.builders {
+		func(i int, root context.Context) {
+			builder := phcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*PasswordHistoryMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, phcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = phcb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, phcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, phcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (phcb *PasswordHistoryCreateBulk) SaveX(ctx context.Context) []*PasswordHistory {
+	v, err := phcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (phcb *PasswordHistoryCreateBulk) Exec(ctx context.Context) error {
+	_, err := phcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (phcb *PasswordHistoryCreateBulk) ExecX(ctx context.Context) {
+	if err := phcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.PasswordHistory.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.PasswordHistoryUpsert) {
+//			SetUserID(v+v).
+//		}).
+//		Exec(ctx)
+func (phcb *PasswordHistoryCreateBulk) OnConflict(opts ...sql.ConflictOption) *PasswordHistoryUpsertBulk {
+	phcb.conflict = opts
+	return &PasswordHistoryUpsertBulk{
+		create: phcb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.PasswordHistory.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (phcb *PasswordHistoryCreateBulk) OnConflictColumns(columns ...string) *PasswordHistoryUpsertBulk {
+	phcb.conflict = append(phcb.conflict, sql.ConflictColumns(columns...))
+	return &PasswordHistoryUpsertBulk{
+		create: phcb,
+	}
+}
+
+// PasswordHistoryUpsertBulk is the builder for "upsert"-ing
+// a bulk of PasswordHistory nodes.
+type PasswordHistoryUpsertBulk struct {
+	create *PasswordHistoryCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.PasswordHistory.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *PasswordHistoryUpsertBulk) UpdateNewValues() *PasswordHistoryUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.PasswordHistory.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *PasswordHistoryUpsertBulk) Ignore() *PasswordHistoryUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *PasswordHistoryUpsertBulk) DoNothing() *PasswordHistoryUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the PasswordHistoryCreateBulk.OnConflict
+// documentation for more info.
+func (u *PasswordHistoryUpsertBulk) Update(set func(*PasswordHistoryUpsert)) *PasswordHistoryUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&PasswordHistoryUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUserID sets the "user_id" field.
+func (u *PasswordHistoryUpsertBulk) SetUserID(v int) *PasswordHistoryUpsertBulk {
+	return u.Update(func(s *PasswordHistoryUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *PasswordHistoryUpsertBulk) UpdateUserID() *PasswordHistoryUpsertBulk {
+	return u.Update(func(s *PasswordHistoryUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetPassword sets the "password" field.
+func (u *PasswordHistoryUpsertBulk) SetPassword(v string) *PasswordHistoryUpsertBulk {
+	return u.Update(func(s *PasswordHistoryUpsert) {
+		s.SetPassword(v)
+	})
+}
+
+// UpdatePassword sets the "password" field to the value that was provided on create.
+func (u *PasswordHistoryUpsertBulk) UpdatePassword() *PasswordHistoryUpsertBulk {
+	return u.Update(func(s *PasswordHistoryUpsert) {
+		s.UpdatePassword()
+	})
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *PasswordHistoryUpsertBulk) SetCreatedAt(v int64) *PasswordHistoryUpsertBulk {
+	return u.Update(func(s *PasswordHistoryUpsert) {
+		s.SetCreatedAt(v)
+	})
+}
+
+// AddCreatedAt adds v to the "created_at" field.
+func (u *PasswordHistoryUpsertBulk) AddCreatedAt(v int64) *PasswordHistoryUpsertBulk {
+	return u.Update(func(s *PasswordHistoryUpsert) {
+		s.AddCreatedAt(v)
+	})
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *PasswordHistoryUpsertBulk) UpdateCreatedAt() *PasswordHistoryUpsertBulk {
+	return u.Update(func(s *PasswordHistoryUpsert) {
+		s.UpdateCreatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *PasswordHistoryUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the PasswordHistoryCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for PasswordHistoryCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *PasswordHistoryUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ginx-contribs/ginx-server/ent/passwordhistory"
+	"github.com/ginx-contribs/ginx-server/ent/predicate"
+)
+
+// PasswordHistoryDelete is the builder for deleting a PasswordHistory entity.
+type PasswordHistoryDelete struct {
+	config
+	hooks    []Hook
+	mutation *PasswordHistoryMutation
+}
+
+// Where appends a list predicates to the PasswordHistoryDelete builder.
+func (phd *PasswordHistoryDelete) Where(ps ...predicate.PasswordHistory) *PasswordHistoryDelete {
+	phd.mutation.Where(ps...)
+	return phd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (phd *PasswordHistoryDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, phd.sqlExec, phd.mutation, phd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (phd *PasswordHistoryDelete) ExecX(ctx context.Context) int {
+	n, err := phd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (phd *PasswordHistoryDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(passwordhistory.Table, sqlgraph.NewFieldSpec(passwordhistory.FieldID, field.TypeInt))
+	if ps := phd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, phd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	phd.mutation.done = true
+	return affected, err
+}
+
+// PasswordHistoryDeleteOne is the builder for deleting a single PasswordHistory entity.
+type PasswordHistoryDeleteOne struct {
+	phd *PasswordHistoryDelete
+}
+
+// Where appends a list predicates to the PasswordHistoryDelete builder.
+func (phdo *PasswordHistoryDeleteOne) Where(ps ...predicate.PasswordHistory) *PasswordHistoryDeleteOne {
+	phdo.phd.mutation.Where(ps...)
+	return phdo
+}
+
+// Exec executes the deletion query.
+func (phdo *PasswordHistoryDeleteOne) Exec(ctx context.Context) error {
+	n, err := phdo.phd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{passwordhistory.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (phdo *PasswordHistoryDeleteOne) ExecX(ctx context.Context) {
+	if err := phdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,410 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ginx-contribs/ginx-server/ent/passwordhistory"
+	"github.com/ginx-contribs/ginx-server/ent/predicate"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+)
+
+// PasswordHistoryUpdate is the builder for updating PasswordHistory entities.
+type PasswordHistoryUpdate struct {
+	config
+	hooks     []Hook
+	mutation  *PasswordHistoryMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// Where appends a list predicates to the PasswordHistoryUpdate builder.
+func (phu *PasswordHistoryUpdate) Where(ps ...predicate.PasswordHistory) *PasswordHistoryUpdate {
+	phu.mutation.Where(ps...)
+	return phu
+}
+
+// SetUserID sets the "user_id" field.
+func (phu *PasswordHistoryUpdate) SetUserID(i int) *PasswordHistoryUpdate {
+	phu.mutation.SetUserID(i)
+	return phu
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (phu *PasswordHistoryUpdate) SetNillableUserID(i *int) *PasswordHistoryUpdate {
+	if i != nil {
+		phu.SetUserID(*i)
+	}
+	return phu
+}
+
+// SetPassword sets the "password" field.
+func (phu *PasswordHistoryUpdate) SetPassword(s string) *PasswordHistoryUpdate {
+	phu.mutation.SetPassword(s)
+	return phu
+}
+
+// SetNillablePassword sets the "password" field if the given value is not nil.
+func (phu *PasswordHistoryUpdate) SetNillablePassword(s *string) *PasswordHistoryUpdate {
+	if s != nil {
+		phu.SetPassword(*s)
+	}
+	return phu
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (phu *PasswordHistoryUpdate) SetCreatedAt(i int64) *PasswordHistoryUpdate {
+	phu.mutation.ResetCreatedAt()
+	phu.mutation.SetCreatedAt(i)
+	return phu
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (phu *PasswordHistoryUpdate) SetNillableCreatedAt(i *int64) *PasswordHistoryUpdate {
+	if i != nil {
+		phu.SetCreatedAt(*i)
+	}
+	return phu
+}
+
+// AddCreatedAt adds i to the "created_at" field.
+func (phu *PasswordHistoryUpdate) AddCreatedAt(i int64) *PasswordHistoryUpdate {
+	phu.mutation.AddCreatedAt(i)
+	return phu
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (phu *PasswordHistoryUpdate) SetUser(u *User) *PasswordHistoryUpdate {
+	return phu.SetUserID(u.ID)
+}
+
+// Mutation returns the PasswordHistoryMutation object of the builder.
+func (phu *PasswordHistoryUpdate) Mutation() *PasswordHistoryMutation {
+	return phu.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (phu *PasswordHistoryUpdate) ClearUser() *PasswordHistoryUpdate {
+	phu.mutation.ClearUser()
+	return phu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (phu *PasswordHistoryUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, phu.sqlSave, phu.mutation, phu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (phu *PasswordHistoryUpdate) SaveX(ctx context.Context) int {
+	affected, err := phu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (phu *PasswordHistoryUpdate) Exec(ctx context.Context) error {
+	_, err := phu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (phu *PasswordHistoryUpdate) ExecX(ctx context.Context) {
+	if err := phu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (phu *PasswordHistoryUpdate) check() error {
+	if phu.mutation.UserCleared() && len(phu.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "PasswordHistory.user"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (phu *PasswordHistoryUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *PasswordHistoryUpdate {
+	phu.modifiers = append(phu.modifiers, modifiers...)
+	return phu
+}
+
+func (phu *PasswordHistoryUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := phu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(passwordhistory.Table, passwordhistory.Columns, sqlgraph.NewFieldSpec(passwordhistory.FieldID, field.TypeInt))
+	if ps := phu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := phu.mutation.Password(); ok {
+		_spec.SetField(passwordhistory.FieldPassword, field.TypeString, value)
+	}
+	if value, ok := phu.mutation.CreatedAt(); ok {
+		_spec.SetField(passwordhistory.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := phu.mutation.AddedCreatedAt(); ok {
+		_spec.AddField(passwordhistory.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if phu.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   passwordhistory.UserTable,
+			Columns: []string{passwordhistory.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := phu.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   passwordhistory.UserTable,
+			Columns: []string{passwordhistory.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(phu.modifiers...)
+	if n, err = sqlgraph.UpdateNodes(ctx, phu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{passwordhistory.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	phu.mutation.done = true
+	return n, nil
+}
+
+// PasswordHistoryUpdateOne is the builder for updating a single PasswordHistory entity.
+type PasswordHistoryUpdateOne struct {
+	config
+	fields    []string
+	hooks     []Hook
+	mutation  *PasswordHistoryMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// SetUserID sets the "user_id" field.
+func (phuo *PasswordHistoryUpdateOne) SetUserID(i int) *PasswordHistoryUpdateOne {
+	phuo.mutation.SetUserID(i)
+	return phuo
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (phuo *PasswordHistoryUpdateOne) SetNillableUserID(i *int) *PasswordHistoryUpdateOne {
+	if i != nil {
+		phuo.SetUserID(*i)
+	}
+	return phuo
+}
+
+// SetPassword sets the "password" field.
+func (phuo *PasswordHistoryUpdateOne) SetPassword(s string) *PasswordHistoryUpdateOne {
+	phuo.mutation.SetPassword(s)
+	return phuo
+}
+
+// SetNillablePassword sets the "password" field if the given value is not nil.
+func (phuo *PasswordHistoryUpdateOne) SetNillablePassword(s *string) *PasswordHistoryUpdateOne {
+	if s != nil {
+		phuo.SetPassword(*s)
+	}
+	return phuo
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (phuo *PasswordHistoryUpdateOne) SetCreatedAt(i int64) *PasswordHistoryUpdateOne {
+	phuo.mutation.ResetCreatedAt()
+	phuo.mutation.SetCreatedAt(i)
+	return phuo
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (phuo *PasswordHistoryUpdateOne) SetNillableCreatedAt(i *int64) *PasswordHistoryUpdateOne {
+	if i != nil {
+		phuo.SetCreatedAt(*i)
+	}
+	return phuo
+}
+
+// AddCreatedAt adds i to the "created_at" field.
+func (phuo *PasswordHistoryUpdateOne) AddCreatedAt(i int64) *PasswordHistoryUpdateOne {
+	phuo.mutation.AddCreatedAt(i)
+	return phuo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (phuo *PasswordHistoryUpdateOne) SetUser(u *User) *PasswordHistoryUpdateOne {
+	return phuo.SetUserID(u.ID)
+}
+
+// Mutation returns the PasswordHistoryMutation object of the builder.
+func (phuo *PasswordHistoryUpdateOne) Mutation() *PasswordHistoryMutation {
+	return phuo.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (phuo *PasswordHistoryUpdateOne) ClearUser() *PasswordHistoryUpdateOne {
+	phuo.mutation.ClearUser()
+	return phuo
+}
+
+// Where appends a list predicates to the PasswordHistoryUpdate builder.
+func (phuo *PasswordHistoryUpdateOne) Where(ps ...predicate.PasswordHistory) *PasswordHistoryUpdateOne {
+	phuo.mutation.Where(ps...)
+	return phuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (phuo *PasswordHistoryUpdateOne) Select(field string, fields ...string) *PasswordHistoryUpdateOne {
+	phuo.fields = append([]string{field}, fields...)
+	return phuo
+}
+
+// Save executes the query and returns the updated PasswordHistory entity.
+func (phuo *PasswordHistoryUpdateOne) Save(ctx context.Context) (*PasswordHistory, error) {
+	return withHooks(ctx, phuo.sqlSave, phuo.mutation, phuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (phuo *PasswordHistoryUpdateOne) SaveX(ctx context.Context) *PasswordHistory {
+	node, err := phuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (phuo *PasswordHistoryUpdateOne) Exec(ctx context.Context) error {
+	_, err := phuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (phuo *PasswordHistoryUpdateOne) ExecX(ctx context.Context) {
+	if err := phuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (phuo *PasswordHistoryUpdateOne) check() error {
+	if phuo.mutation.UserCleared() && len(phuo.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "PasswordHistory.user"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (phuo *PasswordHistoryUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *PasswordHistoryUpdateOne {
+	phuo.modifiers = append(phuo.modifiers, modifiers...)
+	return phuo
+}
+
+func (phuo *PasswordHistoryUpdateOne) sqlSave(ctx context.Context) (_node *PasswordHistory, err error) {
+	if err := phuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(passwordhistory.Table, passwordhistory.Columns, sqlgraph.NewFieldSpec(passwordhistory.FieldID, field.TypeInt))
+	id, ok := phuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "PasswordHistory.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := phuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, passwordhistory.FieldID)
+		for _, f := range fields {
+			if !passwordhistory.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != passwordhistory.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := phuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := phuo.mutation.Password(); ok {
+		_spec.SetField(passwordhistory.FieldPassword, field.TypeString, value)
+	}
+	if value, ok := phuo.mutation.CreatedAt(); ok {
+		_spec.SetField(passwordhistory.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := phuo.mutation.AddedCreatedAt(); ok {
+		_spec.AddField(passwordhistory.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if phuo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   passwordhistory.UserTable,
+			Columns: []string{passwordhistory.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := phuo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   passwordhistory.UserTable,
+			Columns: []string{passwordhistory.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(phuo.modifiers...)
+	_node = &PasswordHistory{config: phuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, phuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{passwordhistory.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	phuo.mutation.done = true
+	return _node, nil
+}
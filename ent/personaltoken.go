@@ -0,0 +1,213 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/ginx-contribs/ginx-server/ent/personaltoken"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+)
+
+// personal access token table
+type PersonalToken struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// public id embedded in the key, used to look up the key
+	KeyID string `json:"key_id,omitempty"`
+	// sha256 hash of the key secret
+	SecretHash string `json:"-"`
+	// UserID holds the value of the "user_id" field.
+	UserID int `json:"user_id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// scopes granted to the key
+	Scopes []string `json:"scopes,omitempty"`
+	// 0 means never expire
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+	// 0 means never used
+	LastUsedAt int64 `json:"last_used_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt int64 `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the PersonalTokenQuery when eager-loading is set.
+	Edges        PersonalTokenEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// PersonalTokenEdges holds the relations/edges for other nodes in the graph.
+type PersonalTokenEdges struct {
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e PersonalTokenEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*PersonalToken) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case personaltoken.FieldScopes:
+			values[i] = new([]byte)
+		case personaltoken.FieldID, personaltoken.FieldUserID, personaltoken.FieldExpiresAt, personaltoken.FieldLastUsedAt, personaltoken.FieldCreatedAt:
+			values[i] = new(sql.NullInt64)
+		case personaltoken.FieldKeyID, personaltoken.FieldSecretHash, personaltoken.FieldName:
+			values[i] = new(sql.NullString)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the PersonalToken fields.
+func (pt *PersonalToken) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case personaltoken.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			pt.ID = int(value.Int64)
+		case personaltoken.FieldKeyID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field key_id", values[i])
+			} else if value.Valid {
+				pt.KeyID = value.String
+			}
+		case personaltoken.FieldSecretHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field secret_hash", values[i])
+			} else if value.Valid {
+				pt.SecretHash = value.String
+			}
+		case personaltoken.FieldUserID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value.Valid {
+				pt.UserID = int(value.Int64)
+			}
+		case personaltoken.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				pt.Name = value.String
+			}
+		case personaltoken.FieldScopes:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field scopes", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &pt.Scopes); err != nil {
+					return fmt.Errorf("unmarshal field scopes: %w", err)
+				}
+			}
+		case personaltoken.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				pt.ExpiresAt = value.Int64
+			}
+		case personaltoken.FieldLastUsedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field last_used_at", values[i])
+			} else if value.Valid {
+				pt.LastUsedAt = value.Int64
+			}
+		case personaltoken.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				pt.CreatedAt = value.Int64
+			}
+		default:
+			pt.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the PersonalToken.
+// This includes values selected through modifiers, order, etc.
+func (pt *PersonalToken) Value(name string) (ent.Value, error) {
+	return pt.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the PersonalToken entity.
+func (pt *PersonalToken) QueryUser() *UserQuery {
+	return NewPersonalTokenClient(pt.config).QueryUser(pt)
+}
+
+// Update returns a builder for updating this PersonalToken.
+// Note that you need to call PersonalToken.Unwrap() before calling this method if this PersonalToken
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (pt *PersonalToken) Update() *PersonalTokenUpdateOne {
+	return NewPersonalTokenClient(pt.config).UpdateOne(pt)
+}
+
+// Unwrap unwraps the PersonalToken entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (pt *PersonalToken) Unwrap() *PersonalToken {
+	_tx, ok := pt.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: PersonalToken is not a transactional entity")
+	}
+	pt.config.driver = _tx.drv
+	return pt
+}
+
+// String implements the fmt.Stringer.
+func (pt *PersonalToken) String() string {
+	var builder strings.Builder
+	builder.WriteString("PersonalToken(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", pt.ID))
+	builder.WriteString("key_id=")
+	builder.WriteString(pt.KeyID)
+	builder.WriteString(", ")
+	builder.WriteString("secret_hash=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", pt.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("name=")
+	builder.WriteString(pt.Name)
+	builder.WriteString(", ")
+	builder.WriteString("scopes=")
+	builder.WriteString(fmt.Sprintf("%v", pt.Scopes))
+	builder.WriteString(", ")
+	builder.WriteString("expires_at=")
+	builder.WriteString(fmt.Sprintf("%v", pt.ExpiresAt))
+	builder.WriteString(", ")
+	builder.WriteString("last_used_at=")
+	builder.WriteString(fmt.Sprintf("%v", pt.LastUsedAt))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(fmt.Sprintf("%v", pt.CreatedAt))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// PersonalTokens is a parsable slice of PersonalToken.
+type PersonalTokens []*PersonalToken
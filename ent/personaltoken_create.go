@@ -0,0 +1,966 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ginx-contribs/ginx-server/ent/personaltoken"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+)
+
+// PersonalTokenCreate is the builder for creating a PersonalToken entity.
+type PersonalTokenCreate struct {
+	config
+	mutation *PersonalTokenMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetKeyID sets the "key_id" field.
+func (ptc *PersonalTokenCreate) SetKeyID(s string) *PersonalTokenCreate {
+	ptc.mutation.SetKeyID(s)
+	return ptc
+}
+
+// SetSecretHash sets the "secret_hash" field.
+func (ptc *PersonalTokenCreate) SetSecretHash(s string) *PersonalTokenCreate {
+	ptc.mutation.SetSecretHash(s)
+	return ptc
+}
+
+// SetUserID sets the "user_id" field.
+func (ptc *PersonalTokenCreate) SetUserID(i int) *PersonalTokenCreate {
+	ptc.mutation.SetUserID(i)
+	return ptc
+}
+
+// SetName sets the "name" field.
+func (ptc *PersonalTokenCreate) SetName(s string) *PersonalTokenCreate {
+	ptc.mutation.SetName(s)
+	return ptc
+}
+
+// SetScopes sets the "scopes" field.
+func (ptc *PersonalTokenCreate) SetScopes(s []string) *PersonalTokenCreate {
+	ptc.mutation.SetScopes(s)
+	return ptc
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (ptc *PersonalTokenCreate) SetExpiresAt(i int64) *PersonalTokenCreate {
+	ptc.mutation.SetExpiresAt(i)
+	return ptc
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (ptc *PersonalTokenCreate) SetNillableExpiresAt(i *int64) *PersonalTokenCreate {
+	if i != nil {
+		ptc.SetExpiresAt(*i)
+	}
+	return ptc
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (ptc *PersonalTokenCreate) SetLastUsedAt(i int64) *PersonalTokenCreate {
+	ptc.mutation.SetLastUsedAt(i)
+	return ptc
+}
+
+// SetNillableLastUsedAt sets the "last_used_at" field if the given value is not nil.
+func (ptc *PersonalTokenCreate) SetNillableLastUsedAt(i *int64) *PersonalTokenCreate {
+	if i != nil {
+		ptc.SetLastUsedAt(*i)
+	}
+	return ptc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (ptc *PersonalTokenCreate) SetCreatedAt(i int64) *PersonalTokenCreate {
+	ptc.mutation.SetCreatedAt(i)
+	return ptc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (ptc *PersonalTokenCreate) SetNillableCreatedAt(i *int64) *PersonalTokenCreate {
+	if i != nil {
+		ptc.SetCreatedAt(*i)
+	}
+	return ptc
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (ptc *PersonalTokenCreate) SetUser(u *User) *PersonalTokenCreate {
+	return ptc.SetUserID(u.ID)
+}
+
+// Mutation returns the PersonalTokenMutation object of the builder.
+func (ptc *PersonalTokenCreate) Mutation() *PersonalTokenMutation {
+	return ptc.mutation
+}
+
+// Save creates the PersonalToken in the database.
+func (ptc *PersonalTokenCreate) Save(ctx context.Context) (*PersonalToken, error) {
+	ptc.defaults()
+	return withHooks(ctx, ptc.sqlSave, ptc.mutation, ptc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (ptc *PersonalTokenCreate) SaveX(ctx context.Context) *PersonalToken {
+	v, err := ptc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ptc *PersonalTokenCreate) Exec(ctx context.Context) error {
+	_, err := ptc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ptc *PersonalTokenCreate) ExecX(ctx context.Context) {
+	if err := ptc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ptc *PersonalTokenCreate) defaults() {
+	if _, ok := ptc.mutation.ExpiresAt(); !ok {
+		v := personaltoken.DefaultExpiresAt
+		ptc.mutation.SetExpiresAt(v)
+	}
+	if _, ok := ptc.mutation.LastUsedAt(); !ok {
+		v := personaltoken.DefaultLastUsedAt
+		ptc.mutation.SetLastUsedAt(v)
+	}
+	if _, ok := ptc.mutation.CreatedAt(); !ok {
+		v := personaltoken.DefaultCreatedAt()
+		ptc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ptc *PersonalTokenCreate) check() error {
+	if _, ok := ptc.mutation.KeyID(); !ok {
+		return &ValidationError{Name: "key_id", err: errors.New(`ent: missing required field "PersonalToken.key_id"`)}
+	}
+	if _, ok := ptc.mutation.SecretHash(); !ok {
+		return &ValidationError{Name: "secret_hash", err: errors.New(`ent: missing required field "PersonalToken.secret_hash"`)}
+	}
+	if _, ok := ptc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "PersonalToken.user_id"`)}
+	}
+	if _, ok := ptc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "PersonalToken.name"`)}
+	}
+	if _, ok := ptc.mutation.ExpiresAt(); !ok {
+		return &ValidationError{Name: "expires_at", err: errors.New(`ent: missing required field "PersonalToken.expires_at"`)}
+	}
+	if _, ok := ptc.mutation.LastUsedAt(); !ok {
+		return &ValidationError{Name: "last_used_at", err: errors.New(`ent: missing required field "PersonalToken.last_used_at"`)}
+	}
+	if _, ok := ptc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "PersonalToken.created_at"`)}
+	}
+	if len(ptc.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "PersonalToken.user"`)}
+	}
+	return nil
+}
+
+func (ptc *PersonalTokenCreate) sqlSave(ctx context.Context) (*PersonalToken, error) {
+	if err := ptc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := ptc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, ptc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	ptc.mutation.id = &_node.ID
+	ptc.mutation.done = true
+	return _node, nil
+}
+
+func (ptc *PersonalTokenCreate) createSpec() (*PersonalToken, *sqlgraph.CreateSpec) {
+	var (
+		_node = &PersonalToken{config: ptc.config}
+		_spec = sqlgraph.NewCreateSpec(personaltoken.Table, sqlgraph.NewFieldSpec(personaltoken.FieldID, field.TypeInt))
+	)
+	_spec.OnConflict = ptc.conflict
+	if value, ok := ptc.mutation.KeyID(); ok {
+		_spec.SetField(personaltoken.FieldKeyID, field.TypeString, value)
+		_node.KeyID = value
+	}
+	if value, ok := ptc.mutation.SecretHash(); ok {
+		_spec.SetField(personaltoken.FieldSecretHash, field.TypeString, value)
+		_node.SecretHash = value
+	}
+	if value, ok := ptc.mutation.Name(); ok {
+		_spec.SetField(personaltoken.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := ptc.mutation.Scopes(); ok {
+		_spec.SetField(personaltoken.FieldScopes, field.TypeJSON, value)
+		_node.Scopes = value
+	}
+	if value, ok := ptc.mutation.ExpiresAt(); ok {
+		_spec.SetField(personaltoken.FieldExpiresAt, field.TypeInt64, value)
+		_node.ExpiresAt = value
+	}
+	if value, ok := ptc.mutation.LastUsedAt(); ok {
+		_spec.SetField(personaltoken.FieldLastUsedAt, field.TypeInt64, value)
+		_node.LastUsedAt = value
+	}
+	if value, ok := ptc.mutation.CreatedAt(); ok {
+		_spec.SetField(personaltoken.FieldCreatedAt, field.TypeInt64, value)
+		_node.CreatedAt = value
+	}
+	if nodes := ptc.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   personaltoken.UserTable,
+			Columns: []string{personaltoken.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.UserID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.PersonalToken.Create().
+//		SetKeyID(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.PersonalTokenUpsert) {
+//			SetKeyID(v+v).
+//		}).
+//		Exec(ctx)
+func (ptc *PersonalTokenCreate) OnConflict(opts ...sql.ConflictOption) *PersonalTokenUpsertOne {
+	ptc.conflict = opts
+	return &PersonalTokenUpsertOne{
+		create: ptc,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.PersonalToken.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (ptc *PersonalTokenCreate) OnConflictColumns(columns ...string) *PersonalTokenUpsertOne {
+	ptc.conflict = append(ptc.conflict, sql.ConflictColumns(columns...))
+	return &PersonalTokenUpsertOne{
+		create: ptc,
+	}
+}
+
+type (
+	// PersonalTokenUpsertOne is the builder for "upsert"-ing
+	//  one PersonalToken node.
+	PersonalTokenUpsertOne struct {
+		create *PersonalTokenCreate
+	}
+
+	// PersonalTokenUpsert is the "OnConflict" setter.
+	PersonalTokenUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetKeyID sets the "key_id" field.
+func (u *PersonalTokenUpsert) SetKeyID(v string) *PersonalTokenUpsert {
+	u.Set(personaltoken.FieldKeyID, v)
+	return u
+}
+
+// UpdateKeyID sets the "key_id" field to the value that was provided on create.
+func (u *PersonalTokenUpsert) UpdateKeyID() *PersonalTokenUpsert {
+	u.SetExcluded(personaltoken.FieldKeyID)
+	return u
+}
+
+// SetSecretHash sets the "secret_hash" field.
+func (u *PersonalTokenUpsert) SetSecretHash(v string) *PersonalTokenUpsert {
+	u.Set(personaltoken.FieldSecretHash, v)
+	return u
+}
+
+// UpdateSecretHash sets the "secret_hash" field to the value that was provided on create.
+func (u *PersonalTokenUpsert) UpdateSecretHash() *PersonalTokenUpsert {
+	u.SetExcluded(personaltoken.FieldSecretHash)
+	return u
+}
+
+// SetUserID sets the "user_id" field.
+func (u *PersonalTokenUpsert) SetUserID(v int) *PersonalTokenUpsert {
+	u.Set(personaltoken.FieldUserID, v)
+	return u
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *PersonalTokenUpsert) UpdateUserID() *PersonalTokenUpsert {
+	u.SetExcluded(personaltoken.FieldUserID)
+	return u
+}
+
+// SetName sets the "name" field.
+func (u *PersonalTokenUpsert) SetName(v string) *PersonalTokenUpsert {
+	u.Set(personaltoken.FieldName, v)
+	return u
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *PersonalTokenUpsert) UpdateName() *PersonalTokenUpsert {
+	u.SetExcluded(personaltoken.FieldName)
+	return u
+}
+
+// SetScopes sets the "scopes" field.
+func (u *PersonalTokenUpsert) SetScopes(v []string) *PersonalTokenUpsert {
+	u.Set(personaltoken.FieldScopes, v)
+	return u
+}
+
+// UpdateScopes sets the "scopes" field to the value that was provided on create.
+func (u *PersonalTokenUpsert) UpdateScopes() *PersonalTokenUpsert {
+	u.SetExcluded(personaltoken.FieldScopes)
+	return u
+}
+
+// ClearScopes clears the value of the "scopes" field.
+func (u *PersonalTokenUpsert) ClearScopes() *PersonalTokenUpsert {
+	u.SetNull(personaltoken.FieldScopes)
+	return u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *PersonalTokenUpsert) SetExpiresAt(v int64) *PersonalTokenUpsert {
+	u.Set(personaltoken.FieldExpiresAt, v)
+	return u
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *PersonalTokenUpsert) UpdateExpiresAt() *PersonalTokenUpsert {
+	u.SetExcluded(personaltoken.FieldExpiresAt)
+	return u
+}
+
+// AddExpiresAt adds v to the "expires_at" field.
+func (u *PersonalTokenUpsert) AddExpiresAt(v int64) *PersonalTokenUpsert {
+	u.Add(personaltoken.FieldExpiresAt, v)
+	return u
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (u *PersonalTokenUpsert) SetLastUsedAt(v int64) *PersonalTokenUpsert {
+	u.Set(personaltoken.FieldLastUsedAt, v)
+	return u
+}
+
+// UpdateLastUsedAt sets the "last_used_at" field to the value that was provided on create.
+func (u *PersonalTokenUpsert) UpdateLastUsedAt() *PersonalTokenUpsert {
+	u.SetExcluded(personaltoken.FieldLastUsedAt)
+	return u
+}
+
+// AddLastUsedAt adds v to the "last_used_at" field.
+func (u *PersonalTokenUpsert) AddLastUsedAt(v int64) *PersonalTokenUpsert {
+	u.Add(personaltoken.FieldLastUsedAt, v)
+	return u
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *PersonalTokenUpsert) SetCreatedAt(v int64) *PersonalTokenUpsert {
+	u.Set(personaltoken.FieldCreatedAt, v)
+	return u
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *PersonalTokenUpsert) UpdateCreatedAt() *PersonalTokenUpsert {
+	u.SetExcluded(personaltoken.FieldCreatedAt)
+	return u
+}
+
+// AddCreatedAt adds v to the "created_at" field.
+func (u *PersonalTokenUpsert) AddCreatedAt(v int64) *PersonalTokenUpsert {
+	u.Add(personaltoken.FieldCreatedAt, v)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.PersonalToken.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *PersonalTokenUpsertOne) UpdateNewValues() *PersonalTokenUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.PersonalToken.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *PersonalTokenUpsertOne) Ignore() *PersonalTokenUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *PersonalTokenUpsertOne) DoNothing() *PersonalTokenUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the PersonalTokenCreate.OnConflict
+// documentation for more info.
+func (u *PersonalTokenUpsertOne) Update(set func(*PersonalTokenUpsert)) *PersonalTokenUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&PersonalTokenUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetKeyID sets the "key_id" field.
+func (u *PersonalTokenUpsertOne) SetKeyID(v string) *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.SetKeyID(v)
+	})
+}
+
+// UpdateKeyID sets the "key_id" field to the value that was provided on create.
+func (u *PersonalTokenUpsertOne) UpdateKeyID() *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.UpdateKeyID()
+	})
+}
+
+// SetSecretHash sets the "secret_hash" field.
+func (u *PersonalTokenUpsertOne) SetSecretHash(v string) *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.SetSecretHash(v)
+	})
+}
+
+// UpdateSecretHash sets the "secret_hash" field to the value that was provided on create.
+func (u *PersonalTokenUpsertOne) UpdateSecretHash() *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.UpdateSecretHash()
+	})
+}
+
+// SetUserID sets the "user_id" field.
+func (u *PersonalTokenUpsertOne) SetUserID(v int) *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *PersonalTokenUpsertOne) UpdateUserID() *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetName sets the "name" field.
+func (u *PersonalTokenUpsertOne) SetName(v string) *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.SetName(v)
+	})
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *PersonalTokenUpsertOne) UpdateName() *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.UpdateName()
+	})
+}
+
+// SetScopes sets the "scopes" field.
+func (u *PersonalTokenUpsertOne) SetScopes(v []string) *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.SetScopes(v)
+	})
+}
+
+// UpdateScopes sets the "scopes" field to the value that was provided on create.
+func (u *PersonalTokenUpsertOne) UpdateScopes() *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.UpdateScopes()
+	})
+}
+
+// ClearScopes clears the value of the "scopes" field.
+func (u *PersonalTokenUpsertOne) ClearScopes() *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.ClearScopes()
+	})
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *PersonalTokenUpsertOne) SetExpiresAt(v int64) *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.SetExpiresAt(v)
+	})
+}
+
+// AddExpiresAt adds v to the "expires_at" field.
+func (u *PersonalTokenUpsertOne) AddExpiresAt(v int64) *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.AddExpiresAt(v)
+	})
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *PersonalTokenUpsertOne) UpdateExpiresAt() *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.UpdateExpiresAt()
+	})
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (u *PersonalTokenUpsertOne) SetLastUsedAt(v int64) *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.SetLastUsedAt(v)
+	})
+}
+
+// AddLastUsedAt adds v to the "last_used_at" field.
+func (u *PersonalTokenUpsertOne) AddLastUsedAt(v int64) *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.AddLastUsedAt(v)
+	})
+}
+
+// UpdateLastUsedAt sets the "last_used_at" field to the value that was provided on create.
+func (u *PersonalTokenUpsertOne) UpdateLastUsedAt() *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.UpdateLastUsedAt()
+	})
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *PersonalTokenUpsertOne) SetCreatedAt(v int64) *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.SetCreatedAt(v)
+	})
+}
+
+// AddCreatedAt adds v to the "created_at" field.
+func (u *PersonalTokenUpsertOne) AddCreatedAt(v int64) *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.AddCreatedAt(v)
+	})
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *PersonalTokenUpsertOne) UpdateCreatedAt() *PersonalTokenUpsertOne {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.UpdateCreatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *PersonalTokenUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for PersonalTokenCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *PersonalTokenUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *PersonalTokenUpsertOne) ID(ctx context.Context) (id int, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *PersonalTokenUpsertOne) IDX(ctx context.Context) int {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// PersonalTokenCreateBulk is the builder for creating many PersonalToken entities in bulk.
+type PersonalTokenCreateBulk struct {
+	config
+	err      error
+	builders []*PersonalTokenCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the PersonalToken entities in the database.
+func (ptcb *PersonalTokenCreateBulk) Save(ctx context.Context) ([]*PersonalToken, error) {
+	if ptcb.err != nil {
+		return nil, ptcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(ptcb.builders))
+	nodes := make([]*PersonalToken, len(ptcb.builders))
+	mutators := make([]Mutator, len(ptcb.builders))
+	for i := range ptcb.builders {
+		func(i int, root context.Context) {
+			builder := ptcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*PersonalTokenMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ptcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = ptcb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ptcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ptcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ptcb *PersonalTokenCreateBulk) SaveX(ctx context.Context) []*PersonalToken {
+	v, err := ptcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ptcb *PersonalTokenCreateBulk) Exec(ctx context.Context) error {
+	_, err := ptcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ptcb *PersonalTokenCreateBulk) ExecX(ctx context.Context) {
+	if err := ptcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.PersonalToken.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.PersonalTokenUpsert) {
+//			SetKeyID(v+v).
+//		}).
+//		Exec(ctx)
+func (ptcb *PersonalTokenCreateBulk) OnConflict(opts ...sql.ConflictOption) *PersonalTokenUpsertBulk {
+	ptcb.conflict = opts
+	return &PersonalTokenUpsertBulk{
+		create: ptcb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.PersonalToken.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (ptcb *PersonalTokenCreateBulk) OnConflictColumns(columns ...string) *PersonalTokenUpsertBulk {
+	ptcb.conflict = append(ptcb.conflict, sql.ConflictColumns(columns...))
+	return &PersonalTokenUpsertBulk{
+		create: ptcb,
+	}
+}
+
+// PersonalTokenUpsertBulk is the builder for "upsert"-ing
+// a bulk of PersonalToken nodes.
+type PersonalTokenUpsertBulk struct {
+	create *PersonalTokenCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.PersonalToken.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *PersonalTokenUpsertBulk) UpdateNewValues() *PersonalTokenUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.PersonalToken.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *PersonalTokenUpsertBulk) Ignore() *PersonalTokenUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *PersonalTokenUpsertBulk) DoNothing() *PersonalTokenUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the PersonalTokenCreateBulk.OnConflict
+// documentation for more info.
+func (u *PersonalTokenUpsertBulk) Update(set func(*PersonalTokenUpsert)) *PersonalTokenUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&PersonalTokenUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetKeyID sets the "key_id" field.
+func (u *PersonalTokenUpsertBulk) SetKeyID(v string) *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.SetKeyID(v)
+	})
+}
+
+// UpdateKeyID sets the "key_id" field to the value that was provided on create.
+func (u *PersonalTokenUpsertBulk) UpdateKeyID() *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.UpdateKeyID()
+	})
+}
+
+// SetSecretHash sets the "secret_hash" field.
+func (u *PersonalTokenUpsertBulk) SetSecretHash(v string) *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.SetSecretHash(v)
+	})
+}
+
+// UpdateSecretHash sets the "secret_hash" field to the value that was provided on create.
+func (u *PersonalTokenUpsertBulk) UpdateSecretHash() *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.UpdateSecretHash()
+	})
+}
+
+// SetUserID sets the "user_id" field.
+func (u *PersonalTokenUpsertBulk) SetUserID(v int) *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *PersonalTokenUpsertBulk) UpdateUserID() *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetName sets the "name" field.
+func (u *PersonalTokenUpsertBulk) SetName(v string) *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.SetName(v)
+	})
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *PersonalTokenUpsertBulk) UpdateName() *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.UpdateName()
+	})
+}
+
+// SetScopes sets the "scopes" field.
+func (u *PersonalTokenUpsertBulk) SetScopes(v []string) *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.SetScopes(v)
+	})
+}
+
+// UpdateScopes sets the "scopes" field to the value that was provided on create.
+func (u *PersonalTokenUpsertBulk) UpdateScopes() *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.UpdateScopes()
+	})
+}
+
+// ClearScopes clears the value of the "scopes" field.
+func (u *PersonalTokenUpsertBulk) ClearScopes() *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.ClearScopes()
+	})
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *PersonalTokenUpsertBulk) SetExpiresAt(v int64) *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.SetExpiresAt(v)
+	})
+}
+
+// AddExpiresAt adds v to the "expires_at" field.
+func (u *PersonalTokenUpsertBulk) AddExpiresAt(v int64) *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.AddExpiresAt(v)
+	})
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *PersonalTokenUpsertBulk) UpdateExpiresAt() *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.UpdateExpiresAt()
+	})
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (u *PersonalTokenUpsertBulk) SetLastUsedAt(v int64) *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.SetLastUsedAt(v)
+	})
+}
+
+// AddLastUsedAt adds v to the "last_used_at" field.
+func (u *PersonalTokenUpsertBulk) AddLastUsedAt(v int64) *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.AddLastUsedAt(v)
+	})
+}
+
+// UpdateLastUsedAt sets the "last_used_at" field to the value that was provided on create.
+func (u *PersonalTokenUpsertBulk) UpdateLastUsedAt() *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.UpdateLastUsedAt()
+	})
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *PersonalTokenUpsertBulk) SetCreatedAt(v int64) *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.SetCreatedAt(v)
+	})
+}
+
+// AddCreatedAt adds v to the "created_at" field.
+func (u *PersonalTokenUpsertBulk) AddCreatedAt(v int64) *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.AddCreatedAt(v)
+	})
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *PersonalTokenUpsertBulk) UpdateCreatedAt() *PersonalTokenUpsertBulk {
+	return u.Update(func(s *PersonalTokenUpsert) {
+		s.UpdateCreatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *PersonalTokenUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the PersonalTokenCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for PersonalTokenCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *PersonalTokenUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
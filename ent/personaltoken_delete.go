@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ginx-contribs/ginx-server/ent/personaltoken"
+	"github.com/ginx-contribs/ginx-server/ent/predicate"
+)
+
+// PersonalTokenDelete is the builder for deleting a PersonalToken entity.
+type PersonalTokenDelete struct {
+	config
+	hooks    []Hook
+	mutation *PersonalTokenMutation
+}
+
+// Where appends a list predicates to the PersonalTokenDelete builder.
+func (ptd *PersonalTokenDelete) Where(ps ...predicate.PersonalToken) *PersonalTokenDelete {
+	ptd.mutation.Where(ps...)
+	return ptd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (ptd *PersonalTokenDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, ptd.sqlExec, ptd.mutation, ptd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ptd *PersonalTokenDelete) ExecX(ctx context.Context) int {
+	n, err := ptd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (ptd *PersonalTokenDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(personaltoken.Table, sqlgraph.NewFieldSpec(personaltoken.FieldID, field.TypeInt))
+	if ps := ptd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, ptd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	ptd.mutation.done = true
+	return affected, err
+}
+
+// PersonalTokenDeleteOne is the builder for deleting a single PersonalToken entity.
+type PersonalTokenDeleteOne struct {
+	ptd *PersonalTokenDelete
+}
+
+// Where appends a list predicates to the PersonalTokenDelete builder.
+func (ptdo *PersonalTokenDeleteOne) Where(ps ...predicate.PersonalToken) *PersonalTokenDeleteOne {
+	ptdo.ptd.mutation.Where(ps...)
+	return ptdo
+}
+
+// Exec executes the deletion query.
+func (ptdo *PersonalTokenDeleteOne) Exec(ctx context.Context) error {
+	n, err := ptdo.ptd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{personaltoken.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ptdo *PersonalTokenDeleteOne) ExecX(ctx context.Context) {
+	if err := ptdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,645 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/ginx-contribs/ginx-server/ent/personaltoken"
+	"github.com/ginx-contribs/ginx-server/ent/predicate"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+)
+
+// PersonalTokenUpdate is the builder for updating PersonalToken entities.
+type PersonalTokenUpdate struct {
+	config
+	hooks     []Hook
+	mutation  *PersonalTokenMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// Where appends a list predicates to the PersonalTokenUpdate builder.
+func (ptu *PersonalTokenUpdate) Where(ps ...predicate.PersonalToken) *PersonalTokenUpdate {
+	ptu.mutation.Where(ps...)
+	return ptu
+}
+
+// SetKeyID sets the "key_id" field.
+func (ptu *PersonalTokenUpdate) SetKeyID(s string) *PersonalTokenUpdate {
+	ptu.mutation.SetKeyID(s)
+	return ptu
+}
+
+// SetNillableKeyID sets the "key_id" field if the given value is not nil.
+func (ptu *PersonalTokenUpdate) SetNillableKeyID(s *string) *PersonalTokenUpdate {
+	if s != nil {
+		ptu.SetKeyID(*s)
+	}
+	return ptu
+}
+
+// SetSecretHash sets the "secret_hash" field.
+func (ptu *PersonalTokenUpdate) SetSecretHash(s string) *PersonalTokenUpdate {
+	ptu.mutation.SetSecretHash(s)
+	return ptu
+}
+
+// SetNillableSecretHash sets the "secret_hash" field if the given value is not nil.
+func (ptu *PersonalTokenUpdate) SetNillableSecretHash(s *string) *PersonalTokenUpdate {
+	if s != nil {
+		ptu.SetSecretHash(*s)
+	}
+	return ptu
+}
+
+// SetUserID sets the "user_id" field.
+func (ptu *PersonalTokenUpdate) SetUserID(i int) *PersonalTokenUpdate {
+	ptu.mutation.SetUserID(i)
+	return ptu
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (ptu *PersonalTokenUpdate) SetNillableUserID(i *int) *PersonalTokenUpdate {
+	if i != nil {
+		ptu.SetUserID(*i)
+	}
+	return ptu
+}
+
+// SetName sets the "name" field.
+func (ptu *PersonalTokenUpdate) SetName(s string) *PersonalTokenUpdate {
+	ptu.mutation.SetName(s)
+	return ptu
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (ptu *PersonalTokenUpdate) SetNillableName(s *string) *PersonalTokenUpdate {
+	if s != nil {
+		ptu.SetName(*s)
+	}
+	return ptu
+}
+
+// SetScopes sets the "scopes" field.
+func (ptu *PersonalTokenUpdate) SetScopes(s []string) *PersonalTokenUpdate {
+	ptu.mutation.SetScopes(s)
+	return ptu
+}
+
+// AppendScopes appends s to the "scopes" field.
+func (ptu *PersonalTokenUpdate) AppendScopes(s []string) *PersonalTokenUpdate {
+	ptu.mutation.AppendScopes(s)
+	return ptu
+}
+
+// ClearScopes clears the value of the "scopes" field.
+func (ptu *PersonalTokenUpdate) ClearScopes() *PersonalTokenUpdate {
+	ptu.mutation.ClearScopes()
+	return ptu
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (ptu *PersonalTokenUpdate) SetExpiresAt(i int64) *PersonalTokenUpdate {
+	ptu.mutation.ResetExpiresAt()
+	ptu.mutation.SetExpiresAt(i)
+	return ptu
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (ptu *PersonalTokenUpdate) SetNillableExpiresAt(i *int64) *PersonalTokenUpdate {
+	if i != nil {
+		ptu.SetExpiresAt(*i)
+	}
+	return ptu
+}
+
+// AddExpiresAt adds i to the "expires_at" field.
+func (ptu *PersonalTokenUpdate) AddExpiresAt(i int64) *PersonalTokenUpdate {
+	ptu.mutation.AddExpiresAt(i)
+	return ptu
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (ptu *PersonalTokenUpdate) SetLastUsedAt(i int64) *PersonalTokenUpdate {
+	ptu.mutation.ResetLastUsedAt()
+	ptu.mutation.SetLastUsedAt(i)
+	return ptu
+}
+
+// SetNillableLastUsedAt sets the "last_used_at" field if the given value is not nil.
+func (ptu *PersonalTokenUpdate) SetNillableLastUsedAt(i *int64) *PersonalTokenUpdate {
+	if i != nil {
+		ptu.SetLastUsedAt(*i)
+	}
+	return ptu
+}
+
+// AddLastUsedAt adds i to the "last_used_at" field.
+func (ptu *PersonalTokenUpdate) AddLastUsedAt(i int64) *PersonalTokenUpdate {
+	ptu.mutation.AddLastUsedAt(i)
+	return ptu
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (ptu *PersonalTokenUpdate) SetCreatedAt(i int64) *PersonalTokenUpdate {
+	ptu.mutation.ResetCreatedAt()
+	ptu.mutation.SetCreatedAt(i)
+	return ptu
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (ptu *PersonalTokenUpdate) SetNillableCreatedAt(i *int64) *PersonalTokenUpdate {
+	if i != nil {
+		ptu.SetCreatedAt(*i)
+	}
+	return ptu
+}
+
+// AddCreatedAt adds i to the "created_at" field.
+func (ptu *PersonalTokenUpdate) AddCreatedAt(i int64) *PersonalTokenUpdate {
+	ptu.mutation.AddCreatedAt(i)
+	return ptu
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (ptu *PersonalTokenUpdate) SetUser(u *User) *PersonalTokenUpdate {
+	return ptu.SetUserID(u.ID)
+}
+
+// Mutation returns the PersonalTokenMutation object of the builder.
+func (ptu *PersonalTokenUpdate) Mutation() *PersonalTokenMutation {
+	return ptu.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (ptu *PersonalTokenUpdate) ClearUser() *PersonalTokenUpdate {
+	ptu.mutation.ClearUser()
+	return ptu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (ptu *PersonalTokenUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, ptu.sqlSave, ptu.mutation, ptu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ptu *PersonalTokenUpdate) SaveX(ctx context.Context) int {
+	affected, err := ptu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (ptu *PersonalTokenUpdate) Exec(ctx context.Context) error {
+	_, err := ptu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ptu *PersonalTokenUpdate) ExecX(ctx context.Context) {
+	if err := ptu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ptu *PersonalTokenUpdate) check() error {
+	if ptu.mutation.UserCleared() && len(ptu.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "PersonalToken.user"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (ptu *PersonalTokenUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *PersonalTokenUpdate {
+	ptu.modifiers = append(ptu.modifiers, modifiers...)
+	return ptu
+}
+
+func (ptu *PersonalTokenUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := ptu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(personaltoken.Table, personaltoken.Columns, sqlgraph.NewFieldSpec(personaltoken.FieldID, field.TypeInt))
+	if ps := ptu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ptu.mutation.KeyID(); ok {
+		_spec.SetField(personaltoken.FieldKeyID, field.TypeString, value)
+	}
+	if value, ok := ptu.mutation.SecretHash(); ok {
+		_spec.SetField(personaltoken.FieldSecretHash, field.TypeString, value)
+	}
+	if value, ok := ptu.mutation.Name(); ok {
+		_spec.SetField(personaltoken.FieldName, field.TypeString, value)
+	}
+	if value, ok := ptu.mutation.Scopes(); ok {
+		_spec.SetField(personaltoken.FieldScopes, field.TypeJSON, value)
+	}
+	if value, ok := ptu.mutation.AppendedScopes(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, personaltoken.FieldScopes, value)
+		})
+	}
+	if ptu.mutation.ScopesCleared() {
+		_spec.ClearField(personaltoken.FieldScopes, field.TypeJSON)
+	}
+	if value, ok := ptu.mutation.ExpiresAt(); ok {
+		_spec.SetField(personaltoken.FieldExpiresAt, field.TypeInt64, value)
+	}
+	if value, ok := ptu.mutation.AddedExpiresAt(); ok {
+		_spec.AddField(personaltoken.FieldExpiresAt, field.TypeInt64, value)
+	}
+	if value, ok := ptu.mutation.LastUsedAt(); ok {
+		_spec.SetField(personaltoken.FieldLastUsedAt, field.TypeInt64, value)
+	}
+	if value, ok := ptu.mutation.AddedLastUsedAt(); ok {
+		_spec.AddField(personaltoken.FieldLastUsedAt, field.TypeInt64, value)
+	}
+	if value, ok := ptu.mutation.CreatedAt(); ok {
+		_spec.SetField(personaltoken.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := ptu.mutation.AddedCreatedAt(); ok {
+		_spec.AddField(personaltoken.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if ptu.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   personaltoken.UserTable,
+			Columns: []string{personaltoken.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ptu.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   personaltoken.UserTable,
+			Columns: []string{personaltoken.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(ptu.modifiers...)
+	if n, err = sqlgraph.UpdateNodes(ctx, ptu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{personaltoken.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	ptu.mutation.done = true
+	return n, nil
+}
+
+// PersonalTokenUpdateOne is the builder for updating a single PersonalToken entity.
+type PersonalTokenUpdateOne struct {
+	config
+	fields    []string
+	hooks     []Hook
+	mutation  *PersonalTokenMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// SetKeyID sets the "key_id" field.
+func (ptuo *PersonalTokenUpdateOne) SetKeyID(s string) *PersonalTokenUpdateOne {
+	ptuo.mutation.SetKeyID(s)
+	return ptuo
+}
+
+// SetNillableKeyID sets the "key_id" field if the given value is not nil.
+func (ptuo *PersonalTokenUpdateOne) SetNillableKeyID(s *string) *PersonalTokenUpdateOne {
+	if s != nil {
+		ptuo.SetKeyID(*s)
+	}
+	return ptuo
+}
+
+// SetSecretHash sets the "secret_hash" field.
+func (ptuo *PersonalTokenUpdateOne) SetSecretHash(s string) *PersonalTokenUpdateOne {
+	ptuo.mutation.SetSecretHash(s)
+	return ptuo
+}
+
+// SetNillableSecretHash sets the "secret_hash" field if the given value is not nil.
+func (ptuo *PersonalTokenUpdateOne) SetNillableSecretHash(s *string) *PersonalTokenUpdateOne {
+	if s != nil {
+		ptuo.SetSecretHash(*s)
+	}
+	return ptuo
+}
+
+// SetUserID sets the "user_id" field.
+func (ptuo *PersonalTokenUpdateOne) SetUserID(i int) *PersonalTokenUpdateOne {
+	ptuo.mutation.SetUserID(i)
+	return ptuo
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (ptuo *PersonalTokenUpdateOne) SetNillableUserID(i *int) *PersonalTokenUpdateOne {
+	if i != nil {
+		ptuo.SetUserID(*i)
+	}
+	return ptuo
+}
+
+// SetName sets the "name" field.
+func (ptuo *PersonalTokenUpdateOne) SetName(s string) *PersonalTokenUpdateOne {
+	ptuo.mutation.SetName(s)
+	return ptuo
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (ptuo *PersonalTokenUpdateOne) SetNillableName(s *string) *PersonalTokenUpdateOne {
+	if s != nil {
+		ptuo.SetName(*s)
+	}
+	return ptuo
+}
+
+// SetScopes sets the "scopes" field.
+func (ptuo *PersonalTokenUpdateOne) SetScopes(s []string) *PersonalTokenUpdateOne {
+	ptuo.mutation.SetScopes(s)
+	return ptuo
+}
+
+// AppendScopes appends s to the "scopes" field.
+func (ptuo *PersonalTokenUpdateOne) AppendScopes(s []string) *PersonalTokenUpdateOne {
+	ptuo.mutation.AppendScopes(s)
+	return ptuo
+}
+
+// ClearScopes clears the value of the "scopes" field.
+func (ptuo *PersonalTokenUpdateOne) ClearScopes() *PersonalTokenUpdateOne {
+	ptuo.mutation.ClearScopes()
+	return ptuo
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (ptuo *PersonalTokenUpdateOne) SetExpiresAt(i int64) *PersonalTokenUpdateOne {
+	ptuo.mutation.ResetExpiresAt()
+	ptuo.mutation.SetExpiresAt(i)
+	return ptuo
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (ptuo *PersonalTokenUpdateOne) SetNillableExpiresAt(i *int64) *PersonalTokenUpdateOne {
+	if i != nil {
+		ptuo.SetExpiresAt(*i)
+	}
+	return ptuo
+}
+
+// AddExpiresAt adds i to the "expires_at" field.
+func (ptuo *PersonalTokenUpdateOne) AddExpiresAt(i int64) *PersonalTokenUpdateOne {
+	ptuo.mutation.AddExpiresAt(i)
+	return ptuo
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (ptuo *PersonalTokenUpdateOne) SetLastUsedAt(i int64) *PersonalTokenUpdateOne {
+	ptuo.mutation.ResetLastUsedAt()
+	ptuo.mutation.SetLastUsedAt(i)
+	return ptuo
+}
+
+// SetNillableLastUsedAt sets the "last_used_at" field if the given value is not nil.
+func (ptuo *PersonalTokenUpdateOne) SetNillableLastUsedAt(i *int64) *PersonalTokenUpdateOne {
+	if i != nil {
+		ptuo.SetLastUsedAt(*i)
+	}
+	return ptuo
+}
+
+// AddLastUsedAt adds i to the "last_used_at" field.
+func (ptuo *PersonalTokenUpdateOne) AddLastUsedAt(i int64) *PersonalTokenUpdateOne {
+	ptuo.mutation.AddLastUsedAt(i)
+	return ptuo
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (ptuo *PersonalTokenUpdateOne) SetCreatedAt(i int64) *PersonalTokenUpdateOne {
+	ptuo.mutation.ResetCreatedAt()
+	ptuo.mutation.SetCreatedAt(i)
+	return ptuo
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (ptuo *PersonalTokenUpdateOne) SetNillableCreatedAt(i *int64) *PersonalTokenUpdateOne {
+	if i != nil {
+		ptuo.SetCreatedAt(*i)
+	}
+	return ptuo
+}
+
+// AddCreatedAt adds i to the "created_at" field.
+func (ptuo *PersonalTokenUpdateOne) AddCreatedAt(i int64) *PersonalTokenUpdateOne {
+	ptuo.mutation.AddCreatedAt(i)
+	return ptuo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (ptuo *PersonalTokenUpdateOne) SetUser(u *User) *PersonalTokenUpdateOne {
+	return ptuo.SetUserID(u.ID)
+}
+
+// Mutation returns the PersonalTokenMutation object of the builder.
+func (ptuo *PersonalTokenUpdateOne) Mutation() *PersonalTokenMutation {
+	return ptuo.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (ptuo *PersonalTokenUpdateOne) ClearUser() *PersonalTokenUpdateOne {
+	ptuo.mutation.ClearUser()
+	return ptuo
+}
+
+// Where appends a list predicates to the PersonalTokenUpdate builder.
+func (ptuo *PersonalTokenUpdateOne) Where(ps ...predicate.PersonalToken) *PersonalTokenUpdateOne {
+	ptuo.mutation.Where(ps...)
+	return ptuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ptuo *PersonalTokenUpdateOne) Select(field string, fields ...string) *PersonalTokenUpdateOne {
+	ptuo.fields = append([]string{field}, fields...)
+	return ptuo
+}
+
+// Save executes the query and returns the updated PersonalToken entity.
+func (ptuo *PersonalTokenUpdateOne) Save(ctx context.Context) (*PersonalToken, error) {
+	return withHooks(ctx, ptuo.sqlSave, ptuo.mutation, ptuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ptuo *PersonalTokenUpdateOne) SaveX(ctx context.Context) *PersonalToken {
+	node, err := ptuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ptuo *PersonalTokenUpdateOne) Exec(ctx context.Context) error {
+	_, err := ptuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ptuo *PersonalTokenUpdateOne) ExecX(ctx context.Context) {
+	if err := ptuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ptuo *PersonalTokenUpdateOne) check() error {
+	if ptuo.mutation.UserCleared() && len(ptuo.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "PersonalToken.user"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (ptuo *PersonalTokenUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *PersonalTokenUpdateOne {
+	ptuo.modifiers = append(ptuo.modifiers, modifiers...)
+	return ptuo
+}
+
+func (ptuo *PersonalTokenUpdateOne) sqlSave(ctx context.Context) (_node *PersonalToken, err error) {
+	if err := ptuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(personaltoken.Table, personaltoken.Columns, sqlgraph.NewFieldSpec(personaltoken.FieldID, field.TypeInt))
+	id, ok := ptuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "PersonalToken.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ptuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, personaltoken.FieldID)
+		for _, f := range fields {
+			if !personaltoken.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != personaltoken.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ptuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ptuo.mutation.KeyID(); ok {
+		_spec.SetField(personaltoken.FieldKeyID, field.TypeString, value)
+	}
+	if value, ok := ptuo.mutation.SecretHash(); ok {
+		_spec.SetField(personaltoken.FieldSecretHash, field.TypeString, value)
+	}
+	if value, ok := ptuo.mutation.Name(); ok {
+		_spec.SetField(personaltoken.FieldName, field.TypeString, value)
+	}
+	if value, ok := ptuo.mutation.Scopes(); ok {
+		_spec.SetField(personaltoken.FieldScopes, field.TypeJSON, value)
+	}
+	if value, ok := ptuo.mutation.AppendedScopes(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, personaltoken.FieldScopes, value)
+		})
+	}
+	if ptuo.mutation.ScopesCleared() {
+		_spec.ClearField(personaltoken.FieldScopes, field.TypeJSON)
+	}
+	if value, ok := ptuo.mutation.ExpiresAt(); ok {
+		_spec.SetField(personaltoken.FieldExpiresAt, field.TypeInt64, value)
+	}
+	if value, ok := ptuo.mutation.AddedExpiresAt(); ok {
+		_spec.AddField(personaltoken.FieldExpiresAt, field.TypeInt64, value)
+	}
+	if value, ok := ptuo.mutation.LastUsedAt(); ok {
+		_spec.SetField(personaltoken.FieldLastUsedAt, field.TypeInt64, value)
+	}
+	if value, ok := ptuo.mutation.AddedLastUsedAt(); ok {
+		_spec.AddField(personaltoken.FieldLastUsedAt, field.TypeInt64, value)
+	}
+	if value, ok := ptuo.mutation.CreatedAt(); ok {
+		_spec.SetField(personaltoken.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := ptuo.mutation.AddedCreatedAt(); ok {
+		_spec.AddField(personaltoken.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if ptuo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   personaltoken.UserTable,
+			Columns: []string{personaltoken.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ptuo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   personaltoken.UserTable,
+			Columns: []string{personaltoken.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(ptuo.modifiers...)
+	_node = &PersonalToken{config: ptuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ptuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{personaltoken.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	ptuo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,705 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ginx-contribs/ginx-server/ent/recoverycode"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+)
+
+// RecoveryCodeCreate is the builder for creating a RecoveryCode entity.
+type RecoveryCodeCreate struct {
+	config
+	mutation *RecoveryCodeMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetUserID sets the "user_id" field.
+func (rcc *RecoveryCodeCreate) SetUserID(i int) *RecoveryCodeCreate {
+	rcc.mutation.SetUserID(i)
+	return rcc
+}
+
+// SetCodeHash sets the "code_hash" field.
+func (rcc *RecoveryCodeCreate) SetCodeHash(s string) *RecoveryCodeCreate {
+	rcc.mutation.SetCodeHash(s)
+	return rcc
+}
+
+// SetUsedAt sets the "used_at" field.
+func (rcc *RecoveryCodeCreate) SetUsedAt(i int64) *RecoveryCodeCreate {
+	rcc.mutation.SetUsedAt(i)
+	return rcc
+}
+
+// SetNillableUsedAt sets the "used_at" field if the given value is not nil.
+func (rcc *RecoveryCodeCreate) SetNillableUsedAt(i *int64) *RecoveryCodeCreate {
+	if i != nil {
+		rcc.SetUsedAt(*i)
+	}
+	return rcc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (rcc *RecoveryCodeCreate) SetCreatedAt(i int64) *RecoveryCodeCreate {
+	rcc.mutation.SetCreatedAt(i)
+	return rcc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (rcc *RecoveryCodeCreate) SetNillableCreatedAt(i *int64) *RecoveryCodeCreate {
+	if i != nil {
+		rcc.SetCreatedAt(*i)
+	}
+	return rcc
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (rcc *RecoveryCodeCreate) SetUser(u *User) *RecoveryCodeCreate {
+	return rcc.SetUserID(u.ID)
+}
+
+// Mutation returns the RecoveryCodeMutation object of the builder.
+func (rcc *RecoveryCodeCreate) Mutation() *RecoveryCodeMutation {
+	return rcc.mutation
+}
+
+// Save creates the RecoveryCode in the database.
+func (rcc *RecoveryCodeCreate) Save(ctx context.Context) (*RecoveryCode, error) {
+	rcc.defaults()
+	return withHooks(ctx, rcc.sqlSave, rcc.mutation, rcc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (rcc *RecoveryCodeCreate) SaveX(ctx context.Context) *RecoveryCode {
+	v, err := rcc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (rcc *RecoveryCodeCreate) Exec(ctx context.Context) error {
+	_, err := rcc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rcc *RecoveryCodeCreate) ExecX(ctx context.Context) {
+	if err := rcc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (rcc *RecoveryCodeCreate) defaults() {
+	if _, ok := rcc.mutation.UsedAt(); !ok {
+		v := recoverycode.DefaultUsedAt
+		rcc.mutation.SetUsedAt(v)
+	}
+	if _, ok := rcc.mutation.CreatedAt(); !ok {
+		v := recoverycode.DefaultCreatedAt()
+		rcc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (rcc *RecoveryCodeCreate) check() error {
+	if _, ok := rcc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "RecoveryCode.user_id"`)}
+	}
+	if _, ok := rcc.mutation.CodeHash(); !ok {
+		return &ValidationError{Name: "code_hash", err: errors.New(`ent: missing required field "RecoveryCode.code_hash"`)}
+	}
+	if _, ok := rcc.mutation.UsedAt(); !ok {
+		return &ValidationError{Name: "used_at", err: errors.New(`ent: missing required field "RecoveryCode.used_at"`)}
+	}
+	if _, ok := rcc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "RecoveryCode.created_at"`)}
+	}
+	if len(rcc.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "RecoveryCode.user"`)}
+	}
+	return nil
+}
+
+func (rcc *RecoveryCodeCreate) sqlSave(ctx context.Context) (*RecoveryCode, error) {
+	if err := rcc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := rcc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, rcc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	rcc.mutation.id = &_node.ID
+	rcc.mutation.done = true
+	return _node, nil
+}
+
+func (rcc *RecoveryCodeCreate) createSpec() (*RecoveryCode, *sqlgraph.CreateSpec) {
+	var (
+		_node = &RecoveryCode{config: rcc.config}
+		_spec = sqlgraph.NewCreateSpec(recoverycode.Table, sqlgraph.NewFieldSpec(recoverycode.FieldID, field.TypeInt))
+	)
+	_spec.OnConflict = rcc.conflict
+	if value, ok := rcc.mutation.CodeHash(); ok {
+		_spec.SetField(recoverycode.FieldCodeHash, field.TypeString, value)
+		_node.CodeHash = value
+	}
+	if value, ok := rcc.mutation.UsedAt(); ok {
+		_spec.SetField(recoverycode.FieldUsedAt, field.TypeInt64, value)
+		_node.UsedAt = value
+	}
+	if value, ok := rcc.mutation.CreatedAt(); ok {
+		_spec.SetField(recoverycode.FieldCreatedAt, field.TypeInt64, value)
+		_node.CreatedAt = value
+	}
+	if nodes := rcc.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   recoverycode.UserTable,
+			Columns: []string{recoverycode.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.UserID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.RecoveryCode.Create().
+//		SetUserID(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.RecoveryCodeUpsert) {
+//			SetUserID(v+v).
+//		}).
+//		Exec(ctx)
+func (rcc *RecoveryCodeCreate) OnConflict(opts ...sql.ConflictOption) *RecoveryCodeUpsertOne {
+	rcc.conflict = opts
+	return &RecoveryCodeUpsertOne{
+		create: rcc,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.RecoveryCode.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (rcc *RecoveryCodeCreate) OnConflictColumns(columns ...string) *RecoveryCodeUpsertOne {
+	rcc.conflict = append(rcc.conflict, sql.ConflictColumns(columns...))
+	return &RecoveryCodeUpsertOne{
+		create: rcc,
+	}
+}
+
+type (
+	// RecoveryCodeUpsertOne is the builder for "upsert"-ing
+	//  one RecoveryCode node.
+	RecoveryCodeUpsertOne struct {
+		create *RecoveryCodeCreate
+	}
+
+	// RecoveryCodeUpsert is the "OnConflict" setter.
+	RecoveryCodeUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetUserID sets the "user_id" field.
+func (u *RecoveryCodeUpsert) SetUserID(v int) *RecoveryCodeUpsert {
+	u.Set(recoverycode.FieldUserID, v)
+	return u
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *RecoveryCodeUpsert) UpdateUserID() *RecoveryCodeUpsert {
+	u.SetExcluded(recoverycode.FieldUserID)
+	return u
+}
+
+// SetCodeHash sets the "code_hash" field.
+func (u *RecoveryCodeUpsert) SetCodeHash(v string) *RecoveryCodeUpsert {
+	u.Set(recoverycode.FieldCodeHash, v)
+	return u
+}
+
+// UpdateCodeHash sets the "code_hash" field to the value that was provided on create.
+func (u *RecoveryCodeUpsert) UpdateCodeHash() *RecoveryCodeUpsert {
+	u.SetExcluded(recoverycode.FieldCodeHash)
+	return u
+}
+
+// SetUsedAt sets the "used_at" field.
+func (u *RecoveryCodeUpsert) SetUsedAt(v int64) *RecoveryCodeUpsert {
+	u.Set(recoverycode.FieldUsedAt, v)
+	return u
+}
+
+// UpdateUsedAt sets the "used_at" field to the value that was provided on create.
+func (u *RecoveryCodeUpsert) UpdateUsedAt() *RecoveryCodeUpsert {
+	u.SetExcluded(recoverycode.FieldUsedAt)
+	return u
+}
+
+// AddUsedAt adds v to the "used_at" field.
+func (u *RecoveryCodeUpsert) AddUsedAt(v int64) *RecoveryCodeUpsert {
+	u.Add(recoverycode.FieldUsedAt, v)
+	return u
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *RecoveryCodeUpsert) SetCreatedAt(v int64) *RecoveryCodeUpsert {
+	u.Set(recoverycode.FieldCreatedAt, v)
+	return u
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *RecoveryCodeUpsert) UpdateCreatedAt() *RecoveryCodeUpsert {
+	u.SetExcluded(recoverycode.FieldCreatedAt)
+	return u
+}
+
+// AddCreatedAt adds v to the "created_at" field.
+func (u *RecoveryCodeUpsert) AddCreatedAt(v int64) *RecoveryCodeUpsert {
+	u.Add(recoverycode.FieldCreatedAt, v)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.RecoveryCode.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *RecoveryCodeUpsertOne) UpdateNewValues() *RecoveryCodeUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.RecoveryCode.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *RecoveryCodeUpsertOne) Ignore() *RecoveryCodeUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *RecoveryCodeUpsertOne) DoNothing() *RecoveryCodeUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the RecoveryCodeCreate.OnConflict
+// documentation for more info.
+func (u *RecoveryCodeUpsertOne) Update(set func(*RecoveryCodeUpsert)) *RecoveryCodeUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&RecoveryCodeUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUserID sets the "user_id" field.
+func (u *RecoveryCodeUpsertOne) SetUserID(v int) *RecoveryCodeUpsertOne {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *RecoveryCodeUpsertOne) UpdateUserID() *RecoveryCodeUpsertOne {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetCodeHash sets the "code_hash" field.
+func (u *RecoveryCodeUpsertOne) SetCodeHash(v string) *RecoveryCodeUpsertOne {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.SetCodeHash(v)
+	})
+}
+
+// UpdateCodeHash sets the "code_hash" field to the value that was provided on create.
+func (u *RecoveryCodeUpsertOne) UpdateCodeHash() *RecoveryCodeUpsertOne {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.UpdateCodeHash()
+	})
+}
+
+// SetUsedAt sets the "used_at" field.
+func (u *RecoveryCodeUpsertOne) SetUsedAt(v int64) *RecoveryCodeUpsertOne {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.SetUsedAt(v)
+	})
+}
+
+// AddUsedAt adds v to the "used_at" field.
+func (u *RecoveryCodeUpsertOne) AddUsedAt(v int64) *RecoveryCodeUpsertOne {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.AddUsedAt(v)
+	})
+}
+
+// UpdateUsedAt sets the "used_at" field to the value that was provided on create.
+func (u *RecoveryCodeUpsertOne) UpdateUsedAt() *RecoveryCodeUpsertOne {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.UpdateUsedAt()
+	})
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *RecoveryCodeUpsertOne) SetCreatedAt(v int64) *RecoveryCodeUpsertOne {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.SetCreatedAt(v)
+	})
+}
+
+// AddCreatedAt adds v to the "created_at" field.
+func (u *RecoveryCodeUpsertOne) AddCreatedAt(v int64) *RecoveryCodeUpsertOne {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.AddCreatedAt(v)
+	})
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *RecoveryCodeUpsertOne) UpdateCreatedAt() *RecoveryCodeUpsertOne {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.UpdateCreatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *RecoveryCodeUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for RecoveryCodeCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *RecoveryCodeUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *RecoveryCodeUpsertOne) ID(ctx context.Context) (id int, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *RecoveryCodeUpsertOne) IDX(ctx context.Context) int {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// RecoveryCodeCreateBulk is the builder for creating many RecoveryCode entities in bulk.
+type RecoveryCodeCreateBulk struct {
+	config
+	err      error
+	builders []*RecoveryCodeCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the RecoveryCode entities in the database.
+func (rccb *RecoveryCodeCreateBulk) Save(ctx context.Context) ([]*RecoveryCode, error) {
+	if rccb.err != nil {
+		return nil, rccb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(rccb.builders))
+	nodes := make([]*RecoveryCode, len(rccb.builders))
+	mutators := make([]Mutator, len(rccb.builders))
+	for i := range rccb.builders {
+		func(i int, root context.Context) {
+			builder := rccb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*RecoveryCodeMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, rccb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = rccb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, rccb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, rccb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (rccb *RecoveryCodeCreateBulk) SaveX(ctx context.Context) []*RecoveryCode {
+	v, err := rccb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (rccb *RecoveryCodeCreateBulk) Exec(ctx context.Context) error {
+	_, err := rccb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rccb *RecoveryCodeCreateBulk) ExecX(ctx context.Context) {
+	if err := rccb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.RecoveryCode.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.RecoveryCodeUpsert) {
+//			SetUserID(v+v).
+//		}).
+//		Exec(ctx)
+func (rccb *RecoveryCodeCreateBulk) OnConflict(opts ...sql.ConflictOption) *RecoveryCodeUpsertBulk {
+	rccb.conflict = opts
+	return &RecoveryCodeUpsertBulk{
+		create: rccb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.RecoveryCode.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (rccb *RecoveryCodeCreateBulk) OnConflictColumns(columns ...string) *RecoveryCodeUpsertBulk {
+	rccb.conflict = append(rccb.conflict, sql.ConflictColumns(columns...))
+	return &RecoveryCodeUpsertBulk{
+		create: rccb,
+	}
+}
+
+// RecoveryCodeUpsertBulk is the builder for "upsert"-ing
+// a bulk of RecoveryCode nodes.
+type RecoveryCodeUpsertBulk struct {
+	create *RecoveryCodeCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.RecoveryCode.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *RecoveryCodeUpsertBulk) UpdateNewValues() *RecoveryCodeUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.RecoveryCode.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *RecoveryCodeUpsertBulk) Ignore() *RecoveryCodeUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *RecoveryCodeUpsertBulk) DoNothing() *RecoveryCodeUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the RecoveryCodeCreateBulk.OnConflict
+// documentation for more info.
+func (u *RecoveryCodeUpsertBulk) Update(set func(*RecoveryCodeUpsert)) *RecoveryCodeUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&RecoveryCodeUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUserID sets the "user_id" field.
+func (u *RecoveryCodeUpsertBulk) SetUserID(v int) *RecoveryCodeUpsertBulk {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *RecoveryCodeUpsertBulk) UpdateUserID() *RecoveryCodeUpsertBulk {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetCodeHash sets the "code_hash" field.
+func (u *RecoveryCodeUpsertBulk) SetCodeHash(v string) *RecoveryCodeUpsertBulk {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.SetCodeHash(v)
+	})
+}
+
+// UpdateCodeHash sets the "code_hash" field to the value that was provided on create.
+func (u *RecoveryCodeUpsertBulk) UpdateCodeHash() *RecoveryCodeUpsertBulk {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.UpdateCodeHash()
+	})
+}
+
+// SetUsedAt sets the "used_at" field.
+func (u *RecoveryCodeUpsertBulk) SetUsedAt(v int64) *RecoveryCodeUpsertBulk {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.SetUsedAt(v)
+	})
+}
+
+// AddUsedAt adds v to the "used_at" field.
+func (u *RecoveryCodeUpsertBulk) AddUsedAt(v int64) *RecoveryCodeUpsertBulk {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.AddUsedAt(v)
+	})
+}
+
+// UpdateUsedAt sets the "used_at" field to the value that was provided on create.
+func (u *RecoveryCodeUpsertBulk) UpdateUsedAt() *RecoveryCodeUpsertBulk {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.UpdateUsedAt()
+	})
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (u *RecoveryCodeUpsertBulk) SetCreatedAt(v int64) *RecoveryCodeUpsertBulk {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.SetCreatedAt(v)
+	})
+}
+
+// AddCreatedAt adds v to the "created_at" field.
+func (u *RecoveryCodeUpsertBulk) AddCreatedAt(v int64) *RecoveryCodeUpsertBulk {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.AddCreatedAt(v)
+	})
+}
+
+// UpdateCreatedAt sets the "created_at" field to the value that was provided on create.
+func (u *RecoveryCodeUpsertBulk) UpdateCreatedAt() *RecoveryCodeUpsertBulk {
+	return u.Update(func(s *RecoveryCodeUpsert) {
+		s.UpdateCreatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *RecoveryCodeUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the RecoveryCodeCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for RecoveryCodeCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *RecoveryCodeUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -2,29 +2,4 @@
 
 package ent
 
-import (
-	"github.com/ginx-contribs/ginx-server/ent/schema"
-	"github.com/ginx-contribs/ginx-server/ent/user"
-)
-
-// The init function reads all schema descriptors with runtime code
-// (default values, validators, hooks and policies) and stitches it
-// to their package variables.
-func init() {
-	userFields := schema.User{}.Fields()
-	_ = userFields
-	// userDescUID is the schema descriptor for uid field.
-	userDescUID := userFields[0].Descriptor()
-	// user.DefaultUID holds the default value on creation for the uid field.
-	user.DefaultUID = userDescUID.Default.(func() string)
-	// userDescCreatedAt is the schema descriptor for created_at field.
-	userDescCreatedAt := userFields[4].Descriptor()
-	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
-	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() int64)
-	// userDescUpdatedAt is the schema descriptor for updated_at field.
-	userDescUpdatedAt := userFields[5].Descriptor()
-	// user.DefaultUpdatedAt holds the default value on creation for the updated_at field.
-	user.DefaultUpdatedAt = userDescUpdatedAt.Default.(func() int64)
-	// user.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
-	user.UpdateDefaultUpdatedAt = userDescUpdatedAt.UpdateDefault.(func() int64)
-}
+// The schema-stitching logic is generated in github.com/ginx-contribs/ginx-server/ent/runtime/runtime.go
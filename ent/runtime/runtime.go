@@ -2,7 +2,194 @@
 
 package runtime
 
-// The schema-stitching logic is generated in github.com/ginx-contribs/ginx-internal/ent/runtime.go
+import (
+	"github.com/ginx-contribs/ginx-server/ent/auditlog"
+	"github.com/ginx-contribs/ginx-server/ent/identity"
+	"github.com/ginx-contribs/ginx-server/ent/loginhistory"
+	"github.com/ginx-contribs/ginx-server/ent/oauthclient"
+	"github.com/ginx-contribs/ginx-server/ent/passwordhistory"
+	"github.com/ginx-contribs/ginx-server/ent/personaltoken"
+	"github.com/ginx-contribs/ginx-server/ent/recoverycode"
+	"github.com/ginx-contribs/ginx-server/ent/role"
+	"github.com/ginx-contribs/ginx-server/ent/schema"
+	"github.com/ginx-contribs/ginx-server/ent/session"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+)
+
+// The init function reads all schema descriptors with runtime code
+// (default values, validators, hooks and policies) and stitches it
+// to their package variables.
+func init() {
+	auditlogFields := schema.AuditLog{}.Fields()
+	_ = auditlogFields
+	// auditlogDescCreatedAt is the schema descriptor for created_at field.
+	auditlogDescCreatedAt := auditlogFields[4].Descriptor()
+	// auditlog.DefaultCreatedAt holds the default value on creation for the created_at field.
+	auditlog.DefaultCreatedAt = auditlogDescCreatedAt.Default.(func() int64)
+	identityFields := schema.Identity{}.Fields()
+	_ = identityFields
+	// identityDescEmail is the schema descriptor for email field.
+	identityDescEmail := identityFields[3].Descriptor()
+	// identity.DefaultEmail holds the default value on creation for the email field.
+	identity.DefaultEmail = identityDescEmail.Default.(string)
+	// identityDescCreatedAt is the schema descriptor for created_at field.
+	identityDescCreatedAt := identityFields[4].Descriptor()
+	// identity.DefaultCreatedAt holds the default value on creation for the created_at field.
+	identity.DefaultCreatedAt = identityDescCreatedAt.Default.(func() int64)
+	// identityDescLastLoginAt is the schema descriptor for last_login_at field.
+	identityDescLastLoginAt := identityFields[5].Descriptor()
+	// identity.DefaultLastLoginAt holds the default value on creation for the last_login_at field.
+	identity.DefaultLastLoginAt = identityDescLastLoginAt.Default.(func() int64)
+	loginhistoryFields := schema.LoginHistory{}.Fields()
+	_ = loginhistoryFields
+	// loginhistoryDescUserAgent is the schema descriptor for user_agent field.
+	loginhistoryDescUserAgent := loginhistoryFields[2].Descriptor()
+	// loginhistory.DefaultUserAgent holds the default value on creation for the user_agent field.
+	loginhistory.DefaultUserAgent = loginhistoryDescUserAgent.Default.(string)
+	// loginhistoryDescIP is the schema descriptor for ip field.
+	loginhistoryDescIP := loginhistoryFields[3].Descriptor()
+	// loginhistory.DefaultIP holds the default value on creation for the ip field.
+	loginhistory.DefaultIP = loginhistoryDescIP.Default.(string)
+	// loginhistoryDescCreatedAt is the schema descriptor for created_at field.
+	loginhistoryDescCreatedAt := loginhistoryFields[4].Descriptor()
+	// loginhistory.DefaultCreatedAt holds the default value on creation for the created_at field.
+	loginhistory.DefaultCreatedAt = loginhistoryDescCreatedAt.Default.(func() int64)
+	oauthclientFields := schema.OAuthClient{}.Fields()
+	_ = oauthclientFields
+	// oauthclientDescClientID is the schema descriptor for client_id field.
+	oauthclientDescClientID := oauthclientFields[0].Descriptor()
+	// oauthclient.DefaultClientID holds the default value on creation for the client_id field.
+	oauthclient.DefaultClientID = oauthclientDescClientID.Default.(func() string)
+	// oauthclientDescSecretHash is the schema descriptor for secret_hash field.
+	oauthclientDescSecretHash := oauthclientFields[1].Descriptor()
+	// oauthclient.DefaultSecretHash holds the default value on creation for the secret_hash field.
+	oauthclient.DefaultSecretHash = oauthclientDescSecretHash.Default.(string)
+	// oauthclientDescPublic is the schema descriptor for public field.
+	oauthclientDescPublic := oauthclientFields[6].Descriptor()
+	// oauthclient.DefaultPublic holds the default value on creation for the public field.
+	oauthclient.DefaultPublic = oauthclientDescPublic.Default.(bool)
+	// oauthclientDescCreatedAt is the schema descriptor for created_at field.
+	oauthclientDescCreatedAt := oauthclientFields[8].Descriptor()
+	// oauthclient.DefaultCreatedAt holds the default value on creation for the created_at field.
+	oauthclient.DefaultCreatedAt = oauthclientDescCreatedAt.Default.(func() int64)
+	// oauthclientDescUpdatedAt is the schema descriptor for updated_at field.
+	oauthclientDescUpdatedAt := oauthclientFields[9].Descriptor()
+	// oauthclient.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	oauthclient.DefaultUpdatedAt = oauthclientDescUpdatedAt.Default.(func() int64)
+	// oauthclient.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	oauthclient.UpdateDefaultUpdatedAt = oauthclientDescUpdatedAt.UpdateDefault.(func() int64)
+	passwordhistoryFields := schema.PasswordHistory{}.Fields()
+	_ = passwordhistoryFields
+	// passwordhistoryDescCreatedAt is the schema descriptor for created_at field.
+	passwordhistoryDescCreatedAt := passwordhistoryFields[2].Descriptor()
+	// passwordhistory.DefaultCreatedAt holds the default value on creation for the created_at field.
+	passwordhistory.DefaultCreatedAt = passwordhistoryDescCreatedAt.Default.(func() int64)
+	personaltokenFields := schema.PersonalToken{}.Fields()
+	_ = personaltokenFields
+	// personaltokenDescExpiresAt is the schema descriptor for expires_at field.
+	personaltokenDescExpiresAt := personaltokenFields[5].Descriptor()
+	// personaltoken.DefaultExpiresAt holds the default value on creation for the expires_at field.
+	personaltoken.DefaultExpiresAt = personaltokenDescExpiresAt.Default.(int64)
+	// personaltokenDescLastUsedAt is the schema descriptor for last_used_at field.
+	personaltokenDescLastUsedAt := personaltokenFields[6].Descriptor()
+	// personaltoken.DefaultLastUsedAt holds the default value on creation for the last_used_at field.
+	personaltoken.DefaultLastUsedAt = personaltokenDescLastUsedAt.Default.(int64)
+	// personaltokenDescCreatedAt is the schema descriptor for created_at field.
+	personaltokenDescCreatedAt := personaltokenFields[7].Descriptor()
+	// personaltoken.DefaultCreatedAt holds the default value on creation for the created_at field.
+	personaltoken.DefaultCreatedAt = personaltokenDescCreatedAt.Default.(func() int64)
+	recoverycodeFields := schema.RecoveryCode{}.Fields()
+	_ = recoverycodeFields
+	// recoverycodeDescUsedAt is the schema descriptor for used_at field.
+	recoverycodeDescUsedAt := recoverycodeFields[2].Descriptor()
+	// recoverycode.DefaultUsedAt holds the default value on creation for the used_at field.
+	recoverycode.DefaultUsedAt = recoverycodeDescUsedAt.Default.(int64)
+	// recoverycodeDescCreatedAt is the schema descriptor for created_at field.
+	recoverycodeDescCreatedAt := recoverycodeFields[3].Descriptor()
+	// recoverycode.DefaultCreatedAt holds the default value on creation for the created_at field.
+	recoverycode.DefaultCreatedAt = recoverycodeDescCreatedAt.Default.(func() int64)
+	roleFields := schema.Role{}.Fields()
+	_ = roleFields
+	// roleDescBuiltin is the schema descriptor for builtin field.
+	roleDescBuiltin := roleFields[2].Descriptor()
+	// role.DefaultBuiltin holds the default value on creation for the builtin field.
+	role.DefaultBuiltin = roleDescBuiltin.Default.(bool)
+	// roleDescCreatedAt is the schema descriptor for created_at field.
+	roleDescCreatedAt := roleFields[3].Descriptor()
+	// role.DefaultCreatedAt holds the default value on creation for the created_at field.
+	role.DefaultCreatedAt = roleDescCreatedAt.Default.(func() int64)
+	// roleDescUpdatedAt is the schema descriptor for updated_at field.
+	roleDescUpdatedAt := roleFields[4].Descriptor()
+	// role.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	role.DefaultUpdatedAt = roleDescUpdatedAt.Default.(func() int64)
+	// role.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	role.UpdateDefaultUpdatedAt = roleDescUpdatedAt.UpdateDefault.(func() int64)
+	sessionFields := schema.Session{}.Fields()
+	_ = sessionFields
+	// sessionDescSid is the schema descriptor for sid field.
+	sessionDescSid := sessionFields[0].Descriptor()
+	// session.DefaultSid holds the default value on creation for the sid field.
+	session.DefaultSid = sessionDescSid.Default.(func() string)
+	// sessionDescUserAgent is the schema descriptor for user_agent field.
+	sessionDescUserAgent := sessionFields[4].Descriptor()
+	// session.DefaultUserAgent holds the default value on creation for the user_agent field.
+	session.DefaultUserAgent = sessionDescUserAgent.Default.(string)
+	// sessionDescIP is the schema descriptor for ip field.
+	sessionDescIP := sessionFields[5].Descriptor()
+	// session.DefaultIP holds the default value on creation for the ip field.
+	session.DefaultIP = sessionDescIP.Default.(string)
+	// sessionDescRemember is the schema descriptor for remember field.
+	sessionDescRemember := sessionFields[6].Descriptor()
+	// session.DefaultRemember holds the default value on creation for the remember field.
+	session.DefaultRemember = sessionDescRemember.Default.(bool)
+	// sessionDescCreatedAt is the schema descriptor for created_at field.
+	sessionDescCreatedAt := sessionFields[7].Descriptor()
+	// session.DefaultCreatedAt holds the default value on creation for the created_at field.
+	session.DefaultCreatedAt = sessionDescCreatedAt.Default.(func() int64)
+	// sessionDescLastSeenAt is the schema descriptor for last_seen_at field.
+	sessionDescLastSeenAt := sessionFields[8].Descriptor()
+	// session.DefaultLastSeenAt holds the default value on creation for the last_seen_at field.
+	session.DefaultLastSeenAt = sessionDescLastSeenAt.Default.(func() int64)
+	userMixin := schema.User{}.Mixin()
+	userMixinHooks0 := userMixin[0].Hooks()
+	user.Hooks[0] = userMixinHooks0[0]
+	userMixinInters0 := userMixin[0].Interceptors()
+	user.Interceptors[0] = userMixinInters0[0]
+	userMixinFields0 := userMixin[0].Fields()
+	_ = userMixinFields0
+	userFields := schema.User{}.Fields()
+	_ = userFields
+	// userDescDeletedAt is the schema descriptor for deleted_at field.
+	userDescDeletedAt := userMixinFields0[0].Descriptor()
+	// user.DefaultDeletedAt holds the default value on creation for the deleted_at field.
+	user.DefaultDeletedAt = userDescDeletedAt.Default.(int64)
+	// userDescUID is the schema descriptor for uid field.
+	userDescUID := userFields[0].Descriptor()
+	// user.DefaultUID holds the default value on creation for the uid field.
+	user.DefaultUID = userDescUID.Default.(func() string)
+	// userDescEmailVerifiedAt is the schema descriptor for email_verified_at field.
+	userDescEmailVerifiedAt := userFields[3].Descriptor()
+	// user.DefaultEmailVerifiedAt holds the default value on creation for the email_verified_at field.
+	user.DefaultEmailVerifiedAt = userDescEmailVerifiedAt.Default.(int64)
+	// userDescTotpEnabled is the schema descriptor for totp_enabled field.
+	userDescTotpEnabled := userFields[6].Descriptor()
+	// user.DefaultTotpEnabled holds the default value on creation for the totp_enabled field.
+	user.DefaultTotpEnabled = userDescTotpEnabled.Default.(bool)
+	// userDescPurgeAt is the schema descriptor for purge_at field.
+	userDescPurgeAt := userFields[15].Descriptor()
+	// user.DefaultPurgeAt holds the default value on creation for the purge_at field.
+	user.DefaultPurgeAt = userDescPurgeAt.Default.(int64)
+	// userDescCreatedAt is the schema descriptor for created_at field.
+	userDescCreatedAt := userFields[16].Descriptor()
+	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
+	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() int64)
+	// userDescUpdatedAt is the schema descriptor for updated_at field.
+	userDescUpdatedAt := userFields[17].Descriptor()
+	// user.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	user.DefaultUpdatedAt = userDescUpdatedAt.Default.(func() int64)
+	// user.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	user.UpdateDefaultUpdatedAt = userDescUpdatedAt.UpdateDefault.(func() int64)
+}
 
 const (
 	Version = "v0.14.1"                                         // Version of ent codegen.
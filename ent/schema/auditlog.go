@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
+)
+
+// AuditLog holds the schema definition for the AuditLog entity.
+type AuditLog struct {
+	ent.Schema
+}
+
+func (AuditLog) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.WithComments(true),
+		schema.Comment("audit log table of administrative operations"),
+	}
+}
+
+// Fields of the AuditLog.
+func (AuditLog) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("actor").Comment("uid of the user who performed the operation"),
+		field.String("action").Comment("operation name, such as user.create"),
+		field.String("target").Comment("uid of the user who the operation is performed on"),
+		field.String("detail").Optional().Comment("additional information about the operation"),
+		field.Int64("created_at").DefaultFunc(ts.UnixMicro),
+	}
+}
+
+// Indexes of the AuditLog.
+func (AuditLog) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("actor"),
+		index.Fields("target"),
+	}
+}
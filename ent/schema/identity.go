@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
+)
+
+// Identity holds the schema definition for the Identity entity.
+type Identity struct {
+	ent.Schema
+}
+
+func (Identity) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.WithComments(true),
+		schema.Comment("third-party login identity table"),
+	}
+}
+
+// Fields of the Identity.
+func (Identity) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("user_id"),
+		field.String("provider").Comment("name of the configured login provider"),
+		field.String("subject").Comment("unique user id at the provider"),
+		field.String("email").Default("").Comment("email reported by the provider"),
+		field.Int64("created_at").DefaultFunc(ts.UnixMicro),
+		field.Int64("last_login_at").DefaultFunc(ts.UnixMicro),
+	}
+}
+
+// Edges of the Identity.
+func (Identity) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).Ref("identities").Field("user_id").Unique().Required(),
+	}
+}
+
+// Indexes of the Identity.
+func (Identity) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("provider", "subject").Unique(),
+		index.Fields("user_id", "provider").Unique(),
+	}
+}
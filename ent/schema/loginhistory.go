@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
+)
+
+// LoginHistory holds the schema definition for the LoginHistory entity.
+type LoginHistory struct {
+	ent.Schema
+}
+
+func (LoginHistory) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.WithComments(true),
+		schema.Comment("successful logins of users, they are kept after sessions ended"),
+	}
+}
+
+// Fields of the LoginHistory.
+func (LoginHistory) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("user_id"),
+		field.String("sid").Comment("id of the session created by login"),
+		field.String("user_agent").Default(""),
+		field.String("ip").Default(""),
+		field.Int64("created_at").DefaultFunc(ts.UnixMicro),
+	}
+}
+
+// Edges of the LoginHistory.
+func (LoginHistory) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).Ref("login_histories").Field("user_id").Unique().Required(),
+	}
+}
+
+// Indexes of the LoginHistory.
+func (LoginHistory) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "created_at"),
+	}
+}
@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/idx"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
+)
+
+// OAuthClient holds the schema definition for the OAuthClient entity.
+type OAuthClient struct {
+	ent.Schema
+}
+
+func (OAuthClient) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.WithComments(true),
+		schema.Comment("oauth2 client application table"),
+	}
+}
+
+// Fields of the OAuthClient.
+func (OAuthClient) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("client_id").DefaultFunc(idx.ULID).Unique(),
+		field.String("secret_hash").Default("").Sensitive().Comment("sha256 hash of the client secret, empty for public clients"),
+		field.String("name"),
+		field.Strings("redirect_uris").Comment("registered redirect uris, they must be matched exactly"),
+		field.Strings("scopes").Comment("scopes the client is allowed to request"),
+		field.Strings("grant_types").Comment("grant types the client is allowed to use"),
+		field.Bool("public").Default(false).Comment("public client could not keep secret, such as spa and native app"),
+		field.Int("user_id").Comment("owner of the client"),
+		field.Int64("created_at").DefaultFunc(ts.UnixMicro),
+		field.Int64("updated_at").DefaultFunc(ts.UnixMicro).UpdateDefault(ts.UnixMicro),
+	}
+}
+
+// Edges of the OAuthClient.
+func (OAuthClient) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).Ref("oauth_clients").Field("user_id").Unique().Required(),
+	}
+}
@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
+)
+
+// PasswordHistory holds the schema definition for the PasswordHistory entity.
+type PasswordHistory struct {
+	ent.Schema
+}
+
+func (PasswordHistory) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.WithComments(true),
+		schema.Comment("previous passwords of users, they could not be reused"),
+	}
+}
+
+// Fields of the PasswordHistory.
+func (PasswordHistory) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("user_id"),
+		field.String("password").Sensitive().Comment("previous password hash in PHC string format"),
+		field.Int64("created_at").DefaultFunc(ts.UnixMicro),
+	}
+}
+
+// Edges of the PasswordHistory.
+func (PasswordHistory) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).Ref("password_histories").Field("user_id").Unique().Required(),
+	}
+}
+
+// Indexes of the PasswordHistory.
+func (PasswordHistory) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "created_at"),
+	}
+}
@@ -0,0 +1,43 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
+)
+
+// PersonalToken holds the schema definition for the PersonalToken entity.
+type PersonalToken struct {
+	ent.Schema
+}
+
+func (PersonalToken) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.WithComments(true),
+		schema.Comment("personal access token table"),
+	}
+}
+
+// Fields of the PersonalToken.
+func (PersonalToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("key_id").Unique().Comment("public id embedded in the key, used to look up the key"),
+		field.String("secret_hash").Sensitive().Comment("sha256 hash of the key secret"),
+		field.Int("user_id"),
+		field.String("name"),
+		field.Strings("scopes").Optional().Comment("scopes granted to the key"),
+		field.Int64("expires_at").Default(0).Comment("0 means never expire"),
+		field.Int64("last_used_at").Default(0).Comment("0 means never used"),
+		field.Int64("created_at").DefaultFunc(ts.UnixMicro),
+	}
+}
+
+// Edges of the PersonalToken.
+func (PersonalToken) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).Ref("personal_tokens").Field("user_id").Unique().Required(),
+	}
+}
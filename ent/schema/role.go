@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
+)
+
+// Role holds the schema definition for the Role entity.
+type Role struct {
+	ent.Schema
+}
+
+func (Role) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.WithComments(true),
+		schema.Comment("role table"),
+	}
+}
+
+// Fields of the Role.
+func (Role) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").Unique(),
+		field.String("description").Optional(),
+		field.Bool("builtin").Default(false).Comment("builtin role is seeded by server, it could not be deleted"),
+		field.Int64("created_at").DefaultFunc(ts.UnixMicro),
+		field.Int64("updated_at").DefaultFunc(ts.UnixMicro).UpdateDefault(ts.UnixMicro),
+	}
+}
+
+// Edges of the Role.
+func (Role) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("permissions", Permission.Type),
+		edge.From("users", User.Type).Ref("roles"),
+	}
+}
@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/idx"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
+)
+
+// Session holds the schema definition for the Session entity.
+type Session struct {
+	ent.Schema
+}
+
+func (Session) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.WithComments(true),
+		schema.Comment("user login session table"),
+	}
+}
+
+// Fields of the Session.
+func (Session) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("sid").DefaultFunc(idx.ULID).Unique().Comment("session id carried in token payload"),
+		field.Int("user_id"),
+		field.String("access_id").Comment("id of the latest access token"),
+		field.String("refresh_id").Optional().Comment("id of the refresh token, empty if not remembered"),
+		field.String("user_agent").Default(""),
+		field.String("ip").Default(""),
+		field.Bool("remember").Default(false),
+		field.Int64("created_at").DefaultFunc(ts.UnixMicro),
+		field.Int64("last_seen_at").DefaultFunc(ts.UnixMicro),
+		field.Int64("expires_at").Comment("session could not be refreshed after expiration"),
+	}
+}
+
+// Edges of the Session.
+func (Session) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).Ref("sessions").Field("user_id").Unique().Required(),
+	}
+}
+
+// Indexes of the Session.
+func (Session) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "expires_at"),
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 	"github.com/ginx-contribs/ginx-server/pkg/toolset/idx"
 	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
 )
@@ -14,6 +16,20 @@ type User struct {
 	ent.Schema
 }
 
+// status of user
+const (
+	StatusActive          = "active"
+	StatusDisabled        = "disabled"
+	StatusPendingDeletion = "pending_deletion"
+	StatusDeleted         = "deleted"
+)
+
+func (User) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		SoftDeleteMixin{},
+	}
+}
+
 func (User) Annotations() []schema.Annotation {
 	return []schema.Annotation{
 		entsql.WithComments(true),
@@ -27,13 +43,42 @@ func (User) Fields() []ent.Field {
 		field.String("uid").DefaultFunc(idx.ULID).Unique(),
 		field.String("username").Unique(),
 		field.String("email").Unique(),
-		field.String("password"),
+		field.Int64("email_verified_at").Default(0).Comment("time when the email was verified, 0 means unverified"),
+		field.String("password").Sensitive().Comment("password hash in PHC string format"),
+		field.String("totp_secret").Optional().Sensitive().Comment("base32 encoded totp secret, it is pending until 2fa enabled"),
+		field.Bool("totp_enabled").Default(false).Comment("whether two-factor authentication is enabled"),
+		field.String("nickname").Optional(),
+		field.String("avatar_url").Optional(),
+		field.String("bio").Optional(),
+		field.String("avatar_key").Optional().Comment("storage key of uploaded avatar, keys of each size are suffixed with _<size>"),
+		field.String("locale").Optional().Comment("preferred language in BCP 47, such as en-US"),
+		field.String("timezone").Optional().Comment("IANA time zone name, such as Asia/Shanghai"),
+		field.JSON("metadata", map[string]any{}).Optional().Comment("arbitrary metadata maintained by the user"),
+		field.Enum("status").Values(StatusActive, StatusDisabled, StatusPendingDeletion, StatusDeleted).Default(StatusActive).
+			Comment("only active user could log in, disabled user is set by administrator, pending deletion user is purged after purge_at unless logs in again"),
+		field.Int64("purge_at").Default(0).Comment("time when the pending deletion user will be purged, 0 means not scheduled"),
 		field.Int64("created_at").DefaultFunc(ts.UnixMicro),
 		field.Int64("updated_at").DefaultFunc(ts.UnixMicro).UpdateDefault(ts.UnixMicro),
 	}
 }
 
+// Indexes of the User.
+func (User) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("status", "purge_at"),
+	}
+}
+
 // Edges of the User.
 func (User) Edges() []ent.Edge {
-	return []ent.Edge{}
+	return []ent.Edge{
+		edge.To("sessions", Session.Type).Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("login_histories", LoginHistory.Type).Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("recovery_codes", RecoveryCode.Type).Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("password_histories", PasswordHistory.Type).Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("identities", Identity.Type).Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("oauth_clients", OAuthClient.Type).Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("personal_tokens", PersonalToken.Type).Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("roles", Role.Type),
+	}
 }
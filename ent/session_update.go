@@ -0,0 +1,706 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ginx-contribs/ginx-server/ent/predicate"
+	"github.com/ginx-contribs/ginx-server/ent/session"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+)
+
+// SessionUpdate is the builder for updating Session entities.
+type SessionUpdate struct {
+	config
+	hooks     []Hook
+	mutation  *SessionMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// Where appends a list predicates to the SessionUpdate builder.
+func (su *SessionUpdate) Where(ps ...predicate.Session) *SessionUpdate {
+	su.mutation.Where(ps...)
+	return su
+}
+
+// SetSid sets the "sid" field.
+func (su *SessionUpdate) SetSid(s string) *SessionUpdate {
+	su.mutation.SetSid(s)
+	return su
+}
+
+// SetNillableSid sets the "sid" field if the given value is not nil.
+func (su *SessionUpdate) SetNillableSid(s *string) *SessionUpdate {
+	if s != nil {
+		su.SetSid(*s)
+	}
+	return su
+}
+
+// SetUserID sets the "user_id" field.
+func (su *SessionUpdate) SetUserID(i int) *SessionUpdate {
+	su.mutation.SetUserID(i)
+	return su
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (su *SessionUpdate) SetNillableUserID(i *int) *SessionUpdate {
+	if i != nil {
+		su.SetUserID(*i)
+	}
+	return su
+}
+
+// SetAccessID sets the "access_id" field.
+func (su *SessionUpdate) SetAccessID(s string) *SessionUpdate {
+	su.mutation.SetAccessID(s)
+	return su
+}
+
+// SetNillableAccessID sets the "access_id" field if the given value is not nil.
+func (su *SessionUpdate) SetNillableAccessID(s *string) *SessionUpdate {
+	if s != nil {
+		su.SetAccessID(*s)
+	}
+	return su
+}
+
+// SetRefreshID sets the "refresh_id" field.
+func (su *SessionUpdate) SetRefreshID(s string) *SessionUpdate {
+	su.mutation.SetRefreshID(s)
+	return su
+}
+
+// SetNillableRefreshID sets the "refresh_id" field if the given value is not nil.
+func (su *SessionUpdate) SetNillableRefreshID(s *string) *SessionUpdate {
+	if s != nil {
+		su.SetRefreshID(*s)
+	}
+	return su
+}
+
+// ClearRefreshID clears the value of the "refresh_id" field.
+func (su *SessionUpdate) ClearRefreshID() *SessionUpdate {
+	su.mutation.ClearRefreshID()
+	return su
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (su *SessionUpdate) SetUserAgent(s string) *SessionUpdate {
+	su.mutation.SetUserAgent(s)
+	return su
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (su *SessionUpdate) SetNillableUserAgent(s *string) *SessionUpdate {
+	if s != nil {
+		su.SetUserAgent(*s)
+	}
+	return su
+}
+
+// SetIP sets the "ip" field.
+func (su *SessionUpdate) SetIP(s string) *SessionUpdate {
+	su.mutation.SetIP(s)
+	return su
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (su *SessionUpdate) SetNillableIP(s *string) *SessionUpdate {
+	if s != nil {
+		su.SetIP(*s)
+	}
+	return su
+}
+
+// SetRemember sets the "remember" field.
+func (su *SessionUpdate) SetRemember(b bool) *SessionUpdate {
+	su.mutation.SetRemember(b)
+	return su
+}
+
+// SetNillableRemember sets the "remember" field if the given value is not nil.
+func (su *SessionUpdate) SetNillableRemember(b *bool) *SessionUpdate {
+	if b != nil {
+		su.SetRemember(*b)
+	}
+	return su
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (su *SessionUpdate) SetCreatedAt(i int64) *SessionUpdate {
+	su.mutation.ResetCreatedAt()
+	su.mutation.SetCreatedAt(i)
+	return su
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (su *SessionUpdate) SetNillableCreatedAt(i *int64) *SessionUpdate {
+	if i != nil {
+		su.SetCreatedAt(*i)
+	}
+	return su
+}
+
+// AddCreatedAt adds i to the "created_at" field.
+func (su *SessionUpdate) AddCreatedAt(i int64) *SessionUpdate {
+	su.mutation.AddCreatedAt(i)
+	return su
+}
+
+// SetLastSeenAt sets the "last_seen_at" field.
+func (su *SessionUpdate) SetLastSeenAt(i int64) *SessionUpdate {
+	su.mutation.ResetLastSeenAt()
+	su.mutation.SetLastSeenAt(i)
+	return su
+}
+
+// SetNillableLastSeenAt sets the "last_seen_at" field if the given value is not nil.
+func (su *SessionUpdate) SetNillableLastSeenAt(i *int64) *SessionUpdate {
+	if i != nil {
+		su.SetLastSeenAt(*i)
+	}
+	return su
+}
+
+// AddLastSeenAt adds i to the "last_seen_at" field.
+func (su *SessionUpdate) AddLastSeenAt(i int64) *SessionUpdate {
+	su.mutation.AddLastSeenAt(i)
+	return su
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (su *SessionUpdate) SetExpiresAt(i int64) *SessionUpdate {
+	su.mutation.ResetExpiresAt()
+	su.mutation.SetExpiresAt(i)
+	return su
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (su *SessionUpdate) SetNillableExpiresAt(i *int64) *SessionUpdate {
+	if i != nil {
+		su.SetExpiresAt(*i)
+	}
+	return su
+}
+
+// AddExpiresAt adds i to the "expires_at" field.
+func (su *SessionUpdate) AddExpiresAt(i int64) *SessionUpdate {
+	su.mutation.AddExpiresAt(i)
+	return su
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (su *SessionUpdate) SetUser(u *User) *SessionUpdate {
+	return su.SetUserID(u.ID)
+}
+
+// Mutation returns the SessionMutation object of the builder.
+func (su *SessionUpdate) Mutation() *SessionMutation {
+	return su.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (su *SessionUpdate) ClearUser() *SessionUpdate {
+	su.mutation.ClearUser()
+	return su
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (su *SessionUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, su.sqlSave, su.mutation, su.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (su *SessionUpdate) SaveX(ctx context.Context) int {
+	affected, err := su.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (su *SessionUpdate) Exec(ctx context.Context) error {
+	_, err := su.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (su *SessionUpdate) ExecX(ctx context.Context) {
+	if err := su.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (su *SessionUpdate) check() error {
+	if su.mutation.UserCleared() && len(su.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Session.user"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (su *SessionUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *SessionUpdate {
+	su.modifiers = append(su.modifiers, modifiers...)
+	return su
+}
+
+func (su *SessionUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := su.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(session.Table, session.Columns, sqlgraph.NewFieldSpec(session.FieldID, field.TypeInt))
+	if ps := su.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := su.mutation.Sid(); ok {
+		_spec.SetField(session.FieldSid, field.TypeString, value)
+	}
+	if value, ok := su.mutation.AccessID(); ok {
+		_spec.SetField(session.FieldAccessID, field.TypeString, value)
+	}
+	if value, ok := su.mutation.RefreshID(); ok {
+		_spec.SetField(session.FieldRefreshID, field.TypeString, value)
+	}
+	if su.mutation.RefreshIDCleared() {
+		_spec.ClearField(session.FieldRefreshID, field.TypeString)
+	}
+	if value, ok := su.mutation.UserAgent(); ok {
+		_spec.SetField(session.FieldUserAgent, field.TypeString, value)
+	}
+	if value, ok := su.mutation.IP(); ok {
+		_spec.SetField(session.FieldIP, field.TypeString, value)
+	}
+	if value, ok := su.mutation.Remember(); ok {
+		_spec.SetField(session.FieldRemember, field.TypeBool, value)
+	}
+	if value, ok := su.mutation.CreatedAt(); ok {
+		_spec.SetField(session.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := su.mutation.AddedCreatedAt(); ok {
+		_spec.AddField(session.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := su.mutation.LastSeenAt(); ok {
+		_spec.SetField(session.FieldLastSeenAt, field.TypeInt64, value)
+	}
+	if value, ok := su.mutation.AddedLastSeenAt(); ok {
+		_spec.AddField(session.FieldLastSeenAt, field.TypeInt64, value)
+	}
+	if value, ok := su.mutation.ExpiresAt(); ok {
+		_spec.SetField(session.FieldExpiresAt, field.TypeInt64, value)
+	}
+	if value, ok := su.mutation.AddedExpiresAt(); ok {
+		_spec.AddField(session.FieldExpiresAt, field.TypeInt64, value)
+	}
+	if su.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   session.UserTable,
+			Columns: []string{session.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := su.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   session.UserTable,
+			Columns: []string{session.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(su.modifiers...)
+	if n, err = sqlgraph.UpdateNodes(ctx, su.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{session.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	su.mutation.done = true
+	return n, nil
+}
+
+// SessionUpdateOne is the builder for updating a single Session entity.
+type SessionUpdateOne struct {
+	config
+	fields    []string
+	hooks     []Hook
+	mutation  *SessionMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// SetSid sets the "sid" field.
+func (suo *SessionUpdateOne) SetSid(s string) *SessionUpdateOne {
+	suo.mutation.SetSid(s)
+	return suo
+}
+
+// SetNillableSid sets the "sid" field if the given value is not nil.
+func (suo *SessionUpdateOne) SetNillableSid(s *string) *SessionUpdateOne {
+	if s != nil {
+		suo.SetSid(*s)
+	}
+	return suo
+}
+
+// SetUserID sets the "user_id" field.
+func (suo *SessionUpdateOne) SetUserID(i int) *SessionUpdateOne {
+	suo.mutation.SetUserID(i)
+	return suo
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (suo *SessionUpdateOne) SetNillableUserID(i *int) *SessionUpdateOne {
+	if i != nil {
+		suo.SetUserID(*i)
+	}
+	return suo
+}
+
+// SetAccessID sets the "access_id" field.
+func (suo *SessionUpdateOne) SetAccessID(s string) *SessionUpdateOne {
+	suo.mutation.SetAccessID(s)
+	return suo
+}
+
+// SetNillableAccessID sets the "access_id" field if the given value is not nil.
+func (suo *SessionUpdateOne) SetNillableAccessID(s *string) *SessionUpdateOne {
+	if s != nil {
+		suo.SetAccessID(*s)
+	}
+	return suo
+}
+
+// SetRefreshID sets the "refresh_id" field.
+func (suo *SessionUpdateOne) SetRefreshID(s string) *SessionUpdateOne {
+	suo.mutation.SetRefreshID(s)
+	return suo
+}
+
+// SetNillableRefreshID sets the "refresh_id" field if the given value is not nil.
+func (suo *SessionUpdateOne) SetNillableRefreshID(s *string) *SessionUpdateOne {
+	if s != nil {
+		suo.SetRefreshID(*s)
+	}
+	return suo
+}
+
+// ClearRefreshID clears the value of the "refresh_id" field.
+func (suo *SessionUpdateOne) ClearRefreshID() *SessionUpdateOne {
+	suo.mutation.ClearRefreshID()
+	return suo
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (suo *SessionUpdateOne) SetUserAgent(s string) *SessionUpdateOne {
+	suo.mutation.SetUserAgent(s)
+	return suo
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (suo *SessionUpdateOne) SetNillableUserAgent(s *string) *SessionUpdateOne {
+	if s != nil {
+		suo.SetUserAgent(*s)
+	}
+	return suo
+}
+
+// SetIP sets the "ip" field.
+func (suo *SessionUpdateOne) SetIP(s string) *SessionUpdateOne {
+	suo.mutation.SetIP(s)
+	return suo
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (suo *SessionUpdateOne) SetNillableIP(s *string) *SessionUpdateOne {
+	if s != nil {
+		suo.SetIP(*s)
+	}
+	return suo
+}
+
+// SetRemember sets the "remember" field.
+func (suo *SessionUpdateOne) SetRemember(b bool) *SessionUpdateOne {
+	suo.mutation.SetRemember(b)
+	return suo
+}
+
+// SetNillableRemember sets the "remember" field if the given value is not nil.
+func (suo *SessionUpdateOne) SetNillableRemember(b *bool) *SessionUpdateOne {
+	if b != nil {
+		suo.SetRemember(*b)
+	}
+	return suo
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (suo *SessionUpdateOne) SetCreatedAt(i int64) *SessionUpdateOne {
+	suo.mutation.ResetCreatedAt()
+	suo.mutation.SetCreatedAt(i)
+	return suo
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (suo *SessionUpdateOne) SetNillableCreatedAt(i *int64) *SessionUpdateOne {
+	if i != nil {
+		suo.SetCreatedAt(*i)
+	}
+	return suo
+}
+
+// AddCreatedAt adds i to the "created_at" field.
+func (suo *SessionUpdateOne) AddCreatedAt(i int64) *SessionUpdateOne {
+	suo.mutation.AddCreatedAt(i)
+	return suo
+}
+
+// SetLastSeenAt sets the "last_seen_at" field.
+func (suo *SessionUpdateOne) SetLastSeenAt(i int64) *SessionUpdateOne {
+	suo.mutation.ResetLastSeenAt()
+	suo.mutation.SetLastSeenAt(i)
+	return suo
+}
+
+// SetNillableLastSeenAt sets the "last_seen_at" field if the given value is not nil.
+func (suo *SessionUpdateOne) SetNillableLastSeenAt(i *int64) *SessionUpdateOne {
+	if i != nil {
+		suo.SetLastSeenAt(*i)
+	}
+	return suo
+}
+
+// AddLastSeenAt adds i to the "last_seen_at" field.
+func (suo *SessionUpdateOne) AddLastSeenAt(i int64) *SessionUpdateOne {
+	suo.mutation.AddLastSeenAt(i)
+	return suo
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (suo *SessionUpdateOne) SetExpiresAt(i int64) *SessionUpdateOne {
+	suo.mutation.ResetExpiresAt()
+	suo.mutation.SetExpiresAt(i)
+	return suo
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (suo *SessionUpdateOne) SetNillableExpiresAt(i *int64) *SessionUpdateOne {
+	if i != nil {
+		suo.SetExpiresAt(*i)
+	}
+	return suo
+}
+
+// AddExpiresAt adds i to the "expires_at" field.
+func (suo *SessionUpdateOne) AddExpiresAt(i int64) *SessionUpdateOne {
+	suo.mutation.AddExpiresAt(i)
+	return suo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (suo *SessionUpdateOne) SetUser(u *User) *SessionUpdateOne {
+	return suo.SetUserID(u.ID)
+}
+
+// Mutation returns the SessionMutation object of the builder.
+func (suo *SessionUpdateOne) Mutation() *SessionMutation {
+	return suo.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (suo *SessionUpdateOne) ClearUser() *SessionUpdateOne {
+	suo.mutation.ClearUser()
+	return suo
+}
+
+// Where appends a list predicates to the SessionUpdate builder.
+func (suo *SessionUpdateOne) Where(ps ...predicate.Session) *SessionUpdateOne {
+	suo.mutation.Where(ps...)
+	return suo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (suo *SessionUpdateOne) Select(field string, fields ...string) *SessionUpdateOne {
+	suo.fields = append([]string{field}, fields...)
+	return suo
+}
+
+// Save executes the query and returns the updated Session entity.
+func (suo *SessionUpdateOne) Save(ctx context.Context) (*Session, error) {
+	return withHooks(ctx, suo.sqlSave, suo.mutation, suo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (suo *SessionUpdateOne) SaveX(ctx context.Context) *Session {
+	node, err := suo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (suo *SessionUpdateOne) Exec(ctx context.Context) error {
+	_, err := suo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (suo *SessionUpdateOne) ExecX(ctx context.Context) {
+	if err := suo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (suo *SessionUpdateOne) check() error {
+	if suo.mutation.UserCleared() && len(suo.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Session.user"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (suo *SessionUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *SessionUpdateOne {
+	suo.modifiers = append(suo.modifiers, modifiers...)
+	return suo
+}
+
+func (suo *SessionUpdateOne) sqlSave(ctx context.Context) (_node *Session, err error) {
+	if err := suo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(session.Table, session.Columns, sqlgraph.NewFieldSpec(session.FieldID, field.TypeInt))
+	id, ok := suo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Session.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := suo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, session.FieldID)
+		for _, f := range fields {
+			if !session.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != session.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := suo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := suo.mutation.Sid(); ok {
+		_spec.SetField(session.FieldSid, field.TypeString, value)
+	}
+	if value, ok := suo.mutation.AccessID(); ok {
+		_spec.SetField(session.FieldAccessID, field.TypeString, value)
+	}
+	if value, ok := suo.mutation.RefreshID(); ok {
+		_spec.SetField(session.FieldRefreshID, field.TypeString, value)
+	}
+	if suo.mutation.RefreshIDCleared() {
+		_spec.ClearField(session.FieldRefreshID, field.TypeString)
+	}
+	if value, ok := suo.mutation.UserAgent(); ok {
+		_spec.SetField(session.FieldUserAgent, field.TypeString, value)
+	}
+	if value, ok := suo.mutation.IP(); ok {
+		_spec.SetField(session.FieldIP, field.TypeString, value)
+	}
+	if value, ok := suo.mutation.Remember(); ok {
+		_spec.SetField(session.FieldRemember, field.TypeBool, value)
+	}
+	if value, ok := suo.mutation.CreatedAt(); ok {
+		_spec.SetField(session.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := suo.mutation.AddedCreatedAt(); ok {
+		_spec.AddField(session.FieldCreatedAt, field.TypeInt64, value)
+	}
+	if value, ok := suo.mutation.LastSeenAt(); ok {
+		_spec.SetField(session.FieldLastSeenAt, field.TypeInt64, value)
+	}
+	if value, ok := suo.mutation.AddedLastSeenAt(); ok {
+		_spec.AddField(session.FieldLastSeenAt, field.TypeInt64, value)
+	}
+	if value, ok := suo.mutation.ExpiresAt(); ok {
+		_spec.SetField(session.FieldExpiresAt, field.TypeInt64, value)
+	}
+	if value, ok := suo.mutation.AddedExpiresAt(); ok {
+		_spec.AddField(session.FieldExpiresAt, field.TypeInt64, value)
+	}
+	if suo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   session.UserTable,
+			Columns: []string{session.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := suo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   session.UserTable,
+			Columns: []string{session.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(suo.modifiers...)
+	_node = &Session{config: suo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, suo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{session.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	suo.mutation.done = true
+	return _node, nil
+}
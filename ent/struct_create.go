@@ -2,11 +2,122 @@
 
 package ent
 
+func (alc *AuditLogCreate) SetAuditLog(input *AuditLog) *AuditLogCreate {
+	alc.SetActor(input.Actor)
+	alc.SetAction(input.Action)
+	alc.SetTarget(input.Target)
+	alc.SetDetail(input.Detail)
+	alc.SetCreatedAt(input.CreatedAt)
+	return alc
+}
+
+func (ic *IdentityCreate) SetIdentity(input *Identity) *IdentityCreate {
+	ic.SetUserID(input.UserID)
+	ic.SetProvider(input.Provider)
+	ic.SetSubject(input.Subject)
+	ic.SetEmail(input.Email)
+	ic.SetCreatedAt(input.CreatedAt)
+	ic.SetLastLoginAt(input.LastLoginAt)
+	return ic
+}
+
+func (lhc *LoginHistoryCreate) SetLoginHistory(input *LoginHistory) *LoginHistoryCreate {
+	lhc.SetUserID(input.UserID)
+	lhc.SetSid(input.Sid)
+	lhc.SetUserAgent(input.UserAgent)
+	lhc.SetIP(input.IP)
+	lhc.SetCreatedAt(input.CreatedAt)
+	return lhc
+}
+
+func (occ *OAuthClientCreate) SetOAuthClient(input *OAuthClient) *OAuthClientCreate {
+	occ.SetClientID(input.ClientID)
+	occ.SetSecretHash(input.SecretHash)
+	occ.SetName(input.Name)
+	occ.SetRedirectUris(input.RedirectUris)
+	occ.SetScopes(input.Scopes)
+	occ.SetGrantTypes(input.GrantTypes)
+	occ.SetPublic(input.Public)
+	occ.SetUserID(input.UserID)
+	occ.SetCreatedAt(input.CreatedAt)
+	occ.SetUpdatedAt(input.UpdatedAt)
+	return occ
+}
+
+func (phc *PasswordHistoryCreate) SetPasswordHistory(input *PasswordHistory) *PasswordHistoryCreate {
+	phc.SetUserID(input.UserID)
+	phc.SetPassword(input.Password)
+	phc.SetCreatedAt(input.CreatedAt)
+	return phc
+}
+
+func (pc *PermissionCreate) SetPermission(input *Permission) *PermissionCreate {
+	pc.SetName(input.Name)
+	pc.SetDescription(input.Description)
+	return pc
+}
+
+func (ptc *PersonalTokenCreate) SetPersonalToken(input *PersonalToken) *PersonalTokenCreate {
+	ptc.SetKeyID(input.KeyID)
+	ptc.SetSecretHash(input.SecretHash)
+	ptc.SetUserID(input.UserID)
+	ptc.SetName(input.Name)
+	ptc.SetScopes(input.Scopes)
+	ptc.SetExpiresAt(input.ExpiresAt)
+	ptc.SetLastUsedAt(input.LastUsedAt)
+	ptc.SetCreatedAt(input.CreatedAt)
+	return ptc
+}
+
+func (rcc *RecoveryCodeCreate) SetRecoveryCode(input *RecoveryCode) *RecoveryCodeCreate {
+	rcc.SetUserID(input.UserID)
+	rcc.SetCodeHash(input.CodeHash)
+	rcc.SetUsedAt(input.UsedAt)
+	rcc.SetCreatedAt(input.CreatedAt)
+	return rcc
+}
+
+func (rc *RoleCreate) SetRole(input *Role) *RoleCreate {
+	rc.SetName(input.Name)
+	rc.SetDescription(input.Description)
+	rc.SetBuiltin(input.Builtin)
+	rc.SetCreatedAt(input.CreatedAt)
+	rc.SetUpdatedAt(input.UpdatedAt)
+	return rc
+}
+
+func (sc *SessionCreate) SetSession(input *Session) *SessionCreate {
+	sc.SetSid(input.Sid)
+	sc.SetUserID(input.UserID)
+	sc.SetAccessID(input.AccessID)
+	sc.SetRefreshID(input.RefreshID)
+	sc.SetUserAgent(input.UserAgent)
+	sc.SetIP(input.IP)
+	sc.SetRemember(input.Remember)
+	sc.SetCreatedAt(input.CreatedAt)
+	sc.SetLastSeenAt(input.LastSeenAt)
+	sc.SetExpiresAt(input.ExpiresAt)
+	return sc
+}
+
 func (uc *UserCreate) SetUser(input *User) *UserCreate {
+	uc.SetDeletedAt(input.DeletedAt)
 	uc.SetUID(input.UID)
 	uc.SetUsername(input.Username)
 	uc.SetEmail(input.Email)
+	uc.SetEmailVerifiedAt(input.EmailVerifiedAt)
 	uc.SetPassword(input.Password)
+	uc.SetTotpSecret(input.TotpSecret)
+	uc.SetTotpEnabled(input.TotpEnabled)
+	uc.SetNickname(input.Nickname)
+	uc.SetAvatarURL(input.AvatarURL)
+	uc.SetBio(input.Bio)
+	uc.SetAvatarKey(input.AvatarKey)
+	uc.SetLocale(input.Locale)
+	uc.SetTimezone(input.Timezone)
+	uc.SetMetadata(input.Metadata)
+	uc.SetStatus(input.Status)
+	uc.SetPurgeAt(input.PurgeAt)
 	uc.SetCreatedAt(input.CreatedAt)
 	uc.SetUpdatedAt(input.UpdatedAt)
 	return uc
@@ -3,6 +3,7 @@
 package ent
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -16,29 +17,157 @@ type User struct {
 	config `json:"-"`
 	// ID of the ent.
 	ID int `json:"id,omitempty"`
+	// time when the row was soft deleted, 0 means not deleted
+	DeletedAt int64 `json:"deleted_at,omitempty"`
 	// UID holds the value of the "uid" field.
 	UID string `json:"uid,omitempty"`
 	// Username holds the value of the "username" field.
 	Username string `json:"username,omitempty"`
 	// Email holds the value of the "email" field.
 	Email string `json:"email,omitempty"`
-	// Password holds the value of the "password" field.
-	Password string `json:"password,omitempty"`
+	// time when the email was verified, 0 means unverified
+	EmailVerifiedAt int64 `json:"email_verified_at,omitempty"`
+	// password hash in PHC string format
+	Password string `json:"-"`
+	// base32 encoded totp secret, it is pending until 2fa enabled
+	TotpSecret string `json:"-"`
+	// whether two-factor authentication is enabled
+	TotpEnabled bool `json:"totp_enabled,omitempty"`
+	// Nickname holds the value of the "nickname" field.
+	Nickname string `json:"nickname,omitempty"`
+	// AvatarURL holds the value of the "avatar_url" field.
+	AvatarURL string `json:"avatar_url,omitempty"`
+	// Bio holds the value of the "bio" field.
+	Bio string `json:"bio,omitempty"`
+	// storage key of uploaded avatar, keys of each size are suffixed with _<size>
+	AvatarKey string `json:"avatar_key,omitempty"`
+	// preferred language in BCP 47, such as en-US
+	Locale string `json:"locale,omitempty"`
+	// IANA time zone name, such as Asia/Shanghai
+	Timezone string `json:"timezone,omitempty"`
+	// arbitrary metadata maintained by the user
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// only active user could log in, disabled user is set by administrator, pending deletion user is purged after purge_at unless logs in again
+	Status user.Status `json:"status,omitempty"`
+	// time when the pending deletion user will be purged, 0 means not scheduled
+	PurgeAt int64 `json:"purge_at,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt int64 `json:"created_at,omitempty"`
 	// UpdatedAt holds the value of the "updated_at" field.
-	UpdatedAt    int64 `json:"updated_at,omitempty"`
+	UpdatedAt int64 `json:"updated_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the UserQuery when eager-loading is set.
+	Edges        UserEdges `json:"edges"`
 	selectValues sql.SelectValues
 }
 
+// UserEdges holds the relations/edges for other nodes in the graph.
+type UserEdges struct {
+	// Sessions holds the value of the sessions edge.
+	Sessions []*Session `json:"sessions,omitempty"`
+	// LoginHistories holds the value of the login_histories edge.
+	LoginHistories []*LoginHistory `json:"login_histories,omitempty"`
+	// RecoveryCodes holds the value of the recovery_codes edge.
+	RecoveryCodes []*RecoveryCode `json:"recovery_codes,omitempty"`
+	// PasswordHistories holds the value of the password_histories edge.
+	PasswordHistories []*PasswordHistory `json:"password_histories,omitempty"`
+	// Identities holds the value of the identities edge.
+	Identities []*Identity `json:"identities,omitempty"`
+	// OauthClients holds the value of the oauth_clients edge.
+	OauthClients []*OAuthClient `json:"oauth_clients,omitempty"`
+	// PersonalTokens holds the value of the personal_tokens edge.
+	PersonalTokens []*PersonalToken `json:"personal_tokens,omitempty"`
+	// Roles holds the value of the roles edge.
+	Roles []*Role `json:"roles,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [8]bool
+}
+
+// SessionsOrErr returns the Sessions value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) SessionsOrErr() ([]*Session, error) {
+	if e.loadedTypes[0] {
+		return e.Sessions, nil
+	}
+	return nil, &NotLoadedError{edge: "sessions"}
+}
+
+// LoginHistoriesOrErr returns the LoginHistories value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) LoginHistoriesOrErr() ([]*LoginHistory, error) {
+	if e.loadedTypes[1] {
+		return e.LoginHistories, nil
+	}
+	return nil, &NotLoadedError{edge: "login_histories"}
+}
+
+// RecoveryCodesOrErr returns the RecoveryCodes value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) RecoveryCodesOrErr() ([]*RecoveryCode, error) {
+	if e.loadedTypes[2] {
+		return e.RecoveryCodes, nil
+	}
+	return nil, &NotLoadedError{edge: "recovery_codes"}
+}
+
+// PasswordHistoriesOrErr returns the PasswordHistories value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) PasswordHistoriesOrErr() ([]*PasswordHistory, error) {
+	if e.loadedTypes[3] {
+		return e.PasswordHistories, nil
+	}
+	return nil, &NotLoadedError{edge: "password_histories"}
+}
+
+// IdentitiesOrErr returns the Identities value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) IdentitiesOrErr() ([]*Identity, error) {
+	if e.loadedTypes[4] {
+		return e.Identities, nil
+	}
+	return nil, &NotLoadedError{edge: "identities"}
+}
+
+// OauthClientsOrErr returns the OauthClients value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) OauthClientsOrErr() ([]*OAuthClient, error) {
+	if e.loadedTypes[5] {
+		return e.OauthClients, nil
+	}
+	return nil, &NotLoadedError{edge: "oauth_clients"}
+}
+
+// PersonalTokensOrErr returns the PersonalTokens value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) PersonalTokensOrErr() ([]*PersonalToken, error) {
+	if e.loadedTypes[6] {
+		return e.PersonalTokens, nil
+	}
+	return nil, &NotLoadedError{edge: "personal_tokens"}
+}
+
+// RolesOrErr returns the Roles value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) RolesOrErr() ([]*Role, error) {
+	if e.loadedTypes[7] {
+		return e.Roles, nil
+	}
+	return nil, &NotLoadedError{edge: "roles"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*User) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case user.FieldID, user.FieldCreatedAt, user.FieldUpdatedAt:
+		case user.FieldMetadata:
+			values[i] = new([]byte)
+		case user.FieldTotpEnabled:
+			values[i] = new(sql.NullBool)
+		case user.FieldID, user.FieldDeletedAt, user.FieldEmailVerifiedAt, user.FieldPurgeAt, user.FieldCreatedAt, user.FieldUpdatedAt:
 			values[i] = new(sql.NullInt64)
-		case user.FieldUID, user.FieldUsername, user.FieldEmail, user.FieldPassword:
+		case user.FieldUID, user.FieldUsername, user.FieldEmail, user.FieldPassword, user.FieldTotpSecret, user.FieldNickname, user.FieldAvatarURL, user.FieldBio, user.FieldAvatarKey, user.FieldLocale, user.FieldTimezone, user.FieldStatus:
 			values[i] = new(sql.NullString)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -61,6 +190,12 @@ func (u *User) assignValues(columns []string, values []any) error {
 				return fmt.Errorf("unexpected type %T for field id", value)
 			}
 			u.ID = int(value.Int64)
+		case user.FieldDeletedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field deleted_at", values[i])
+			} else if value.Valid {
+				u.DeletedAt = value.Int64
+			}
 		case user.FieldUID:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field uid", values[i])
@@ -79,12 +214,86 @@ func (u *User) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				u.Email = value.String
 			}
+		case user.FieldEmailVerifiedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field email_verified_at", values[i])
+			} else if value.Valid {
+				u.EmailVerifiedAt = value.Int64
+			}
 		case user.FieldPassword:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field password", values[i])
 			} else if value.Valid {
 				u.Password = value.String
 			}
+		case user.FieldTotpSecret:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field totp_secret", values[i])
+			} else if value.Valid {
+				u.TotpSecret = value.String
+			}
+		case user.FieldTotpEnabled:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field totp_enabled", values[i])
+			} else if value.Valid {
+				u.TotpEnabled = value.Bool
+			}
+		case user.FieldNickname:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field nickname", values[i])
+			} else if value.Valid {
+				u.Nickname = value.String
+			}
+		case user.FieldAvatarURL:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field avatar_url", values[i])
+			} else if value.Valid {
+				u.AvatarURL = value.String
+			}
+		case user.FieldBio:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field bio", values[i])
+			} else if value.Valid {
+				u.Bio = value.String
+			}
+		case user.FieldAvatarKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field avatar_key", values[i])
+			} else if value.Valid {
+				u.AvatarKey = value.String
+			}
+		case user.FieldLocale:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field locale", values[i])
+			} else if value.Valid {
+				u.Locale = value.String
+			}
+		case user.FieldTimezone:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field timezone", values[i])
+			} else if value.Valid {
+				u.Timezone = value.String
+			}
+		case user.FieldMetadata:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field metadata", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &u.Metadata); err != nil {
+					return fmt.Errorf("unmarshal field metadata: %w", err)
+				}
+			}
+		case user.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				u.Status = user.Status(value.String)
+			}
+		case user.FieldPurgeAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field purge_at", values[i])
+			} else if value.Valid {
+				u.PurgeAt = value.Int64
+			}
 		case user.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -110,6 +319,46 @@ func (u *User) Value(name string) (ent.Value, error) {
 	return u.selectValues.Get(name)
 }
 
+// QuerySessions queries the "sessions" edge of the User entity.
+func (u *User) QuerySessions() *SessionQuery {
+	return NewUserClient(u.config).QuerySessions(u)
+}
+
+// QueryLoginHistories queries the "login_histories" edge of the User entity.
+func (u *User) QueryLoginHistories() *LoginHistoryQuery {
+	return NewUserClient(u.config).QueryLoginHistories(u)
+}
+
+// QueryRecoveryCodes queries the "recovery_codes" edge of the User entity.
+func (u *User) QueryRecoveryCodes() *RecoveryCodeQuery {
+	return NewUserClient(u.config).QueryRecoveryCodes(u)
+}
+
+// QueryPasswordHistories queries the "password_histories" edge of the User entity.
+func (u *User) QueryPasswordHistories() *PasswordHistoryQuery {
+	return NewUserClient(u.config).QueryPasswordHistories(u)
+}
+
+// QueryIdentities queries the "identities" edge of the User entity.
+func (u *User) QueryIdentities() *IdentityQuery {
+	return NewUserClient(u.config).QueryIdentities(u)
+}
+
+// QueryOauthClients queries the "oauth_clients" edge of the User entity.
+func (u *User) QueryOauthClients() *OAuthClientQuery {
+	return NewUserClient(u.config).QueryOauthClients(u)
+}
+
+// QueryPersonalTokens queries the "personal_tokens" edge of the User entity.
+func (u *User) QueryPersonalTokens() *PersonalTokenQuery {
+	return NewUserClient(u.config).QueryPersonalTokens(u)
+}
+
+// QueryRoles queries the "roles" edge of the User entity.
+func (u *User) QueryRoles() *RoleQuery {
+	return NewUserClient(u.config).QueryRoles(u)
+}
+
 // Update returns a builder for updating this User.
 // Note that you need to call User.Unwrap() before calling this method if this User
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -133,6 +382,9 @@ func (u *User) String() string {
 	var builder strings.Builder
 	builder.WriteString("User(")
 	builder.WriteString(fmt.Sprintf("id=%v, ", u.ID))
+	builder.WriteString("deleted_at=")
+	builder.WriteString(fmt.Sprintf("%v", u.DeletedAt))
+	builder.WriteString(", ")
 	builder.WriteString("uid=")
 	builder.WriteString(u.UID)
 	builder.WriteString(", ")
@@ -142,8 +394,42 @@ func (u *User) String() string {
 	builder.WriteString("email=")
 	builder.WriteString(u.Email)
 	builder.WriteString(", ")
-	builder.WriteString("password=")
-	builder.WriteString(u.Password)
+	builder.WriteString("email_verified_at=")
+	builder.WriteString(fmt.Sprintf("%v", u.EmailVerifiedAt))
+	builder.WriteString(", ")
+	builder.WriteString("password=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("totp_secret=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("totp_enabled=")
+	builder.WriteString(fmt.Sprintf("%v", u.TotpEnabled))
+	builder.WriteString(", ")
+	builder.WriteString("nickname=")
+	builder.WriteString(u.Nickname)
+	builder.WriteString(", ")
+	builder.WriteString("avatar_url=")
+	builder.WriteString(u.AvatarURL)
+	builder.WriteString(", ")
+	builder.WriteString("bio=")
+	builder.WriteString(u.Bio)
+	builder.WriteString(", ")
+	builder.WriteString("avatar_key=")
+	builder.WriteString(u.AvatarKey)
+	builder.WriteString(", ")
+	builder.WriteString("locale=")
+	builder.WriteString(u.Locale)
+	builder.WriteString(", ")
+	builder.WriteString("timezone=")
+	builder.WriteString(u.Timezone)
+	builder.WriteString(", ")
+	builder.WriteString("metadata=")
+	builder.WriteString(fmt.Sprintf("%v", u.Metadata))
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(fmt.Sprintf("%v", u.Status))
+	builder.WriteString(", ")
+	builder.WriteString("purge_at=")
+	builder.WriteString(fmt.Sprintf("%v", u.PurgeAt))
 	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(fmt.Sprintf("%v", u.CreatedAt))
@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/ginx-contribs/ginx"
+	"github.com/ginx-contribs/ginx-server/pkg/ratelimit"
 	"github.com/ginx-contribs/ginx-server/pkg/token"
-	"github.com/ginx-contribs/ginx/contribs/ratelimit/counter"
 	"github.com/pkg/errors"
 	"time"
 )
@@ -18,13 +18,34 @@ var Private = ginx.V{Key: AuthKey, Val: 0}
 // Public means that api no need to be authenticated
 var Public = ginx.V{Key: AuthKey, Val: 1}
 
+const PermissionKey = "permission"
+
+// Permission metadata means that api needs the login user to be granted with the permission
+func Permission(name string) ginx.V {
+	return ginx.V{Key: PermissionKey, Val: name}
+}
+
+const ScopeKey = "scope"
+
+// Scope metadata means that api is accessible to delegated tokens granted with the scope,
+// private apis without it are only accessible to first-party tokens.
+func Scope(name string) ginx.V {
+	return ginx.V{Key: ScopeKey, Val: name}
+}
+
 const CountKey = "count"
 
-// CountLimit metadata means that api need to rate limit by number of requests
+// CountLimit metadata means that api need to rate limit by number of requests, it overrides the global limit
 func CountLimit(limit int, duration time.Duration) ginx.V {
-	return ginx.V{Key: CountKey, Val: counter.Limiter{Limit: limit, Window: duration}}
+	return ginx.V{Key: CountKey, Val: ratelimit.Rule{Limit: limit, Window: duration}}
 }
 
+const CacheKey = "cache"
+
+// Cacheable metadata means that the response of api could be cached by uri for a short while,
+// it only takes effect on public GET apis, since the cache key does not distinguish callers.
+var Cacheable = ginx.V{Key: CacheKey, Val: true}
+
 const tokenKey = "auth.token.context.info.key"
 
 // SetTokenInfo stores token information into context
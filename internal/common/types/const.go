@@ -14,5 +14,9 @@ const customCode = 0_000_000
 var (
 	ErrBadParams = statuserr.Errorf("bad parameters").SetCode(400_001).SetStatus(status.BadRequest)
 
+	ErrInvalidCursor = statuserr.Errorf("invalid pagination cursor").SetCode(400_002).SetStatus(status.BadRequest)
+
+	ErrTooManyRequests = statuserr.Errorf("too many requests").SetCode(429_001).SetStatus(status.TooManyRequests)
+
 	ErrInternal = statuserr.Errorf("internal server error").SetCode(500_000).SetStatus(status.InternalServerError)
 )
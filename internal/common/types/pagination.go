@@ -0,0 +1,46 @@
+package types
+
+import (
+	"errors"
+	"github.com/ginx-contribs/ginx-server/ent"
+	"strings"
+)
+
+// CursorOptions are query parameters of cursor pagination, exactly one of first and last must be given.
+// Cursors are opaque strings taken from the pageInfo of previous responses.
+type CursorOptions struct {
+	// returns items after the cursor
+	After string `form:"after"`
+	// returns items before the cursor
+	Before string `form:"before"`
+	// returns the first n items
+	First int `form:"first" binding:"required_without=Last,excluded_with=Last,omitempty,gt=0,lte=100"`
+	// returns the last n items
+	Last int `form:"last" binding:"required_without=First,excluded_with=First,omitempty,gt=0,lte=100"`
+}
+
+// Args returns the cursor arguments ordered by field in direction, empty values fall back to id and DESC
+func (c CursorOptions) Args(field, direction string) ent.CursorArgs {
+	return ent.CursorArgs{
+		After:     c.After,
+		First:     c.First,
+		Before:    c.Before,
+		Last:      c.Last,
+		Field:     field,
+		Direction: ent.OrderDirection(strings.ToUpper(direction)),
+	}
+}
+
+// CursorResult is a page of list paginated by cursor
+type CursorResult[T any] struct {
+	List     []T          `json:"list"`
+	PageInfo ent.PageInfo `json:"pageInfo"`
+}
+
+// CursorError converts errors caused by invalid cursors or arguments into ErrInvalidCursor, others are returned as is
+func CursorError(err error) error {
+	if errors.Is(err, ent.ErrInvalidCursor) || errors.Is(err, ent.ErrInvalidCursorArgs) {
+		return ErrInvalidCursor
+	}
+	return err
+}
@@ -4,8 +4,13 @@ import (
 	"github.com/ginx-contribs/ginx"
 	"github.com/ginx-contribs/ginx-server/ent"
 	"github.com/ginx-contribs/ginx-server/internal/conf"
+	"github.com/ginx-contribs/ginx-server/pkg/challenge"
 	"github.com/ginx-contribs/ginx-server/pkg/email"
+	"github.com/ginx-contribs/ginx-server/pkg/export"
 	"github.com/ginx-contribs/ginx-server/pkg/mq"
+	"github.com/ginx-contribs/ginx-server/pkg/oauth"
+	"github.com/ginx-contribs/ginx-server/pkg/passwd"
+	"github.com/ginx-contribs/ginx-server/pkg/storage"
 	"github.com/ginx-contribs/ginx-server/pkg/token"
 	"github.com/google/wire"
 	"github.com/redis/go-redis/v9"
@@ -19,10 +24,31 @@ var Provider = wire.NewSet(
 	wire.FieldsOf(new(Injector), "Token"),
 	wire.FieldsOf(new(Injector), "Email"),
 	wire.FieldsOf(new(Injector), "MQ"),
+	wire.FieldsOf(new(Injector), "Hasher"),
+	wire.FieldsOf(new(Injector), "PasswordPolicy"),
+	wire.FieldsOf(new(Injector), "OAuth"),
+	wire.FieldsOf(new(Injector), "Storage"),
+	wire.FieldsOf(new(Injector), "Exporters"),
+	wire.FieldsOf(new(Injector), "Cursor"),
+	wire.FieldsOf(new(Injector), "Challenges"),
 	// configuration
 	wire.FieldsOf(new(*conf.App), "Jwt"),
 	wire.FieldsOf(new(*conf.App), "Email"),
+	wire.FieldsOf(new(*conf.App), "Password"),
 	wire.FieldsOf(new(*conf.App), "Meta"),
+	wire.FieldsOf(new(*conf.App), "Session"),
+	wire.FieldsOf(new(*conf.App), "TwoFA"),
+	wire.FieldsOf(new(*conf.App), "OAuth"),
+	wire.FieldsOf(new(*conf.App), "OAuthServer"),
+	wire.FieldsOf(new(*conf.App), "PersonalToken"),
+	wire.FieldsOf(new(*conf.App), "RBAC"),
+	wire.FieldsOf(new(*conf.App), "Lockout"),
+	wire.FieldsOf(new(*conf.App), "MagicLink"),
+	wire.FieldsOf(new(*conf.App), "Server"),
+	wire.FieldsOf(new(*conf.App), "Storage"),
+	wire.FieldsOf(new(*conf.App), "Account"),
+	wire.FieldsOf(new(*conf.App), "Export"),
+	wire.FieldsOf(new(*conf.App), "Bulk"),
 )
 
 // Injector holds all needed object for initializing app
@@ -41,6 +67,20 @@ type Injector struct {
 	Email *email.Sender
 	// message queue
 	MQ mq.Queue
+	// password hasher
+	Hasher *passwd.Hasher
+	// policy for checking new passwords
+	PasswordPolicy *passwd.Policy
+	// third-party login providers
+	OAuth *oauth.Registry
+	// object storage
+	Storage storage.Storage
+	// personal data exporters registered by modules
+	Exporters *export.Registry
+	// signer of pagination cursors
+	Cursor *ent.CursorSigner
+	// verification challenge usages registered by modules
+	Challenges *challenge.Registry
 }
 
 // Response is a basic http json response, just for document.
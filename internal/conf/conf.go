@@ -7,13 +7,27 @@ import (
 
 // App is configuration for the whole application
 type App struct {
-	Server Server   `toml:"server" comment:"http server configuration"`
-	Log    Log      `toml:"log" comment:"server log configuration"`
-	DB     DB       `toml:"db" comment:"database connection configuration"`
-	Redis  Redis    `toml:"redis" comment:"redis connection configuration"`
-	Email  Email    `toml:"email" comment:"email smtp client configuration"`
-	Jwt    Jwt      `toml:"jwt" comment:"jwt secret configuration"`
-	Meta   MetaInfo `toml:"-"`
+	Server        Server        `toml:"server" comment:"http server configuration"`
+	Log           Log           `toml:"log" comment:"server log configuration"`
+	DB            DB            `toml:"db" comment:"database connection configuration"`
+	Redis         Redis         `toml:"redis" comment:"redis connection configuration"`
+	Email         Email         `toml:"email" comment:"email smtp client configuration"`
+	Jwt           Jwt           `toml:"jwt" comment:"jwt secret configuration"`
+	Password      Password      `toml:"password" comment:"password hashing and policy configuration"`
+	Session       Session       `toml:"session" comment:"login session configuration"`
+	TwoFA         TwoFA         `toml:"twofa" comment:"two-factor authentication configuration"`
+	OAuth         OAuth         `toml:"oauth" comment:"third-party login configuration"`
+	OAuthServer   OAuthServer   `toml:"oauthServer" comment:"oauth2 authorization server configuration"`
+	PersonalToken PersonalToken `toml:"personalToken" comment:"personal access token configuration"`
+	RBAC          RBAC          `toml:"rbac" comment:"role-based access control configuration"`
+	Lockout       Lockout       `toml:"lockout" comment:"login brute-force protection configuration"`
+	MagicLink     MagicLink     `toml:"magicLink" comment:"passwordless login by email link configuration"`
+	RateLimit     RateLimit     `toml:"ratelimit" comment:"request rate limiting configuration"`
+	Storage       Storage       `toml:"storage" comment:"object storage configuration"`
+	Account       Account       `toml:"account" comment:"account deletion configuration"`
+	Export        Export        `toml:"export" comment:"personal data export configuration"`
+	Bulk          Bulk          `toml:"bulk" comment:"bulk user import and export configuration"`
+	Meta          MetaInfo      `toml:"-"`
 }
 
 // MetaInfo for program
@@ -28,6 +42,8 @@ type MetaInfo struct {
 type Server struct {
 	Address      string            `toml:"address" comment:"server bind address"`
 	BasePath     string            `toml:"basepath" comment:"base path for api"`
+	PublicURL    string            `toml:"publicUrl" comment:"external url of server visited by users, it is used to build links in emails"`
+	CursorSecret string            `toml:"cursorSecret" comment:"secret to sign pagination cursors, a random one is used if empty, then cursors are invalid after restart"`
 	ReadTimeout  duration.Duration `toml:"readTimeout" comment:"the maximum duration for reading the entire request"`
 	WriteTimeout duration.Duration `toml:"writeTimeout" comment:"the maximum duration before timing out writes of the response"`
 	IdleTimeout  duration.Duration `toml:"idleTimeout" comment:"the maximum amount of time to wait for the next request when keep-alives are enabled"`
@@ -84,19 +100,182 @@ type Jwt struct {
 type AccessToken struct {
 	Expire duration.Duration `toml:"expire" comment:"duration to expire access token"`
 	Delay  duration.Duration `toml:"delay" comment:"delay duration after expiration"`
-	Key    string            `toml:"key" comment:"access token signing key"`
+	Key    string            `toml:"key" comment:"access token HS512 signing key, it is ignored if keys is not empty"`
+	Keys   []SigningKey      `toml:"keys" comment:"access token signing key ring, the first one is used to sign, the others are only used to verify during rotation"`
 }
 
 type RefreshToken struct {
 	Expire duration.Duration `toml:"expire" comment:"duration to expire refresh token"`
-	Key    string            `toml:"key" comment:"refresh token signing key"`
+	Key    string            `toml:"key" comment:"refresh token HS512 signing key, it is ignored if keys is not empty"`
+	Keys   []SigningKey      `toml:"keys" comment:"refresh token signing key ring, the first one is used to sign, the others are only used to verify during rotation"`
+	Rotate bool              `toml:"rotate" comment:"whether to issue a new refresh token on every refresh, reusing an old one revokes all tokens of the login"`
 }
 
+// SigningKey is a jwt signing key
+type SigningKey struct {
+	Id      string `toml:"id" comment:"key id, it is written in jwt header as kid, required and unique among keys"`
+	Method  string `toml:"method" comment:"HS256 | HS384 | HS512 | RS256 | RS384 | RS512 | ES256 | ES384 | ES512 | EdDSA"`
+	Secret  string `toml:"secret" comment:"secret for HMAC methods"`
+	Private string `toml:"private" comment:"private key pem file for asymmetric methods, it could be empty if key is only used to verify"`
+	Public  string `toml:"public" comment:"public key pem file for asymmetric methods, it is derived from private key if empty"`
+}
+
+// Session is configuration for login sessions
+type Session struct {
+	Max              int               `toml:"max" comment:"maximum number of concurrent sessions per user, the oldest one will be evicted on new login, 0 means unlimited"`
+	HistoryRetention duration.Duration `toml:"historyRetention" comment:"how long login history is kept, 0 means forever"`
+}
+
+// TwoFA is configuration for TOTP two-factor authentication
+type TwoFA struct {
+	Issuer        string            `toml:"issuer" comment:"issuer name displayed in authenticator apps"`
+	ChallengeTTL  duration.Duration `toml:"challengeTTL" comment:"lifetime of the login challenge token"`
+	MaxAttempts   int               `toml:"maxAttempts" comment:"maximum attempts of code for a login challenge, or failed attempts to enable or disable 2fa in challengeTTL"`
+	RecoveryCodes int               `toml:"recoveryCodes" comment:"number of recovery codes generated when 2fa enabled"`
+}
+
+// OAuth is configuration for third-party login by OpenID Connect or OAuth2 providers
+type OAuth struct {
+	StateTTL  duration.Duration        `toml:"stateTTL" comment:"lifetime of the authorization state, user must complete login in this duration"`
+	Providers map[string]OAuthProvider `toml:"providers" comment:"providers keyed by name, the name is used in api path, e.g. /auth/oauth/google/authorize"`
+}
+
+// OAuthProvider is configuration for a single login provider
+type OAuthProvider struct {
+	Issuer       string   `toml:"issuer" comment:"OpenID Connect issuer, endpoints will be discovered from it"`
+	ClientID     string   `toml:"clientId" comment:"oauth client id"`
+	ClientSecret string   `toml:"clientSecret" comment:"oauth client secret"`
+	Scopes       []string `toml:"scopes" comment:"requested scopes, defaults to openid, profile and email for OpenID Connect"`
+	RedirectURL  string   `toml:"redirectUrl" comment:"redirect url registered at the provider, it should point to the frontend callback page"`
+	AuthURL      string   `toml:"authUrl" comment:"authorization endpoint for plain OAuth2 provider without issuer"`
+	TokenURL     string   `toml:"tokenUrl" comment:"token endpoint for plain OAuth2 provider without issuer"`
+	UserInfoURL  string   `toml:"userInfoUrl" comment:"userinfo endpoint for plain OAuth2 provider without issuer"`
+	LinkByEmail  bool     `toml:"linkByEmail" comment:"link to existing user with the same verified email on first login, only enable it for providers which verify emails"`
+}
+
+// OAuthServer is configuration for issuing tokens to oauth2 clients
+type OAuthServer struct {
+	CodeTTL duration.Duration `toml:"codeTTL" comment:"lifetime of the authorization code"`
+	Scopes  []string          `toml:"scopes" comment:"scopes supported by server, oauth clients and personal access tokens could only be granted with them"`
+}
+
+// PersonalToken is configuration for long-lived personal access tokens
+type PersonalToken struct {
+	Max       int               `toml:"max" comment:"maximum number of tokens per user, 0 means unlimited"`
+	MaxExpire duration.Duration `toml:"maxExpire" comment:"maximum lifetime of token, 0 means tokens could never expire"`
+}
+
+// RBAC is configuration for role-based access control
+type RBAC struct {
+	Admins []string `toml:"admins" comment:"usernames which are granted with the builtin admin role on startup"`
+}
+
+// Lockout is configuration for login brute-force protection, failed attempts are counted by username and by client ip
+type Lockout struct {
+	Threshold   int               `toml:"threshold" comment:"failed attempts of a username before it is locked, the owner will be notified by email"`
+	IPThreshold int               `toml:"ipThreshold" comment:"failed attempts from a client ip before it is locked"`
+	Window      duration.Duration `toml:"window" comment:"failed attempts are forgotten if there is no new failure in this duration"`
+	Duration    duration.Duration `toml:"duration" comment:"how long a locked username or client ip is rejected"`
+	BaseDelay   duration.Duration `toml:"baseDelay" comment:"delay required after the first failure, it doubles on each subsequent failure"`
+	MaxDelay    duration.Duration `toml:"maxDelay" comment:"upper bound of the delay between failures"`
+}
+
+// MagicLink is configuration for passwordless login, links are sent by email and could be used only once
+type MagicLink struct {
+	TTL        duration.Duration `toml:"ttl" comment:"lifetime of the login link"`
+	Secret     string            `toml:"secret" comment:"secret to sign login links, a random one is used if empty, then links are invalid after restart"`
+	BindClient bool              `toml:"bindClient" comment:"links could only be used from the ip and user agent which requested them"`
+}
+
+// Password is configuration for password hashing and policy
+type Password struct {
+	Algorithm string         `toml:"algorithm" comment:"argon2id | bcrypt"`
+	Argon2id  Argon2id       `toml:"argon2id" comment:"argon2id cost parameters"`
+	Bcrypt    Bcrypt         `toml:"bcrypt" comment:"bcrypt cost parameters"`
+	Policy    PasswordPolicy `toml:"policy" comment:"password strength policy"`
+}
+
+// PasswordPolicy is configuration for checking new passwords
+type PasswordPolicy struct {
+	MinLength  int    `toml:"minLength" comment:"min number of characters"`
+	MaxLength  int    `toml:"maxLength" comment:"max number of characters"`
+	MinClasses int    `toml:"minClasses" comment:"min number of character classes in lower, upper, digit and symbol"`
+	MinScore   int    `toml:"minScore" comment:"min strength score from 0 to 4, 0 means disabled"`
+	Breached   string `toml:"breached" comment:"sorted breached password list file of upper case sha1 hashes in HASH:COUNT lines, empty means disabled"`
+	History    int    `toml:"history" comment:"number of recent passwords that could not be reused, including the current one, 0 means disabled"`
+}
+
+type Argon2id struct {
+	Time    uint32 `toml:"time" comment:"number of passes over the memory"`
+	Memory  uint32 `toml:"memory" comment:"memory size in KiB"`
+	Threads uint8  `toml:"threads" comment:"degree of parallelism"`
+	KeyLen  uint32 `toml:"keyLen" comment:"length of the derived key"`
+	SaltLen uint32 `toml:"saltLen" comment:"length of the random salt"`
+}
+
+type Bcrypt struct {
+	Cost int `toml:"cost" comment:"bcrypt cost, range in [4, 31]"`
+}
+
+// RateLimit is configuration for request rate limiting, apis could override the limit by route.CountLimit
 type RateLimit struct {
-	Public struct {
-		Limit  int               `toml:"limit"`
-		Window duration.Duration `toml:"window"`
-	} `toml:"public"`
+	Disable   bool              `toml:"disable" comment:"disable rate limiting"`
+	Algorithm string            `toml:"algorithm" comment:"fixed | sliding | bucket"`
+	Limit     int               `toml:"limit" comment:"default maximum requests per client in window, clients are identified by api key, login user or ip"`
+	Window    duration.Duration `toml:"window" comment:"default rate limit window"`
+}
+
+// Storage is configuration for object storage, such as uploaded avatars
+type Storage struct {
+	Driver  string            `toml:"driver" comment:"local | s3"`
+	SignTTL duration.Duration `toml:"signTTL" comment:"lifetime of signed download urls"`
+	Local   LocalStorage      `toml:"local" comment:"local filesystem storage configuration"`
+	S3      S3Storage         `toml:"s3" comment:"S3-compatible storage configuration"`
+	Avatar  Avatar            `toml:"avatar" comment:"avatar upload configuration"`
+}
+
+type LocalStorage struct {
+	Root      string `toml:"root" comment:"directory where objects are stored"`
+	URLPrefix string `toml:"urlPrefix" comment:"url path where objects are served by signed urls"`
+	Secret    string `toml:"secret" comment:"secret to sign download urls, a random one is used if empty, then urls are invalid after restart"`
+}
+
+type S3Storage struct {
+	Endpoint  string `toml:"endpoint" comment:"service endpoint with scheme, such as https://s3.us-east-1.amazonaws.com"`
+	Region    string `toml:"region" comment:"bucket region"`
+	Bucket    string `toml:"bucket" comment:"bucket name"`
+	AccessKey string `toml:"accessKey" comment:"access key id"`
+	SecretKey string `toml:"secretKey" comment:"secret access key"`
+	PathStyle bool   `toml:"pathStyle" comment:"use path-style urls, most self-hosted S3-compatible services require it"`
+}
+
+type Avatar struct {
+	MaxSize   int64 `toml:"maxSize" comment:"maximum size of uploaded avatar file in bytes"`
+	MaxPixels int   `toml:"maxPixels" comment:"maximum pixels of uploaded avatar image"`
+	Sizes     []int `toml:"sizes" comment:"side lengths of square avatars to be generated"`
+}
+
+// Account is configuration for deletion of accounts, accounts deleted by their owners are purged after the grace period
+type Account struct {
+	DeletionGrace      duration.Duration `toml:"deletionGrace" comment:"duration before the account deleted by its owner is purged, logging in during it cancels the deletion"`
+	PurgeInterval      duration.Duration `toml:"purgeInterval" comment:"interval of checking for accounts to be purged"`
+	PurgeMode          string            `toml:"purgeMode" comment:"anonymize | remove, anonymize keeps the row with personal data erased, remove deletes the row permanently"`
+	PurgeBatch         int               `toml:"purgeBatch" comment:"maximum number of accounts purged in a batch"`
+	EmailRevertTTL     duration.Duration `toml:"emailRevertTTL" comment:"lifetime of the link sent to the old address to revert an email change"`
+	RestrictUnverified bool              `toml:"restrictUnverified" comment:"users with unverified email could not log in, they could verify it by resetting password"`
+}
+
+// Export is configuration for personal data export, archives are stored in object storage
+type Export struct {
+	LinkTTL       duration.Duration `toml:"linkTTL" comment:"lifetime of the download link sent by email, archives are deleted after it"`
+	SweepInterval duration.Duration `toml:"sweepInterval" comment:"interval of checking for expired archives to be deleted"`
+}
+
+// Bulk is configuration for bulk import and export of users
+type Bulk struct {
+	BatchSize int   `toml:"batchSize" comment:"number of users inserted in one transaction"`
+	MaxRows   int   `toml:"maxRows" comment:"maximum rows of a file imported through api, it is not limited in command line"`
+	MaxSize   int64 `toml:"maxSize" comment:"maximum size in bytes of a file imported through api"`
 }
 
 type Email struct {
@@ -120,4 +299,5 @@ type EmailMq struct {
 type VerifyCode struct {
 	TTL      duration.Duration `toml:"ttl" comment:"lifetime for verification code"`
 	RetryTTL duration.Duration `toml:"retry" comment:"max wait time before asking for another new verification code"`
+	Attempts int64             `toml:"attempts" comment:"max checks of a verification code, it is invalidated after that"`
 }
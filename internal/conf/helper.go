@@ -42,6 +42,7 @@ var DefaultConfig = App{
 	Server: Server{
 		Address:      "127.0.0.1:8080",
 		BasePath:     "/api",
+		PublicURL:    "http://127.0.0.1:8080",
 		ReadTimeout:  duration.Minute,
 		WriteTimeout: duration.Minute,
 		IdleTimeout:  5 * duration.Minute,
@@ -89,6 +90,7 @@ var DefaultConfig = App{
 		Code: VerifyCode{
 			TTL:      5 * duration.Minute,
 			RetryTTL: duration.Minute,
+			Attempts: 5,
 		},
 	},
 	Jwt: Jwt{
@@ -96,13 +98,99 @@ var DefaultConfig = App{
 		Access: AccessToken{
 			Expire: 4 * duration.Hour,
 			Delay:  10 * duration.Minute,
-			Key:    "01J6EA2G4FSF9ABC218VFJ2B3C",
 		},
 		Refresh: RefreshToken{
 			Expire: 144 * duration.Hour,
-			Key:    "01J6EA3FKDDHTT9Q8Z5YKWHVCE",
 		},
 	},
+	Password: Password{
+		Algorithm: "argon2id",
+		Argon2id: Argon2id{
+			Time:    3,
+			Memory:  64 * 1024,
+			Threads: 2,
+			KeyLen:  32,
+			SaltLen: 16,
+		},
+		Bcrypt: Bcrypt{
+			Cost: 12,
+		},
+		Policy: PasswordPolicy{
+			MinLength:  8,
+			MaxLength:  128,
+			MinClasses: 2,
+			MinScore:   2,
+			History:    3,
+		},
+	},
+	Session: Session{
+		Max:              10,
+		HistoryRetention: 90 * 24 * duration.Hour,
+	},
+	TwoFA: TwoFA{
+		Issuer:        "ginx-server",
+		ChallengeTTL:  5 * duration.Minute,
+		MaxAttempts:   5,
+		RecoveryCodes: 10,
+	},
+	OAuth: OAuth{
+		StateTTL: 10 * duration.Minute,
+	},
+	OAuthServer: OAuthServer{
+		CodeTTL: duration.Minute,
+		Scopes:  []string{"profile", "email"},
+	},
+	PersonalToken: PersonalToken{
+		Max: 20,
+	},
+	Lockout: Lockout{
+		Threshold:   5,
+		IPThreshold: 50,
+		Window:      15 * duration.Minute,
+		Duration:    15 * duration.Minute,
+		BaseDelay:   duration.Second,
+		MaxDelay:    30 * duration.Second,
+	},
+	MagicLink: MagicLink{
+		TTL: 15 * duration.Minute,
+	},
+	RateLimit: RateLimit{
+		Algorithm: "sliding",
+		Limit:     300,
+		Window:    duration.Minute,
+	},
+	Storage: Storage{
+		Driver:  "local",
+		SignTTL: duration.Hour,
+		Local: LocalStorage{
+			Root:      "/etc/ginx-internal/storage",
+			URLPrefix: "/storage",
+		},
+		S3: S3Storage{
+			Region: "us-east-1",
+		},
+		Avatar: Avatar{
+			MaxSize:   5 << 20,
+			MaxPixels: 25_000_000,
+			Sizes:     []int{512, 256, 64},
+		},
+	},
+	Account: Account{
+		DeletionGrace:  14 * 24 * duration.Hour,
+		PurgeInterval:  duration.Hour,
+		PurgeMode:      "anonymize",
+		PurgeBatch:     100,
+		EmailRevertTTL: 7 * 24 * duration.Hour,
+	},
+	Export: Export{
+		LinkTTL:       24 * duration.Hour,
+		SweepInterval: duration.Hour,
+	},
+	Bulk: Bulk{
+		BatchSize: 500,
+		MaxRows:   10_000,
+		MaxSize:   10 << 20,
+	},
 }
 
 // Revise check the given configuration, if field value is zero then it will be overwritten by same filed value of DefaultConfig
@@ -131,10 +219,13 @@ func Revise(cfg App) (App, error) {
 func reviseMap(src, dst map[string]any) {
 	for srcKey, srcVal := range src {
 		dstVal := dst[srcKey]
-		if reflect.TypeOf(dstVal).Kind() == reflect.Map && reflect.TypeOf(srcVal).Kind() == reflect.Map {
-			reviseMap(srcVal.(map[string]any), dstVal.(map[string]any))
+		// typed maps such as oauth providers are values rather than nested structs
+		srcMap, srcOk := srcVal.(map[string]any)
+		dstMap, dstOk := dstVal.(map[string]any)
+		if srcOk && dstOk {
+			reviseMap(srcMap, dstMap)
 		} else {
-			if reflect.ValueOf(dstVal).IsZero() {
+			if dstVal == nil || reflect.ValueOf(dstVal).IsZero() {
 				dst[srcKey] = srcVal
 			}
 		}
@@ -0,0 +1,256 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/ginx-contribs/ginx"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/handler"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ginxutils"
+	"github.com/ginx-contribs/ginx/pkg/resp"
+	"net/http"
+)
+
+type AdminUserAPI struct {
+	AdminUserHandler handler.AdminUserHandler
+}
+
+// Create
+// @Summary      Create
+// @Description  create a new user
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        AdminUserCreateOptions  body  types.AdminUserCreateOptions  true "AdminUserCreateOptions"
+// @Success      200  {object}  types.Response{data=types.AdminUserInfo}
+// @Security     BearerAuth
+// @Router       /admin/users [POST]
+func (a AdminUserAPI) Create(ctx *gin.Context) {
+	var opt types.AdminUserCreateOptions
+	if err := ginx.ShouldValidateJSON(ctx, &opt); err != nil {
+		return
+	}
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	userInfo, err := a.AdminUserHandler.CreateUser(ctx, tokenInfo.Claims.Subject, opt)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(userInfo).JSON()
+	}
+}
+
+// Update
+// @Summary      Update
+// @Description  update username, email or password of the user, empty fields are left unchanged.
+// @Description  the user will be logged out everywhere if password reset.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        uid                     path  string                        true "uid"
+// @Param        AdminUserUpdateOptions  body  types.AdminUserUpdateOptions  true "AdminUserUpdateOptions"
+// @Success      200  {object}  types.Response{data=types.AdminUserInfo}
+// @Security     BearerAuth
+// @Router       /admin/users/{uid} [PUT]
+func (a AdminUserAPI) Update(ctx *gin.Context) {
+	var uidOpt types.UidOptions
+	if err := ginx.ShouldValidateURI(ctx, &uidOpt); err != nil {
+		return
+	}
+	var opt types.AdminUserUpdateOptions
+	if err := ginx.ShouldValidateJSON(ctx, &opt); err != nil {
+		return
+	}
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	userInfo, err := a.AdminUserHandler.UpdateUser(ctx, tokenInfo.Claims.Subject, uidOpt.Uid, opt)
+	if err != nil {
+		failPasswordPolicy(ctx, err)
+	} else {
+		resp.Ok(ctx).Data(userInfo).JSON()
+	}
+}
+
+// Disable
+// @Summary      Disable
+// @Description  disable the user, it will be logged out everywhere and could not log in until enabled, users pending deletion are rejected
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        uid  path  string  true "uid"
+// @Success      200  {object}  types.Response{data=types.AdminUserInfo}
+// @Security     BearerAuth
+// @Router       /admin/users/{uid}/disable [POST]
+func (a AdminUserAPI) Disable(ctx *gin.Context) {
+	a.setDisabled(ctx, true)
+}
+
+// Enable
+// @Summary      Enable
+// @Description  enable the disabled user
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        uid  path  string  true "uid"
+// @Success      200  {object}  types.Response{data=types.AdminUserInfo}
+// @Security     BearerAuth
+// @Router       /admin/users/{uid}/enable [POST]
+func (a AdminUserAPI) Enable(ctx *gin.Context) {
+	a.setDisabled(ctx, false)
+}
+
+func (a AdminUserAPI) setDisabled(ctx *gin.Context, disabled bool) {
+	var opt types.UidOptions
+	if err := ginx.ShouldValidateURI(ctx, &opt); err != nil {
+		return
+	}
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	userInfo, err := a.AdminUserHandler.SetDisabled(ctx, tokenInfo.Claims.Subject, opt.Uid, disabled)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(userInfo).JSON()
+	}
+}
+
+// Delete
+// @Summary      Delete
+// @Description  delete the user, all of its sessions and tokens are revoked
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        uid  path  string  true "uid"
+// @Success      200  {object}  types.Response
+// @Security     BearerAuth
+// @Router       /admin/users/{uid} [DELETE]
+func (a AdminUserAPI) Delete(ctx *gin.Context) {
+	var opt types.UidOptions
+	if err := ginx.ShouldValidateURI(ctx, &opt); err != nil {
+		return
+	}
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	if err := a.AdminUserHandler.DeleteUser(ctx, tokenInfo.Claims.Subject, opt.Uid); err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Msg("user deleted").JSON()
+	}
+}
+
+// Logout
+// @Summary      Logout
+// @Description  force the user to log out everywhere, personal access tokens of the user are revoked as well
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        uid  path  string  true "uid"
+// @Success      200  {object}  types.Response
+// @Security     BearerAuth
+// @Router       /admin/users/{uid}/logout [POST]
+func (a AdminUserAPI) Logout(ctx *gin.Context) {
+	var opt types.UidOptions
+	if err := ginx.ShouldValidateURI(ctx, &opt); err != nil {
+		return
+	}
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	if err := a.AdminUserHandler.ForceLogout(ctx, tokenInfo.Claims.Subject, opt.Uid); err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Msg("user logged out").JSON()
+	}
+}
+
+// Import
+// @Summary      Import
+// @Description  import users from csv or json lines file, all rows are validated first, then valid ones are inserted in batches.
+// @Description  rows failed to import are reported with reasons, nothing is written in dry run.
+// @Tags         admin
+// @Accept       mpfd
+// @Produce      json
+// @Param        ImportOptions  query     types.ImportOptions  true "ImportOptions"
+// @Param        file           formData  file                 true "csv with header or json lines, see types.ImportUserRow"
+// @Success      200  {object}  types.Response{data=types.ImportReport}
+// @Security     BearerAuth
+// @Router       /admin/users/import [POST]
+func (a AdminUserAPI) Import(ctx *gin.Context) {
+	var opt types.ImportOptions
+	if err := ginx.ShouldValidateQuery(ctx, &opt); err != nil {
+		return
+	}
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	// leave room for multipart boundaries and headers
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, a.AdminUserHandler.BulkHandler.Config.MaxSize+64<<10)
+	fileHeader, err := ctx.FormFile(types.ImportFileField)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		resp.Fail(ctx).Error(types.ErrImportTooLarge).JSON()
+		return
+	} else if err != nil {
+		resp.Fail(ctx).Error(types.ErrImportFile).JSON()
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		resp.Fail(ctx).Error(types.ErrImportFile).JSON()
+		return
+	}
+	defer file.Close()
+
+	report, err := a.AdminUserHandler.ImportUsers(ctx, tokenInfo.Claims.Subject, file, opt)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(report).JSON()
+	}
+}
+
+// Export
+// @Summary      Export
+// @Description  download all users as csv or json lines file, which could be imported again
+// @Tags         admin
+// @Produce      octet-stream
+// @Param        ExportUsersOptions  query  types.ExportUsersOptions  true "ExportUsersOptions"
+// @Success      200  {file}  file
+// @Security     BearerAuth
+// @Router       /admin/users/export [GET]
+func (a AdminUserAPI) Export(ctx *gin.Context) {
+	var opt types.ExportUsersOptions
+	if err := ginx.ShouldValidateQuery(ctx, &opt); err != nil {
+		return
+	}
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	contentType := "text/csv"
+	if opt.Format == types.FormatJSONL {
+		contentType = "application/x-ndjson"
+	}
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="users.%s"`, opt.Format))
+
+	err := a.AdminUserHandler.ExportUsers(ctx, tokenInfo.Claims.Subject, ctx.Writer, opt.Format)
+	if err != nil && !ctx.Writer.Written() {
+		resp.Fail(ctx).Error(err).JSON()
+	} else if err != nil {
+		// the response is partially written, it could only be aborted
+		ctx.Error(err)
+		ctx.Abort()
+	}
+}
@@ -7,19 +7,23 @@ import (
 	"github.com/ginx-contribs/ginx-server/internal/modules/system/handler"
 	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
 	"github.com/ginx-contribs/ginx-server/pkg/token"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ginxutils"
 	"github.com/ginx-contribs/ginx/pkg/resp"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
 	"github.com/golang-jwt/jwt/v5"
 )
 
 type AuthAPI struct {
-	TokenResolver  *token.Resolver
-	AuthHandler    handler.AuthHandler
-	CaptchaHandler handler.CaptchaHandler
+	TokenResolver    *token.Resolver
+	AuthHandler      handler.AuthHandler
+	CaptchaHandler   handler.CaptchaHandler
+	MagicLinkHandler handler.MagicLinkHandler
 }
 
 // Login
 // @Summary      Login
-// @Description  login with password, and returns jwt token pair
+// @Description  login with password, and returns jwt token pair, or a challenge token if 2fa enabled
+// @Description  repeated failures delay further attempts, and lock the username or client ip for a while
 // @Tags         auth
 // @Accept       json
 // @Produce      json
@@ -33,7 +37,39 @@ func (a *AuthAPI) Login(ctx *gin.Context) {
 	}
 
 	// login by username and password
-	tokenPair, err := a.AuthHandler.LoginWithPassword(ctx, loginOpt)
+	tokenPair, challenge, err := a.AuthHandler.LoginWithPassword(ctx, loginOpt, clientInfo(ctx))
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+		return
+	}
+
+	if challenge != "" {
+		resp.Ok(ctx).Msg("2fa required").Data(types.TokenResult{Challenge: challenge}).JSON()
+		return
+	}
+
+	resp.Ok(ctx).Msg("login ok").Data(types.TokenResult{
+		AccessToken:  tokenPair.Access.Raw,
+		RefreshToken: tokenPair.Refresh.Raw,
+	}).JSON()
+}
+
+// LoginTwoFA
+// @Summary      LoginTwoFA
+// @Description  exchange the login challenge and a 2fa code or recovery code for jwt token pair
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        TwoFALoginOptions  body  types.TwoFALoginOptions  true "TwoFALoginOptions"
+// @Success      200  {object}  types.Response{data=types.TokenResult}
+// @Router       /auth/login/2fa [POST]
+func (a *AuthAPI) LoginTwoFA(ctx *gin.Context) {
+	var loginOpt types.TwoFALoginOptions
+	if err := ginx.ShouldValidateJSON(ctx, &loginOpt); err != nil {
+		return
+	}
+
+	tokenPair, err := a.AuthHandler.LoginWithTwoFA(ctx, loginOpt, clientInfo(ctx))
 	if err != nil {
 		resp.Fail(ctx).Error(err).JSON()
 		return
@@ -84,7 +120,7 @@ func (a *AuthAPI) ResetPassword(ctx *gin.Context) {
 	}
 
 	if err := a.AuthHandler.ResetPassword(ctx, restOpt); err != nil {
-		resp.Fail(ctx).Error(err).JSON()
+		failPasswordPolicy(ctx, err)
 		return
 	}
 	resp.Ok(ctx).Msg("reset password ok").JSON()
@@ -106,10 +142,13 @@ func (a *AuthAPI) Refresh(ctx *gin.Context) {
 	}
 
 	// ask for refresh TokenHandler
-	tokenPair, err := a.TokenResolver.Refresh(ctx, refreshOpt.AccessToken, refreshOpt.RefreshToken)
+	tokenPair, err := a.AuthHandler.Refresh(ctx, refreshOpt)
 	if err != nil {
+		var statusErr statuserr.Error
 		if errors.Is(err, jwt.ErrTokenExpired) {
 			resp.Fail(ctx).Error(types.ErrCredentialExpired).JSON()
+		} else if errors.As(err, &statusErr) {
+			resp.Fail(ctx).Error(err).JSON()
 		} else {
 			ctx.Error(err)
 			resp.Fail(ctx).Error(types.ErrCredentialInvalid).JSON()
@@ -122,6 +161,58 @@ func (a *AuthAPI) Refresh(ctx *gin.Context) {
 	}).JSON()
 }
 
+// Logout
+// @Summary      Logout
+// @Description  revoke current access token and the refresh token
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        LogoutOptions  body  types.LogoutOptions  false "LogoutOptions"
+// @Success      200  {object}  types.Response
+// @Security     BearerAuth
+// @Router       /auth/logout [POST]
+func (a *AuthAPI) Logout(ctx *gin.Context) {
+	var logoutOpt types.LogoutOptions
+	if ctx.Request.ContentLength > 0 {
+		if err := ginx.ShouldValidateJSON(ctx, &logoutOpt); err != nil {
+			return
+		}
+	}
+
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+
+	if err := a.AuthHandler.Logout(ctx, tokenInfo, logoutOpt.RefreshToken); err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+		return
+	}
+	resp.Ok(ctx).Msg("logout ok").JSON()
+}
+
+// LogoutAll
+// @Summary      LogoutAll
+// @Description  revoke all tokens of current user, it will log out all devices
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  types.Response
+// @Security     BearerAuth
+// @Router       /auth/logout-all [POST]
+func (a *AuthAPI) LogoutAll(ctx *gin.Context) {
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+
+	if err := a.AuthHandler.LogoutAll(ctx, tokenInfo.Claims.Subject); err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+		return
+	}
+	resp.Ok(ctx).Msg("logout all ok").JSON()
+}
+
 // Captcha
 // @Summary      Captcha
 // @Description  send captcha code mail to specified email address
@@ -137,16 +228,99 @@ func (a *AuthAPI) Captcha(ctx *gin.Context) {
 		return
 	}
 
-	// check usage
-	if err := types.CheckValidUsage(verifyOpt.Usage); err != nil {
-		resp.Fail(ctx).Error(types.ErrVerifyCodeUsageUnsupported).JSON()
+	err := a.CaptchaHandler.Apply(ctx, verifyOpt)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
 		return
 	}
+	resp.Ok(ctx).Msg("mail has been sent").JSON()
+}
 
-	err := a.CaptchaHandler.SendCaptchaEmail(ctx, verifyOpt.To, verifyOpt.Usage)
+// MagicLink
+// @Summary      MagicLink
+// @Description  send a login link to the email address, it is short-lived and could be used only once.
+// @Description  the response is the same whether the address is registered or not.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        MagicLinkOptions  body  types.MagicLinkOptions  true  "MagicLinkOptions"
+// @Success      200  {object}  types.Response
+// @Router       /auth/magic-link [POST]
+func (a *AuthAPI) MagicLink(ctx *gin.Context) {
+	var opt types.MagicLinkOptions
+	if err := ginx.ShouldValidateJSON(ctx, &opt); err != nil {
+		return
+	}
+	if err := a.MagicLinkHandler.Send(ctx, opt, clientInfo(ctx)); err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+		return
+	}
+	resp.Ok(ctx).Msg("login link will be sent if the address is registered").JSON()
+}
+
+// VerifyMagicLink
+// @Summary      VerifyMagicLink
+// @Description  log in with the token in login link, and returns jwt token pair, or a challenge token if 2fa enabled
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        token  query  string  true  "login link token"
+// @Success      200  {object}  types.Response{data=types.TokenResult}
+// @Router       /auth/magic-link/verify [GET]
+func (a *AuthAPI) VerifyMagicLink(ctx *gin.Context) {
+	var opt types.MagicLinkVerifyOptions
+	if err := ginx.ShouldValidateQuery(ctx, &opt); err != nil {
+		return
+	}
+	result, err := a.MagicLinkHandler.Verify(ctx, opt.Token, clientInfo(ctx))
+	// link is single-use, its tokens must not be stored by any cache
+	ctx.Header("Cache-Control", "no-store")
 	if err != nil {
 		resp.Fail(ctx).Error(err).JSON()
 		return
 	}
-	resp.Ok(ctx).Msg("mail has been sent").JSON()
+	if result.Challenge != "" {
+		resp.Ok(ctx).Msg("2fa required").Data(result).JSON()
+		return
+	}
+	resp.Ok(ctx).Msg("login ok").Data(result).JSON()
+}
+
+// RevokeMagicLinks
+// @Summary      RevokeMagicLinks
+// @Description  revoke all unused login links of current user
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  types.Response
+// @Security     BearerAuth
+// @Router       /auth/magic-link [DELETE]
+func (a *AuthAPI) RevokeMagicLinks(ctx *gin.Context) {
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	if err := a.MagicLinkHandler.Revoke(ctx, tokenInfo.Claims.Subject); err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+		return
+	}
+	resp.Ok(ctx).Msg("login links revoked").JSON()
+}
+
+// clientInfo returns information of the requesting device
+func clientInfo(ctx *gin.Context) types.ClientInfo {
+	return types.ClientInfo{
+		UserAgent: ctx.Request.UserAgent(),
+		IP:        ctx.ClientIP(),
+	}
+}
+
+// failPasswordPolicy responds the error, violations of password policy are carried in data as invalid params do
+func failPasswordPolicy(ctx *gin.Context, err error) {
+	var policyErr types.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		resp.Fail(ctx).Data(gin.H{"violations": policyErr.Violations}).Error(err).JSON()
+		return
+	}
+	resp.Fail(ctx).Error(err).JSON()
 }
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ginx-contribs/ginx"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/handler"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ginxutils"
+	"github.com/ginx-contribs/ginx/pkg/resp"
+)
+
+type OAuthAPI struct {
+	AuthHandler  handler.AuthHandler
+	OAuthHandler handler.OAuthHandler
+}
+
+// Authorize
+// @Summary      Authorize
+// @Description  start login with the third-party provider, client should redirect user to the returned url
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        provider  path   string  true  "provider name"
+// @Param        remember  query  bool    false "remember user or not"
+// @Success      200  {object}  types.Response{data=types.OAuthAuthorizeResult}
+// @Router       /auth/oauth/{provider}/authorize [GET]
+func (o OAuthAPI) Authorize(ctx *gin.Context) {
+	var providerOpt types.OAuthProviderOptions
+	if err := ginx.ShouldValidateURI(ctx, &providerOpt); err != nil {
+		return
+	}
+	var opt types.OAuthAuthorizeOptions
+	if err := ginx.ShouldValidateQuery(ctx, &opt); err != nil {
+		return
+	}
+	url, err := o.OAuthHandler.AuthorizeURL(ctx, providerOpt.Provider, "", opt.Remember)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(types.OAuthAuthorizeResult{URL: url}).JSON()
+	}
+}
+
+// Callback
+// @Summary      Callback
+// @Description  complete login with the code and state returned by provider, and returns jwt token pair, or a challenge token if 2fa enabled.
+// @Description  a new user is registered if the identity has not been linked yet.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        provider  path  string  true "provider name"
+// @Param        OAuthCallbackOptions  body  types.OAuthCallbackOptions  true "OAuthCallbackOptions"
+// @Success      200  {object}  types.Response{data=types.TokenResult}
+// @Router       /auth/oauth/{provider}/callback [POST]
+func (o OAuthAPI) Callback(ctx *gin.Context) {
+	var providerOpt types.OAuthProviderOptions
+	if err := ginx.ShouldValidateURI(ctx, &providerOpt); err != nil {
+		return
+	}
+	var opt types.OAuthCallbackOptions
+	if err := ginx.ShouldValidateJSON(ctx, &opt); err != nil {
+		return
+	}
+
+	tokenPair, challenge, err := o.AuthHandler.LoginWithOAuth(ctx, providerOpt.Provider, opt, clientInfo(ctx))
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+		return
+	}
+
+	if challenge != "" {
+		resp.Ok(ctx).Msg("2fa required").Data(types.TokenResult{Challenge: challenge}).JSON()
+		return
+	}
+
+	resp.Ok(ctx).Msg("login ok").Data(types.TokenResult{
+		AccessToken:  tokenPair.Access.Raw,
+		RefreshToken: tokenPair.Refresh.Raw,
+	}).JSON()
+}
+
+// Identities
+// @Summary      Identities
+// @Description  list third-party identities linked to current user
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  types.Response{data=[]types.IdentityInfo}
+// @Security     BearerAuth
+// @Router       /user/identities [GET]
+func (o OAuthAPI) Identities(ctx *gin.Context) {
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	identities, err := o.OAuthHandler.ListIdentities(ctx, tokenInfo.Claims.Subject)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(identities).JSON()
+	}
+}
+
+// Link
+// @Summary      Link
+// @Description  start linking the third-party provider to current user, client should redirect user to the returned url
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        provider  path  string  true "provider name"
+// @Success      200  {object}  types.Response{data=types.OAuthAuthorizeResult}
+// @Security     BearerAuth
+// @Router       /user/identities/{provider}/authorize [POST]
+func (o OAuthAPI) Link(ctx *gin.Context) {
+	var providerOpt types.OAuthProviderOptions
+	if err := ginx.ShouldValidateURI(ctx, &providerOpt); err != nil {
+		return
+	}
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	url, err := o.OAuthHandler.AuthorizeURL(ctx, providerOpt.Provider, tokenInfo.Claims.Subject, false)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(types.OAuthAuthorizeResult{URL: url}).JSON()
+	}
+}
+
+// LinkCallback
+// @Summary      LinkCallback
+// @Description  complete linking with the code and state returned by provider
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        provider  path  string  true "provider name"
+// @Param        OAuthCallbackOptions  body  types.OAuthCallbackOptions  true "OAuthCallbackOptions"
+// @Success      200  {object}  types.Response
+// @Security     BearerAuth
+// @Router       /user/identities/{provider}/callback [POST]
+func (o OAuthAPI) LinkCallback(ctx *gin.Context) {
+	var providerOpt types.OAuthProviderOptions
+	if err := ginx.ShouldValidateURI(ctx, &providerOpt); err != nil {
+		return
+	}
+	var opt types.OAuthCallbackOptions
+	if err := ginx.ShouldValidateJSON(ctx, &opt); err != nil {
+		return
+	}
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	if err := o.OAuthHandler.Link(ctx, providerOpt.Provider, tokenInfo.Claims.Subject, opt.Code, opt.State); err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Msg("identity linked").JSON()
+	}
+}
+
+// Unlink
+// @Summary      Unlink
+// @Description  unlink the third-party provider from current user
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        provider  path  string  true "provider name"
+// @Success      200  {object}  types.Response
+// @Security     BearerAuth
+// @Router       /user/identities/{provider} [DELETE]
+func (o OAuthAPI) Unlink(ctx *gin.Context) {
+	var providerOpt types.OAuthProviderOptions
+	if err := ginx.ShouldValidateURI(ctx, &providerOpt); err != nil {
+		return
+	}
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	if err := o.OAuthHandler.Unlink(ctx, tokenInfo.Claims.Subject, providerOpt.Provider); err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Msg("identity unlinked").JSON()
+	}
+}
@@ -0,0 +1,235 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/ginx-contribs/ginx"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/handler"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ginxutils"
+	"github.com/ginx-contribs/ginx/pkg/resp"
+	"github.com/pkg/errors"
+	"net/http"
+)
+
+type OAuthServerAPI struct {
+	OAuthServerHandler handler.OAuthServerHandler
+}
+
+// CreateClient
+// @Summary      CreateClient
+// @Description  register a new oauth2 client owned by current user, the secret is only returned once
+// @Tags         oauth
+// @Accept       json
+// @Produce      json
+// @Param        OAuthClientCreateOptions  body  types.OAuthClientCreateOptions  true "OAuthClientCreateOptions"
+// @Success      200  {object}  types.Response{data=types.OAuthClientCreateResult}
+// @Security     BearerAuth
+// @Router       /oauth/clients [POST]
+func (o OAuthServerAPI) CreateClient(ctx *gin.Context) {
+	var opt types.OAuthClientCreateOptions
+	if err := ginx.ShouldValidateJSON(ctx, &opt); err != nil {
+		return
+	}
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	result, err := o.OAuthServerHandler.CreateClient(ctx, tokenInfo.Claims.Subject, opt)
+	// client secret must not be stored by any cache
+	ctx.Header("Cache-Control", "no-store")
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(result).JSON()
+	}
+}
+
+// ListClients
+// @Summary      ListClients
+// @Description  list oauth2 clients owned by current user
+// @Tags         oauth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  types.Response{data=[]types.OAuthClientInfo}
+// @Security     BearerAuth
+// @Router       /oauth/clients [GET]
+func (o OAuthServerAPI) ListClients(ctx *gin.Context) {
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	clients, err := o.OAuthServerHandler.ListClients(ctx, tokenInfo.Claims.Subject)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(clients).JSON()
+	}
+}
+
+// DeleteClient
+// @Summary      DeleteClient
+// @Description  delete the oauth2 client owned by current user
+// @Tags         oauth
+// @Accept       json
+// @Produce      json
+// @Param        id  path  string  true "client id"
+// @Success      200  {object}  types.Response
+// @Security     BearerAuth
+// @Router       /oauth/clients/{id} [DELETE]
+func (o OAuthServerAPI) DeleteClient(ctx *gin.Context) {
+	var opt types.OAuthClientIdOptions
+	if err := ginx.ShouldValidateURI(ctx, &opt); err != nil {
+		return
+	}
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	if err := o.OAuthServerHandler.DeleteClient(ctx, tokenInfo.Claims.Subject, opt.Id); err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Msg("client deleted").JSON()
+	}
+}
+
+// Consent
+// @Summary      Consent
+// @Description  validate the authorization request, and returns the client and scopes which current user is asked to consent to
+// @Tags         oauth
+// @Accept       json
+// @Produce      json
+// @Param        response_type          query  string  true  "must be code"
+// @Param        client_id              query  string  true  "client id"
+// @Param        redirect_uri           query  string  true  "registered redirect uri"
+// @Param        scope                  query  string  false "space-delimited scopes"
+// @Param        state                  query  string  false "opaque value returned to client"
+// @Param        code_challenge         query  string  true  "PKCE code challenge"
+// @Param        code_challenge_method  query  string  true  "must be S256"
+// @Success      200  {object}  types.Response{data=types.ConsentInfo}
+// @Security     BearerAuth
+// @Router       /oauth/authorize [GET]
+func (o OAuthServerAPI) Consent(ctx *gin.Context) {
+	var opt types.AuthorizeOptions
+	if err := ginx.ShouldValidateQuery(ctx, &opt); err != nil {
+		return
+	}
+	consent, err := o.OAuthServerHandler.Consent(ctx, opt)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(consent).JSON()
+	}
+}
+
+// Approve
+// @Summary      Approve
+// @Description  approve or deny the authorization request, user should be redirected to the returned uri
+// @Tags         oauth
+// @Accept       json
+// @Produce      json
+// @Param        ApproveOptions  body  types.ApproveOptions  true "ApproveOptions"
+// @Success      200  {object}  types.Response{data=types.ApproveResult}
+// @Security     BearerAuth
+// @Router       /oauth/authorize [POST]
+func (o OAuthServerAPI) Approve(ctx *gin.Context) {
+	var opt types.ApproveOptions
+	if err := ginx.ShouldValidateJSON(ctx, &opt); err != nil {
+		return
+	}
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	result, err := o.OAuthServerHandler.Approve(ctx, tokenInfo.Claims.Subject, opt)
+	// authorization code must not be stored by any cache
+	ctx.Header("Cache-Control", "no-store")
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(result).JSON()
+	}
+}
+
+// Token
+// @Summary      Token
+// @Description  oauth2 token endpoint, supports authorization_code with PKCE, refresh_token and client_credentials grants.
+// @Description  client authenticates with http basic auth or client_id and client_secret in form, response follows RFC 6749.
+// @Tags         oauth
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        grant_type     formData  string  true  "authorization_code | refresh_token | client_credentials"
+// @Param        code           formData  string  false "authorization code"
+// @Param        redirect_uri   formData  string  false "redirect uri used in authorization request"
+// @Param        code_verifier  formData  string  false "PKCE code verifier"
+// @Param        refresh_token  formData  string  false "refresh token"
+// @Param        scope          formData  string  false "space-delimited scopes for client_credentials"
+// @Success      200  {object}  types.OAuthTokenResult
+// @Failure      400  {object}  types.OAuthError
+// @Router       /oauth/token [POST]
+func (o OAuthServerAPI) Token(ctx *gin.Context) {
+	var opt types.OAuthTokenOptions
+	if err := ctx.ShouldBindWith(&opt, binding.Form); err != nil {
+		oauthError(ctx, types.ErrInvalidRequest)
+		return
+	}
+	opt.ClientId, opt.ClientSecret = clientCredentials(ctx, opt.ClientId, opt.ClientSecret)
+
+	result, err := o.OAuthServerHandler.IssueToken(ctx, opt)
+	if err != nil {
+		oauthError(ctx, err)
+		return
+	}
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Pragma", "no-cache")
+	ctx.JSON(http.StatusOK, result)
+}
+
+// Revoke
+// @Summary      Revoke
+// @Description  oauth2 token revocation endpoint defined in RFC 7009, it always succeeds for invalid token
+// @Tags         oauth
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        token            formData  string  true  "access token or refresh token"
+// @Param        token_type_hint  formData  string  false "access_token | refresh_token"
+// @Success      200
+// @Failure      401  {object}  types.OAuthError
+// @Router       /oauth/revoke [POST]
+func (o OAuthServerAPI) Revoke(ctx *gin.Context) {
+	var opt types.OAuthRevokeOptions
+	if err := ctx.ShouldBindWith(&opt, binding.Form); err != nil || opt.Token == "" {
+		oauthError(ctx, types.ErrInvalidRequest)
+		return
+	}
+	opt.ClientId, opt.ClientSecret = clientCredentials(ctx, opt.ClientId, opt.ClientSecret)
+
+	if err := o.OAuthServerHandler.Revoke(ctx, opt); err != nil {
+		oauthError(ctx, err)
+		return
+	}
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Status(http.StatusOK)
+}
+
+// clientCredentials prefers credentials in http basic auth to the ones in form
+func clientCredentials(ctx *gin.Context, clientId, clientSecret string) (string, string) {
+	if id, secret, ok := ctx.Request.BasicAuth(); ok {
+		return id, secret
+	}
+	return clientId, clientSecret
+}
+
+// oauthError responds error in the format defined by RFC 6749 section 5.2
+func oauthError(ctx *gin.Context, err error) {
+	var oerr types.OAuthError
+	if !errors.As(err, &oerr) {
+		ctx.Error(err)
+		oerr = types.ErrServerError
+	}
+	if oerr.Code == types.ErrInvalidClient.Code {
+		ctx.Header("WWW-Authenticate", `Basic realm="oauth"`)
+	}
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(int(oerr.Status), oerr)
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ginx-contribs/ginx"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/handler"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/token"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ginxutils"
+	"github.com/ginx-contribs/ginx/pkg/resp"
+)
+
+type PersonalTokenAPI struct {
+	PersonalTokenHandler handler.PersonalTokenHandler
+}
+
+// Create
+// @Summary      Create
+// @Description  create a personal access token for current user, the token is only returned once, and it could not be created by personal access token.
+// @Description  it could be used in header as Authorization: Bearer gx_... or X-API-Key: gx_...
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        PersonalTokenCreateOptions  body  types.PersonalTokenCreateOptions  true "PersonalTokenCreateOptions"
+// @Success      200  {object}  types.Response{data=types.PersonalTokenCreateResult}
+// @Security     BearerAuth
+// @Router       /user/tokens [POST]
+func (p PersonalTokenAPI) Create(ctx *gin.Context) {
+	var opt types.PersonalTokenCreateOptions
+	if err := ginx.ShouldValidateJSON(ctx, &opt); err != nil {
+		return
+	}
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	if isPersonalToken(tokenInfo) {
+		resp.Fail(ctx).Error(types.ErrPersonalTokenForbidden).JSON()
+		return
+	}
+	result, err := p.PersonalTokenHandler.Create(ctx, tokenInfo.Claims.Subject, opt)
+	// token is only returned once, it must not be stored by any cache
+	ctx.Header("Cache-Control", "no-store")
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(result).JSON()
+	}
+}
+
+// List
+// @Summary      List
+// @Description  list personal access tokens of current user
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  types.Response{data=[]types.PersonalTokenInfo}
+// @Security     BearerAuth
+// @Router       /user/tokens [GET]
+func (p PersonalTokenAPI) List(ctx *gin.Context) {
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	tokens, err := p.PersonalTokenHandler.List(ctx, tokenInfo.Claims.Subject)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(tokens).JSON()
+	}
+}
+
+// Revoke
+// @Summary      Revoke
+// @Description  revoke the personal access token of current user, it could not be revoked by personal access token
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        id  path  string  true "token id"
+// @Success      200  {object}  types.Response
+// @Security     BearerAuth
+// @Router       /user/tokens/{id} [DELETE]
+func (p PersonalTokenAPI) Revoke(ctx *gin.Context) {
+	var opt types.PersonalTokenIdOptions
+	if err := ginx.ShouldValidateURI(ctx, &opt); err != nil {
+		return
+	}
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	if isPersonalToken(tokenInfo) {
+		resp.Fail(ctx).Error(types.ErrPersonalTokenForbidden).JSON()
+		return
+	}
+	if err := p.PersonalTokenHandler.Revoke(ctx, tokenInfo.Claims.Subject, opt.Id); err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Msg("token revoked").JSON()
+	}
+}
+
+// isPersonalToken returns whether the request is authenticated by a personal access token
+func isPersonalToken(tokenInfo *token.Token) bool {
+	_, ok := tokenInfo.Claims.Payload[types.PersonalTokenPayloadKey]
+	return ok
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ginx-contribs/ginx"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/handler"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx/pkg/resp"
+)
+
+type RoleAPI struct {
+	RoleHandler handler.RoleHandler
+}
+
+// ListPermissions
+// @Summary      ListPermissions
+// @Description  list all permissions which could be granted to roles
+// @Tags         role
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  types.Response{data=[]types.PermissionInfo}
+// @Security     BearerAuth
+// @Router       /admin/permissions [GET]
+func (r RoleAPI) ListPermissions(ctx *gin.Context) {
+	perms, err := r.RoleHandler.ListPermissions(ctx)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(perms).JSON()
+	}
+}
+
+// ListRoles
+// @Summary      ListRoles
+// @Description  list all roles with their permissions
+// @Tags         role
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  types.Response{data=[]types.RoleInfo}
+// @Security     BearerAuth
+// @Router       /admin/roles [GET]
+func (r RoleAPI) ListRoles(ctx *gin.Context) {
+	roles, err := r.RoleHandler.ListRoles(ctx)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(roles).JSON()
+	}
+}
+
+// CreateRole
+// @Summary      CreateRole
+// @Description  create a new role with the given permissions
+// @Tags         role
+// @Accept       json
+// @Produce      json
+// @Param        RoleCreateOptions  body  types.RoleCreateOptions  true "RoleCreateOptions"
+// @Success      200  {object}  types.Response{data=types.RoleInfo}
+// @Security     BearerAuth
+// @Router       /admin/roles [POST]
+func (r RoleAPI) CreateRole(ctx *gin.Context) {
+	var opt types.RoleCreateOptions
+	if err := ginx.ShouldValidateJSON(ctx, &opt); err != nil {
+		return
+	}
+	role, err := r.RoleHandler.CreateRole(ctx, opt)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(role).JSON()
+	}
+}
+
+// DeleteRole
+// @Summary      DeleteRole
+// @Description  delete the role, builtin role could not be deleted
+// @Tags         role
+// @Accept       json
+// @Produce      json
+// @Param        name  path  string  true "role name"
+// @Success      200  {object}  types.Response
+// @Security     BearerAuth
+// @Router       /admin/roles/{name} [DELETE]
+func (r RoleAPI) DeleteRole(ctx *gin.Context) {
+	var opt types.RoleNameOptions
+	if err := ginx.ShouldValidateURI(ctx, &opt); err != nil {
+		return
+	}
+	if err := r.RoleHandler.DeleteRole(ctx, opt.Name); err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Msg("role deleted").JSON()
+	}
+}
+
+// ListUserRoles
+// @Summary      ListUserRoles
+// @Description  list roles granted to the user
+// @Tags         role
+// @Accept       json
+// @Produce      json
+// @Param        uid  path  string  true "uid"
+// @Success      200  {object}  types.Response{data=[]types.RoleInfo}
+// @Security     BearerAuth
+// @Router       /admin/users/{uid}/roles [GET]
+func (r RoleAPI) ListUserRoles(ctx *gin.Context) {
+	var opt types.UidOptions
+	if err := ginx.ShouldValidateURI(ctx, &opt); err != nil {
+		return
+	}
+	roles, err := r.RoleHandler.ListUserRoles(ctx, opt.Uid)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(roles).JSON()
+	}
+}
+
+// Grant
+// @Summary      Grant
+// @Description  grant the role to the user
+// @Tags         role
+// @Accept       json
+// @Produce      json
+// @Param        uid   path  string  true "uid"
+// @Param        role  path  string  true "role name"
+// @Success      200  {object}  types.Response
+// @Security     BearerAuth
+// @Router       /admin/users/{uid}/roles/{role} [PUT]
+func (r RoleAPI) Grant(ctx *gin.Context) {
+	var opt types.UserRoleOptions
+	if err := ginx.ShouldValidateURI(ctx, &opt); err != nil {
+		return
+	}
+	if err := r.RoleHandler.Grant(ctx, opt.Uid, opt.Role); err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Msg("role granted").JSON()
+	}
+}
+
+// Revoke
+// @Summary      Revoke
+// @Description  revoke the role from the user
+// @Tags         role
+// @Accept       json
+// @Produce      json
+// @Param        uid   path  string  true "uid"
+// @Param        role  path  string  true "role name"
+// @Success      200  {object}  types.Response
+// @Security     BearerAuth
+// @Router       /admin/users/{uid}/roles/{role} [DELETE]
+func (r RoleAPI) Revoke(ctx *gin.Context) {
+	var opt types.UserRoleOptions
+	if err := ginx.ShouldValidateURI(ctx, &opt); err != nil {
+		return
+	}
+	if err := r.RoleHandler.Revoke(ctx, opt.Uid, opt.Role); err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Msg("role revoked").JSON()
+	}
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ginx-contribs/ginx"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/handler"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ginxutils"
+	"github.com/ginx-contribs/ginx/pkg/resp"
+)
+
+type SessionAPI struct {
+	SessionHandler handler.SessionHandler
+}
+
+// List
+// @Summary      List
+// @Description  list active login sessions of current user
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  types.Response{data=[]types.SessionInfo}
+// @Security     BearerAuth
+// @Router       /user/sessions [GET]
+func (s SessionAPI) List(ctx *gin.Context) {
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	sid, _ := tokenInfo.Claims.Payload[types.SessionPayloadKey].(string)
+	sessions, err := s.SessionHandler.ListByUID(ctx, tokenInfo.Claims.Subject, sid)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(sessions).JSON()
+	}
+}
+
+// Terminate
+// @Summary      Terminate
+// @Description  terminate the specified login session of current user
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        id  path  string  true "session id"
+// @Success      200  {object}  types.Response
+// @Security     BearerAuth
+// @Router       /user/sessions/{id} [DELETE]
+func (s SessionAPI) Terminate(ctx *gin.Context) {
+	var opt types.SessionIdOptions
+	if err := ginx.ShouldValidateURI(ctx, &opt); err != nil {
+		return
+	}
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	if err := s.SessionHandler.Terminate(ctx, tokenInfo.Claims.Subject, opt.Id); err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Msg("session terminated").JSON()
+	}
+}
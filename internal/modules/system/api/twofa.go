@@ -0,0 +1,93 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ginx-contribs/ginx"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/handler"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ginxutils"
+	"github.com/ginx-contribs/ginx/pkg/resp"
+)
+
+type TwoFAAPI struct {
+	TwoFAHandler handler.TwoFAHandler
+}
+
+// Setup
+// @Summary      Setup
+// @Description  generate a new totp secret for current user, it takes effect after enabled
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  types.Response{data=types.TwoFASetupResult}
+// @Security     BearerAuth
+// @Router       /user/2fa/setup [POST]
+func (t TwoFAAPI) Setup(ctx *gin.Context) {
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	result, err := t.TwoFAHandler.Setup(ctx, tokenInfo.Claims.Subject)
+	// secret must not be stored by any cache
+	ctx.Header("Cache-Control", "no-store")
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(result).JSON()
+	}
+}
+
+// Enable
+// @Summary      Enable
+// @Description  enable 2fa by confirming the first code, and returns one-time recovery codes
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        TwoFACodeOptions  body  types.TwoFACodeOptions  true "TwoFACodeOptions"
+// @Success      200  {object}  types.Response{data=types.TwoFAEnableResult}
+// @Security     BearerAuth
+// @Router       /user/2fa/enable [POST]
+func (t TwoFAAPI) Enable(ctx *gin.Context) {
+	var opt types.TwoFACodeOptions
+	if err := ginx.ShouldValidateJSON(ctx, &opt); err != nil {
+		return
+	}
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	result, err := t.TwoFAHandler.Enable(ctx, tokenInfo.Claims.Subject, opt.Code)
+	// recovery codes must not be stored by any cache
+	ctx.Header("Cache-Control", "no-store")
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Msg("2fa enabled").Data(result).JSON()
+	}
+}
+
+// Disable
+// @Summary      Disable
+// @Description  disable 2fa with a current code
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        TwoFACodeOptions  body  types.TwoFACodeOptions  true "TwoFACodeOptions"
+// @Success      200  {object}  types.Response
+// @Security     BearerAuth
+// @Router       /user/2fa/disable [POST]
+func (t TwoFAAPI) Disable(ctx *gin.Context) {
+	var opt types.TwoFACodeOptions
+	if err := ginx.ShouldValidateJSON(ctx, &opt); err != nil {
+		return
+	}
+	tokenInfo, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	if err := t.TwoFAHandler.Disable(ctx, tokenInfo.Claims.Subject, opt.Code); err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Msg("2fa disabled").JSON()
+	}
+}
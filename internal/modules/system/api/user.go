@@ -7,19 +7,27 @@ import (
 	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
 	"github.com/ginx-contribs/ginx-server/pkg/toolset/ginxutils"
 	"github.com/ginx-contribs/ginx/pkg/resp"
+	"github.com/pkg/errors"
+	"net/http"
 )
 
 type UserAPI struct {
-	UserHandler handler.UserHandler
+	UserHandler        handler.UserHandler
+	AuthHandler        handler.AuthHandler
+	AvatarHandler      handler.AvatarHandler
+	AccountHandler     handler.AccountHandler
+	ExportHandler      handler.ExportHandler
+	EmailChangeHandler handler.EmailChangeHandler
 }
 
 // Profile
 // @Summary      Profile
-// @Description  return user information for current user
+// @Description  return user information for current user, delegated tokens need profile scope, and email is returned only with email scope
 // @Tags         user
 // @Accept       json
 // @Produce      json
 // @Success      200  {object}  types.Response{data=types.UserInfo}
+// @Security     BearerAuth
 // @Router       /user/profile [GET]
 func (u UserAPI) Profile(ctx *gin.Context) {
 	token, ok := ginxutils.GetLoginUserToken(ctx)
@@ -28,6 +36,223 @@ func (u UserAPI) Profile(ctx *gin.Context) {
 	}
 	uid := token.Claims.Payload["uid"].(string)
 	userInfo, err := u.UserHandler.FindByUID(ctx, uid)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+		return
+	}
+	// email is only visible to delegated tokens granted with email scope
+	if ginxutils.IsDelegated(token) && !token.Claims.HasScope(types.ScopeEmail) {
+		userInfo.Email, userInfo.EmailVerifiedAt = "", 0
+	}
+	resp.Ok(ctx).Data(userInfo).JSON()
+}
+
+// UpdateProfile
+// @Summary      UpdateProfile
+// @Description  update profile of current user, absent fields are left unchanged
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        UserProfileUpdateOptions  body  types.UserProfileUpdateOptions  true "UserProfileUpdateOptions"
+// @Success      200  {object}  types.Response{data=types.UserInfo}
+// @Security     BearerAuth
+// @Router       /user/profile [PATCH]
+func (u UserAPI) UpdateProfile(ctx *gin.Context) {
+	var opt types.UserProfileUpdateOptions
+	if err := ginx.ShouldValidateJSON(ctx, &opt); err != nil {
+		return
+	}
+	token, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	userInfo, err := u.UserHandler.UpdateProfile(ctx, token.Claims.Subject, opt)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(userInfo).JSON()
+	}
+}
+
+// DeleteAccount
+// @Summary      DeleteAccount
+// @Description  schedule deletion of current user, it will be logged out everywhere, and purged after the grace period unless logs in again
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  types.Response{data=types.AccountDeletionInfo}
+// @Security     BearerAuth
+// @Router       /user/me [DELETE]
+func (u UserAPI) DeleteAccount(ctx *gin.Context) {
+	token, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	info, err := u.AccountHandler.ScheduleDeletion(ctx, token.Claims.Subject)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(info).JSON()
+	}
+}
+
+// Export
+// @Summary      Export
+// @Description  request an archive of personal data of current user, the download link will be sent by email when it is ready
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  types.Response
+// @Security     BearerAuth
+// @Router       /user/export [POST]
+func (u UserAPI) Export(ctx *gin.Context) {
+	token, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	if err := u.ExportHandler.Request(ctx, token.Claims.Subject); err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Msg("export is in progress, the download link will be sent by email").JSON()
+	}
+}
+
+// ChangePassword
+// @Summary      ChangePassword
+// @Description  change password of current user with the old password, all sessions will be logged out after changed
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        ChangePasswordOptions  body  types.ChangePasswordOptions  true "ChangePasswordOptions"
+// @Success      200  {object}  types.Response
+// @Security     BearerAuth
+// @Router       /user/password [PUT]
+func (u UserAPI) ChangePassword(ctx *gin.Context) {
+	var opt types.ChangePasswordOptions
+	if err := ginx.ShouldValidateJSON(ctx, &opt); err != nil {
+		return
+	}
+	token, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	if err := u.AuthHandler.ChangePassword(ctx, token.Claims.Subject, opt); err != nil {
+		failPasswordPolicy(ctx, err)
+	} else {
+		resp.Ok(ctx).Msg("password changed, please log in again").JSON()
+	}
+}
+
+// ChangeEmail
+// @Summary      ChangeEmail
+// @Description  apply for changing email of current user with the password, a verification code is sent to the new address.
+// @Description  applying for the current address verifies it.
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        EmailChangeOptions  body  types.EmailChangeOptions  true "EmailChangeOptions"
+// @Success      200  {object}  types.Response
+// @Security     BearerAuth
+// @Router       /user/email/change [POST]
+func (u UserAPI) ChangeEmail(ctx *gin.Context) {
+	var opt types.EmailChangeOptions
+	if err := ginx.ShouldValidateJSON(ctx, &opt); err != nil {
+		return
+	}
+	token, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	if err := u.EmailChangeHandler.Apply(ctx, token.Claims.Subject, opt); err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Msg("mail has been sent").JSON()
+	}
+}
+
+// ConfirmEmail
+// @Summary      ConfirmEmail
+// @Description  confirm the email change with the code sent to the new address, the old address is notified with a revert link
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        EmailConfirmOptions  body  types.EmailConfirmOptions  true "EmailConfirmOptions"
+// @Success      200  {object}  types.Response{data=types.UserInfo}
+// @Security     BearerAuth
+// @Router       /user/email/confirm [POST]
+func (u UserAPI) ConfirmEmail(ctx *gin.Context) {
+	var opt types.EmailConfirmOptions
+	if err := ginx.ShouldValidateJSON(ctx, &opt); err != nil {
+		return
+	}
+	token, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	info, err := u.EmailChangeHandler.Confirm(ctx, token.Claims.Subject, opt)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(info).JSON()
+	}
+}
+
+// RevertEmail
+// @Summary      RevertEmail
+// @Description  revert the email change by the link sent to the old address, the user is logged out everywhere
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        token  query  string  true  "revert token"
+// @Success      200  {object}  types.Response
+// @Router       /user/email/revert [GET]
+func (u UserAPI) RevertEmail(ctx *gin.Context) {
+	var opt types.EmailRevertOptions
+	if err := ginx.ShouldValidateQuery(ctx, &opt); err != nil {
+		return
+	}
+	if err := u.EmailChangeHandler.Revert(ctx, opt.Token); err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Msg("email has been reverted, please log in and change your password").JSON()
+	}
+}
+
+// UploadAvatar
+// @Summary      UploadAvatar
+// @Description  upload avatar of current user, it is cropped to square and resized to several sizes.
+// @Description  the avatar url of user is updated to the avatar api.
+// @Tags         user
+// @Accept       mpfd
+// @Produce      json
+// @Param        file  formData  file  true "jpeg, png or gif image"
+// @Success      200  {object}  types.Response{data=types.UserInfo}
+// @Security     BearerAuth
+// @Router       /user/avatar [POST]
+func (u UserAPI) UploadAvatar(ctx *gin.Context) {
+	token, ok := ginxutils.GetLoginUserToken(ctx)
+	if !ok {
+		return
+	}
+	// leave room for multipart boundaries and headers
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, u.AvatarHandler.Config.Avatar.MaxSize+64<<10)
+	fileHeader, err := ctx.FormFile(types.AvatarField)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		resp.Fail(ctx).Error(types.ErrAvatarTooLarge).JSON()
+		return
+	} else if err != nil {
+		resp.Fail(ctx).Error(types.ErrAvatarRequired).JSON()
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		resp.Fail(ctx).Error(types.ErrAvatarRequired).JSON()
+		return
+	}
+	defer file.Close()
+
+	userInfo, err := u.AvatarHandler.Upload(ctx, token.Claims.Subject, file)
 	if err != nil {
 		resp.Fail(ctx).Error(err).JSON()
 	} else {
@@ -35,21 +260,47 @@ func (u UserAPI) Profile(ctx *gin.Context) {
 	}
 }
 
+// Avatar
+// @Summary      Avatar
+// @Description  redirect to the signed download url of user avatar
+// @Tags         user
+// @Param        uid   path   string  true  "uid"
+// @Param        size  query  int     false "expected side length in pixels"
+// @Success      302
+// @Router       /user/{uid}/avatar [GET]
+func (u UserAPI) Avatar(ctx *gin.Context) {
+	var opt types.AvatarOptions
+	if err := ginx.ShouldValidateURI(ctx, &opt); err != nil {
+		return
+	}
+	if err := ginx.ShouldValidateQuery(ctx, &opt); err != nil {
+		return
+	}
+	signedURL, err := u.AvatarHandler.URL(ctx, opt.Uid, opt.Size)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+		return
+	}
+	// signed url should not be cached longer than its lifetime
+	ctx.Header("Cache-Control", "private, max-age=60")
+	ctx.Redirect(http.StatusFound, signedURL)
+}
+
 // Info
 // @Summary      Info
-// @Description  get user information by given uid
+// @Description  get public user information by given uid
 // @Tags         user
 // @Accept       json
 // @Produce      json
 // @Param        uid  path  string  true "uid"
-// @Success      200  {object}  types.Response{data=types.UserInfo}
+// @Success      200  {object}  types.Response{data=types.PublicUserInfo}
 // @Router       /user/:uid [GET]
 func (u UserAPI) Info(ctx *gin.Context) {
 	var opt types.UidOptions
 	if err := ginx.ShouldValidateURI(ctx, &opt); err != nil {
 		return
 	}
-	userInfo, err := u.UserHandler.FindByUID(ctx, opt.Uid)
+	userInfo, err := u.UserHandler.FindPublicByUID(ctx, opt.Uid)
 	if err != nil {
 		resp.Fail(ctx).Error(err).JSON()
 	} else {
@@ -59,20 +310,45 @@ func (u UserAPI) Info(ctx *gin.Context) {
 
 // List
 // @Summary      List
-// @Description  list user info by page
+// @Description  list user info by page, users could be filtered by status and creation time, and sorted by field
 // @Tags         user
 // @Accept       json
 // @Produce      json
 // @Param        SearchUserOptions   query   types.SearchUserOptions  true  "SearchUserOptions"
 // @Success      200  {object}  types.Response{data=types.UserSearchResult}
+// @Security     BearerAuth
 // @Router       /users [GET]
 func (u UserAPI) List(ctx *gin.Context) {
-	var page types.SearchUserOptions
-	if err := ginx.ShouldValidateQuery(ctx, &page); err != nil {
+	var option types.SearchUserOptions
+	if err := ginx.ShouldValidateQuery(ctx, &option); err != nil {
+		return
+	}
+
+	userInfoList, err := u.UserHandler.ListUserByPage(ctx, option)
+	if err != nil {
+		resp.Fail(ctx).Error(err).JSON()
+	} else {
+		resp.Ok(ctx).Data(userInfoList).JSON()
+	}
+}
+
+// ListByCursor
+// @Summary      ListByCursor
+// @Description  list user info by cursor, which keeps stable on large tables, cursors are taken from pageInfo of the previous page
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        CursorUserOptions   query   types.CursorUserOptions  true  "CursorUserOptions"
+// @Success      200  {object}  types.Response{data=types.UserCursorResult{list=[]types.UserInfo,pageInfo=ent.PageInfo}}
+// @Security     BearerAuth
+// @Router       /users/cursor [GET]
+func (u UserAPI) ListByCursor(ctx *gin.Context) {
+	var option types.CursorUserOptions
+	if err := ginx.ShouldValidateQuery(ctx, &option); err != nil {
 		return
 	}
 
-	userInfoList, err := u.UserHandler.ListUserByPage(ctx, page.Page, page.Size, page.Search)
+	userInfoList, err := u.UserHandler.ListUserByCursor(ctx, option)
 	if err != nil {
 		resp.Fail(ctx).Error(err).JSON()
 	} else {
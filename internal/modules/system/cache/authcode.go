@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"encoding/json"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/net/context"
+	"time"
+)
+
+// AuthCodeCache is responsible for storing oauth2 authorization codes issued to clients
+type AuthCodeCache interface {
+	// Set stores the authorization code with ttl
+	Set(ctx context.Context, code string, value types.AuthCode, ttl time.Duration) error
+	// GetDel returns the authorization code and removes it, so that each code could be used only once, returns redis.Nil if not found
+	GetDel(ctx context.Context, code string) (types.AuthCode, error)
+}
+
+var _ AuthCodeCache = (*RedisAuthCodeCache)(nil)
+
+func NewRedisAuthCodeCache(cache *redis.Client) *RedisAuthCodeCache {
+	return &RedisAuthCodeCache{cache: cache}
+}
+
+// RedisAuthCodeCache implements AuthCodeCache with redis string
+type RedisAuthCodeCache struct {
+	cache *redis.Client
+}
+
+func (r *RedisAuthCodeCache) Set(ctx context.Context, code string, value types.AuthCode, ttl time.Duration) error {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return r.cache.Set(ctx, "oauth:code:"+code, bytes, ttl).Err()
+}
+
+func (r *RedisAuthCodeCache) GetDel(ctx context.Context, code string) (types.AuthCode, error) {
+	bytes, err := r.cache.GetDel(ctx, "oauth:code:"+code).Bytes()
+	if err != nil {
+		return types.AuthCode{}, err
+	}
+	var value types.AuthCode
+	if err := json.Unmarshal(bytes, &value); err != nil {
+		return types.AuthCode{}, err
+	}
+	return value, nil
+}
@@ -3,24 +3,23 @@ package cache
 import (
 	"errors"
 	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
-	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"github.com/ginx-contribs/ginx-server/pkg/challenge"
 	"github.com/redis/go-redis/v9"
 	"golang.org/x/net/context"
 	"time"
 )
 
-var (
-	ErrCodeRepeated = errors.New("code repeated")
-)
-
-// CaptchaCache is responsible for storing captcha code into cache
+// CaptchaCache is responsible for storing captcha code into cache, codes are keyed by usage and recipient,
+// so that a code is only valid for the recipient it was sent to.
 type CaptchaCache interface {
-	// Set storage code into cache with ttl, return false if retry failed.
-	Set(ctx context.Context, usage types.Usage, code, to string, ttl, retry time.Duration) (bool, error)
-	// Get returns the specified code
-	Get(ctx context.Context, usage types.Usage, code string) (string, error)
-	// Del remove the specified code from cache
-	Del(ctx context.Context, usage types.Usage, code string) error
+	// Set stores the code of recipient with ttl, it replaces the previous code,
+	// returns false if the recipient has applied for a code of the usage within retry.
+	Set(ctx context.Context, usage types.Usage, to, code string, ttl, retry time.Duration) (bool, error)
+	// Check reports whether the code matches the one of recipient, the code is removed after maxAttempts checks.
+	// Returns redis.Nil if there is no code.
+	Check(ctx context.Context, usage types.Usage, to, code string, maxAttempts int64) (bool, error)
+	// Del removes the code of recipient along with the retry marker
+	Del(ctx context.Context, usage types.Usage, to string) error
 }
 
 var _ CaptchaCache = (*RedisCaptchaCache)(nil)
@@ -29,50 +28,62 @@ func NewRedisCaptchaCache(cache *redis.Client) *RedisCaptchaCache {
 	return &RedisCaptchaCache{cache: cache}
 }
 
-// RedisCaptchaCache implements CaptchaCache with redis cache
+// RedisCaptchaCache implements CaptchaCache with redis hash, only the hash of code is stored
 type RedisCaptchaCache struct {
 	cache *redis.Client
 }
 
-func (r *RedisCaptchaCache) Set(ctx context.Context, usage types.Usage, code, to string, ttl, retry time.Duration) (bool, error) {
-
-	// check retry ttl
-	retryRes, err := r.cache.Get(ctx, to).Result()
-	if !errors.Is(err, redis.Nil) && err != nil {
-		return false, statuserr.InternalError(err)
-	} else if err == nil && retryRes != "" {
-		return false, nil
-	}
-
-	// check if is repeated
-	get, err := r.Get(ctx, usage, code)
-	if !errors.Is(err, redis.Nil) && err != nil {
+func (r *RedisCaptchaCache) Set(ctx context.Context, usage types.Usage, to, code string, ttl, retry time.Duration) (bool, error) {
+	ok, err := r.cache.SetNX(ctx, captchaKey("retry", usage, to), 1, retry).Result()
+	if err != nil || !ok {
 		return false, err
-	} else if get != "" {
-		return false, ErrCodeRepeated
 	}
 
-	codeKey := usage.Name() + ":" + code
-
-	// set verify code
-	if _, err := r.cache.Set(ctx, codeKey, to, ttl).Result(); err != nil {
+	key := captchaKey("code", usage, to)
+	_, err = r.cache.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key, "hash", challenge.Hash(string(usage), to, code), "attempts", 0)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
 		return false, err
 	}
+	return true, nil
+}
 
-	// set retry ttl
-	if err := r.cache.Set(ctx, to, to, retry).Err(); err != nil {
+func (r *RedisCaptchaCache) Check(ctx context.Context, usage types.Usage, to, code string, maxAttempts int64) (bool, error) {
+	key := captchaKey("code", usage, to)
+	var (
+		attempts *redis.IntCmd
+		hash     *redis.StringCmd
+	)
+	_, err := r.cache.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		attempts = pipe.HIncrBy(ctx, key, "attempts", 1)
+		hash = pipe.HGet(ctx, key, "hash")
+		return nil
+	})
+	// the key is created by HIncrBy if code does not exist
+	if errors.Is(err, redis.Nil) {
+		return false, errors.Join(redis.Nil, r.cache.Del(ctx, key).Err())
+	} else if err != nil {
 		return false, err
 	}
 
-	return true, nil
+	match, exhausted := challenge.Verify(hash.Val(), string(usage), to, code, attempts.Val(), maxAttempts)
+	if exhausted {
+		if err := r.cache.Del(ctx, key).Err(); err != nil {
+			return false, err
+		}
+	}
+	return match, nil
 }
 
-func (r *RedisCaptchaCache) Get(ctx context.Context, usage types.Usage, code string) (string, error) {
-	codeKey := usage.Name() + ":" + code
-	return r.cache.Get(ctx, codeKey).Result()
+func (r *RedisCaptchaCache) Del(ctx context.Context, usage types.Usage, to string) error {
+	return r.cache.Del(ctx, captchaKey("code", usage, to), captchaKey("retry", usage, to)).Err()
 }
 
-func (r *RedisCaptchaCache) Del(ctx context.Context, usage types.Usage, code string) error {
-	codeKey := usage.Name() + ":" + code
-	return r.cache.Del(ctx, codeKey).Err()
+// captchaKey returns the namespaced key, such as captcha:code:register:foo@example.com
+func captchaKey(kind string, usage types.Usage, to string) string {
+	return "captcha:" + kind + ":" + string(usage) + ":" + to
 }
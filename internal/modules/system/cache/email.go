@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"encoding/json"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/net/context"
+	"time"
+)
+
+// EmailChangeCache is responsible for storing pending email changes of users, and the links to revert changes
+type EmailChangeCache interface {
+	// SetPending stores the new email which the user applies for with ttl, it replaces the previous one
+	SetPending(ctx context.Context, uid, email string, ttl time.Duration) error
+	// GetPending returns the new email which the user applies for, returns redis.Nil if not found
+	GetPending(ctx context.Context, uid string) (string, error)
+	// DelPending removes the pending email change of the user
+	DelPending(ctx context.Context, uid string) error
+	// SetRevert stores the change which could be reverted by token with ttl
+	SetRevert(ctx context.Context, token string, value types.EmailRevert, ttl time.Duration) error
+	// GetDelRevert returns the change and removes it, so that each token could be used only once, returns redis.Nil if not found
+	GetDelRevert(ctx context.Context, token string) (types.EmailRevert, error)
+}
+
+var _ EmailChangeCache = (*RedisEmailChangeCache)(nil)
+
+func NewRedisEmailChangeCache(cache *redis.Client) *RedisEmailChangeCache {
+	return &RedisEmailChangeCache{cache: cache}
+}
+
+// RedisEmailChangeCache implements EmailChangeCache with redis string
+type RedisEmailChangeCache struct {
+	cache *redis.Client
+}
+
+func (r *RedisEmailChangeCache) SetPending(ctx context.Context, uid, email string, ttl time.Duration) error {
+	return r.cache.Set(ctx, "email:change:"+uid, email, ttl).Err()
+}
+
+func (r *RedisEmailChangeCache) GetPending(ctx context.Context, uid string) (string, error) {
+	return r.cache.Get(ctx, "email:change:"+uid).Result()
+}
+
+func (r *RedisEmailChangeCache) DelPending(ctx context.Context, uid string) error {
+	return r.cache.Del(ctx, "email:change:"+uid).Err()
+}
+
+func (r *RedisEmailChangeCache) SetRevert(ctx context.Context, token string, value types.EmailRevert, ttl time.Duration) error {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return r.cache.Set(ctx, "email:revert:"+token, bytes, ttl).Err()
+}
+
+func (r *RedisEmailChangeCache) GetDelRevert(ctx context.Context, token string) (types.EmailRevert, error) {
+	bytes, err := r.cache.GetDel(ctx, "email:revert:"+token).Bytes()
+	if err != nil {
+		return types.EmailRevert{}, err
+	}
+	var value types.EmailRevert
+	if err := json.Unmarshal(bytes, &value); err != nil {
+		return types.EmailRevert{}, err
+	}
+	return value, nil
+}
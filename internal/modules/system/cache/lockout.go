@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/net/context"
+	"strconv"
+	"time"
+)
+
+// LockoutCache is responsible for counting failed login attempts by key, such as username or client ip
+type LockoutCache interface {
+	// Get returns the recent failed attempts of the key
+	Get(ctx context.Context, key string) (types.LoginAttempts, error)
+	// Fail records a failed attempt at the given time, attempts are kept for ttl since the last failure
+	Fail(ctx context.Context, key string, at time.Time, ttl time.Duration) (types.LoginAttempts, error)
+	// Lock rejects the key until the given time
+	Lock(ctx context.Context, key string, until time.Time) error
+	// Reset forgets all failed attempts of the key
+	Reset(ctx context.Context, key string) error
+}
+
+var _ LockoutCache = (*RedisLockoutCache)(nil)
+
+func NewRedisLockoutCache(cache *redis.Client) *RedisLockoutCache {
+	return &RedisLockoutCache{cache: cache}
+}
+
+// RedisLockoutCache implements LockoutCache with redis hash
+type RedisLockoutCache struct {
+	cache *redis.Client
+}
+
+func (r *RedisLockoutCache) Get(ctx context.Context, key string) (types.LoginAttempts, error) {
+	values, err := r.cache.HGetAll(ctx, "lockout:"+key).Result()
+	if err != nil {
+		return types.LoginAttempts{}, err
+	}
+	return parseAttempts(values), nil
+}
+
+func (r *RedisLockoutCache) Fail(ctx context.Context, key string, at time.Time, ttl time.Duration) (types.LoginAttempts, error) {
+	key = "lockout:" + key
+	var values *redis.MapStringStringCmd
+	_, err := r.cache.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HIncrBy(ctx, key, "failures", 1)
+		pipe.HSet(ctx, key, "last", at.UnixMicro())
+		pipe.Expire(ctx, key, ttl)
+		values = pipe.HGetAll(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return types.LoginAttempts{}, err
+	}
+	return parseAttempts(values.Val()), nil
+}
+
+func (r *RedisLockoutCache) Lock(ctx context.Context, key string, until time.Time) error {
+	key = "lockout:" + key
+	_, err := r.cache.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, "locked", until.UnixMicro())
+		pipe.ExpireAt(ctx, key, until)
+		return nil
+	})
+	return err
+}
+
+func (r *RedisLockoutCache) Reset(ctx context.Context, key string) error {
+	return r.cache.Del(ctx, "lockout:"+key).Err()
+}
+
+func parseAttempts(values map[string]string) types.LoginAttempts {
+	failures, _ := strconv.ParseInt(values["failures"], 10, 64)
+	last, _ := strconv.ParseInt(values["last"], 10, 64)
+	locked, _ := strconv.ParseInt(values["locked"], 10, 64)
+	return types.LoginAttempts{Failures: failures, LastFailure: last, LockedUntil: locked}
+}
@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"encoding/json"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/net/context"
+	"time"
+)
+
+// MagicLinkCache is responsible for storing pending login links, links of a user are indexed so that they could be revoked together
+type MagicLinkCache interface {
+	// Set stores the link with ttl
+	Set(ctx context.Context, id string, value types.MagicLink, ttl time.Duration) error
+	// Get returns the link, returns redis.Nil if not found
+	Get(ctx context.Context, id string) (types.MagicLink, error)
+	// Del removes the link of the user, returns false if it has been removed, so that each link could be used only once
+	Del(ctx context.Context, uid, id string) (bool, error)
+	// DelAll removes all links of the user
+	DelAll(ctx context.Context, uid string) error
+}
+
+var _ MagicLinkCache = (*RedisMagicLinkCache)(nil)
+
+func NewRedisMagicLinkCache(cache *redis.Client) *RedisMagicLinkCache {
+	return &RedisMagicLinkCache{cache: cache}
+}
+
+// RedisMagicLinkCache implements MagicLinkCache with redis string, and a redis set of link ids for each user
+type RedisMagicLinkCache struct {
+	cache *redis.Client
+}
+
+func (r *RedisMagicLinkCache) Set(ctx context.Context, id string, value types.MagicLink, ttl time.Duration) error {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = r.cache.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, "magic:link:"+id, bytes, ttl)
+		// the index lives as long as the latest link
+		pipe.SAdd(ctx, "magic:links:"+value.Uid, id)
+		pipe.Expire(ctx, "magic:links:"+value.Uid, ttl)
+		return nil
+	})
+	return err
+}
+
+func (r *RedisMagicLinkCache) Get(ctx context.Context, id string) (types.MagicLink, error) {
+	bytes, err := r.cache.Get(ctx, "magic:link:"+id).Bytes()
+	if err != nil {
+		return types.MagicLink{}, err
+	}
+	var value types.MagicLink
+	if err := json.Unmarshal(bytes, &value); err != nil {
+		return types.MagicLink{}, err
+	}
+	return value, nil
+}
+
+func (r *RedisMagicLinkCache) Del(ctx context.Context, uid, id string) (bool, error) {
+	var deleted *redis.IntCmd
+	_, err := r.cache.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		deleted = pipe.Del(ctx, "magic:link:"+id)
+		pipe.SRem(ctx, "magic:links:"+uid, id)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return deleted.Val() == 1, nil
+}
+
+func (r *RedisMagicLinkCache) DelAll(ctx context.Context, uid string) error {
+	ids, err := r.cache.SMembers(ctx, "magic:links:"+uid).Result()
+	if err != nil {
+		return err
+	}
+	keys := []string{"magic:links:" + uid}
+	for _, id := range ids {
+		keys = append(keys, "magic:link:"+id)
+	}
+	return r.cache.Del(ctx, keys...).Err()
+}
@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"encoding/json"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/net/context"
+	"time"
+)
+
+// OAuthStateCache is responsible for storing pending third-party authorization requests
+type OAuthStateCache interface {
+	// Set stores the state with ttl
+	Set(ctx context.Context, state string, value types.OAuthState, ttl time.Duration) error
+	// GetDel returns the state and removes it, so that each state could be used only once, returns redis.Nil if not found
+	GetDel(ctx context.Context, state string) (types.OAuthState, error)
+}
+
+var _ OAuthStateCache = (*RedisOAuthStateCache)(nil)
+
+func NewRedisOAuthStateCache(cache *redis.Client) *RedisOAuthStateCache {
+	return &RedisOAuthStateCache{cache: cache}
+}
+
+// RedisOAuthStateCache implements OAuthStateCache with redis string
+type RedisOAuthStateCache struct {
+	cache *redis.Client
+}
+
+func (r *RedisOAuthStateCache) Set(ctx context.Context, state string, value types.OAuthState, ttl time.Duration) error {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return r.cache.Set(ctx, "oauth:state:"+state, bytes, ttl).Err()
+}
+
+func (r *RedisOAuthStateCache) GetDel(ctx context.Context, state string) (types.OAuthState, error) {
+	bytes, err := r.cache.GetDel(ctx, "oauth:state:"+state).Bytes()
+	if err != nil {
+		return types.OAuthState{}, err
+	}
+	var value types.OAuthState
+	if err := json.Unmarshal(bytes, &value); err != nil {
+		return types.OAuthState{}, err
+	}
+	return value, nil
+}
@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"errors"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/net/context"
+	"strconv"
+	"time"
+)
+
+// TwoFACache is responsible for storing 2fa login challenges and used totp steps into cache
+type TwoFACache interface {
+	// SetChallenge stores the challenge with ttl
+	SetChallenge(ctx context.Context, id string, challenge types.TwoFAChallenge, ttl time.Duration) error
+	// GetChallenge returns the challenge and increases its attempts, returns redis.Nil if not found
+	GetChallenge(ctx context.Context, id string) (types.TwoFAChallenge, int64, error)
+	// DelChallenge removes the challenge
+	DelChallenge(ctx context.Context, id string) error
+	// UseStep marks the totp step of user as the last used one, returns false if it is not later than the last used
+	UseStep(ctx context.Context, uid string, step int64, ttl time.Duration) (bool, error)
+	// IncrAttempts increases the code attempts of user, attempts are counted in ttl since the first one
+	IncrAttempts(ctx context.Context, uid string, ttl time.Duration) (int64, error)
+	// DelAttempts forgets the code attempts of user
+	DelAttempts(ctx context.Context, uid string) error
+}
+
+var _ TwoFACache = (*RedisTwoFACache)(nil)
+
+func NewRedisTwoFACache(cache *redis.Client) *RedisTwoFACache {
+	return &RedisTwoFACache{cache: cache}
+}
+
+// RedisTwoFACache implements TwoFACache with redis hash
+type RedisTwoFACache struct {
+	cache *redis.Client
+}
+
+func (r *RedisTwoFACache) SetChallenge(ctx context.Context, id string, challenge types.TwoFAChallenge, ttl time.Duration) error {
+	key := "2fa:challenge:" + id
+	_, err := r.cache.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, "uid", challenge.Uid, "remember", challenge.Remember, "attempts", 0)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	return err
+}
+
+func (r *RedisTwoFACache) GetChallenge(ctx context.Context, id string) (types.TwoFAChallenge, int64, error) {
+	key := "2fa:challenge:" + id
+	var (
+		attempts *redis.IntCmd
+		values   *redis.MapStringStringCmd
+	)
+	_, err := r.cache.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		attempts = pipe.HIncrBy(ctx, key, "attempts", 1)
+		values = pipe.HGetAll(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return types.TwoFAChallenge{}, 0, err
+	}
+	// the key is created by HIncrBy if challenge does not exist
+	if values.Val()["uid"] == "" {
+		return types.TwoFAChallenge{}, 0, errors.Join(redis.Nil, r.DelChallenge(ctx, id))
+	}
+	remember, _ := strconv.ParseBool(values.Val()["remember"])
+	return types.TwoFAChallenge{Uid: values.Val()["uid"], Remember: remember}, attempts.Val(), nil
+}
+
+func (r *RedisTwoFACache) DelChallenge(ctx context.Context, id string) error {
+	return r.cache.Del(ctx, "2fa:challenge:"+id).Err()
+}
+
+// useStepScript stores the step only if it is later than the stored one, returns 1 if stored
+var useStepScript = redis.NewScript(`
+local last = tonumber(redis.call("GET", KEYS[1]) or "-1")
+local step = tonumber(ARGV[1])
+if step <= last then
+	return 0
+end
+redis.call("SET", KEYS[1], step, "PX", ARGV[2])
+return 1
+`)
+
+func (r *RedisTwoFACache) UseStep(ctx context.Context, uid string, step int64, ttl time.Duration) (bool, error) {
+	stored, err := useStepScript.Run(ctx, r.cache, []string{"2fa:step:" + uid}, step, ttl.Milliseconds()).Int()
+	return stored == 1, err
+}
+
+func (r *RedisTwoFACache) IncrAttempts(ctx context.Context, uid string, ttl time.Duration) (int64, error) {
+	key := "2fa:attempts:" + uid
+	attempts, err := r.cache.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if attempts == 1 {
+		err = r.cache.Expire(ctx, key, ttl).Err()
+	}
+	return attempts, err
+}
+
+func (r *RedisTwoFACache) DelAttempts(ctx context.Context, uid string) error {
+	return r.cache.Del(ctx, "2fa:attempts:"+uid).Err()
+}
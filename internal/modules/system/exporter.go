@@ -0,0 +1,50 @@
+package system
+
+import (
+	"context"
+	"github.com/ginx-contribs/ginx-server/ent"
+	systype "github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/export"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+)
+
+// Exporters returns personal data exporters of system module. Sessions are where the user is logged in now,
+// login history keeps every login within retention, and identities record the last login time of each third-party provider.
+func (m Module) Exporters() []export.Exporter {
+	return []export.Exporter{
+		export.Func("user", func(ctx context.Context, uid string) (any, error) {
+			queryUser, err := m.UserRepo.FindByUID(ctx, uid)
+			if ent.IsNotFound(err) {
+				return nil, systype.ErrUserNotFund
+			} else if err != nil {
+				return nil, statuserr.InternalError(err)
+			}
+			return systype.EntToAdminUser(queryUser), nil
+		}),
+		export.Func("sessions", func(ctx context.Context, uid string) (any, error) {
+			return m.SessionHandler.ListByUID(ctx, uid, "")
+		}),
+		export.Func("login_history", func(ctx context.Context, uid string) (any, error) {
+			return m.SessionHandler.ListHistoryByUID(ctx, uid)
+		}),
+		export.Func("identities", func(ctx context.Context, uid string) (any, error) {
+			return m.OAuthHandler.ListIdentities(ctx, uid)
+		}),
+		export.Func("personal_tokens", func(ctx context.Context, uid string) (any, error) {
+			return m.PersonalTokenHandler.List(ctx, uid)
+		}),
+		export.Func("oauth_clients", func(ctx context.Context, uid string) (any, error) {
+			return m.OAuthServerHandler.ListClients(ctx, uid)
+		}),
+		export.Func("roles", func(ctx context.Context, uid string) (any, error) {
+			return m.RoleHandler.ListUserRoles(ctx, uid)
+		}),
+		export.Func("audit_logs", func(ctx context.Context, uid string) (any, error) {
+			logs, err := m.AuditLogRepo.ListByTarget(ctx, uid)
+			if err != nil {
+				return nil, statuserr.InternalError(err)
+			}
+			return systype.EntsToAuditLogs(logs), nil
+		}),
+	}
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+	"github.com/ginx-contribs/ginx-server/internal/conf"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/logh"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"golang.org/x/net/context"
+	"log/slog"
+	"time"
+)
+
+// AccountHandler is responsible for the lifecycle of accounts. Accounts deleted by their owners are pending
+// until the grace period ends, then they are purged in background.
+type AccountHandler struct {
+	UserRepo      repo.UserRepo
+	AuthHandler   AuthHandler
+	AvatarHandler AvatarHandler
+	ExportHandler ExportHandler
+	Config        conf.Account
+}
+
+// CheckActive returns error if the user does not exist or is not active, it is used to authenticate requests
+func (a AccountHandler) CheckActive(ctx context.Context, uid string) error {
+	queryUser, err := a.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		return types.ErrCredentialInvalid
+	} else if err != nil {
+		return statuserr.InternalError(err)
+	}
+	return statusError(queryUser.Status)
+}
+
+// statusError returns the error of user status, it is nil only for active users
+func statusError(status user.Status) error {
+	switch status {
+	case user.StatusActive:
+		return nil
+	case user.StatusDisabled:
+		return types.ErrUserDisabled
+	case user.StatusPendingDeletion:
+		return types.ErrAccountPendingDeletion
+	default:
+		return types.ErrCredentialInvalid
+	}
+}
+
+// ScheduleDeletion marks the account as pending deletion and logs it out everywhere,
+// the account will be purged after the grace period unless its owner logs in again.
+func (a AccountHandler) ScheduleDeletion(ctx context.Context, uid string) (types.AccountDeletionInfo, error) {
+	queryUser, err := a.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		return types.AccountDeletionInfo{}, types.ErrUserNotFund
+	} else if err != nil {
+		return types.AccountDeletionInfo{}, statuserr.InternalError(err)
+	}
+
+	purgeAt := ts.Now().Add(a.Config.DeletionGrace.Duration()).UnixMicro()
+	if _, err := a.UserRepo.ScheduleDeletion(ctx, queryUser.ID, purgeAt); err != nil {
+		return types.AccountDeletionInfo{}, statuserr.InternalError(err)
+	}
+	if err := a.AuthHandler.LogoutAll(ctx, uid); err != nil {
+		return types.AccountDeletionInfo{}, err
+	}
+	return types.AccountDeletionInfo{PurgeAt: purgeAt}, nil
+}
+
+// Purge logs out the user everywhere, deletes its avatar and exports, then anonymizes or removes it according to the purge mode
+func (a AccountHandler) Purge(ctx context.Context, queryUser *ent.User) error {
+	if err := a.AuthHandler.LogoutAll(ctx, queryUser.UID); err != nil {
+		return err
+	}
+	a.AvatarHandler.Remove(ctx, queryUser.AvatarKey)
+	a.ExportHandler.Remove(ctx, queryUser.UID)
+
+	var err error
+	if a.Config.PurgeMode == types.PurgeRemove {
+		err = a.UserRepo.Remove(ctx, queryUser.ID)
+	} else {
+		err = a.UserRepo.Anonymize(ctx, queryUser)
+	}
+	if err != nil {
+		return statuserr.InternalError(err)
+	}
+	return nil
+}
+
+// PurgeExpired purges all accounts whose grace period has ended, and returns the number of purged accounts
+func (a AccountHandler) PurgeExpired(ctx context.Context) (int, error) {
+	var purged int
+	for {
+		expired, err := a.UserRepo.ListPurgeable(ctx, ts.Now().UnixMicro(), a.Config.PurgeBatch)
+		if err != nil {
+			return purged, statuserr.InternalError(err)
+		}
+		for _, queryUser := range expired {
+			if err := a.Purge(ctx, queryUser); err != nil {
+				return purged, err
+			}
+			purged++
+		}
+		if len(expired) < a.Config.PurgeBatch {
+			return purged, nil
+		}
+	}
+}
+
+// StartPurge purges expired accounts periodically in background until ctx is done
+func (a AccountHandler) StartPurge(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(a.Config.PurgeInterval.Duration())
+		defer ticker.Stop()
+		for {
+			purged, err := a.PurgeExpired(ctx)
+			logh.NoError("purge expired accounts failed", err)
+			if purged > 0 {
+				slog.Info("expired accounts purged", slog.Int("count", purged))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
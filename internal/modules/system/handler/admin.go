@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"golang.org/x/net/context"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// AdminUserHandler is responsible for managing users by administrators, every operation is recorded in audit log
+// with the uid of acting administrator.
+type AdminUserHandler struct {
+	UserHandler       UserHandler
+	AuthHandler       AuthHandler
+	AccountHandler    AccountHandler
+	BulkHandler       BulkHandler
+	UserRepo          repo.UserRepo
+	PersonalTokenRepo repo.PersonalTokenRepo
+	AuditLogRepo      repo.AuditLogRepo
+}
+
+// CreateUser creates a new user
+func (a AdminUserHandler) CreateUser(ctx context.Context, actor string, option types.AdminUserCreateOptions) (types.AdminUserInfo, error) {
+	newUser, err := a.UserHandler.CreateUser(ctx, option.Username, option.Email, option.Password)
+	if err != nil {
+		return types.AdminUserInfo{}, err
+	}
+	a.audit(ctx, actor, types.AuditUserCreate, newUser.UID, "")
+	return types.EntToAdminUser(newUser), nil
+}
+
+// UpdateUser updates username, email or password of the user, the user will be logged out everywhere if password reset.
+func (a AdminUserHandler) UpdateUser(ctx context.Context, actor, uid string, option types.AdminUserUpdateOptions) (types.AdminUserInfo, error) {
+	queryUser, err := a.findUser(ctx, uid)
+	if err != nil {
+		return types.AdminUserInfo{}, err
+	}
+	if err := a.UserHandler.CheckDuplicate(ctx, queryUser.ID, option.Username, option.Email); err != nil {
+		return types.AdminUserInfo{}, err
+	}
+
+	if option.Password != "" {
+		if err := a.AuthHandler.PasswordHandler.Check(ctx, queryUser, option.Password); err != nil {
+			return types.AdminUserInfo{}, err
+		}
+	}
+	// account and password are updated in one transaction, the previous password is recorded into history
+	updated, err := a.AuthHandler.PasswordHandler.UpdateAccount(ctx, queryUser, option.Username, option.Email, option.Password)
+	if err != nil {
+		return types.AdminUserInfo{}, err
+	}
+
+	if option.Password != "" {
+		if err := a.AuthHandler.LogoutAll(ctx, uid); err != nil {
+			return types.AdminUserInfo{}, err
+		}
+	}
+
+	// only names of changed fields are recorded, never the values of password
+	var changed []string
+	if option.Username != "" {
+		changed = append(changed, "username")
+	}
+	if option.Email != "" {
+		changed = append(changed, "email")
+	}
+	if option.Password != "" {
+		changed = append(changed, "password")
+	}
+	a.audit(ctx, actor, types.AuditUserUpdate, uid, strings.Join(changed, ","))
+	return types.EntToAdminUser(updated), nil
+}
+
+// SetDisabled disables or enables the user, disabled user is logged out everywhere and could not log in again.
+// Users pending deletion are rejected, otherwise their scheduled deletion would be lost.
+func (a AdminUserHandler) SetDisabled(ctx context.Context, actor, uid string, disabled bool) (types.AdminUserInfo, error) {
+	if actor == uid {
+		return types.AdminUserInfo{}, types.ErrOperateSelf
+	}
+	queryUser, err := a.findUser(ctx, uid)
+	if err != nil {
+		return types.AdminUserInfo{}, err
+	}
+	if queryUser.Status == user.StatusPendingDeletion {
+		return types.AdminUserInfo{}, types.ErrUserPendingDeletion
+	}
+	newStatus := user.StatusActive
+	if disabled {
+		newStatus = user.StatusDisabled
+	}
+	updated, err := a.UserRepo.UpdateStatus(ctx, queryUser.ID, newStatus)
+	if err != nil {
+		return types.AdminUserInfo{}, statuserr.InternalError(err)
+	}
+
+	action := types.AuditUserEnable
+	if disabled {
+		action = types.AuditUserDisable
+		if err := a.AuthHandler.LogoutAll(ctx, uid); err != nil {
+			return types.AdminUserInfo{}, err
+		}
+	}
+	a.audit(ctx, actor, action, uid, "")
+	return types.EntToAdminUser(updated), nil
+}
+
+// DeleteUser purges the user immediately without grace period
+func (a AdminUserHandler) DeleteUser(ctx context.Context, actor, uid string) error {
+	if actor == uid {
+		return types.ErrOperateSelf
+	}
+	queryUser, err := a.findUser(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if err := a.AccountHandler.Purge(ctx, queryUser); err != nil {
+		return err
+	}
+	a.audit(ctx, actor, types.AuditUserDelete, uid, "")
+	return nil
+}
+
+// ForceLogout revokes all tokens, sessions and personal access tokens of the user
+func (a AdminUserHandler) ForceLogout(ctx context.Context, actor, uid string) error {
+	queryUser, err := a.findUser(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if err := a.AuthHandler.LogoutAll(ctx, uid); err != nil {
+		return err
+	}
+	if _, err := a.PersonalTokenRepo.RemoveByUserID(ctx, queryUser.ID); err != nil {
+		return statuserr.InternalError(err)
+	}
+	a.audit(ctx, actor, types.AuditUserLogout, uid, "")
+	return nil
+}
+
+// ImportUsers imports users from r, each imported user is recorded in audit log, see BulkHandler.Import
+func (a AdminUserHandler) ImportUsers(ctx context.Context, actor string, r io.Reader, option types.ImportOptions) (types.ImportReport, error) {
+	report, err := a.BulkHandler.Import(ctx, r, option, a.BulkHandler.Config.MaxRows)
+	if err != nil {
+		return types.ImportReport{}, err
+	}
+	for _, imported := range report.Users {
+		a.audit(ctx, actor, types.AuditUserImport, imported.Uid, fmt.Sprintf("row %d", imported.Row))
+	}
+	return report, nil
+}
+
+// ExportUsers writes all users to w in the format, see BulkHandler.Export
+func (a AdminUserHandler) ExportUsers(ctx context.Context, actor string, w io.Writer, format string) error {
+	exported, err := a.BulkHandler.Export(ctx, w, format)
+	if err != nil {
+		return err
+	}
+	a.audit(ctx, actor, types.AuditUserExport, "", fmt.Sprintf("%d users in %s", exported, format))
+	return nil
+}
+
+// audit records the operation which has already taken effect, so failing the request would mislead the caller
+// into retrying it. The failure is logged with the whole record instead, so that it could still be traced.
+func (a AdminUserHandler) audit(ctx context.Context, actor, action, target, detail string) {
+	if _, err := a.AuditLogRepo.Create(ctx, actor, action, target, detail); err != nil {
+		slog.Error("record audit log failed",
+			slog.String("actor", actor),
+			slog.String("action", action),
+			slog.String("target", target),
+			slog.String("detail", detail),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func (a AdminUserHandler) findUser(ctx context.Context, uid string) (*ent.User, error) {
+	queryUser, err := a.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		return nil, types.ErrUserNotFund
+	} else if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	return queryUser, nil
+}
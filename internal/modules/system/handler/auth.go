@@ -1,20 +1,22 @@
 package handler
 
 import (
-	"crypto/sha1"
-	"encoding/base64"
-	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/ent/user"
 	"github.com/ginx-contribs/ginx-server/internal/conf"
 	"github.com/ginx-contribs/ginx-server/internal/modules/system/cache"
 	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
 	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/challenge"
 	"github.com/ginx-contribs/ginx-server/pkg/email"
+	"github.com/ginx-contribs/ginx-server/pkg/logh"
+	"github.com/ginx-contribs/ginx-server/pkg/passwd"
 	"github.com/ginx-contribs/ginx-server/pkg/token"
 	"github.com/ginx-contribs/ginx-server/pkg/toolset/captcha"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/idx"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
 	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
-	"github.com/ginx-contribs/str2bytes"
 	"github.com/pkg/errors"
 	"github.com/redis/go-redis/v9"
 	"github.com/wneessen/go-mail"
@@ -23,43 +25,154 @@ import (
 
 // AuthHandler is responsible for user authentication
 type AuthHandler struct {
-	Token          *token.Resolver
-	UserRepo       repo.UserRepo
-	CaptchaHandler CaptchaHandler
+	Token           *token.Resolver
+	UserRepo        repo.UserRepo
+	CaptchaHandler  CaptchaHandler
+	SessionHandler  SessionHandler
+	TwoFAHandler    TwoFAHandler
+	OAuthHandler    OAuthHandler
+	LockoutHandler  LockoutHandler
+	PasswordHandler PasswordHandler
+	Hasher          *passwd.Hasher
+	Account         conf.Account
 }
 
-// EncryptPassword encrypts password with sha512
-func (a AuthHandler) EncryptPassword(s string) string {
-	sum512 := sha1.Sum(str2bytes.Str2Bytes(s))
-	return base64.StdEncoding.EncodeToString(sum512[:])
-}
+// LoginWithPassword user login by password, it returns a challenge for the second factor instead of token pair if user enabled 2fa.
+// Unknown user and wrong password are not distinguishable by either response or timing.
+func (a AuthHandler) LoginWithPassword(ctx context.Context, option types.LoginOptions, client types.ClientInfo) (token.Pair, string, error) {
+	// reject locked username or client ip
+	if err := a.LockoutHandler.Check(ctx, option.Username, client.IP); err != nil {
+		return token.Pair{}, "", err
+	}
 
-// LoginWithPassword user login by password
-func (a AuthHandler) LoginWithPassword(ctx context.Context, option types.LoginOptions) (token.Pair, error) {
 	// find user from repository
 	queryUser, err := a.UserRepo.FindByNameOrMail(ctx, option.Username)
 	if ent.IsNotFound(err) {
-		return token.Pair{}, err
+		// costs the same time as checking a real password
+		logh.NoError("verify dummy password failed", a.Hasher.VerifyDummy(option.Password))
+		return token.Pair{}, "", a.loginFailed(ctx, option.Username, client, nil)
 	} else if err != nil { // db error
-		return token.Pair{}, statuserr.InternalError(err)
+		return token.Pair{}, "", statuserr.InternalError(err)
 	}
 
 	// check password
-	hashPaswd := a.EncryptPassword(option.Password)
-	if queryUser.Password != hashPaswd {
-		return token.Pair{}, types.ErrPasswordMismatch
+	match, rehash, err := a.Hasher.Verify(option.Password, queryUser.Password)
+	if err != nil {
+		return token.Pair{}, "", statuserr.InternalError(err)
+	} else if !match {
+		return token.Pair{}, "", a.loginFailed(ctx, option.Username, client, queryUser)
+	}
+	logh.NoError("reset login failures failed", a.LockoutHandler.Succeed(ctx, option.Username))
+
+	// upgrade outdated password hash transparently, it should not block login if failed
+	if rehash {
+		logh.NoError("rehash password failed", a.rehashPassword(ctx, queryUser.ID, option.Password))
+	}
+
+	return a.login(ctx, queryUser, option.Remember, client)
+}
+
+// loginFailed records the failed attempt, and returns the uniform error
+func (a AuthHandler) loginFailed(ctx context.Context, username string, client types.ClientInfo, queryUser *ent.User) error {
+	if err := a.LockoutHandler.Fail(ctx, username, client.IP, queryUser); err != nil {
+		return err
+	}
+	return types.ErrLoginFailed
+}
+
+// LoginWithOAuth completes the third-party login, it returns a challenge for the second factor instead of token pair
+// if user enabled 2fa.
+func (a AuthHandler) LoginWithOAuth(ctx context.Context, provider string, option types.OAuthCallbackOptions, client types.ClientInfo) (token.Pair, string, error) {
+	queryUser, remember, err := a.OAuthHandler.Authenticate(ctx, provider, option.Code, option.State)
+	if err != nil {
+		return token.Pair{}, "", err
+	}
+	return a.login(ctx, queryUser, remember, client)
+}
+
+// LoginWithTwoFA exchanges the login challenge and a totp code or recovery code for token pair
+func (a AuthHandler) LoginWithTwoFA(ctx context.Context, option types.TwoFALoginOptions, client types.ClientInfo) (token.Pair, error) {
+	queryUser, challenge, err := a.TwoFAHandler.Verify(ctx, option.Challenge, option.Code)
+	if err != nil {
+		return token.Pair{}, err
+	} else if err := loginStatusError(queryUser.Status); err != nil {
+		return token.Pair{}, err
+	}
+	return a.issueSession(ctx, queryUser, challenge.Remember, client)
+}
+
+// login issues a new session for the user who has passed the first factor, or a challenge if 2fa enabled
+func (a AuthHandler) login(ctx context.Context, queryUser *ent.User, remember bool, client types.ClientInfo) (token.Pair, string, error) {
+	if err := loginStatusError(queryUser.Status); err != nil {
+		return token.Pair{}, "", err
+	}
+	if a.Account.RestrictUnverified && queryUser.EmailVerifiedAt == 0 {
+		return token.Pair{}, "", types.ErrEmailUnverified
+	}
+
+	// second factor is required
+	if queryUser.TotpEnabled {
+		challenge, err := a.TwoFAHandler.Challenge(ctx, queryUser, remember)
+		return token.Pair{}, challenge, err
+	}
+
+	tokenPair, err := a.issueSession(ctx, queryUser, remember, client)
+	return tokenPair, "", err
+}
+
+// loginStatusError returns the error of user status for logging in, pending deletion user is allowed to log in to cancel the deletion
+func loginStatusError(status user.Status) error {
+	if status == user.StatusPendingDeletion {
+		return nil
+	}
+	return statusError(status)
+}
+
+// issueSession issues a new token pair for the user, and records it as a session.
+// The deletion of user is cancelled since its owner has logged in again.
+func (a AuthHandler) issueSession(ctx context.Context, queryUser *ent.User, remember bool, client types.ClientInfo) (token.Pair, error) {
+	if queryUser.Status == user.StatusPendingDeletion {
+		if _, err := a.UserRepo.CancelDeletion(ctx, queryUser.ID); err != nil {
+			return token.Pair{}, statuserr.InternalError(err)
+		}
 	}
 
-	// issue token
+	sid := idx.ULID()
 	tokenPair, err := a.Token.Issue(ctx, gin.H{
-		"username": queryUser.Username,
-		"uid":      queryUser.UID,
-	}, option.Remember)
+		"username":              queryUser.Username,
+		"uid":                   queryUser.UID,
+		types.SessionPayloadKey: sid,
+	}, remember)
 
 	if err != nil {
 		return token.Pair{}, statuserr.InternalError(err)
 	}
 
+	// record session
+	if err := a.SessionHandler.Create(ctx, queryUser, sid, tokenPair, client); err != nil {
+		return token.Pair{}, err
+	}
+
+	return tokenPair, nil
+}
+
+// Refresh refreshes the token pair, and updates the session which it belongs to
+func (a AuthHandler) Refresh(ctx context.Context, option types.RefreshTokenOptions) (token.Pair, error) {
+	tokenPair, err := a.Token.Refresh(ctx, option.AccessToken, option.RefreshToken)
+	if errors.Is(err, token.ErrRefreshTokenReused) {
+		// the login might be compromised, its session is no longer valid
+		sid, _ := tokenPair.Refresh.Claims.Payload[types.SessionPayloadKey].(string)
+		if err := a.SessionHandler.Remove(ctx, sid); err != nil {
+			return token.Pair{}, err
+		}
+		return token.Pair{}, types.ErrTokenReused
+	} else if err != nil {
+		return tokenPair, err
+	}
+	sid, _ := tokenPair.Access.Claims.Payload[types.SessionPayloadKey].(string)
+	if err := a.SessionHandler.Touch(ctx, sid, tokenPair); err != nil {
+		return token.Pair{}, err
+	}
 	return tokenPair, nil
 }
 
@@ -89,13 +202,18 @@ func (a AuthHandler) RegisterNewUser(ctx context.Context, option types.RegisterO
 	}
 
 	// create new user
-	user, err := a.UserRepo.CreateNewUser(ctx, option.Username, option.Email, a.EncryptPassword(option.Password))
+	hashPasswd, err := a.Hasher.Hash(option.Password)
+	if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	// the email has been verified by code
+	user, err := a.UserRepo.CreateNewUser(ctx, option.Username, option.Email, hashPasswd, ts.UnixMicro())
 	if err != nil {
 		return nil, statuserr.InternalError(err)
 	}
 
 	// remove verify code
-	err = a.CaptchaHandler.Remove(ctx, option.Code, types.UsageRegister)
+	err = a.CaptchaHandler.Remove(ctx, option.Email, types.UsageRegister)
 	if err != nil {
 		return nil, err
 	}
@@ -115,74 +233,164 @@ func (a AuthHandler) ResetPassword(ctx context.Context, option types.ResetOption
 	queryUser, err := a.UserRepo.FindByEmail(ctx, option.Email)
 	if ent.IsNotFound(err) {
 		return types.ErrUserNotFund
+	} else if err != nil {
+		return statuserr.InternalError(err)
 	}
 
 	// update password
-	_, err = a.UserRepo.UpdateOnePassword(ctx, queryUser.ID, a.EncryptPassword(option.Password))
+	if err := a.PasswordHandler.Check(ctx, queryUser, option.Password); err != nil {
+		return err
+	}
+	if _, err := a.PasswordHandler.Update(ctx, queryUser, option.Password); err != nil {
+		return err
+	}
+	// the email has been verified by code as well
+	if queryUser.EmailVerifiedAt == 0 {
+		if _, err := a.UserRepo.VerifyEmail(ctx, queryUser.ID); err != nil {
+			return statuserr.InternalError(err)
+		}
+	}
+
+	// remove verify code
+	err = a.CaptchaHandler.Remove(ctx, option.Email, types.UsageReset)
 	if err != nil {
+		return err
+	}
+
+	// kick out all existing sessions
+	return a.LogoutAll(ctx, queryUser.UID)
+}
+
+// ChangePassword changes password of the user after checking the old one, the new one must not be used recently.
+// All sessions are logged out after changed.
+func (a AuthHandler) ChangePassword(ctx context.Context, uid string, option types.ChangePasswordOptions) error {
+	queryUser, err := a.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		return types.ErrUserNotFund
+	} else if err != nil {
 		return statuserr.InternalError(err)
 	}
 
-	// remove verify code
-	err = a.CaptchaHandler.Remove(ctx, option.Code, types.UsageReset)
+	match, _, err := a.Hasher.Verify(option.OldPassword, queryUser.Password)
 	if err != nil {
+		return statuserr.InternalError(err)
+	} else if !match {
+		return types.ErrPasswordMismatch
+	}
+
+	if err := a.PasswordHandler.Check(ctx, queryUser, option.NewPassword); err != nil {
 		return err
 	}
-	return nil
+	if _, err := a.PasswordHandler.Update(ctx, queryUser, option.NewPassword); err != nil {
+		return err
+	}
+
+	// kick out all existing sessions, include the current one
+	return a.LogoutAll(ctx, uid)
+}
+
+// Logout revokes the current access token and its refresh token
+func (a AuthHandler) Logout(ctx context.Context, access *token.Token, refreshToken string) error {
+	var refresh token.Token
+	if refreshToken != "" {
+		verified, err := a.Token.VerifyRefresh(ctx, refreshToken)
+		// expired refresh token has nothing to revoke
+		if err != nil && !errors.Is(err, token.ErrRefreshTokenExpired) {
+			return types.ErrCredentialInvalid
+		} else if err == nil {
+			refresh = verified
+		}
+	}
+
+	err := a.Token.Revoke(ctx, *access, refresh)
+	if errors.Is(err, token.ErrMisMatchTokenPair) {
+		return types.ErrCredentialInvalid
+	} else if err != nil {
+		return err
+	}
+
+	sid, _ := access.Claims.Payload[types.SessionPayloadKey].(string)
+	return a.SessionHandler.Remove(ctx, sid)
+}
+
+// LogoutAll revokes all tokens of the specified user
+func (a AuthHandler) LogoutAll(ctx context.Context, uid string) error {
+	if err := a.Token.RevokeAllForSubject(ctx, uid); err != nil {
+		return err
+	}
+	return a.SessionHandler.RemoveAll(ctx, uid)
+}
+
+// rehashPassword hashes password with the preferred algorithm, then updates it
+func (a AuthHandler) rehashPassword(ctx context.Context, id int, password string) error {
+	hashPasswd, err := a.Hasher.Hash(password)
+	if err != nil {
+		return err
+	}
+	_, err = a.UserRepo.UpdateOnePassword(ctx, id, hashPasswd)
+	return err
 }
 
 type CaptchaHandler struct {
 	CaptchaCache cache.CaptchaCache
 	EmailHandler EmailHandler
+	Challenges   *challenge.Registry
 
 	MetaInfo conf.MetaInfo
 }
 
-// SendCaptchaEmail send a verify code email to the specified address
-func (v CaptchaHandler) SendCaptchaEmail(ctx context.Context, to string, usage types.Usage) error {
-	ttl := v.EmailHandler.Config.Code.TTL
-	retryttl := v.EmailHandler.Config.Code.RetryTTL
-	var code string
-
-	for try := 0; ; try++ {
-		// max retry 10 times
-		if try > 10 {
-			return types.ErrVerifyCodeRetryLater
-		}
+// RegisterUsages registers challenge usages of system module
+func (v CaptchaHandler) RegisterUsages() error {
+	return v.Challenges.Register(
+		challenge.Usage{Name: string(types.UsageRegister), Description: "register account"},
+		challenge.Usage{Name: string(types.UsageReset), Description: "reset password"},
+		challenge.Usage{Name: string(types.UsageChangeEmail), Description: "change email", Private: true},
+	)
+}
 
-		// generated a verification code
-		code = captcha.GenCaptcha(8)
-		tryOk, err := v.CaptchaCache.Set(ctx, usage, code, to, ttl.Duration(), retryttl.Duration())
+// Apply sends a verify code email for the public usage, codes of private usages could only be sent by their modules
+func (v CaptchaHandler) Apply(ctx context.Context, option types.CaptchaOption) error {
+	setting, ok := v.Challenges.Get(string(option.Usage))
+	if !ok || setting.Private {
+		return types.ErrVerifyCodeUsageUnsupported
+	}
+	return v.SendCaptchaEmail(ctx, option.To, option.Usage)
+}
 
-		if errors.Is(err, cache.ErrCodeRepeated) {
-			continue
-		} else if err != nil {
-			return statuserr.InternalError(err)
-		} else if !tryOk {
-			return types.ErrVerifyCodeRetryLater
-		} else {
-			break
-		}
+// SendCaptchaEmail send a verify code email to the specified address, the usage must be registered
+func (v CaptchaHandler) SendCaptchaEmail(ctx context.Context, to string, usage types.Usage) error {
+	setting, ok := v.Challenges.Get(string(usage))
+	if !ok {
+		return types.ErrVerifyCodeUsageUnsupported
+	}
+
+	// codes are keyed by recipient, so they never collide with codes of others
+	code := captcha.GenCode(setting.Length, setting.Alphabet)
+	ok, err := v.CaptchaCache.Set(ctx, usage, to, code, setting.TTL, setting.Retry)
+	if err != nil {
+		return statuserr.InternalError(err)
+	} else if !ok {
+		return types.ErrVerifyCodeRetryLater
 	}
 
 	msg := email.Message{
 		ContentType: mail.TypeTextHTML,
 		To:          []string{to},
-		Subject:     fmt.Sprintf("you are applying for verification code tp %s.", usage.String()),
+		Subject:     setting.Subject,
 		Message: map[string]any{
 			"to":       to,
-			"action":   usage.String(),
-			"duration": ttl.String(),
+			"action":   setting.Description,
+			"duration": setting.TTL.String(),
 			"code":     code,
 			"author":   v.MetaInfo.Author,
 		},
-		Template: email.TemplateCaptcha,
+		Template: setting.Template,
 	}
 
 	// send email
-	err := v.EmailHandler.Publish(ctx, msg)
+	err = v.EmailHandler.Publish(ctx, msg)
 	if err != nil {
-		if err := v.CaptchaCache.Del(ctx, usage, code); err != nil {
+		if err := v.CaptchaCache.Del(ctx, usage, to); err != nil {
 			return statuserr.InternalError(err)
 		}
 		return err
@@ -190,10 +398,14 @@ func (v CaptchaHandler) SendCaptchaEmail(ctx context.Context, to string, usage t
 	return nil
 }
 
-// Check checks captcha if is valid
+// Check checks captcha of the recipient if is valid, the code is invalidated after too many checks
 func (v CaptchaHandler) Check(ctx context.Context, to, code string, usage types.Usage) error {
-	getTo, err := v.CaptchaCache.Get(ctx, usage, code)
-	if errors.Is(err, redis.Nil) || getTo != to {
+	setting, ok := v.Challenges.Get(string(usage))
+	if !ok {
+		return types.ErrVerifyCodeUsageUnsupported
+	}
+	ok, err := v.CaptchaCache.Check(ctx, usage, to, code, setting.Attempts)
+	if errors.Is(err, redis.Nil) || (err == nil && !ok) {
 		return types.ErrVerifyCodeInvalid
 	} else if err != nil {
 		return statuserr.InternalError(err)
@@ -201,9 +413,9 @@ func (v CaptchaHandler) Check(ctx context.Context, to, code string, usage types.
 	return nil
 }
 
-// Remove removes captcha from cache
-func (v CaptchaHandler) Remove(ctx context.Context, code string, usage types.Usage) error {
-	err := v.CaptchaCache.Del(ctx, usage, code)
+// Remove removes captcha of the recipient from cache
+func (v CaptchaHandler) Remove(ctx context.Context, to string, usage types.Usage) error {
+	err := v.CaptchaCache.Del(ctx, usage, to)
 	if err != nil {
 		return statuserr.InternalError(err)
 	}
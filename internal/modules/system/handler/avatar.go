@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/internal/conf"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/imaging"
+	"github.com/ginx-contribs/ginx-server/pkg/logh"
+	"github.com/ginx-contribs/ginx-server/pkg/storage"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/idx"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// AvatarHandler is responsible for processing uploaded avatars, and serving them by signed urls
+type AvatarHandler struct {
+	UserRepo repo.UserRepo
+	Storage  storage.Storage
+	Config   conf.Storage
+	Server   conf.Server
+}
+
+// Upload decodes the image, crops it to square, then stores it in all configured sizes.
+// The avatar url of user is pointed to the avatar api, previous uploaded avatar will be deleted.
+func (a AvatarHandler) Upload(ctx context.Context, uid string, r io.Reader) (types.UserInfo, error) {
+	queryUser, err := a.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		return types.UserInfo{}, types.ErrUserNotFund
+	} else if err != nil {
+		return types.UserInfo{}, statuserr.InternalError(err)
+	}
+
+	_, r, err = storage.Sniff(storage.LimitReader(r, a.Config.Avatar.MaxSize), types.AvatarFormats...)
+	if err != nil {
+		return types.UserInfo{}, avatarError(err)
+	}
+	img, _, err := imaging.Decode(r, a.Config.Avatar.MaxPixels)
+	if err != nil {
+		return types.UserInfo{}, avatarError(err)
+	}
+	square := imaging.CropSquare(img)
+
+	// images with transparency are kept as png
+	ext := ".jpg"
+	if !square.Opaque() {
+		ext = ".png"
+	}
+	id := idx.ULID()
+	key := "avatars/" + uid + "/" + id + ext
+	for _, size := range a.Config.Avatar.Sizes {
+		var buf bytes.Buffer
+		contentType, err := imaging.Encode(&buf, imaging.Resize(square, size, size))
+		if err != nil {
+			return types.UserInfo{}, statuserr.InternalError(err)
+		}
+		if err := a.Storage.Put(ctx, avatarKey(key, size), &buf, int64(buf.Len()), contentType); err != nil {
+			return types.UserInfo{}, statuserr.InternalError(err)
+		}
+	}
+
+	// version in query makes clients refresh their cache
+	avatarURL := path.Join("/", a.Server.BasePath, "user", uid, "avatar") + "?v=" + id
+	updated, err := a.UserRepo.UpdateAvatar(ctx, queryUser.ID, key, avatarURL)
+	if err != nil {
+		return types.UserInfo{}, statuserr.InternalError(err)
+	}
+
+	a.Remove(ctx, queryUser.AvatarKey)
+	return types.EntToUser(updated), nil
+}
+
+// Remove deletes the uploaded avatar in all sizes, failures are only logged since objects are unreachable anyway
+func (a AvatarHandler) Remove(ctx context.Context, key string) {
+	if key == "" {
+		return
+	}
+	for _, size := range a.Config.Avatar.Sizes {
+		logh.NoError("delete avatar failed", a.Storage.Delete(ctx, avatarKey(key, size)))
+	}
+}
+
+// URL returns the signed url of the user avatar in the nearest larger size
+func (a AvatarHandler) URL(ctx context.Context, uid string, size int) (string, error) {
+	queryUser, err := a.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		return "", types.ErrUserNotFund
+	} else if err != nil {
+		return "", statuserr.InternalError(err)
+	}
+	if queryUser.AvatarKey == "" || len(a.Config.Avatar.Sizes) == 0 {
+		return "", types.ErrAvatarNotFound
+	}
+
+	sizes := slices.Sorted(slices.Values(a.Config.Avatar.Sizes))
+	pick := sizes[len(sizes)-1]
+	if i, _ := slices.BinarySearch(sizes, size); size > 0 && i < len(sizes) {
+		pick = sizes[i]
+	}
+	signedURL, err := a.Storage.SignURL(ctx, avatarKey(queryUser.AvatarKey, pick), a.Config.SignTTL.Duration())
+	if err != nil {
+		return "", statuserr.InternalError(err)
+	}
+	return signedURL, nil
+}
+
+// avatarKey returns the key of avatar in the given size, such as avatars/<uid>/<id>_64.jpg
+func avatarKey(key string, size int) string {
+	ext := path.Ext(key)
+	return strings.TrimSuffix(key, ext) + "_" + strconv.Itoa(size) + ext
+}
+
+// avatarError converts errors of reading and decoding image to status errors, corrupted images are treated as unsupported.
+func avatarError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.Is(err, storage.ErrTooLarge) || errors.Is(err, imaging.ErrTooManyPixels) || errors.As(err, &maxBytesErr) {
+		return types.ErrAvatarTooLarge
+	}
+	return types.ErrAvatarFormat
+}
@@ -0,0 +1,386 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/internal/conf"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/email"
+	"github.com/ginx-contribs/ginx-server/pkg/logh"
+	"github.com/ginx-contribs/ginx-server/pkg/passwd"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"github.com/go-playground/validator/v10"
+	"github.com/wneessen/go-mail"
+	"golang.org/x/net/context"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// rowValidator validates imported rows with the same rules as api parameters
+var rowValidator = validator.New()
+
+// importRow is a decoded row along with its number in file
+type importRow struct {
+	types.ImportUserRow
+	row int
+}
+
+// BulkHandler is responsible for importing and exporting users in bulk, it is shared by the admin api and command line.
+type BulkHandler struct {
+	UserRepo     repo.UserRepo
+	Hasher       *passwd.Hasher
+	Policy       *passwd.Policy
+	EmailHandler EmailHandler
+	Config       conf.Bulk
+	Server       conf.Server
+	MetaInfo     conf.MetaInfo
+}
+
+// Import imports users from r in the format, maxRows <= 0 means no limit. All rows are validated first,
+// then valid rows are inserted in batches, each batch in a transaction. Nothing is written in dry run.
+// Rows failed to decode, validate or insert are reported with reasons instead of failing the whole import.
+func (b BulkHandler) Import(ctx context.Context, r io.Reader, option types.ImportOptions, maxRows int) (types.ImportReport, error) {
+	report := types.ImportReport{DryRun: option.DryRun, Users: []types.ImportedUser{}, Errors: []types.ImportRowError{}}
+	rows, total, err := b.readRows(r, option.Format, maxRows, &report)
+	if err != nil {
+		return types.ImportReport{}, err
+	}
+	report.Total = total
+
+	valid, err := b.validateRows(ctx, rows, &report)
+	if err != nil {
+		return types.ImportReport{}, err
+	}
+
+	if !option.DryRun {
+		for batch := range slices.Chunk(valid, b.batchSize()) {
+			created, err := b.insert(ctx, batch, &report)
+			if err != nil {
+				return types.ImportReport{}, err
+			}
+			if option.Invite {
+				for i, newUser := range created {
+					logh.NoError("send invitation failed", b.invite(ctx, newUser, batch[i].Password == "" && batch[i].PasswordHash == ""))
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(report.Errors, func(a, b types.ImportRowError) int {
+		return a.Row - b.Row
+	})
+	report.Imported = len(report.Users)
+	report.Failed = len(report.Errors)
+	if option.DryRun {
+		report.Imported = len(valid)
+	}
+	return report, nil
+}
+
+func (b BulkHandler) batchSize() int {
+	return max(b.Config.BatchSize, 1)
+}
+
+// readRows decodes rows from r, rows which could not be decoded are reported, and the number of all rows is returned
+func (b BulkHandler) readRows(r io.Reader, format string, maxRows int, report *types.ImportReport) ([]importRow, int, error) {
+	var (
+		rows  []importRow
+		total int
+	)
+	add := func(row importRow, err error) error {
+		total++
+		if maxRows > 0 && total > maxRows {
+			return types.ErrImportTooLarge
+		}
+		if err != nil {
+			report.Errors = append(report.Errors, types.ImportRowError{Row: row.row, Error: err.Error()})
+			return nil
+		}
+		row.Username, row.Email = strings.TrimSpace(row.Username), strings.TrimSpace(row.Email)
+		rows = append(rows, row)
+		return nil
+	}
+
+	switch format {
+	case types.FormatCSV:
+		return rows, total, readCSV(r, add)
+	case types.FormatJSONL:
+		return rows, total, readJSONL(r, add)
+	default:
+		return nil, 0, types.ErrBulkFormat
+	}
+}
+
+// readCSV decodes rows from csv with header, rows are numbered from 1 excluding the header
+func readCSV(r io.Reader, add func(importRow, error) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil
+	} else if err != nil {
+		return types.ErrImportHeader
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			// excel prepends byte order mark to utf-8 csv
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["username"]; !ok {
+		return types.ErrImportHeader
+	}
+	if _, ok := columns["email"]; !ok {
+		return types.ErrImportHeader
+	}
+
+	for n := 1; ; n++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		row := importRow{row: n}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			if err := add(row, parseErr.Err); err != nil {
+				return err
+			}
+			continue
+		} else if err != nil {
+			return statuserr.InternalError(err)
+		}
+
+		column := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+		row.ImportUserRow = types.ImportUserRow{
+			Username:     column("username"),
+			Email:        column("email"),
+			Nickname:     column("nickname"),
+			Password:     column("password"),
+			PasswordHash: column("password_hash"),
+		}
+		if err := add(row, nil); err != nil {
+			return err
+		}
+	}
+}
+
+// readJSONL decodes rows from json lines, rows are numbered by lines, and blank lines are skipped
+func readJSONL(r io.Reader, add func(importRow, error) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), 1<<20)
+	for n := 1; scanner.Scan(); n++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		row := importRow{row: n}
+		if err := add(row, json.Unmarshal(line, &row.ImportUserRow)); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return statuserr.InternalError(err)
+	}
+	return nil
+}
+
+// validateRows reports invalid rows, rows conflicting with each other or with existing users, then returns valid rows
+func (b BulkHandler) validateRows(ctx context.Context, rows []importRow, report *types.ImportReport) ([]importRow, error) {
+	var (
+		checked []importRow
+		names   = make(map[string]bool)
+		emails  = make(map[string]bool)
+	)
+	for _, row := range rows {
+		reason, err := b.validateRow(row.ImportUserRow)
+		if err != nil {
+			return nil, statuserr.InternalError(err)
+		}
+		if reason == "" && names[row.Username] {
+			reason = "duplicate username in file"
+		} else if reason == "" && emails[row.Email] {
+			reason = "duplicate email in file"
+		}
+		if reason != "" {
+			report.Errors = append(report.Errors, rowError(row, reason))
+			continue
+		}
+		names[row.Username], emails[row.Email] = true, true
+		checked = append(checked, row)
+	}
+
+	var valid []importRow
+	for batch := range slices.Chunk(checked, b.batchSize()) {
+		usernames, addresses := make([]string, 0, len(batch)), make([]string, 0, len(batch))
+		for _, row := range batch {
+			usernames = append(usernames, row.Username)
+			addresses = append(addresses, row.Email)
+		}
+		takenNames, takenEmails, err := b.UserRepo.FindTaken(ctx, usernames, addresses)
+		if err != nil {
+			return nil, statuserr.InternalError(err)
+		}
+		for _, row := range batch {
+			if takenNames[row.Username] {
+				report.Errors = append(report.Errors, rowError(row, types.ErrUserAlreadyExists.Error()))
+			} else if takenEmails[row.Email] {
+				report.Errors = append(report.Errors, rowError(row, types.ErrEmailAlreadyUsed.Error()))
+			} else {
+				valid = append(valid, row)
+			}
+		}
+	}
+	return valid, nil
+}
+
+// validateRow returns the reason why the row is invalid, or empty if it is valid. Plain passwords must meet the password policy,
+// pre-hashed ones could not be checked.
+func (b BulkHandler) validateRow(row types.ImportUserRow) (string, error) {
+	switch {
+	case rowValidator.Var(row.Username, "required,alphanum") != nil:
+		return "username is required and must be alphanumeric", nil
+	case rowValidator.Var(row.Email, "required,email") != nil:
+		return "email is required and must be a valid address", nil
+	case row.Password != "" && row.PasswordHash != "":
+		return "password and passwordHash can not be given at the same time", nil
+	case row.PasswordHash != "" && !b.Hasher.Valid(row.PasswordHash):
+		return "passwordHash is malformed or generated by unsupported algorithm", nil
+	case row.Password != "":
+		violations, err := b.Policy.Check(row.Password, row.Username, row.Email)
+		if err != nil || len(violations) == 0 {
+			return "", err
+		}
+		return types.PasswordPolicyError{Violations: violations}.Error(), nil
+	}
+	return "", nil
+}
+
+func rowError(row importRow, reason string) types.ImportRowError {
+	return types.ImportRowError{Row: row.row, Username: row.Username, Email: row.Email, Error: reason}
+}
+
+// insert creates users of the batch in a transaction, rows of the batch are all reported if it fails
+func (b BulkHandler) insert(ctx context.Context, batch []importRow, report *types.ImportReport) ([]*ent.User, error) {
+	newUsers := make([]repo.NewUser, 0, len(batch))
+	for _, row := range batch {
+		hashPasswd := row.PasswordHash
+		if row.Password != "" {
+			var err error
+			if hashPasswd, err = b.Hasher.Hash(row.Password); err != nil {
+				return nil, statuserr.InternalError(err)
+			}
+		}
+		newUsers = append(newUsers, repo.NewUser{
+			Username: row.Username,
+			Email:    row.Email,
+			Nickname: row.Nickname,
+			Password: hashPasswd,
+		})
+	}
+
+	created, err := b.UserRepo.CreateBulk(ctx, newUsers)
+	if err != nil {
+		// users may be created concurrently since validated, the batch is rolled back as a whole
+		logh.NoError("import batch failed", err)
+		for _, row := range batch {
+			report.Errors = append(report.Errors, rowError(row, "failed to insert the batch of this row, please retry"))
+		}
+		return nil, nil
+	}
+	for i, newUser := range created {
+		report.Users = append(report.Users, types.ImportedUser{Row: batch[i].row, Uid: newUser.UID})
+	}
+	return created, nil
+}
+
+// invite sends the invitation email to the imported user, users without password are told to reset it
+func (b BulkHandler) invite(ctx context.Context, newUser *ent.User, setPassword bool) error {
+	msg := email.Message{
+		ContentType: mail.TypeTextHTML,
+		To:          []string{newUser.Email},
+		Subject:     "you are invited to " + b.MetaInfo.AppName,
+		Message: map[string]any{
+			"username":    newUser.Username,
+			"appName":     b.MetaInfo.AppName,
+			"link":        b.Server.PublicURL,
+			"setPassword": setPassword,
+			"author":      b.MetaInfo.Author,
+		},
+		Template: email.TemplateInvite,
+	}
+	return b.EmailHandler.Publish(ctx, msg)
+}
+
+// exportColumns is the csv header of exported users
+var exportColumns = []string{"uid", "username", "email", "nickname", "status", "created_at"}
+
+// Export writes all users to w in the format ordered by id, and returns the number of exported users.
+// Users are read in batches, so that the whole table is never loaded into memory.
+func (b BulkHandler) Export(ctx context.Context, w io.Writer, format string) (int, error) {
+	var write func(row types.ExportUserRow) error
+	var flush func() error
+	switch format {
+	case types.FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(exportColumns); err != nil {
+			return 0, err
+		}
+		write = func(row types.ExportUserRow) error {
+			return writer.Write([]string{row.Uid, row.Username, row.Email, row.Nickname, row.Status, strconv.FormatInt(row.CreatedAt, 10)})
+		}
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	case types.FormatJSONL:
+		encoder := json.NewEncoder(w)
+		write = func(row types.ExportUserRow) error {
+			return encoder.Encode(row)
+		}
+		flush = func() error { return nil }
+	default:
+		return 0, types.ErrBulkFormat
+	}
+
+	var exported, lastID int
+	for {
+		users, err := b.UserRepo.ListAfterID(ctx, lastID, b.batchSize())
+		if err != nil {
+			return exported, statuserr.InternalError(err)
+		}
+		for _, queryUser := range users {
+			row := types.ExportUserRow{
+				Uid:       queryUser.UID,
+				Username:  queryUser.Username,
+				Email:     queryUser.Email,
+				Nickname:  queryUser.Nickname,
+				Status:    queryUser.Status.String(),
+				CreatedAt: queryUser.CreatedAt,
+			}
+			if err := write(row); err != nil {
+				return exported, err
+			}
+			exported++
+			lastID = queryUser.ID
+		}
+		if len(users) < b.batchSize() {
+			return exported, flush()
+		}
+	}
+}
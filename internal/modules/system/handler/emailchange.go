@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"errors"
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/internal/conf"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/cache"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/email"
+	"github.com/ginx-contribs/ginx-server/pkg/logh"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/idx"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"github.com/redis/go-redis/v9"
+	"github.com/wneessen/go-mail"
+	"golang.org/x/net/context"
+	"net/url"
+	"strings"
+)
+
+// EmailChangeHandler is responsible for changing email of users. The new address is verified by code before swapped,
+// then the old address is notified with a link to revert the change, in case that the account is compromised.
+type EmailChangeHandler struct {
+	UserRepo         repo.UserRepo
+	EmailChangeCache cache.EmailChangeCache
+	CaptchaHandler   CaptchaHandler
+	AuthHandler      AuthHandler
+	EmailHandler     EmailHandler
+	Config           conf.Account
+	Server           conf.Server
+	MetaInfo         conf.MetaInfo
+}
+
+// Apply sends a verification code to the new address after checking the password, the change is pending until confirmed.
+func (e EmailChangeHandler) Apply(ctx context.Context, uid string, option types.EmailChangeOptions) error {
+	queryUser, err := e.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		return types.ErrUserNotFund
+	} else if err != nil {
+		return statuserr.InternalError(err)
+	}
+
+	match, _, err := e.AuthHandler.Hasher.Verify(option.Password, queryUser.Password)
+	if err != nil {
+		return statuserr.InternalError(err)
+	} else if !match {
+		return types.ErrPasswordMismatch
+	}
+
+	// the current address is only verified, others must not be used by anyone
+	if option.Email != queryUser.Email {
+		userByEmail, err := e.UserRepo.FindByEmail(ctx, option.Email)
+		if !ent.IsNotFound(err) && err != nil {
+			return statuserr.InternalError(err)
+		} else if userByEmail != nil {
+			return types.ErrEmailAlreadyUsed
+		}
+	}
+
+	setting, ok := e.CaptchaHandler.Challenges.Get(string(types.UsageChangeEmail))
+	if !ok {
+		return types.ErrVerifyCodeUsageUnsupported
+	}
+	if err := e.EmailChangeCache.SetPending(ctx, uid, option.Email, setting.TTL); err != nil {
+		return statuserr.InternalError(err)
+	}
+	if err := e.CaptchaHandler.SendCaptchaEmail(ctx, option.Email, types.UsageChangeEmail); err != nil {
+		logh.NoError("remove pending email change failed", e.EmailChangeCache.DelPending(ctx, uid))
+		return err
+	}
+	return nil
+}
+
+// Confirm swaps the email with the pending one after verifying the code, the old address is notified with a revert link.
+func (e EmailChangeHandler) Confirm(ctx context.Context, uid string, option types.EmailConfirmOptions) (types.UserInfo, error) {
+	newEmail, err := e.EmailChangeCache.GetPending(ctx, uid)
+	if errors.Is(err, redis.Nil) {
+		return types.UserInfo{}, types.ErrNoEmailChange
+	} else if err != nil {
+		return types.UserInfo{}, statuserr.InternalError(err)
+	}
+	if err := e.CaptchaHandler.Check(ctx, newEmail, option.Code, types.UsageChangeEmail); err != nil {
+		return types.UserInfo{}, err
+	}
+
+	queryUser, err := e.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		return types.UserInfo{}, types.ErrUserNotFund
+	} else if err != nil {
+		return types.UserInfo{}, statuserr.InternalError(err)
+	}
+	oldEmail := queryUser.Email
+
+	var updated *ent.User
+	if newEmail == oldEmail {
+		updated, err = e.UserRepo.VerifyEmail(ctx, queryUser.ID)
+	} else {
+		// the address might be taken by others since applied
+		updated, err = e.UserRepo.UpdateEmail(ctx, queryUser.ID, newEmail)
+	}
+	if ent.IsConstraintError(err) {
+		return types.UserInfo{}, types.ErrEmailAlreadyUsed
+	} else if err != nil {
+		return types.UserInfo{}, statuserr.InternalError(err)
+	}
+
+	if err := e.CaptchaHandler.Remove(ctx, newEmail, types.UsageChangeEmail); err != nil {
+		return types.UserInfo{}, err
+	}
+	if err := e.EmailChangeCache.DelPending(ctx, uid); err != nil {
+		return types.UserInfo{}, statuserr.InternalError(err)
+	}
+
+	if newEmail != oldEmail {
+		logh.NoError("send email change notification failed", e.notify(ctx, updated, oldEmail))
+	}
+	return types.EntToUser(updated), nil
+}
+
+// Revert restores the old email by the token in revert link, and logs out the user everywhere.
+// It fails if the email has been changed again, or the old address has been taken by others.
+func (e EmailChangeHandler) Revert(ctx context.Context, token string) error {
+	change, err := e.EmailChangeCache.GetDelRevert(ctx, token)
+	if errors.Is(err, redis.Nil) {
+		return types.ErrEmailRevertInvalid
+	} else if err != nil {
+		return statuserr.InternalError(err)
+	}
+
+	queryUser, err := e.UserRepo.FindByUID(ctx, change.Uid)
+	if ent.IsNotFound(err) {
+		return types.ErrEmailRevertInvalid
+	} else if err != nil {
+		return statuserr.InternalError(err)
+	} else if queryUser.Email != change.NewEmail {
+		return types.ErrEmailRevertInvalid
+	}
+
+	// the old address has been verified by receiving the link
+	_, err = e.UserRepo.UpdateEmail(ctx, queryUser.ID, change.OldEmail)
+	if ent.IsConstraintError(err) {
+		return types.ErrEmailAlreadyUsed
+	} else if err != nil {
+		return statuserr.InternalError(err)
+	}
+	if err := e.EmailChangeCache.DelPending(ctx, queryUser.UID); err != nil {
+		return statuserr.InternalError(err)
+	}
+	return e.AuthHandler.LogoutAll(ctx, queryUser.UID)
+}
+
+// notify sends an email to the old address with the link to revert the change
+func (e EmailChangeHandler) notify(ctx context.Context, updated *ent.User, oldEmail string) error {
+	token := idx.Secret(32)
+	change := types.EmailRevert{Uid: updated.UID, OldEmail: oldEmail, NewEmail: updated.Email}
+	if err := e.EmailChangeCache.SetRevert(ctx, token, change, e.Config.EmailRevertTTL.Duration()); err != nil {
+		return err
+	}
+
+	link := strings.TrimSuffix(e.Server.PublicURL, "/") + e.Server.BasePath + "/user/email/revert?" + url.Values{"token": {token}}.Encode()
+	msg := email.Message{
+		ContentType: mail.TypeTextHTML,
+		To:          []string{oldEmail},
+		Subject:     "the email address of your account has been changed",
+		Message: map[string]any{
+			"username": updated.Username,
+			"email":    updated.Email,
+			"link":     link,
+			"ttl":      e.Config.EmailRevertTTL.String(),
+			"author":   e.MetaInfo.Author,
+		},
+		Template: email.TemplateEmailChanged,
+	}
+	return e.EmailHandler.Publish(ctx, msg)
+}
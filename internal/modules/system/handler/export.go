@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/internal/conf"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/email"
+	"github.com/ginx-contribs/ginx-server/pkg/export"
+	"github.com/ginx-contribs/ginx-server/pkg/logh"
+	"github.com/ginx-contribs/ginx-server/pkg/mq"
+	"github.com/ginx-contribs/ginx-server/pkg/storage"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/idx"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"github.com/wneessen/go-mail"
+	"golang.org/x/net/context"
+	"log/slog"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// exportPrefix is the prefix of archive keys, archives are stored as exports/<uid>/<ulid>.zip
+const exportPrefix = "exports/"
+
+// export jobs are processed by a single consumer, since building archives is heavy
+const (
+	exportTopic    = "export"
+	exportGroup    = "export-group"
+	exportConsumer = "exporter"
+)
+
+// ExportHandler is responsible for exporting personal data of users. Archives are built in background by
+// exporters registered in registry, then stored in object storage, and the download link is sent by email.
+// Archives are deleted once their links expire.
+type ExportHandler struct {
+	Exporters    *export.Registry
+	Storage      storage.Storage
+	EmailHandler EmailHandler
+	UserRepo     repo.UserRepo
+	Queue        mq.Queue
+	Config       conf.Export
+	Server       conf.Server
+	MetaInfo     conf.MetaInfo
+}
+
+// Subscribe registers the consumer of export jobs into queue
+func (e ExportHandler) Subscribe() error {
+	return e.Queue.Subscribe(&ExportConsumer{handler: e})
+}
+
+// Request publishes an export job of the user, the archive will be built asynchronously
+func (e ExportHandler) Request(ctx context.Context, uid string) error {
+	_, err := e.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		return types.ErrUserNotFund
+	} else if err != nil {
+		return statuserr.InternalError(err)
+	}
+	if _, err := e.Queue.Publish(ctx, exportTopic, map[string]any{"uid": uid}, 0); err != nil {
+		return statuserr.InternalError(err)
+	}
+	return nil
+}
+
+// Build gathers personal data of the user into a zip archive, stores it, then emails the signed download link
+func (e ExportHandler) Build(ctx context.Context, uid string) error {
+	queryUser, err := e.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		// user has been deleted since requested
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var archive bytes.Buffer
+	if err := e.Exporters.Archive(ctx, uid, &archive); err != nil {
+		return err
+	}
+	key := path.Join(exportPrefix, uid, idx.ULID()+".zip")
+	if err := e.Storage.Put(ctx, key, &archive, int64(archive.Len()), "application/zip"); err != nil {
+		return err
+	}
+	link, err := e.Storage.SignURL(ctx, key, e.Config.LinkTTL.Duration())
+	if err != nil {
+		return err
+	}
+
+	msg := email.Message{
+		ContentType: mail.TypeTextHTML,
+		To:          []string{queryUser.Email},
+		Subject:     "your personal data export is ready",
+		Message: map[string]any{
+			"username": queryUser.Username,
+			"link":     e.absoluteURL(link),
+			"ttl":      e.Config.LinkTTL.String(),
+			"author":   e.MetaInfo.Author,
+		},
+		Template: email.TemplateExport,
+	}
+	return e.EmailHandler.Publish(ctx, msg)
+}
+
+// Remove deletes all archives of the user, failures are only logged
+func (e ExportHandler) Remove(ctx context.Context, uid string) {
+	archives, err := e.Storage.List(ctx, exportPrefix+uid+"/")
+	if err != nil {
+		logh.NoError("list exports failed", err)
+		return
+	}
+	for _, archive := range archives {
+		logh.NoError("delete export failed", e.Storage.Delete(ctx, archive.Key))
+	}
+}
+
+// Sweep deletes archives whose download links have expired, and returns the number of deleted archives
+func (e ExportHandler) Sweep(ctx context.Context) (int, error) {
+	archives, err := e.Storage.List(ctx, exportPrefix)
+	if err != nil {
+		return 0, err
+	}
+	var deleted int
+	expiredAt := ts.Now().Add(-e.Config.LinkTTL.Duration())
+	for _, archive := range archives {
+		if archive.ModTime.After(expiredAt) {
+			continue
+		}
+		if err := e.Storage.Delete(ctx, archive.Key); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// StartSweep deletes expired archives periodically in background until ctx is done
+func (e ExportHandler) StartSweep(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(e.Config.SweepInterval.Duration())
+		defer ticker.Stop()
+		for {
+			deleted, err := e.Sweep(ctx)
+			logh.NoError("sweep expired exports failed", err)
+			if deleted > 0 {
+				slog.Info("expired exports deleted", slog.Int("count", deleted))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// absoluteURL resolves the signed url against the public url of server, urls of local storage are relative
+func (e ExportHandler) absoluteURL(link string) string {
+	if parsed, err := url.Parse(link); err == nil && parsed.IsAbs() {
+		return link
+	}
+	return strings.TrimSuffix(e.Server.PublicURL, "/") + link
+}
+
+// ExportConsumer is responsible for reading export jobs from queue and then building archives
+type ExportConsumer struct {
+	handler ExportHandler
+}
+
+func (c *ExportConsumer) Name() string {
+	return exportConsumer
+}
+
+func (c *ExportConsumer) Topic() string {
+	return exportTopic
+}
+
+func (c *ExportConsumer) Group() string {
+	return exportGroup
+}
+
+func (c *ExportConsumer) Size() int64 {
+	return 1
+}
+
+func (c *ExportConsumer) Consume(ctx context.Context, id string, value any) error {
+	val, ok := value.(map[string]any)
+	if !ok {
+		return fmt.Errorf("mismatched value type from mq, expected map[string]any, but got %T", value)
+	}
+	uid, ok := val["uid"].(string)
+	if !ok {
+		return fmt.Errorf("mismatched uid type from mq, expected string, but got %T", val["uid"])
+	}
+	return c.handler.Build(ctx, uid)
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/internal/conf"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/cache"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/email"
+	"github.com/ginx-contribs/ginx-server/pkg/logh"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"github.com/wneessen/go-mail"
+	"golang.org/x/net/context"
+	"strings"
+	"time"
+)
+
+// LockoutHandler is responsible for protecting password login from brute-force attacks.
+// Failures are counted by the submitted username rather than the user, so that unknown usernames behave the same as existing ones.
+type LockoutHandler struct {
+	LockoutCache cache.LockoutCache
+	EmailHandler EmailHandler
+	Config       conf.Lockout
+	MetaInfo     conf.MetaInfo
+}
+
+// Check returns error if the username or client ip is locked, or the attempt comes too soon after the last failure
+func (l LockoutHandler) Check(ctx context.Context, username, ip string) error {
+	now := ts.Now().UnixMicro()
+
+	userAttempts, err := l.LockoutCache.Get(ctx, userLockoutKey(username))
+	if err != nil {
+		return statuserr.InternalError(err)
+	}
+	ipAttempts, err := l.LockoutCache.Get(ctx, ipLockoutKey(ip))
+	if err != nil {
+		return statuserr.InternalError(err)
+	}
+	if userAttempts.LockedUntil > now || ipAttempts.LockedUntil > now {
+		return types.ErrLoginLocked
+	}
+	if userAttempts.Failures > 0 && userAttempts.LastFailure+l.delay(userAttempts.Failures).Microseconds() > now {
+		return types.ErrLoginTooFrequent
+	}
+	return nil
+}
+
+// Fail records a failed attempt, the username or client ip will be locked once reaching the threshold.
+// owner is the user matching the username, it is notified by email when locked, and could be nil if not found.
+func (l LockoutHandler) Fail(ctx context.Context, username, ip string, owner *ent.User) error {
+	now := ts.Now()
+	window := l.Config.Window.Duration()
+	until := now.Add(l.Config.Duration.Duration())
+
+	userAttempts, err := l.LockoutCache.Fail(ctx, userLockoutKey(username), now, window)
+	if err != nil {
+		return statuserr.InternalError(err)
+	}
+	if userAttempts.Failures >= int64(l.Config.Threshold) {
+		if err := l.LockoutCache.Lock(ctx, userLockoutKey(username), until); err != nil {
+			return statuserr.InternalError(err)
+		}
+		if owner != nil {
+			logh.NoError("send lockout notification failed", l.notify(ctx, owner, ip, userAttempts.Failures))
+		}
+	}
+
+	ipAttempts, err := l.LockoutCache.Fail(ctx, ipLockoutKey(ip), now, window)
+	if err != nil {
+		return statuserr.InternalError(err)
+	}
+	if ipAttempts.Failures >= int64(l.Config.IPThreshold) {
+		if err := l.LockoutCache.Lock(ctx, ipLockoutKey(ip), until); err != nil {
+			return statuserr.InternalError(err)
+		}
+	}
+	return nil
+}
+
+// Succeed forgets failed attempts of the username, failures of client ip are kept,
+// otherwise an attacker could reset them by logging in to an account of its own.
+func (l LockoutHandler) Succeed(ctx context.Context, username string) error {
+	if err := l.LockoutCache.Reset(ctx, userLockoutKey(username)); err != nil {
+		return statuserr.InternalError(err)
+	}
+	return nil
+}
+
+// delay returns the required interval after the given number of failures, it grows exponentially
+func (l LockoutHandler) delay(failures int64) time.Duration {
+	base, maxDelay := l.Config.BaseDelay.Duration(), l.Config.MaxDelay.Duration()
+	if failures <= 0 || base <= 0 {
+		return 0
+	}
+	delay := base
+	for i := int64(1); i < failures && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+// notify sends an email to the owner that the account has been locked
+func (l LockoutHandler) notify(ctx context.Context, owner *ent.User, ip string, failures int64) error {
+	msg := email.Message{
+		ContentType: mail.TypeTextHTML,
+		To:          []string{owner.Email},
+		Subject:     "your account has been temporarily locked",
+		Message: map[string]any{
+			"username": owner.Username,
+			"failures": failures,
+			"ip":       ip,
+			"duration": l.Config.Duration.String(),
+			"author":   l.MetaInfo.Author,
+		},
+		Template: email.TemplateLockout,
+	}
+	return l.EmailHandler.Publish(ctx, msg)
+}
+
+func userLockoutKey(username string) string {
+	return "user:" + strings.ToLower(strings.TrimSpace(username))
+}
+
+func ipLockoutKey(ip string) string {
+	return "ip:" + ip
+}
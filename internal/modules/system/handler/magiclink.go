@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"errors"
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/internal/conf"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/cache"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/email"
+	"github.com/ginx-contribs/ginx-server/pkg/linksign"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/idx"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"github.com/redis/go-redis/v9"
+	"github.com/wneessen/go-mail"
+	"golang.org/x/net/context"
+	"net/url"
+	"strings"
+)
+
+// NewLinkSigner returns the signer of login links, a random key is used if the secret is not configured
+func NewLinkSigner(cfg conf.MagicLink) (*linksign.Signer, error) {
+	return linksign.New([]byte(cfg.Secret))
+}
+
+// MagicLinkHandler is responsible for passwordless login by links sent to email. Each link is signed with its expiration,
+// and stored in cache until used, so that it could be used only once and revoked at any time.
+type MagicLinkHandler struct {
+	UserRepo       repo.UserRepo
+	AuthHandler    AuthHandler
+	MagicLinkCache cache.MagicLinkCache
+	EmailHandler   EmailHandler
+	Signer         *linksign.Signer
+	Config         conf.MagicLink
+	Server         conf.Server
+	MetaInfo       conf.MetaInfo
+}
+
+// Send emails a login link to the owner of the address. Nothing is sent to unknown addresses and users who could not log in,
+// so that whether an address is registered could not be told from the response.
+func (m MagicLinkHandler) Send(ctx context.Context, option types.MagicLinkOptions, client types.ClientInfo) error {
+	queryUser, err := m.UserRepo.FindByEmail(ctx, option.Email)
+	if ent.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return statuserr.InternalError(err)
+	} else if loginStatusError(queryUser.Status) != nil {
+		return nil
+	}
+
+	id := idx.Secret(16)
+	ttl := m.Config.TTL.Duration()
+	link := types.MagicLink{Uid: queryUser.UID, Remember: option.Remember, IP: client.IP, UserAgent: client.UserAgent}
+	if err := m.MagicLinkCache.Set(ctx, id, link, ttl); err != nil {
+		return statuserr.InternalError(err)
+	}
+	signed := m.Signer.Sign(id, ttl)
+
+	msg := email.Message{
+		ContentType: mail.TypeTextHTML,
+		To:          []string{queryUser.Email},
+		Subject:     "log in to your account",
+		Message: map[string]any{
+			"username": queryUser.Username,
+			"link":     strings.TrimSuffix(m.Server.PublicURL, "/") + m.Server.BasePath + "/auth/magic-link/verify?" + url.Values{"token": {signed}}.Encode(),
+			"ttl":      m.Config.TTL.String(),
+			"ip":       client.IP,
+			"author":   m.MetaInfo.Author,
+		},
+		Template: email.TemplateMagicLink,
+	}
+	return m.EmailHandler.Publish(ctx, msg)
+}
+
+// Verify consumes the login link and logs in its owner, it returns a challenge for the second factor instead of token pair
+// if user enabled 2fa. Links bound to clients are not consumed by other clients.
+func (m MagicLinkHandler) Verify(ctx context.Context, signed string, client types.ClientInfo) (types.TokenResult, error) {
+	id, err := m.Signer.Verify(signed)
+	if err != nil {
+		return types.TokenResult{}, types.ErrMagicLinkInvalid
+	}
+
+	link, err := m.MagicLinkCache.Get(ctx, id)
+	if errors.Is(err, redis.Nil) {
+		return types.TokenResult{}, types.ErrMagicLinkInvalid
+	} else if err != nil {
+		return types.TokenResult{}, statuserr.InternalError(err)
+	}
+	if m.Config.BindClient && (link.IP != client.IP || link.UserAgent != client.UserAgent) {
+		return types.TokenResult{}, types.ErrMagicLinkInvalid
+	}
+	// the link might be used concurrently, only the one which removes it wins
+	if deleted, err := m.MagicLinkCache.Del(ctx, link.Uid, id); err != nil {
+		return types.TokenResult{}, statuserr.InternalError(err)
+	} else if !deleted {
+		return types.TokenResult{}, types.ErrMagicLinkInvalid
+	}
+
+	queryUser, err := m.UserRepo.FindByUID(ctx, link.Uid)
+	if ent.IsNotFound(err) {
+		return types.TokenResult{}, types.ErrMagicLinkInvalid
+	} else if err != nil {
+		return types.TokenResult{}, statuserr.InternalError(err)
+	}
+	// the email has been verified by receiving the link
+	if queryUser.EmailVerifiedAt == 0 {
+		if queryUser, err = m.UserRepo.VerifyEmail(ctx, queryUser.ID); err != nil {
+			return types.TokenResult{}, statuserr.InternalError(err)
+		}
+	}
+
+	tokenPair, challenge, err := m.AuthHandler.login(ctx, queryUser, link.Remember, client)
+	if err != nil {
+		return types.TokenResult{}, err
+	} else if challenge != "" {
+		return types.TokenResult{Challenge: challenge}, nil
+	}
+	return types.TokenResult{AccessToken: tokenPair.Access.Raw, RefreshToken: tokenPair.Refresh.Raw}, nil
+}
+
+// Revoke revokes all unused login links of the user
+func (m MagicLinkHandler) Revoke(ctx context.Context, uid string) error {
+	if err := m.MagicLinkCache.DelAll(ctx, uid); err != nil {
+		return statuserr.InternalError(err)
+	}
+	return nil
+}
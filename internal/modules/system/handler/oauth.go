@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/internal/conf"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/cache"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/logh"
+	"github.com/ginx-contribs/ginx-server/pkg/oauth"
+	"github.com/ginx-contribs/ginx-server/pkg/passwd"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/idx"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/net/context"
+	"strings"
+	"unicode"
+)
+
+// OAuthHandler is responsible for login with third-party providers and linking identities
+type OAuthHandler struct {
+	Registry        *oauth.Registry
+	UserRepo        repo.UserRepo
+	IdentityRepo    repo.IdentityRepo
+	OAuthStateCache cache.OAuthStateCache
+	Hasher          *passwd.Hasher
+	Config          conf.OAuth
+}
+
+// AuthorizeURL starts an authorization request, and returns url of the provider authorization page.
+// uid is the user who is linking identity, it should be empty for login.
+func (o OAuthHandler) AuthorizeURL(ctx context.Context, providerName, uid string, remember bool) (string, error) {
+	provider, err := o.provider(ctx, providerName)
+	if err != nil {
+		return "", err
+	}
+
+	stateId := idx.Secret(32)
+	state := types.OAuthState{
+		Provider: providerName,
+		Verifier: idx.Secret(32),
+		Nonce:    idx.Secret(16),
+		Uid:      uid,
+		Remember: remember,
+	}
+	if err := o.OAuthStateCache.Set(ctx, stateId, state, o.Config.StateTTL.Duration()); err != nil {
+		return "", statuserr.InternalError(err)
+	}
+	return provider.AuthCodeURL(stateId, state.Nonce, state.Verifier), nil
+}
+
+// Authenticate completes the login authorization request, and returns the user linked to the identity.
+// A new user will be registered if no user is linked yet.
+func (o OAuthHandler) Authenticate(ctx context.Context, providerName, code, stateId string) (*ent.User, bool, error) {
+	identity, state, err := o.exchange(ctx, providerName, code, stateId, "")
+	if err != nil {
+		return nil, false, err
+	}
+
+	linked, err := o.IdentityRepo.FindBySubject(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		logh.NoError("update identity login failed", o.IdentityRepo.TouchLogin(ctx, linked.ID, identity.Email))
+		return linked.Edges.User, state.Remember, nil
+	} else if !ent.IsNotFound(err) {
+		return nil, false, statuserr.InternalError(err)
+	}
+
+	// user must have an email
+	if identity.Email == "" {
+		return nil, false, types.ErrOAuthEmailRequired
+	}
+
+	userByEmail, err := o.UserRepo.FindByEmail(ctx, identity.Email)
+	if err != nil && !ent.IsNotFound(err) {
+		return nil, false, statuserr.InternalError(err)
+	} else if userByEmail != nil {
+		// only trust the email verified by provider, otherwise anyone could take over the account
+		if !o.Config.Providers[providerName].LinkByEmail || !identity.EmailVerified {
+			return nil, false, types.ErrEmailAlreadyUsed
+		}
+		if _, err := o.IdentityRepo.Create(ctx, userByEmail.ID, identity.Provider, identity.Subject, identity.Email); ent.IsConstraintError(err) {
+			return nil, false, types.ErrIdentityAlreadyLinked
+		} else if err != nil {
+			return nil, false, statuserr.InternalError(err)
+		}
+		return userByEmail, state.Remember, nil
+	}
+
+	newUser, err := o.register(ctx, identity)
+	if err != nil {
+		return nil, false, err
+	}
+	return newUser, state.Remember, nil
+}
+
+// Link completes the linking authorization request, and links the identity to the user
+func (o OAuthHandler) Link(ctx context.Context, providerName, uid, code, stateId string) error {
+	queryUser, err := o.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		return types.ErrUserNotFund
+	} else if err != nil {
+		return statuserr.InternalError(err)
+	}
+
+	identity, _, err := o.exchange(ctx, providerName, code, stateId, uid)
+	if err != nil {
+		return err
+	}
+
+	_, err = o.IdentityRepo.Create(ctx, queryUser.ID, identity.Provider, identity.Subject, identity.Email)
+	if ent.IsConstraintError(err) {
+		return types.ErrIdentityAlreadyLinked
+	} else if err != nil {
+		return statuserr.InternalError(err)
+	}
+	return nil
+}
+
+// ListIdentities returns identities linked to the user
+func (o OAuthHandler) ListIdentities(ctx context.Context, uid string) ([]types.IdentityInfo, error) {
+	queryUser, err := o.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		return nil, types.ErrUserNotFund
+	} else if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	identities, err := o.IdentityRepo.ListByUserID(ctx, queryUser.ID)
+	if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	return types.EntsToIdentities(identities), nil
+}
+
+// Unlink removes the identity of provider from the user
+func (o OAuthHandler) Unlink(ctx context.Context, uid, providerName string) error {
+	queryUser, err := o.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		return types.ErrUserNotFund
+	} else if err != nil {
+		return statuserr.InternalError(err)
+	}
+	removed, err := o.IdentityRepo.RemoveByProvider(ctx, queryUser.ID, providerName)
+	if err != nil {
+		return statuserr.InternalError(err)
+	} else if removed == 0 {
+		return types.ErrIdentityNotFound
+	}
+	return nil
+}
+
+// exchange consumes the state, then exchanges the authorization code for identity
+func (o OAuthHandler) exchange(ctx context.Context, providerName, code, stateId, uid string) (oauth.Identity, types.OAuthState, error) {
+	state, err := o.OAuthStateCache.GetDel(ctx, stateId)
+	if errors.Is(err, redis.Nil) {
+		return oauth.Identity{}, state, types.ErrOAuthStateInvalid
+	} else if err != nil {
+		return oauth.Identity{}, state, statuserr.InternalError(err)
+	}
+	// state must be issued for the same provider and the same user
+	if state.Provider != providerName || state.Uid != uid {
+		return oauth.Identity{}, state, types.ErrOAuthStateInvalid
+	}
+
+	provider, err := o.provider(ctx, providerName)
+	if err != nil {
+		return oauth.Identity{}, state, err
+	}
+	identity, err := provider.Exchange(ctx, code, state.Nonce, state.Verifier)
+	if err != nil {
+		logh.NoError("oauth exchange failed", err)
+		return oauth.Identity{}, state, types.ErrOAuthExchangeFailed
+	}
+	return identity, state, nil
+}
+
+// register creates a new user for the identity, password is random so that user could only login by provider
+// until the password is reset.
+func (o OAuthHandler) register(ctx context.Context, identity oauth.Identity) (*ent.User, error) {
+	username, err := o.availableUsername(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	hashPasswd, err := o.Hasher.Hash(idx.Secret(32))
+	if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	var emailVerifiedAt int64
+	if identity.EmailVerified {
+		emailVerifiedAt = ts.UnixMicro()
+	}
+	newUser, err := o.IdentityRepo.CreateWithUser(ctx, username, identity.Email, emailVerifiedAt, hashPasswd, identity.Provider, identity.Subject)
+	if ent.IsConstraintError(err) {
+		return nil, types.ErrUserAlreadyExists
+	} else if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	return newUser, nil
+}
+
+// availableUsername derives an alphanumeric username from the identity, a random suffix is appended if it is taken.
+func (o OAuthHandler) availableUsername(ctx context.Context, identity oauth.Identity) (string, error) {
+	base := identity.Name
+	if base == "" {
+		base, _, _ = strings.Cut(identity.Email, "@")
+	}
+	base = strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+		return -1
+	}, base)
+	if base == "" {
+		base = "user"
+	}
+
+	username := base
+	for range 5 {
+		_, err := o.UserRepo.FindByName(ctx, username)
+		if ent.IsNotFound(err) {
+			return username, nil
+		} else if err != nil {
+			return "", statuserr.InternalError(err)
+		}
+		username = base + strings.ToLower(idx.ULID()[20:])
+	}
+	return "", types.ErrUserAlreadyExists
+}
+
+func (o OAuthHandler) provider(ctx context.Context, name string) (*oauth.Provider, error) {
+	provider, err := o.Registry.Get(ctx, name)
+	if errors.Is(err, oauth.ErrProviderNotFound) {
+		return nil, types.ErrOAuthProviderNotFound
+	} else if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	return provider, nil
+}
@@ -0,0 +1,379 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+	"github.com/ginx-contribs/ginx-server/internal/conf"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/cache"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/token"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/idx"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/net/context"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// OAuthServerHandler is responsible for issuing tokens to oauth2 clients
+type OAuthServerHandler struct {
+	Token           *token.Resolver
+	UserRepo        repo.UserRepo
+	OAuthClientRepo repo.OAuthClientRepo
+	AuthCodeCache   cache.AuthCodeCache
+	Config          conf.OAuthServer
+}
+
+// CreateClient registers a new client owned by the user, the secret is only returned once.
+func (o OAuthServerHandler) CreateClient(ctx context.Context, uid string, option types.OAuthClientCreateOptions) (types.OAuthClientCreateResult, error) {
+	queryUser, err := o.findUser(ctx, uid)
+	if err != nil {
+		return types.OAuthClientCreateResult{}, err
+	}
+
+	for _, scope := range option.Scopes {
+		if !slices.Contains(o.Config.Scopes, scope) {
+			return types.OAuthClientCreateResult{}, types.ErrOAuthScopeInvalid
+		}
+	}
+	if slices.Contains(option.GrantTypes, types.GrantAuthorizationCode) && len(option.RedirectURIs) == 0 {
+		return types.OAuthClientCreateResult{}, types.ErrOAuthRedirectInvalid
+	}
+	// public client could not authenticate itself
+	if option.Public && slices.Contains(option.GrantTypes, types.GrantClientCredentials) {
+		return types.OAuthClientCreateResult{}, types.ErrOAuthGrantInvalid
+	}
+
+	var secret string
+	if !option.Public {
+		secret = idx.Secret(32)
+	}
+	client, err := o.OAuthClientRepo.Create(ctx, queryUser.ID, ent.OAuthClient{
+		Name:         option.Name,
+		SecretHash:   hashClientSecret(secret),
+		RedirectUris: option.RedirectURIs,
+		Scopes:       option.Scopes,
+		GrantTypes:   option.GrantTypes,
+		Public:       option.Public,
+	})
+	if err != nil {
+		return types.OAuthClientCreateResult{}, statuserr.InternalError(err)
+	}
+	return types.OAuthClientCreateResult{OAuthClientInfo: types.EntToOAuthClient(client), Secret: secret}, nil
+}
+
+// ListClients returns clients owned by the user
+func (o OAuthServerHandler) ListClients(ctx context.Context, uid string) ([]types.OAuthClientInfo, error) {
+	queryUser, err := o.findUser(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	clients, err := o.OAuthClientRepo.ListByUserID(ctx, queryUser.ID)
+	if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	return types.EntsToOAuthClients(clients), nil
+}
+
+// DeleteClient removes the client owned by the user, tokens already issued to it stay valid until expired.
+func (o OAuthServerHandler) DeleteClient(ctx context.Context, uid, clientId string) error {
+	queryUser, err := o.findUser(ctx, uid)
+	if err != nil {
+		return err
+	}
+	removed, err := o.OAuthClientRepo.RemoveByClientID(ctx, queryUser.ID, clientId)
+	if err != nil {
+		return statuserr.InternalError(err)
+	} else if removed == 0 {
+		return types.ErrOAuthClientNotFound
+	}
+	return nil
+}
+
+// Consent validates the authorization request, and returns what the user is asked to consent to.
+func (o OAuthServerHandler) Consent(ctx context.Context, option types.AuthorizeOptions) (types.ConsentInfo, error) {
+	client, scope, err := o.checkAuthorize(ctx, option)
+	if err != nil {
+		return types.ConsentInfo{}, err
+	}
+	return types.ConsentInfo{
+		ClientId: client.ClientID,
+		Name:     client.Name,
+		Scopes:   strings.Fields(scope),
+	}, nil
+}
+
+// Approve issues an authorization code if the user approves, and returns the client redirect uri carrying it.
+func (o OAuthServerHandler) Approve(ctx context.Context, uid string, option types.ApproveOptions) (types.ApproveResult, error) {
+	_, scope, err := o.checkAuthorize(ctx, option.AuthorizeOptions)
+	if err != nil {
+		return types.ApproveResult{}, err
+	}
+
+	query := url.Values{}
+	if option.State != "" {
+		query.Set("state", option.State)
+	}
+
+	if !option.Approve {
+		query.Set("error", "access_denied")
+		return types.ApproveResult{RedirectURI: withQuery(option.RedirectURI, query)}, nil
+	}
+
+	code := idx.Secret(32)
+	authCode := types.AuthCode{
+		ClientId:    option.ClientId,
+		Uid:         uid,
+		RedirectURI: option.RedirectURI,
+		Scope:       scope,
+		Challenge:   option.CodeChallenge,
+	}
+	if err := o.AuthCodeCache.Set(ctx, code, authCode, o.Config.CodeTTL.Duration()); err != nil {
+		return types.ApproveResult{}, statuserr.InternalError(err)
+	}
+	query.Set("code", code)
+	return types.ApproveResult{RedirectURI: withQuery(option.RedirectURI, query)}, nil
+}
+
+// IssueToken authenticates the client, then issues tokens with the requested grant.
+func (o OAuthServerHandler) IssueToken(ctx context.Context, option types.OAuthTokenOptions) (types.OAuthTokenResult, error) {
+	client, err := o.authenticateClient(ctx, option.ClientId, option.ClientSecret)
+	if err != nil {
+		return types.OAuthTokenResult{}, err
+	}
+	if !slices.Contains(client.GrantTypes, option.GrantType) {
+		switch option.GrantType {
+		case types.GrantAuthorizationCode, types.GrantRefreshToken, types.GrantClientCredentials:
+			return types.OAuthTokenResult{}, types.ErrUnauthorizedClient
+		default:
+			return types.OAuthTokenResult{}, types.ErrUnsupportedGrantType
+		}
+	}
+
+	switch option.GrantType {
+	case types.GrantAuthorizationCode:
+		return o.exchangeCode(ctx, client, option)
+	case types.GrantRefreshToken:
+		return o.refresh(ctx, client, option)
+	default:
+		return o.clientCredentials(ctx, client, option)
+	}
+}
+
+// Revoke revokes the token issued to the client, invalid tokens are ignored as RFC 7009 required.
+func (o OAuthServerHandler) Revoke(ctx context.Context, option types.OAuthRevokeOptions) error {
+	client, err := o.authenticateClient(ctx, option.ClientId, option.ClientSecret)
+	if err != nil {
+		return err
+	}
+
+	if option.TokenTypeHint != "refresh_token" {
+		access, err := o.Token.VerifyAccess(ctx, option.Token)
+		if err == nil {
+			if !slices.Contains(access.Claims.Audience, client.ClientID) {
+				return nil
+			}
+			return o.revokeToken(ctx, access, token.Token{})
+		}
+	}
+
+	refresh, err := o.Token.VerifyRefresh(ctx, option.Token)
+	if err != nil || !slices.Contains(refresh.Claims.Audience, client.ClientID) {
+		return nil
+	}
+	return o.revokeToken(ctx, token.Token{}, refresh)
+}
+
+func (o OAuthServerHandler) exchangeCode(ctx context.Context, client *ent.OAuthClient, option types.OAuthTokenOptions) (types.OAuthTokenResult, error) {
+	if option.Code == "" || option.CodeVerifier == "" {
+		return types.OAuthTokenResult{}, types.ErrInvalidRequest.Describe("code and code_verifier are required")
+	}
+	authCode, err := o.AuthCodeCache.GetDel(ctx, option.Code)
+	if errors.Is(err, redis.Nil) {
+		return types.OAuthTokenResult{}, types.ErrInvalidGrant
+	} else if err != nil {
+		return types.OAuthTokenResult{}, types.ErrServerError
+	}
+
+	// code is bound to the client, redirect uri and code challenge
+	if authCode.ClientId != client.ClientID || authCode.RedirectURI != option.RedirectURI ||
+		subtle.ConstantTimeCompare([]byte(authCode.Challenge), []byte(pkceChallenge(option.CodeVerifier))) != 1 {
+		return types.OAuthTokenResult{}, types.ErrInvalidGrant
+	}
+
+	queryUser, err := o.UserRepo.FindByUID(ctx, authCode.Uid)
+	if ent.IsNotFound(err) || (err == nil && queryUser.Status != user.StatusActive) {
+		return types.OAuthTokenResult{}, types.ErrInvalidGrant
+	} else if err != nil {
+		return types.OAuthTokenResult{}, types.ErrServerError
+	}
+
+	payload := map[string]any{
+		"username":             queryUser.Username,
+		"uid":                  queryUser.UID,
+		types.ClientPayloadKey: client.ClientID,
+	}
+	refresh := slices.Contains(client.GrantTypes, types.GrantRefreshToken)
+	return o.issue(ctx, client, payload, refresh, authCode.Scope)
+}
+
+func (o OAuthServerHandler) refresh(ctx context.Context, client *ent.OAuthClient, option types.OAuthTokenOptions) (types.OAuthTokenResult, error) {
+	if option.RefreshToken == "" {
+		return types.OAuthTokenResult{}, types.ErrInvalidRequest.Describe("refresh_token is required")
+	}
+	// the rotated refresh token is also checked, reusing it should revoke its family
+	refreshToken, err := o.Token.VerifyRefresh(ctx, option.RefreshToken)
+	if refreshToken.Claims.ID == "" || !slices.Contains(refreshToken.Claims.Audience, client.ClientID) {
+		return types.OAuthTokenResult{}, types.ErrInvalidGrant
+	} else if err != nil && !errors.Is(err, token.ErrRefreshTokenExpired) {
+		return types.OAuthTokenResult{}, types.ErrInvalidGrant
+	}
+
+	pair, err := o.Token.RefreshWithoutAccess(ctx, option.RefreshToken)
+	if err != nil {
+		// status error is only returned when cache failed
+		var statusErr statuserr.Error
+		if errors.As(err, &statusErr) {
+			return types.OAuthTokenResult{}, types.ErrServerError
+		}
+		return types.OAuthTokenResult{}, types.ErrInvalidGrant
+	}
+	return tokenResult(pair), nil
+}
+
+func (o OAuthServerHandler) clientCredentials(ctx context.Context, client *ent.OAuthClient, option types.OAuthTokenOptions) (types.OAuthTokenResult, error) {
+	scope, ok := grantScope(client, option.Scope)
+	if !ok {
+		return types.OAuthTokenResult{}, types.ErrInvalidScope
+	}
+	payload := map[string]any{
+		types.ClientPayloadKey: client.ClientID,
+	}
+	return o.issue(ctx, client, payload, false, scope)
+}
+
+func (o OAuthServerHandler) issue(ctx context.Context, client *ent.OAuthClient, payload map[string]any, refresh bool, scope string) (types.OAuthTokenResult, error) {
+	pair, err := o.Token.IssueFor(ctx, payload, refresh, token.Grant{Scope: scope, Audience: []string{client.ClientID}})
+	if err != nil {
+		return types.OAuthTokenResult{}, types.ErrServerError
+	}
+	return tokenResult(pair), nil
+}
+
+func (o OAuthServerHandler) revokeToken(ctx context.Context, access, refresh token.Token) error {
+	if err := o.Token.Revoke(ctx, access, refresh); err != nil {
+		return types.ErrServerError
+	}
+	return nil
+}
+
+// checkAuthorize validates the authorization request, and returns the client and the scope to be granted
+func (o OAuthServerHandler) checkAuthorize(ctx context.Context, option types.AuthorizeOptions) (*ent.OAuthClient, string, error) {
+	client, err := o.OAuthClientRepo.FindByClientID(ctx, option.ClientId)
+	if ent.IsNotFound(err) {
+		return nil, "", types.ErrOAuthClientNotFound
+	} else if err != nil {
+		return nil, "", statuserr.InternalError(err)
+	}
+
+	if !slices.Contains(client.RedirectUris, option.RedirectURI) {
+		return nil, "", types.ErrOAuthRedirectInvalid
+	}
+	if !slices.Contains(client.GrantTypes, types.GrantAuthorizationCode) {
+		return nil, "", types.ErrOAuthGrantInvalid
+	}
+	// only authorization code flow with S256 PKCE is supported
+	if option.ResponseType != "code" || option.CodeChallengeMethod != "S256" {
+		return nil, "", types.ErrOAuthRequestInvalid
+	}
+	scope, ok := grantScope(client, option.Scope)
+	if !ok {
+		return nil, "", types.ErrOAuthScopeInvalid
+	}
+	return client, scope, nil
+}
+
+// authenticateClient checks the client credentials, public clients are identified by client id only.
+func (o OAuthServerHandler) authenticateClient(ctx context.Context, clientId, secret string) (*ent.OAuthClient, error) {
+	if clientId == "" {
+		return nil, types.ErrInvalidClient
+	}
+	client, err := o.OAuthClientRepo.FindByClientID(ctx, clientId)
+	if ent.IsNotFound(err) {
+		return nil, types.ErrInvalidClient
+	} else if err != nil {
+		return nil, types.ErrServerError
+	}
+	if client.Public {
+		return client, nil
+	}
+	if secret == "" || subtle.ConstantTimeCompare([]byte(client.SecretHash), []byte(hashClientSecret(secret))) != 1 {
+		return nil, types.ErrInvalidClient
+	}
+	return client, nil
+}
+
+func (o OAuthServerHandler) findUser(ctx context.Context, uid string) (*ent.User, error) {
+	queryUser, err := o.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		return nil, types.ErrUserNotFund
+	} else if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	return queryUser, nil
+}
+
+// grantScope returns the requested scope if all of them are allowed for client, or all allowed scopes if nothing requested.
+func grantScope(client *ent.OAuthClient, requested string) (string, bool) {
+	scopes := strings.Fields(requested)
+	if len(scopes) == 0 {
+		return strings.Join(client.Scopes, " "), true
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(client.Scopes, scope) {
+			return "", false
+		}
+	}
+	return strings.Join(scopes, " "), true
+}
+
+func tokenResult(pair token.Pair) types.OAuthTokenResult {
+	return types.OAuthTokenResult{
+		AccessToken:  pair.Access.Raw,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(pair.Access.Claims.ExpiresAt.Sub(ts.Now()).Seconds()),
+		RefreshToken: pair.Refresh.Raw,
+		Scope:        pair.Access.Claims.Scope,
+	}
+}
+
+// withQuery appends query to the redirect uri, the existing query of uri is kept.
+func withQuery(uri string, query url.Values) string {
+	if strings.Contains(uri, "?") {
+		return uri + "&" + query.Encode()
+	}
+	return uri + "?" + query.Encode()
+}
+
+// pkceChallenge returns the S256 code challenge of verifier
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// client secrets are random, so sha256 is sufficient.
+func hashClientSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
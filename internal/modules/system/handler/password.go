@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/internal/conf"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/passwd"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"golang.org/x/net/context"
+)
+
+// PasswordHandler is responsible for changing passwords of existing users. New passwords are checked against the policy
+// and recent passwords of the user, previous passwords are recorded as history when changed.
+type PasswordHandler struct {
+	PasswordHistoryRepo repo.PasswordHistoryRepo
+	Hasher              *passwd.Hasher
+	Policy              *passwd.Policy
+	Config              conf.Password
+}
+
+// Check returns PasswordPolicyError if the new password contains personal information of the user or has been used recently.
+// The rest rules are checked by the validator on params, only the rules depending on username and email are checked again,
+// since params may not carry them.
+func (p PasswordHandler) Check(ctx context.Context, queryUser *ent.User, password string) error {
+	violations := p.Policy.CheckPersonal(password, queryUser.Username, queryUser.Email)
+	reused, err := p.reused(ctx, queryUser, password)
+	if err != nil {
+		return statuserr.InternalError(err)
+	} else if reused {
+		violations = append(violations, passwd.Violation{
+			Rule:    passwd.RuleHistory,
+			Message: fmt.Sprintf("password must not be the same as any of the last %d passwords", p.Config.Policy.History),
+		})
+	}
+	if len(violations) > 0 {
+		return types.PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// Update hashes the new password and updates it, the previous one is recorded into history
+func (p PasswordHandler) Update(ctx context.Context, queryUser *ent.User, password string) (*ent.User, error) {
+	return p.UpdateAccount(ctx, queryUser, "", "", password)
+}
+
+// UpdateAccount updates username, email and password of the user at once, empty values are left unchanged.
+// The email set by others is unverified, and the password is updated as Update does if not empty.
+func (p PasswordHandler) UpdateAccount(ctx context.Context, queryUser *ent.User, username, email, password string) (*ent.User, error) {
+	var hashPasswd string
+	if password != "" {
+		hashed, err := p.Hasher.Hash(password)
+		if err != nil {
+			return nil, statuserr.InternalError(err)
+		}
+		hashPasswd = hashed
+	}
+	updated, err := p.PasswordHistoryRepo.UpdateAccount(ctx, queryUser, username, email, hashPasswd, p.Config.Policy.History-1)
+	if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	return updated, nil
+}
+
+// reused reports whether the password matches the current one or the recent ones in history
+func (p PasswordHandler) reused(ctx context.Context, queryUser *ent.User, password string) (bool, error) {
+	if p.Config.Policy.History <= 0 {
+		return false, nil
+	}
+	hashes := []string{queryUser.Password}
+	if p.Config.Policy.History > 1 {
+		histories, err := p.PasswordHistoryRepo.ListRecent(ctx, queryUser.ID, p.Config.Policy.History-1)
+		if err != nil {
+			return false, err
+		}
+		for _, history := range histories {
+			hashes = append(hashes, history.Password)
+		}
+	}
+
+	for _, hash := range hashes {
+		// users imported without password or anonymized have no password
+		if hash == "" {
+			continue
+		}
+		match, _, err := p.Hasher.Verify(password, hash)
+		if err != nil {
+			return false, err
+		} else if match {
+			return true, nil
+		}
+	}
+	return false, nil
+}
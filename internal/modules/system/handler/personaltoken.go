@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/internal/conf"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/apikey"
+	"github.com/ginx-contribs/ginx-server/pkg/logh"
+	"github.com/ginx-contribs/ginx-server/pkg/token"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/net/context"
+	"slices"
+	"strings"
+	"time"
+)
+
+// last used time is updated at most once in this interval
+const lastUsedInterval = time.Minute
+
+// PersonalTokenHandler is responsible for long-lived personal access tokens used by machine clients
+type PersonalTokenHandler struct {
+	UserRepo          repo.UserRepo
+	PersonalTokenRepo repo.PersonalTokenRepo
+	Config            conf.PersonalToken
+	OAuthServer       conf.OAuthServer
+}
+
+// Create creates a new token for the user, the token is only returned once.
+func (p PersonalTokenHandler) Create(ctx context.Context, uid string, option types.PersonalTokenCreateOptions) (types.PersonalTokenCreateResult, error) {
+	queryUser, err := p.findUser(ctx, uid)
+	if err != nil {
+		return types.PersonalTokenCreateResult{}, err
+	}
+
+	for _, scope := range option.Scopes {
+		if !p.validScope(scope) {
+			return types.PersonalTokenCreateResult{}, types.ErrOAuthScopeInvalid
+		}
+	}
+	expiresIn := time.Duration(option.ExpiresIn) * time.Second
+	if maxExpire := p.Config.MaxExpire.Duration(); maxExpire > 0 && (expiresIn == 0 || expiresIn > maxExpire) {
+		return types.PersonalTokenCreateResult{}, types.ErrPersonalTokenExpireInvalid
+	}
+
+	if p.Config.Max > 0 {
+		count, err := p.PersonalTokenRepo.CountByUserID(ctx, queryUser.ID)
+		if err != nil {
+			return types.PersonalTokenCreateResult{}, statuserr.InternalError(err)
+		} else if count >= p.Config.Max {
+			return types.PersonalTokenCreateResult{}, types.ErrPersonalTokenLimit
+		}
+	}
+
+	key, keyId, hash, err := apikey.New()
+	if err != nil {
+		return types.PersonalTokenCreateResult{}, statuserr.InternalError(err)
+	}
+	var expiresAt int64
+	if expiresIn > 0 {
+		expiresAt = ts.Now().Add(expiresIn).UnixMicro()
+	}
+	created, err := p.PersonalTokenRepo.Create(ctx, queryUser.ID, ent.PersonalToken{
+		KeyID:      keyId,
+		SecretHash: hash,
+		Name:       option.Name,
+		Scopes:     option.Scopes,
+		ExpiresAt:  expiresAt,
+	})
+	if err != nil {
+		return types.PersonalTokenCreateResult{}, statuserr.InternalError(err)
+	}
+	return types.PersonalTokenCreateResult{PersonalTokenInfo: types.EntToPersonalToken(created), Token: key}, nil
+}
+
+// List returns tokens of the user
+func (p PersonalTokenHandler) List(ctx context.Context, uid string) ([]types.PersonalTokenInfo, error) {
+	queryUser, err := p.findUser(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := p.PersonalTokenRepo.ListByUserID(ctx, queryUser.ID)
+	if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	return types.EntsToPersonalTokens(tokens), nil
+}
+
+// Revoke removes the token of the user
+func (p PersonalTokenHandler) Revoke(ctx context.Context, uid, keyId string) error {
+	queryUser, err := p.findUser(ctx, uid)
+	if err != nil {
+		return err
+	}
+	removed, err := p.PersonalTokenRepo.RemoveByKeyID(ctx, queryUser.ID, keyId)
+	if err != nil {
+		return statuserr.InternalError(err)
+	} else if removed == 0 {
+		return types.ErrPersonalTokenNotFound
+	}
+	return nil
+}
+
+// Verify checks the key, and returns an equivalent access token, so that handlers could treat it as a login user.
+func (p PersonalTokenHandler) Verify(ctx context.Context, key string) (token.Token, error) {
+	keyId, secret, ok := apikey.Parse(key)
+	if !ok {
+		return token.Token{}, types.ErrCredentialInvalid
+	}
+	found, err := p.PersonalTokenRepo.FindByKeyID(ctx, keyId)
+	if ent.IsNotFound(err) {
+		return token.Token{}, types.ErrCredentialInvalid
+	} else if err != nil {
+		return token.Token{}, statuserr.InternalError(err)
+	}
+	if !apikey.Verify(secret, found.SecretHash) {
+		return token.Token{}, types.ErrCredentialInvalid
+	} else if found.Edges.User == nil {
+		return token.Token{}, types.ErrCredentialInvalid
+	} else if err := statusError(found.Edges.User.Status); err != nil {
+		return token.Token{}, err
+	}
+
+	now := ts.Now()
+	if found.ExpiresAt > 0 && found.ExpiresAt <= now.UnixMicro() {
+		return token.Token{}, token.ErrAccessTokenExpired
+	}
+	logh.NoError("update access token last used failed",
+		p.PersonalTokenRepo.TouchUsed(ctx, found.ID, now.UnixMicro(), now.Add(-lastUsedInterval).UnixMicro()))
+
+	claims := token.Claims{
+		Payload: map[string]any{
+			"username":                    found.Edges.User.Username,
+			"uid":                         found.Edges.User.UID,
+			types.PersonalTokenPayloadKey: found.KeyID,
+		},
+		Scope: strings.Join(found.Scopes, " "),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:  found.Edges.User.UID,
+			ID:       found.KeyID,
+			IssuedAt: jwt.NewNumericDate(ts.FromUnixMicro(found.CreatedAt)),
+		},
+	}
+	if found.ExpiresAt > 0 {
+		claims.ExpiresAt = jwt.NewNumericDate(ts.FromUnixMicro(found.ExpiresAt))
+	}
+	return token.Token{Raw: key, Claims: claims}, nil
+}
+
+// validScope returns true if the scope is an oauth2 scope, a personal token scope or a permission name
+func (p PersonalTokenHandler) validScope(scope string) bool {
+	if slices.Contains(p.OAuthServer.Scopes, scope) || slices.Contains(types.PersonalTokenScopes, scope) {
+		return true
+	}
+	return slices.ContainsFunc(types.Permissions, func(permission types.PermissionInfo) bool {
+		return permission.Name == scope
+	})
+}
+
+func (p PersonalTokenHandler) findUser(ctx context.Context, uid string) (*ent.User, error) {
+	queryUser, err := p.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		return nil, types.ErrUserNotFund
+	} else if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	return queryUser, nil
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/internal/conf"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"golang.org/x/net/context"
+	"log/slog"
+	"slices"
+)
+
+// RoleHandler is responsible for roles, permissions and role assignments
+type RoleHandler struct {
+	UserRepo repo.UserRepo
+	RoleRepo repo.RoleRepo
+	Config   conf.RBAC
+}
+
+// Seed synchronizes the permission catalog and the builtin admin role, then grants it to the configured admins.
+func (r RoleHandler) Seed(ctx context.Context) error {
+	var perms []ent.Permission
+	for _, perm := range types.Permissions {
+		perms = append(perms, ent.Permission{Name: perm.Name, Description: perm.Description})
+	}
+	admin, err := r.RoleRepo.Seed(ctx, types.AdminRole, perms)
+	if err != nil {
+		return err
+	}
+	if len(r.Config.Admins) > 0 {
+		granted, err := r.RoleRepo.GrantByUsernames(ctx, admin.ID, r.Config.Admins)
+		if err != nil {
+			return err
+		} else if granted > 0 {
+			slog.Info("admin role granted", slog.Int("users", granted))
+		}
+	}
+	return nil
+}
+
+// Permissions returns names of all permissions granted to the user
+func (r RoleHandler) Permissions(ctx context.Context, uid string) ([]string, error) {
+	return r.RoleRepo.PermissionsByUID(ctx, uid)
+}
+
+// ListPermissions returns the permission catalog
+func (r RoleHandler) ListPermissions(ctx context.Context) ([]types.PermissionInfo, error) {
+	perms, err := r.RoleRepo.ListPermissions(ctx)
+	if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	return types.EntsToPermissions(perms), nil
+}
+
+// ListRoles returns all roles
+func (r RoleHandler) ListRoles(ctx context.Context) ([]types.RoleInfo, error) {
+	roles, err := r.RoleRepo.List(ctx)
+	if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	return types.EntsToRoles(roles), nil
+}
+
+// CreateRole creates a new role with the given permissions
+func (r RoleHandler) CreateRole(ctx context.Context, option types.RoleCreateOptions) (types.RoleInfo, error) {
+	_, err := r.RoleRepo.FindByName(ctx, option.Name)
+	if err == nil {
+		return types.RoleInfo{}, types.ErrRoleAlreadyExists
+	} else if !ent.IsNotFound(err) {
+		return types.RoleInfo{}, statuserr.InternalError(err)
+	}
+
+	var permissionIds []int
+	if len(option.Permissions) > 0 {
+		perms, err := r.RoleRepo.FindPermissions(ctx, option.Permissions)
+		if err != nil {
+			return types.RoleInfo{}, statuserr.InternalError(err)
+		}
+		for _, perm := range perms {
+			permissionIds = append(permissionIds, perm.ID)
+		}
+		if len(perms) != len(slices.Compact(slices.Sorted(slices.Values(option.Permissions)))) {
+			return types.RoleInfo{}, types.ErrPermissionNotFound
+		}
+	}
+
+	created, err := r.RoleRepo.Create(ctx, ent.Role{Name: option.Name, Description: option.Description}, permissionIds)
+	if ent.IsConstraintError(err) {
+		return types.RoleInfo{}, types.ErrRoleAlreadyExists
+	} else if err != nil {
+		return types.RoleInfo{}, statuserr.InternalError(err)
+	}
+	created, err = r.RoleRepo.FindByName(ctx, created.Name)
+	if err != nil {
+		return types.RoleInfo{}, statuserr.InternalError(err)
+	}
+	return types.EntToRole(created), nil
+}
+
+// DeleteRole deletes the role, builtin role could not be deleted
+func (r RoleHandler) DeleteRole(ctx context.Context, name string) error {
+	found, err := r.findRole(ctx, name)
+	if err != nil {
+		return err
+	} else if found.Builtin {
+		return types.ErrRoleBuiltin
+	}
+	if _, err := r.RoleRepo.RemoveByName(ctx, name); err != nil {
+		return statuserr.InternalError(err)
+	}
+	return nil
+}
+
+// ListUserRoles returns roles granted to the user
+func (r RoleHandler) ListUserRoles(ctx context.Context, uid string) ([]types.RoleInfo, error) {
+	queryUser, err := r.findUser(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := r.RoleRepo.ListByUserID(ctx, queryUser.ID)
+	if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	return types.EntsToRoles(roles), nil
+}
+
+// Grant grants the role to the user
+func (r RoleHandler) Grant(ctx context.Context, uid, roleName string) error {
+	queryUser, err := r.findUser(ctx, uid)
+	if err != nil {
+		return err
+	}
+	found, err := r.findRole(ctx, roleName)
+	if err != nil {
+		return err
+	}
+	if err := r.RoleRepo.Grant(ctx, queryUser.ID, found.ID); err != nil {
+		return statuserr.InternalError(err)
+	}
+	return nil
+}
+
+// Revoke removes the role from the user
+func (r RoleHandler) Revoke(ctx context.Context, uid, roleName string) error {
+	queryUser, err := r.findUser(ctx, uid)
+	if err != nil {
+		return err
+	}
+	found, err := r.findRole(ctx, roleName)
+	if err != nil {
+		return err
+	}
+	if err := r.RoleRepo.Revoke(ctx, queryUser.ID, found.ID); err != nil {
+		return statuserr.InternalError(err)
+	}
+	return nil
+}
+
+func (r RoleHandler) findRole(ctx context.Context, name string) (*ent.Role, error) {
+	found, err := r.RoleRepo.FindByName(ctx, name)
+	if ent.IsNotFound(err) {
+		return nil, types.ErrRoleNotFound
+	} else if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	return found, nil
+}
+
+func (r RoleHandler) findUser(ctx context.Context, uid string) (*ent.User, error) {
+	queryUser, err := r.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		return nil, types.ErrUserNotFund
+	} else if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	return queryUser, nil
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/internal/conf"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/logh"
+	"github.com/ginx-contribs/ginx-server/pkg/token"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/net/context"
+	"time"
+)
+
+// last seen time is updated at most once in this interval
+const lastSeenInterval = time.Minute
+
+// SessionHandler is responsible for recording where users are logged in
+type SessionHandler struct {
+	SessionRepo      repo.SessionRepo
+	LoginHistoryRepo repo.LoginHistoryRepo
+	Token            *token.Resolver
+	Config           conf.Session
+}
+
+// Create records the issued token pair as a new session and the login into history,
+// the oldest sessions will be evicted if over limit.
+func (s SessionHandler) Create(ctx context.Context, queryUser *ent.User, sid string, pair token.Pair, client types.ClientInfo) error {
+	// clean up expired sessions
+	if _, err := s.SessionRepo.RemoveExpired(ctx, queryUser.ID); err != nil {
+		return statuserr.InternalError(err)
+	}
+	if err := s.recordLogin(ctx, queryUser, sid, client); err != nil {
+		return err
+	}
+
+	_, err := s.SessionRepo.CreateSession(ctx, &ent.Session{
+		Sid:       sid,
+		UserID:    queryUser.ID,
+		AccessID:  pair.Access.Claims.ID,
+		RefreshID: pair.Refresh.Claims.ID,
+		UserAgent: client.UserAgent,
+		IP:        client.IP,
+		Remember:  pair.Access.Claims.Remember,
+		ExpiresAt: sessionExpiresAt(pair),
+	})
+	if err != nil {
+		return statuserr.InternalError(err)
+	}
+
+	if s.Config.Max <= 0 {
+		return nil
+	}
+
+	// evict the oldest sessions
+	sessions, err := s.SessionRepo.ListByUserID(ctx, queryUser.ID)
+	if err != nil {
+		return statuserr.InternalError(err)
+	}
+	if len(sessions) <= s.Config.Max {
+		return nil
+	}
+	return s.terminate(ctx, queryUser.UID, sessions[s.Config.Max:]...)
+}
+
+// Touch updates the session with the refreshed token pair
+func (s SessionHandler) Touch(ctx context.Context, sid string, pair token.Pair) error {
+	if sid == "" {
+		return nil
+	}
+	_, err := s.SessionRepo.UpdateTokens(ctx, sid, pair.Access.Claims.ID, pair.Refresh.Claims.ID, sessionExpiresAt(pair))
+	if err != nil {
+		return statuserr.InternalError(err)
+	}
+	return nil
+}
+
+// Seen marks the session of the access token as seen, it is best-effort and throttled by lastSeenInterval.
+func (s SessionHandler) Seen(ctx context.Context, access token.Token) {
+	sid, _ := access.Claims.Payload[types.SessionPayloadKey].(string)
+	if sid == "" {
+		return
+	}
+	now := ts.Now()
+	logh.NoError("update session last seen failed",
+		s.SessionRepo.TouchSeen(ctx, sid, now.UnixMicro(), now.Add(-lastSeenInterval).UnixMicro()))
+}
+
+// ListByUID returns all active sessions of the specified user
+func (s SessionHandler) ListByUID(ctx context.Context, uid, currentSid string) ([]types.SessionInfo, error) {
+	sessions, err := s.SessionRepo.ListByUID(ctx, uid)
+	if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	infos := types.EntsToSessions(sessions)
+	for i := range infos {
+		infos[i].Current = infos[i].Id == currentSid
+	}
+	return infos, nil
+}
+
+// Terminate revokes tokens of the specified session, then removes it.
+func (s SessionHandler) Terminate(ctx context.Context, uid, sid string) error {
+	record, err := s.SessionRepo.FindUserSession(ctx, uid, sid)
+	if ent.IsNotFound(err) {
+		return types.ErrSessionNotFound
+	} else if err != nil {
+		return statuserr.InternalError(err)
+	}
+	return s.terminate(ctx, uid, record)
+}
+
+// Remove just removes the session record, tokens should be revoked by caller
+func (s SessionHandler) Remove(ctx context.Context, sid string) error {
+	if sid == "" {
+		return nil
+	}
+	if _, err := s.SessionRepo.RemoveBySids(ctx, sid); err != nil {
+		return statuserr.InternalError(err)
+	}
+	return nil
+}
+
+// RemoveAll removes all session records of the specified user, tokens should be revoked by caller
+func (s SessionHandler) RemoveAll(ctx context.Context, uid string) error {
+	if _, err := s.SessionRepo.RemoveByUID(ctx, uid); err != nil {
+		return statuserr.InternalError(err)
+	}
+	return nil
+}
+
+// ListHistoryByUID returns login history of the specified user
+func (s SessionHandler) ListHistoryByUID(ctx context.Context, uid string) ([]types.LoginHistoryInfo, error) {
+	histories, err := s.LoginHistoryRepo.ListByUID(ctx, uid)
+	if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	return types.EntsToLoginHistories(histories), nil
+}
+
+// recordLogin records the login into history, and removes the ones out of retention
+func (s SessionHandler) recordLogin(ctx context.Context, queryUser *ent.User, sid string, client types.ClientInfo) error {
+	if retention := s.Config.HistoryRetention.Duration(); retention > 0 {
+		if _, err := s.LoginHistoryRepo.RemoveBefore(ctx, queryUser.ID, ts.Now().Add(-retention).UnixMicro()); err != nil {
+			return statuserr.InternalError(err)
+		}
+	}
+	_, err := s.LoginHistoryRepo.Create(ctx, &ent.LoginHistory{
+		UserID:    queryUser.ID,
+		Sid:       sid,
+		UserAgent: client.UserAgent,
+		IP:        client.IP,
+	})
+	if err != nil {
+		return statuserr.InternalError(err)
+	}
+	return nil
+}
+
+func (s SessionHandler) terminate(ctx context.Context, uid string, sessions ...*ent.Session) error {
+	var sids []string
+	for _, record := range sessions {
+		access, refresh := sessionTokens(uid, record)
+		if err := s.Token.Revoke(ctx, access, refresh); err != nil {
+			return err
+		}
+		sids = append(sids, record.Sid)
+	}
+	if _, err := s.SessionRepo.RemoveBySids(ctx, sids...); err != nil {
+		return statuserr.InternalError(err)
+	}
+	return nil
+}
+
+// sessionTokens returns the token pair of the session which only has id and subject, it is enough for revoking.
+func sessionTokens(uid string, record *ent.Session) (token.Token, token.Token) {
+	var access, refresh token.Token
+	access.Claims.RegisteredClaims = jwt.RegisteredClaims{ID: record.AccessID, Subject: uid}
+	if record.RefreshID != "" {
+		refresh.Claims.RegisteredClaims = jwt.RegisteredClaims{ID: record.RefreshID, Subject: uid}
+	}
+	return access, refresh
+}
+
+// sessionExpiresAt returns when the session could not be refreshed anymore
+func sessionExpiresAt(pair token.Pair) int64 {
+	expiresAt := pair.Access.Claims.ExpiresAt
+	if pair.Refresh.Claims.ExpiresAt != nil {
+		expiresAt = pair.Refresh.Claims.ExpiresAt
+	}
+	if expiresAt == nil {
+		return 0
+	}
+	return expiresAt.UnixMicro()
+}
@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/internal/conf"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/cache"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/idx"
+	"github.com/ginx-contribs/ginx-server/pkg/totp"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/net/context"
+	"strings"
+	"time"
+)
+
+// TwoFAHandler is responsible for TOTP two-factor authentication
+type TwoFAHandler struct {
+	UserRepo         repo.UserRepo
+	RecoveryCodeRepo repo.RecoveryCodeRepo
+	TwoFACache       cache.TwoFACache
+	Config           conf.TwoFA
+}
+
+// Setup generates a new pending totp secret for the user, it takes effect after enabled.
+func (t TwoFAHandler) Setup(ctx context.Context, uid string) (types.TwoFASetupResult, error) {
+	queryUser, err := t.findUser(ctx, uid)
+	if err != nil {
+		return types.TwoFASetupResult{}, err
+	} else if queryUser.TotpEnabled {
+		return types.TwoFASetupResult{}, types.ErrTwoFAAlreadyEnabled
+	}
+
+	otp := t.totp()
+	secret, err := otp.Secret()
+	if err != nil {
+		return types.TwoFASetupResult{}, statuserr.InternalError(err)
+	}
+	if _, err := t.UserRepo.UpdateTotpSecret(ctx, queryUser.ID, secret); err != nil {
+		return types.TwoFASetupResult{}, statuserr.InternalError(err)
+	}
+	return types.TwoFASetupResult{
+		Secret: secret,
+		URI:    otp.URI(t.Config.Issuer, queryUser.Username, secret),
+	}, nil
+}
+
+// Enable confirms the pending secret with the first code, then returns new recovery codes.
+func (t TwoFAHandler) Enable(ctx context.Context, uid, code string) (types.TwoFAEnableResult, error) {
+	queryUser, err := t.findUser(ctx, uid)
+	if err != nil {
+		return types.TwoFAEnableResult{}, err
+	} else if queryUser.TotpEnabled {
+		return types.TwoFAEnableResult{}, types.ErrTwoFAAlreadyEnabled
+	} else if queryUser.TotpSecret == "" {
+		return types.TwoFAEnableResult{}, types.ErrTwoFANotSetup
+	}
+
+	if err := t.checkLimitedCode(ctx, queryUser, code); err != nil {
+		return types.TwoFAEnableResult{}, err
+	}
+
+	codes, err := t.newRecoveryCodes(ctx, queryUser.ID)
+	if err != nil {
+		return types.TwoFAEnableResult{}, err
+	}
+	if _, err := t.UserRepo.UpdateTotpEnabled(ctx, queryUser.ID, true); err != nil {
+		return types.TwoFAEnableResult{}, statuserr.InternalError(err)
+	}
+	return types.TwoFAEnableResult{RecoveryCodes: codes}, nil
+}
+
+// Disable turns off 2fa with a current code, secret and recovery codes will be removed.
+func (t TwoFAHandler) Disable(ctx context.Context, uid, code string) error {
+	queryUser, err := t.findUser(ctx, uid)
+	if err != nil {
+		return err
+	} else if !queryUser.TotpEnabled {
+		return types.ErrTwoFANotEnabled
+	}
+
+	if err := t.checkLimitedCode(ctx, queryUser, code); err != nil {
+		return err
+	}
+
+	if _, err := t.UserRepo.UpdateTotpEnabled(ctx, queryUser.ID, false); err != nil {
+		return statuserr.InternalError(err)
+	}
+	if _, err := t.RecoveryCodeRepo.RemoveByUserID(ctx, queryUser.ID); err != nil {
+		return statuserr.InternalError(err)
+	}
+	return nil
+}
+
+// Challenge creates a short-lived challenge for the user who has passed the first factor
+func (t TwoFAHandler) Challenge(ctx context.Context, queryUser *ent.User, remember bool) (string, error) {
+	id := idx.Secret(32)
+	challenge := types.TwoFAChallenge{Uid: queryUser.UID, Remember: remember}
+	if err := t.TwoFACache.SetChallenge(ctx, id, challenge, t.Config.ChallengeTTL.Duration()); err != nil {
+		return "", statuserr.InternalError(err)
+	}
+	return id, nil
+}
+
+// Verify checks the challenge with a totp code or recovery code, the challenge is consumed if succeeds,
+// or attempts are exhausted.
+func (t TwoFAHandler) Verify(ctx context.Context, challengeId, code string) (*ent.User, types.TwoFAChallenge, error) {
+	challenge, attempts, err := t.TwoFACache.GetChallenge(ctx, challengeId)
+	if errors.Is(err, redis.Nil) {
+		return nil, challenge, types.ErrTwoFAChallengeInvalid
+	} else if err != nil {
+		return nil, challenge, statuserr.InternalError(err)
+	}
+
+	if t.Config.MaxAttempts > 0 && attempts > int64(t.Config.MaxAttempts) {
+		if err := t.TwoFACache.DelChallenge(ctx, challengeId); err != nil {
+			return nil, challenge, statuserr.InternalError(err)
+		}
+		return nil, challenge, types.ErrTwoFAChallengeInvalid
+	}
+
+	queryUser, err := t.findUser(ctx, challenge.Uid)
+	if err != nil {
+		return nil, challenge, err
+	} else if !queryUser.TotpEnabled {
+		return nil, challenge, types.ErrTwoFAChallengeInvalid
+	}
+
+	// totp code is numeric, otherwise try as recovery code
+	if err := t.checkCode(ctx, queryUser, code); errors.Is(err, types.ErrTwoFACodeInvalid) {
+		used, err := t.RecoveryCodeRepo.UseCode(ctx, queryUser.ID, hashRecoveryCode(code))
+		if err != nil {
+			return nil, challenge, statuserr.InternalError(err)
+		} else if used == 0 {
+			return nil, challenge, types.ErrTwoFACodeInvalid
+		}
+	} else if err != nil {
+		return nil, challenge, err
+	}
+
+	if err := t.TwoFACache.DelChallenge(ctx, challengeId); err != nil {
+		return nil, challenge, statuserr.InternalError(err)
+	}
+	return queryUser, challenge, nil
+}
+
+// checkLimitedCode validates the totp code as checkCode does, the user is rejected after max attempts
+// until challenge ttl passes, attempts are forgotten once succeeds.
+func (t TwoFAHandler) checkLimitedCode(ctx context.Context, queryUser *ent.User, code string) error {
+	if t.Config.MaxAttempts <= 0 {
+		return t.checkCode(ctx, queryUser, code)
+	}
+	attempts, err := t.TwoFACache.IncrAttempts(ctx, queryUser.UID, t.Config.ChallengeTTL.Duration())
+	if err != nil {
+		return statuserr.InternalError(err)
+	} else if attempts > int64(t.Config.MaxAttempts) {
+		return types.ErrTwoFATooManyAttempts
+	}
+	if err := t.checkCode(ctx, queryUser, code); err != nil {
+		return err
+	}
+	if err := t.TwoFACache.DelAttempts(ctx, queryUser.UID); err != nil {
+		return statuserr.InternalError(err)
+	}
+	return nil
+}
+
+// checkCode validates the totp code, a step could not be used again, neither could the earlier ones.
+func (t TwoFAHandler) checkCode(ctx context.Context, queryUser *ent.User, code string) error {
+	otp := t.totp()
+	step, ok, err := otp.Validate(queryUser.TotpSecret, code, time.Now())
+	if err != nil {
+		return statuserr.InternalError(err)
+	} else if !ok {
+		return types.ErrTwoFACodeInvalid
+	}
+	// prevent replay in the validation window
+	fresh, err := t.TwoFACache.UseStep(ctx, queryUser.UID, step, 5*time.Minute)
+	if err != nil {
+		return statuserr.InternalError(err)
+	} else if !fresh {
+		return types.ErrTwoFACodeInvalid
+	}
+	return nil
+}
+
+// newRecoveryCodes replaces the recovery codes of user, only hashes are stored.
+func (t TwoFAHandler) newRecoveryCodes(ctx context.Context, userId int) ([]string, error) {
+	var codes, hashes []string
+	for range t.Config.RecoveryCodes {
+		// 10 base32 characters, formatted as xxxxx-xxxxx
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, statuserr.InternalError(err)
+		}
+		code := strings.ToLower(base32.StdEncoding.EncodeToString(b))[:10]
+		code = code[:5] + "-" + code[5:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	if err := t.RecoveryCodeRepo.ReplaceCodes(ctx, userId, hashes); err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	return codes, nil
+}
+
+func (t TwoFAHandler) findUser(ctx context.Context, uid string) (*ent.User, error) {
+	queryUser, err := t.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		return nil, types.ErrUserNotFund
+	} else if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	return queryUser, nil
+}
+
+func (t TwoFAHandler) totp() *totp.TOTP {
+	return totp.New(totp.Options{})
+}
+
+// recovery codes have enough entropy, so sha256 is sufficient.
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
@@ -2,15 +2,24 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"entgo.io/ent/dialect/sql"
+	"errors"
 	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+	"github.com/ginx-contribs/ginx-server/internal/common/types"
 	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
 	types2 "github.com/ginx-contribs/ginx-server/internal/modules/system/types"
-	"github.com/ginx-contribs/ginx/constant/status"
+	"github.com/ginx-contribs/ginx-server/pkg/logh"
+	"github.com/ginx-contribs/ginx-server/pkg/passwd"
 	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"strings"
 )
 
 type UserHandler struct {
 	UserRepo *repo.UserRepo
+	Hasher   *passwd.Hasher
+	Cursor   *ent.CursorSigner
 }
 
 func (u UserHandler) FindByUID(ctx context.Context, uid string) (types2.UserInfo, error) {
@@ -23,29 +32,162 @@ func (u UserHandler) FindByUID(ctx context.Context, uid string) (types2.UserInfo
 	return types2.EntToUser(record), nil
 }
 
-func (u UserHandler) ListUserByPage(ctx context.Context, page, size int, pattern string) (types2.UserSearchResult, error) {
-	users, err := u.UserRepo.ListByPage(ctx, page, size, pattern)
-	if err != nil {
-		return types2.UserSearchResult{}, statuserr.InternalError(err)
+// FindPublicByUID returns the public profile of the user
+func (u UserHandler) FindPublicByUID(ctx context.Context, uid string) (types2.PublicUserInfo, error) {
+	record, err := u.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		return types2.PublicUserInfo{}, types2.ErrUserNotFund
+	} else if err != nil {
+		return types2.PublicUserInfo{}, statuserr.InternalError(err)
 	}
-	toUsers := types2.EntsToUsers(users)
-	return types2.UserSearchResult{Total: int64(len(users)), List: toUsers}, nil
+	return types2.EntToPublicUser(record), nil
 }
 
-func (u UserHandler) CreateUser(ctx context.Context, username string, email string, password string) (types2.UserInfo, error) {
-	newUser, err := u.UserRepo.CreateNewUser(ctx, username, email, password)
+// UpdateProfile applies the given fields to profile of the user
+func (u UserHandler) UpdateProfile(ctx context.Context, uid string, option types2.UserProfileUpdateOptions) (types2.UserInfo, error) {
+	record, err := u.UserRepo.FindByUID(ctx, uid)
+	if ent.IsNotFound(err) {
+		return types2.UserInfo{}, types2.ErrUserNotFund
+	} else if err != nil {
+		return types2.UserInfo{}, statuserr.InternalError(err)
+	}
+
+	if option.Nickname != nil {
+		record.Nickname = *option.Nickname
+	}
+	if option.AvatarURL != nil {
+		record.AvatarURL = *option.AvatarURL
+	}
+	if option.Bio != nil {
+		record.Bio = *option.Bio
+	}
+	if option.Locale != nil {
+		record.Locale = *option.Locale
+	}
+	if option.Timezone != nil {
+		record.Timezone = *option.Timezone
+	}
+	if option.Metadata != nil {
+		encoded, err := json.Marshal(option.Metadata)
+		if err != nil {
+			return types2.UserInfo{}, statuserr.InternalError(err)
+		} else if len(encoded) > types2.MaxMetadataSize {
+			return types2.UserInfo{}, types2.ErrMetadataTooLarge
+		}
+		record.Metadata = option.Metadata
+	}
+
+	updated, err := u.UserRepo.UpdateProfile(ctx, record)
 	if err != nil {
 		return types2.UserInfo{}, statuserr.InternalError(err)
 	}
-	return types2.EntToUser(newUser), nil
+	return types2.EntToUser(updated), nil
+}
+
+// ListUserByPage lists users matching the options by page, sorted by the given field and direction
+func (u UserHandler) ListUserByPage(ctx context.Context, option types2.SearchUserOptions) (types2.UserSearchResult, error) {
+	filter := userFilter(option.UserFilterOptions)
+	pageList, err := u.UserRepo.ListByPage(ctx, option.Page, option.Size, filter, userOrder(option.Sort, option.Order))
+	if err != nil {
+		logh.NoError("list users failed", err)
+		return types2.UserSearchResult{}, types.ErrInternal
+	}
+
+	total := int64(pageList.PageDetails.Total)
+	return types2.UserSearchResult{
+		Page:       option.Page,
+		Size:       option.Size,
+		Total:      total,
+		TotalPages: (total + int64(option.Size) - 1) / int64(option.Size),
+		List:       types2.EntsToUsers(pageList.List),
+	}, nil
+}
+
+// ListUserByCursor lists users matching the options by cursor, sorted by the given field and direction
+func (u UserHandler) ListUserByCursor(ctx context.Context, option types2.CursorUserOptions) (types.CursorResult[types2.UserInfo], error) {
+	args := option.Args(option.Sort, option.Order)
+	conn, err := u.UserRepo.ListByCursor(ctx, u.Cursor, args, userFilter(option.UserFilterOptions))
+	if err = types.CursorError(err); errors.Is(err, types.ErrInvalidCursor) {
+		return types.CursorResult[types2.UserInfo]{}, err
+	} else if err != nil {
+		logh.NoError("list users by cursor failed", err)
+		return types.CursorResult[types2.UserInfo]{}, types.ErrInternal
+	}
+	return types.CursorResult[types2.UserInfo]{
+		List:     types2.EntsToUsers(conn.List),
+		PageInfo: conn.PageInfo,
+	}, nil
+}
+
+func userFilter(option types2.UserFilterOptions) repo.UserFilter {
+	return repo.UserFilter{
+		Pattern:       option.Search,
+		Status:        user.Status(option.Status),
+		CreatedAfter:  option.CreatedAfter,
+		CreatedBefore: option.CreatedBefore,
+	}
+}
+
+// userSortFields maps sortable fields to order functions of user
+var userSortFields = map[string]func(...sql.OrderTermOption) user.OrderOption{
+	user.FieldID:        user.ByID,
+	user.FieldUsername:  user.ByUsername,
+	user.FieldEmail:     user.ByEmail,
+	user.FieldCreatedAt: user.ByCreatedAt,
+	user.FieldUpdatedAt: user.ByUpdatedAt,
+}
+
+// userOrder returns the order of users by field and direction, id is always used as the tie-breaker so that pages are stable
+func userOrder(field, direction string) user.OrderOption {
+	by, ok := userSortFields[field]
+	if !ok {
+		by = user.ByID
+	}
+	term := sql.OrderDesc()
+	if ent.OrderDirection(strings.ToUpper(direction)) == ent.OrderDirectionAsc {
+		term = sql.OrderAsc()
+	}
+	return func(s *sql.Selector) {
+		by(term)(s)
+		if field != user.FieldID {
+			user.ByID(term)(s)
+		}
+	}
 }
 
-func (u UserHandler) RemoveUser(ctx context.Context, uid string) error {
-	deleted, err := u.UserRepo.RemoveByUID(ctx, uid)
+// CreateUser creates a new user with the plain password, username and email should not be used by others
+func (u UserHandler) CreateUser(ctx context.Context, username string, email string, password string) (*ent.User, error) {
+	if err := u.CheckDuplicate(ctx, 0, username, email); err != nil {
+		return nil, err
+	}
+	hashPasswd, err := u.Hasher.Hash(password)
 	if err != nil {
-		return statuserr.InternalError(err)
-	} else if deleted == 0 {
-		return statuserr.Errorf("expected deleted 1, but got 0, remove user failed: uid %s", uid).SetStatus(status.InternalServerError)
+		return nil, statuserr.InternalError(err)
+	}
+	newUser, err := u.UserRepo.CreateNewUser(ctx, username, email, hashPasswd, 0)
+	if err != nil {
+		return nil, statuserr.InternalError(err)
+	}
+	return newUser, nil
+}
+
+// CheckDuplicate returns error if the username or email is used by users other than the given id, empty values are not checked
+func (u UserHandler) CheckDuplicate(ctx context.Context, id int, username, email string) error {
+	if username != "" {
+		userByName, err := u.UserRepo.FindByName(ctx, username)
+		if !ent.IsNotFound(err) && err != nil {
+			return statuserr.InternalError(err)
+		} else if userByName != nil && userByName.ID != id {
+			return types2.ErrUserAlreadyExists
+		}
+	}
+	if email != "" {
+		userByEmail, err := u.UserRepo.FindByEmail(ctx, email)
+		if !ent.IsNotFound(err) && err != nil {
+			return statuserr.InternalError(err)
+		} else if userByEmail != nil && userByEmail.ID != id {
+			return types2.ErrEmailAlreadyUsed
+		}
 	}
 	return nil
 }
@@ -0,0 +1,29 @@
+package repo
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/ent/auditlog"
+	"golang.org/x/net/context"
+)
+
+type AuditLogRepo struct {
+	DB *ent.Client
+}
+
+// Create records an operation performed by actor on target
+func (a AuditLogRepo) Create(ctx context.Context, actor, action, target, detail string) (*ent.AuditLog, error) {
+	return a.DB.AuditLog.Create().
+		SetActor(actor).
+		SetAction(action).
+		SetTarget(target).
+		SetDetail(detail).
+		Save(ctx)
+}
+
+// ListByTarget returns all operations performed on the target in time order
+func (a AuditLogRepo) ListByTarget(ctx context.Context, target string) ([]*ent.AuditLog, error) {
+	return a.DB.AuditLog.Query().
+		Where(auditlog.TargetEQ(target)).
+		Order(auditlog.ByCreatedAt(), auditlog.ByID()).
+		All(ctx)
+}
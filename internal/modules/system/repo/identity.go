@@ -0,0 +1,88 @@
+package repo
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/ent/identity"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
+	"golang.org/x/net/context"
+)
+
+type IdentityRepo struct {
+	DB *ent.Client
+}
+
+// FindBySubject returns the identity of provider user, with its user loaded
+func (i IdentityRepo) FindBySubject(ctx context.Context, provider, subject string) (*ent.Identity, error) {
+	return i.DB.Identity.Query().
+		Where(
+			identity.ProviderEQ(provider),
+			identity.SubjectEQ(subject),
+		).
+		WithUser().
+		Only(ctx)
+}
+
+// ListByUserID returns all identities linked to the user
+func (i IdentityRepo) ListByUserID(ctx context.Context, userId int) ([]*ent.Identity, error) {
+	return i.DB.Identity.Query().
+		Where(identity.UserIDEQ(userId)).
+		Order(ent.Asc(identity.FieldCreatedAt)).
+		All(ctx)
+}
+
+// Create links the provider user to the user
+func (i IdentityRepo) Create(ctx context.Context, userId int, provider, subject, email string) (*ent.Identity, error) {
+	return i.DB.Identity.Create().
+		SetUserID(userId).
+		SetProvider(provider).
+		SetSubject(subject).
+		SetEmail(email).
+		Save(ctx)
+}
+
+// CreateWithUser creates a new user and links the provider user to it in one transaction,
+// emailVerifiedAt is 0 if the email is not verified by provider.
+func (i IdentityRepo) CreateWithUser(ctx context.Context, username, email string, emailVerifiedAt int64, password, provider, subject string) (*ent.User, error) {
+	var newUser *ent.User
+	err := withTx(ctx, i.DB, func(tx *ent.Tx) error {
+		created, err := tx.User.Create().
+			SetUsername(username).
+			SetEmail(email).
+			SetEmailVerifiedAt(emailVerifiedAt).
+			SetPassword(password).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Identity.Create().
+			SetUserID(created.ID).
+			SetProvider(provider).
+			SetSubject(subject).
+			SetEmail(email).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+		newUser = created
+		return nil
+	})
+	return newUser, err
+}
+
+// TouchLogin updates the last login time and the email reported by provider
+func (i IdentityRepo) TouchLogin(ctx context.Context, id int, email string) error {
+	return i.DB.Identity.UpdateOneID(id).
+		SetEmail(email).
+		SetLastLoginAt(ts.UnixMicro()).
+		Exec(ctx)
+}
+
+// RemoveByProvider unlinks the provider from the user
+func (i IdentityRepo) RemoveByProvider(ctx context.Context, userId int, provider string) (int, error) {
+	return i.DB.Identity.Delete().
+		Where(
+			identity.UserIDEQ(userId),
+			identity.ProviderEQ(provider),
+		).
+		Exec(ctx)
+}
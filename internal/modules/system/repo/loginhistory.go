@@ -0,0 +1,40 @@
+package repo
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/ent/loginhistory"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+	"golang.org/x/net/context"
+)
+
+type LoginHistoryRepo struct {
+	DB *ent.Client
+}
+
+// Create records a successful login
+func (l LoginHistoryRepo) Create(ctx context.Context, record *ent.LoginHistory) (*ent.LoginHistory, error) {
+	return l.DB.LoginHistory.Create().
+		SetUserID(record.UserID).
+		SetSid(record.Sid).
+		SetUserAgent(record.UserAgent).
+		SetIP(record.IP).
+		Save(ctx)
+}
+
+// ListByUID returns all logins of the specified user, ordered by created time from newest to oldest
+func (l LoginHistoryRepo) ListByUID(ctx context.Context, uid string) ([]*ent.LoginHistory, error) {
+	return l.DB.LoginHistory.Query().
+		Where(loginhistory.HasUserWith(user.UIDEQ(uid))).
+		Order(ent.Desc(loginhistory.FieldCreatedAt), ent.Desc(loginhistory.FieldID)).
+		All(ctx)
+}
+
+// RemoveBefore removes logins of the specified user which are created before the given time
+func (l LoginHistoryRepo) RemoveBefore(ctx context.Context, userId int, before int64) (int, error) {
+	return l.DB.LoginHistory.Delete().
+		Where(
+			loginhistory.UserIDEQ(userId),
+			loginhistory.CreatedAtLT(before),
+		).
+		Exec(ctx)
+}
@@ -0,0 +1,49 @@
+package repo
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/ent/oauthclient"
+	"golang.org/x/net/context"
+)
+
+type OAuthClientRepo struct {
+	DB *ent.Client
+}
+
+// FindByClientID returns the client matching the given client id
+func (o OAuthClientRepo) FindByClientID(ctx context.Context, clientId string) (*ent.OAuthClient, error) {
+	return o.DB.OAuthClient.Query().
+		Where(oauthclient.ClientIDEQ(clientId)).
+		Only(ctx)
+}
+
+// ListByUserID returns all clients owned by the user
+func (o OAuthClientRepo) ListByUserID(ctx context.Context, userId int) ([]*ent.OAuthClient, error) {
+	return o.DB.OAuthClient.Query().
+		Where(oauthclient.UserIDEQ(userId)).
+		Order(ent.Asc(oauthclient.FieldCreatedAt)).
+		All(ctx)
+}
+
+// Create registers a new client owned by the user
+func (o OAuthClientRepo) Create(ctx context.Context, userId int, client ent.OAuthClient) (*ent.OAuthClient, error) {
+	return o.DB.OAuthClient.Create().
+		SetUserID(userId).
+		SetName(client.Name).
+		SetSecretHash(client.SecretHash).
+		SetRedirectUris(client.RedirectUris).
+		SetScopes(client.Scopes).
+		SetGrantTypes(client.GrantTypes).
+		SetPublic(client.Public).
+		Save(ctx)
+}
+
+// RemoveByClientID removes the client owned by the user
+func (o OAuthClientRepo) RemoveByClientID(ctx context.Context, userId int, clientId string) (int, error) {
+	return o.DB.OAuthClient.Delete().
+		Where(
+			oauthclient.UserIDEQ(userId),
+			oauthclient.ClientIDEQ(clientId),
+		).
+		Exec(ctx)
+}
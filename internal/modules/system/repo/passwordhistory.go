@@ -0,0 +1,74 @@
+package repo
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/ent/passwordhistory"
+	"golang.org/x/net/context"
+)
+
+type PasswordHistoryRepo struct {
+	DB *ent.Client
+}
+
+// ListRecent returns the latest previous passwords of the user, newest first
+func (p PasswordHistoryRepo) ListRecent(ctx context.Context, userId int, limit int) ([]*ent.PasswordHistory, error) {
+	return p.DB.PasswordHistory.Query().
+		Where(passwordhistory.UserIDEQ(userId)).
+		Order(ent.Desc(passwordhistory.FieldID)).
+		Limit(limit).
+		All(ctx)
+}
+
+// UpdatePassword updates password of the user and records the old one in a transaction, only the latest keep
+// previous passwords are kept, nothing is recorded and all are removed if keep <= 0.
+func (p PasswordHistoryRepo) UpdatePassword(ctx context.Context, queryUser *ent.User, password string, keep int) (*ent.User, error) {
+	return p.UpdateAccount(ctx, queryUser, "", "", password, keep)
+}
+
+// UpdateAccount updates username, email and password of the user in a transaction, empty values are left unchanged.
+// If password is changed, the old one is recorded as UpdatePassword does.
+func (p PasswordHistoryRepo) UpdateAccount(ctx context.Context, queryUser *ent.User, username, email, password string, keep int) (*ent.User, error) {
+	var updated *ent.User
+	err := withTx(ctx, p.DB, func(tx *ent.Tx) error {
+		update := tx.User.UpdateOneID(queryUser.ID)
+		if username != "" {
+			update = update.SetUsername(username)
+		}
+		if email != "" {
+			update = update.SetEmail(email).SetEmailVerifiedAt(0)
+		}
+		if password == "" {
+			var err error
+			updated, err = update.Save(ctx)
+			return err
+		}
+
+		if keep > 0 && queryUser.Password != "" {
+			err := tx.PasswordHistory.Create().
+				SetUserID(queryUser.ID).
+				SetPassword(queryUser.Password).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		expired, err := tx.PasswordHistory.Query().
+			Where(passwordhistory.UserIDEQ(queryUser.ID)).
+			Order(ent.Desc(passwordhistory.FieldID)).
+			Offset(max(keep, 0)).
+			IDs(ctx)
+		if err != nil {
+			return err
+		}
+		if len(expired) > 0 {
+			if _, err := tx.PasswordHistory.Delete().Where(passwordhistory.IDIn(expired...)).Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		updated, err = update.SetPassword(password).Save(ctx)
+		return err
+	})
+	return updated, err
+}
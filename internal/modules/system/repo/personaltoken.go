@@ -0,0 +1,75 @@
+package repo
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/ent/personaltoken"
+	"golang.org/x/net/context"
+)
+
+type PersonalTokenRepo struct {
+	DB *ent.Client
+}
+
+// FindByKeyID returns the token matching the given key id, with its user loaded
+func (p PersonalTokenRepo) FindByKeyID(ctx context.Context, keyId string) (*ent.PersonalToken, error) {
+	return p.DB.PersonalToken.Query().
+		Where(personaltoken.KeyIDEQ(keyId)).
+		WithUser().
+		Only(ctx)
+}
+
+// ListByUserID returns all tokens of the user
+func (p PersonalTokenRepo) ListByUserID(ctx context.Context, userId int) ([]*ent.PersonalToken, error) {
+	return p.DB.PersonalToken.Query().
+		Where(personaltoken.UserIDEQ(userId)).
+		Order(ent.Desc(personaltoken.FieldCreatedAt)).
+		All(ctx)
+}
+
+// CountByUserID returns number of tokens of the user
+func (p PersonalTokenRepo) CountByUserID(ctx context.Context, userId int) (int, error) {
+	return p.DB.PersonalToken.Query().
+		Where(personaltoken.UserIDEQ(userId)).
+		Count(ctx)
+}
+
+// Create creates a new token for the user
+func (p PersonalTokenRepo) Create(ctx context.Context, userId int, token ent.PersonalToken) (*ent.PersonalToken, error) {
+	return p.DB.PersonalToken.Create().
+		SetUserID(userId).
+		SetKeyID(token.KeyID).
+		SetSecretHash(token.SecretHash).
+		SetName(token.Name).
+		SetScopes(token.Scopes).
+		SetExpiresAt(token.ExpiresAt).
+		Save(ctx)
+}
+
+// TouchUsed updates the last used time if it is earlier than before, so that frequent requests do not write every time.
+func (p PersonalTokenRepo) TouchUsed(ctx context.Context, id int, at, before int64) error {
+	_, err := p.DB.PersonalToken.Update().
+		Where(
+			personaltoken.IDEQ(id),
+			personaltoken.LastUsedAtLT(before),
+		).
+		SetLastUsedAt(at).
+		Save(ctx)
+	return err
+}
+
+// RemoveByKeyID removes the token of the user
+func (p PersonalTokenRepo) RemoveByKeyID(ctx context.Context, userId int, keyId string) (int, error) {
+	return p.DB.PersonalToken.Delete().
+		Where(
+			personaltoken.UserIDEQ(userId),
+			personaltoken.KeyIDEQ(keyId),
+		).
+		Exec(ctx)
+}
+
+// RemoveByUserID removes all tokens of the user
+func (p PersonalTokenRepo) RemoveByUserID(ctx context.Context, userId int) (int, error) {
+	return p.DB.PersonalToken.Delete().
+		Where(personaltoken.UserIDEQ(userId)).
+		Exec(ctx)
+}
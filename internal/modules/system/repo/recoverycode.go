@@ -0,0 +1,48 @@
+package repo
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/ent/recoverycode"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
+	"golang.org/x/net/context"
+)
+
+type RecoveryCodeRepo struct {
+	DB *ent.Client
+}
+
+// ReplaceCodes removes all recovery codes of the user, then creates new codes with the given hashes
+func (r RecoveryCodeRepo) ReplaceCodes(ctx context.Context, userId int, hashes []string) error {
+	return withTx(ctx, r.DB, func(tx *ent.Tx) error {
+		_, err := tx.RecoveryCode.Delete().
+			Where(recoverycode.UserIDEQ(userId)).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		var creates []*ent.RecoveryCodeCreate
+		for _, hash := range hashes {
+			creates = append(creates, tx.RecoveryCode.Create().SetUserID(userId).SetCodeHash(hash))
+		}
+		return tx.RecoveryCode.CreateBulk(creates...).Exec(ctx)
+	})
+}
+
+// UseCode marks the unused code matching the given hash as used, returns 0 if no such code.
+func (r RecoveryCodeRepo) UseCode(ctx context.Context, userId int, hash string) (int, error) {
+	return r.DB.RecoveryCode.Update().
+		Where(
+			recoverycode.UserIDEQ(userId),
+			recoverycode.CodeHashEQ(hash),
+			recoverycode.UsedAtEQ(0),
+		).
+		SetUsedAt(ts.UnixMicro()).
+		Save(ctx)
+}
+
+// RemoveByUserID removes all recovery codes of the user
+func (r RecoveryCodeRepo) RemoveByUserID(ctx context.Context, userId int) (int, error) {
+	return r.DB.RecoveryCode.Delete().
+		Where(recoverycode.UserIDEQ(userId)).
+		Exec(ctx)
+}
@@ -0,0 +1,156 @@
+package repo
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/ent/permission"
+	"github.com/ginx-contribs/ginx-server/ent/role"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+	"golang.org/x/net/context"
+)
+
+type RoleRepo struct {
+	DB *ent.Client
+}
+
+// FindByName returns the role matching the given name, with its permissions loaded
+func (r RoleRepo) FindByName(ctx context.Context, name string) (*ent.Role, error) {
+	return r.DB.Role.Query().
+		Where(role.NameEQ(name)).
+		WithPermissions().
+		Only(ctx)
+}
+
+// List returns all roles with their permissions
+func (r RoleRepo) List(ctx context.Context) ([]*ent.Role, error) {
+	return r.DB.Role.Query().
+		WithPermissions().
+		Order(ent.Asc(role.FieldID)).
+		All(ctx)
+}
+
+// ListByUserID returns roles granted to the user
+func (r RoleRepo) ListByUserID(ctx context.Context, userId int) ([]*ent.Role, error) {
+	return r.DB.Role.Query().
+		Where(role.HasUsersWith(user.IDEQ(userId))).
+		WithPermissions().
+		Order(ent.Asc(role.FieldID)).
+		All(ctx)
+}
+
+// ListPermissions returns all permissions
+func (r RoleRepo) ListPermissions(ctx context.Context) ([]*ent.Permission, error) {
+	return r.DB.Permission.Query().
+		Order(ent.Asc(permission.FieldName)).
+		All(ctx)
+}
+
+// FindPermissions returns permissions matching the given names
+func (r RoleRepo) FindPermissions(ctx context.Context, names []string) ([]*ent.Permission, error) {
+	return r.DB.Permission.Query().
+		Where(permission.NameIn(names...)).
+		All(ctx)
+}
+
+// PermissionsByUID returns names of all permissions granted to the user through roles
+func (r RoleRepo) PermissionsByUID(ctx context.Context, uid string) ([]string, error) {
+	return r.DB.Permission.Query().
+		Where(permission.HasRolesWith(role.HasUsersWith(user.UIDEQ(uid)))).
+		Select(permission.FieldName).
+		Strings(ctx)
+}
+
+// Create creates a new role with the given permissions
+func (r RoleRepo) Create(ctx context.Context, newRole ent.Role, permissionIds []int) (*ent.Role, error) {
+	return r.DB.Role.Create().
+		SetName(newRole.Name).
+		SetDescription(newRole.Description).
+		AddPermissionIDs(permissionIds...).
+		Save(ctx)
+}
+
+// RemoveByName removes the role which is not builtin
+func (r RoleRepo) RemoveByName(ctx context.Context, name string) (int, error) {
+	return r.DB.Role.Delete().
+		Where(
+			role.NameEQ(name),
+			role.BuiltinEQ(false),
+		).
+		Exec(ctx)
+}
+
+// Grant grants the role to the user, it is no-op if the user already has the role
+func (r RoleRepo) Grant(ctx context.Context, userId int, roleId int) error {
+	return withTx(ctx, r.DB, func(tx *ent.Tx) error {
+		exist, err := tx.User.Query().
+			Where(
+				user.IDEQ(userId),
+				user.HasRolesWith(role.IDEQ(roleId)),
+			).
+			Exist(ctx)
+		if err != nil || exist {
+			return err
+		}
+		return tx.User.UpdateOneID(userId).AddRoleIDs(roleId).Exec(ctx)
+	})
+}
+
+// Revoke removes the role from the user
+func (r RoleRepo) Revoke(ctx context.Context, userId int, roleId int) error {
+	return r.DB.User.UpdateOneID(userId).RemoveRoleIDs(roleId).Exec(ctx)
+}
+
+// GrantByUsernames grants the role to users matching the given usernames who do not have it yet
+func (r RoleRepo) GrantByUsernames(ctx context.Context, roleId int, usernames []string) (int, error) {
+	return r.DB.User.Update().
+		Where(
+			user.UsernameIn(usernames...),
+			user.Not(user.HasRolesWith(role.IDEQ(roleId))),
+		).
+		AddRoleIDs(roleId).
+		Save(ctx)
+}
+
+// Seed synchronizes the permission catalog into database, then ensures the builtin role exists and is granted with all permissions.
+func (r RoleRepo) Seed(ctx context.Context, roleName string, perms []ent.Permission) (*ent.Role, error) {
+	var seeded *ent.Role
+	err := withTx(ctx, r.DB, func(tx *ent.Tx) error {
+		for _, perm := range perms {
+			err := tx.Permission.Create().
+				SetName(perm.Name).
+				SetDescription(perm.Description).
+				OnConflictColumns(permission.FieldName).
+				UpdateDescription().
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		builtin, err := tx.Role.Query().Where(role.NameEQ(roleName)).Only(ctx)
+		if ent.IsNotFound(err) {
+			builtin, err = tx.Role.Create().
+				SetName(roleName).
+				SetDescription("builtin role granted with all permissions").
+				SetBuiltin(true).
+				Save(ctx)
+		}
+		if err != nil {
+			return err
+		}
+
+		missing, err := tx.Permission.Query().
+			Where(permission.Not(permission.HasRolesWith(role.IDEQ(builtin.ID)))).
+			IDs(ctx)
+		if err != nil {
+			return err
+		}
+		if len(missing) > 0 {
+			if err := tx.Role.UpdateOneID(builtin.ID).AddPermissionIDs(missing...).Exec(ctx); err != nil {
+				return err
+			}
+		}
+		seeded = builtin
+		return nil
+	})
+	return seeded, err
+}
@@ -0,0 +1,114 @@
+package repo
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/ent/session"
+	"github.com/ginx-contribs/ginx-server/ent/user"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
+	"golang.org/x/net/context"
+)
+
+type SessionRepo struct {
+	DB *ent.Client
+}
+
+// CreateSession creates a new session record
+func (s SessionRepo) CreateSession(ctx context.Context, record *ent.Session) (*ent.Session, error) {
+	return s.DB.Session.Create().
+		SetSid(record.Sid).
+		SetUserID(record.UserID).
+		SetAccessID(record.AccessID).
+		SetRefreshID(record.RefreshID).
+		SetUserAgent(record.UserAgent).
+		SetIP(record.IP).
+		SetRemember(record.Remember).
+		SetExpiresAt(record.ExpiresAt).
+		Save(ctx)
+}
+
+// FindBySid returns the session matching the given sid
+func (s SessionRepo) FindBySid(ctx context.Context, sid string) (*ent.Session, error) {
+	return s.DB.Session.Query().
+		Where(
+			session.SidEQ(sid),
+		).Only(ctx)
+}
+
+// FindUserSession returns the unexpired session matching the given sid and uid
+func (s SessionRepo) FindUserSession(ctx context.Context, uid, sid string) (*ent.Session, error) {
+	return s.DB.Session.Query().
+		Where(
+			session.SidEQ(sid),
+			session.ExpiresAtGT(ts.UnixMicro()),
+			session.HasUserWith(user.UIDEQ(uid)),
+		).Only(ctx)
+}
+
+// ListByUID returns all unexpired sessions of the specified user, ordered by created time from newest to oldest
+func (s SessionRepo) ListByUID(ctx context.Context, uid string) ([]*ent.Session, error) {
+	return s.DB.Session.Query().
+		Where(
+			session.ExpiresAtGT(ts.UnixMicro()),
+			session.HasUserWith(user.UIDEQ(uid)),
+		).
+		Order(ent.Desc(session.FieldCreatedAt)).
+		All(ctx)
+}
+
+// ListByUserID returns all unexpired sessions of the specified user, ordered by created time from newest to oldest
+func (s SessionRepo) ListByUserID(ctx context.Context, userId int) ([]*ent.Session, error) {
+	return s.DB.Session.Query().
+		Where(
+			session.UserIDEQ(userId),
+			session.ExpiresAtGT(ts.UnixMicro()),
+		).
+		Order(ent.Desc(session.FieldCreatedAt)).
+		All(ctx)
+}
+
+// UpdateTokens updates the token ids of the session, and marks it as seen
+func (s SessionRepo) UpdateTokens(ctx context.Context, sid, accessId, refreshId string, expiresAt int64) (int, error) {
+	return s.DB.Session.Update().
+		Where(session.SidEQ(sid)).
+		SetAccessID(accessId).
+		SetRefreshID(refreshId).
+		SetExpiresAt(expiresAt).
+		SetLastSeenAt(ts.UnixMicro()).
+		Save(ctx)
+}
+
+// TouchSeen updates the last seen time if it is earlier than before, so that frequent requests do not write every time.
+func (s SessionRepo) TouchSeen(ctx context.Context, sid string, at, before int64) error {
+	_, err := s.DB.Session.Update().
+		Where(
+			session.SidEQ(sid),
+			session.LastSeenAtLT(before),
+		).
+		SetLastSeenAt(at).
+		Save(ctx)
+	return err
+}
+
+// RemoveBySids removes sessions matching the given sids
+func (s SessionRepo) RemoveBySids(ctx context.Context, sids ...string) (int, error) {
+	return s.DB.Session.Delete().
+		Where(session.SidIn(sids...)).
+		Exec(ctx)
+}
+
+// RemoveByUID removes all sessions of the specified user
+func (s SessionRepo) RemoveByUID(ctx context.Context, uid string) (int, error) {
+	return s.DB.Session.Delete().
+		Where(session.HasUserWith(user.UIDEQ(uid))).
+		Exec(ctx)
+}
+
+// RemoveExpired removes expired sessions of the specified user
+func (s SessionRepo) RemoveExpired(ctx context.Context, userId int) (int, error) {
+	return s.DB.Session.Delete().
+		Where(
+			session.UserIDEQ(userId),
+			session.ExpiresAtLTE(ts.UnixMicro()),
+		).
+		Exec(ctx)
+}
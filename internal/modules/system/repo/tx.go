@@ -0,0 +1,28 @@
+package repo
+
+import (
+	"fmt"
+	"github.com/ginx-contribs/ginx-server/ent"
+	"golang.org/x/net/context"
+)
+
+// withTx runs fn in a transaction, it will be rolled back if fn returns error or panics
+func withTx(ctx context.Context, client *ent.Client, fn func(tx *ent.Tx) error) error {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			_ = tx.Rollback()
+			panic(v)
+		}
+	}()
+	if err := fn(tx); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: rolling back transaction: %v", err, rerr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
@@ -2,7 +2,16 @@ package repo
 
 import (
 	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/ent/identity"
+	"github.com/ginx-contribs/ginx-server/ent/loginhistory"
+	"github.com/ginx-contribs/ginx-server/ent/oauthclient"
+	"github.com/ginx-contribs/ginx-server/ent/passwordhistory"
+	"github.com/ginx-contribs/ginx-server/ent/personaltoken"
+	"github.com/ginx-contribs/ginx-server/ent/recoverycode"
+	"github.com/ginx-contribs/ginx-server/ent/schema"
+	"github.com/ginx-contribs/ginx-server/ent/session"
 	"github.com/ginx-contribs/ginx-server/ent/user"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
 	"golang.org/x/net/context"
 )
 
@@ -46,19 +55,74 @@ func (u UserRepo) FindByEmail(ctx context.Context, email string) (*ent.User, err
 		).Only(ctx)
 }
 
-// CreateNewUser creates a new user with the minimum information
-func (u UserRepo) CreateNewUser(ctx context.Context, username string, email string, password string) (*ent.User, error) {
+// CreateNewUser creates a new user with the minimum information, emailVerifiedAt is 0 if the email is unverified
+func (u UserRepo) CreateNewUser(ctx context.Context, username string, email string, password string, emailVerifiedAt int64) (*ent.User, error) {
 	return u.DB.User.Create().
 		SetUsername(username).
 		SetEmail(email).
+		SetEmailVerifiedAt(emailVerifiedAt).
 		SetPassword(password).
 		Save(ctx)
 }
 
-func (u UserRepo) RemoveByUID(ctx context.Context, uid string) (int, error) {
-	return u.DB.User.Delete().
-		Where(user.UIDEQ(uid)).
-		Exec(ctx)
+// NewUser is a user to be created in bulk
+type NewUser struct {
+	Username string
+	Email    string
+	Nickname string
+	// hashed password
+	Password string
+}
+
+// CreateBulk creates users in one transaction, none of them is created if any fails
+func (u UserRepo) CreateBulk(ctx context.Context, users []NewUser) ([]*ent.User, error) {
+	var created []*ent.User
+	err := withTx(ctx, u.DB, func(tx *ent.Tx) error {
+		builders := make([]*ent.UserCreate, 0, len(users))
+		for _, newUser := range users {
+			builders = append(builders, tx.User.Create().
+				SetUsername(newUser.Username).
+				SetEmail(newUser.Email).
+				SetNickname(newUser.Nickname).
+				SetPassword(newUser.Password),
+			)
+		}
+		var err error
+		created, err = tx.User.CreateBulk(builders...).Save(ctx)
+		return err
+	})
+	return created, err
+}
+
+// FindTaken returns usernames and emails among the given ones which are already used, including soft deleted users
+func (u UserRepo) FindTaken(ctx context.Context, usernames, emails []string) (map[string]bool, map[string]bool, error) {
+	users, err := u.DB.User.Query().
+		Where(
+			user.Or(
+				user.UsernameIn(usernames...),
+				user.EmailIn(emails...),
+			),
+		).
+		Select(user.FieldUsername, user.FieldEmail).
+		All(schema.SkipSoftDelete(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+	takenNames, takenEmails := make(map[string]bool), make(map[string]bool)
+	for _, taken := range users {
+		takenNames[taken.Username] = true
+		takenEmails[taken.Email] = true
+	}
+	return takenNames, takenEmails, nil
+}
+
+// ListAfterID returns at most limit users whose id is greater than the given id in order of id, it is used to iterate all users
+func (u UserRepo) ListAfterID(ctx context.Context, id int, limit int) ([]*ent.User, error) {
+	return u.DB.User.Query().
+		Where(user.IDGT(id)).
+		Order(user.ByID()).
+		Limit(limit).
+		All(ctx)
 }
 
 // UpdateOnePassword updates the user password with specified email
@@ -68,33 +132,182 @@ func (u UserRepo) UpdateOnePassword(ctx context.Context, id int, password string
 		Save(ctx)
 }
 
-// ListByPage list users by page
-func (u UserRepo) ListByPage(ctx context.Context, page, size int, pattern string) ([]*ent.User, error) {
-	if page < 1 {
-		page = 1
-	}
+// VerifyEmail marks the email of user as verified
+func (u UserRepo) VerifyEmail(ctx context.Context, id int) (*ent.User, error) {
+	return u.DB.User.UpdateOneID(id).
+		SetEmailVerifiedAt(ts.UnixMicro()).
+		Save(ctx)
+}
 
-	if size < 1 {
-		size = 10
-	}
+// UpdateEmail updates the email of user, which has been verified by its owner
+func (u UserRepo) UpdateEmail(ctx context.Context, id int, email string) (*ent.User, error) {
+	return u.DB.User.UpdateOneID(id).
+		SetEmail(email).
+		SetEmailVerifiedAt(ts.UnixMicro()).
+		Save(ctx)
+}
 
-	query := u.DB.User.Query()
+// UserFilter filters users in listing, zero values are ignored
+type UserFilter struct {
+	// matches username or email
+	Pattern       string
+	Status        user.Status
+	CreatedAfter  int64
+	CreatedBefore int64
+}
 
-	// pattern
-	if pattern != "" {
-		query = query.
-			Where(
-				user.Or(
-					user.UsernameContains(pattern),
-					user.EmailContains(pattern),
-				),
-			)
+// ListByPage list users matching the filter by page, the total count of matched users is returned along with the page
+func (u UserRepo) ListByPage(ctx context.Context, page, size int, filter UserFilter, order user.OrderOption) (*ent.UserPageList, error) {
+	return u.filter(u.DB.User.Query(), filter).Page(ctx, uint64(page), uint64(size), func(pager *ent.UserPager) {
+		pager.Order = order
+	})
+}
+
+// ListByCursor list users matching the filter by cursor, which is stable and fast on large tables
+func (u UserRepo) ListByCursor(ctx context.Context, signer *ent.CursorSigner, args ent.CursorArgs, filter UserFilter) (*ent.UserConnection, error) {
+	return u.filter(u.DB.User.Query(), filter).Paginate(ctx, signer, args)
+}
+
+func (u UserRepo) filter(query *ent.UserQuery, filter UserFilter) *ent.UserQuery {
+	if filter.Pattern != "" {
+		query = query.Where(
+			user.Or(
+				user.UsernameContains(filter.Pattern),
+				user.EmailContains(filter.Pattern),
+			),
+		)
+	}
+	if filter.Status != "" {
+		query = query.Where(user.StatusEQ(filter.Status))
+	}
+	if filter.CreatedAfter > 0 {
+		query = query.Where(user.CreatedAtGTE(filter.CreatedAfter))
+	}
+	if filter.CreatedBefore > 0 {
+		query = query.Where(user.CreatedAtLT(filter.CreatedBefore))
 	}
+	return query
+}
 
-	// pagination
-	users, err := query.Offset((page - 1) * size).Limit(size).All(ctx)
-	if err != nil {
-		return []*ent.User{}, nil
+// UpdateTotpSecret stores the pending totp secret, 2fa remains disabled until confirmed
+func (u UserRepo) UpdateTotpSecret(ctx context.Context, id int, secret string) (*ent.User, error) {
+	return u.DB.User.UpdateOneID(id).
+		SetTotpSecret(secret).
+		SetTotpEnabled(false).
+		Save(ctx)
+}
+
+// UpdateTotpEnabled enables or disables 2fa, the secret will be cleared if disabled
+func (u UserRepo) UpdateTotpEnabled(ctx context.Context, id int, enabled bool) (*ent.User, error) {
+	update := u.DB.User.UpdateOneID(id).SetTotpEnabled(enabled)
+	if !enabled {
+		update = update.ClearTotpSecret()
 	}
-	return users, nil
+	return update.Save(ctx)
+}
+
+// UpdateStatus updates status of the user
+func (u UserRepo) UpdateStatus(ctx context.Context, id int, status user.Status) (*ent.User, error) {
+	return u.DB.User.UpdateOneID(id).
+		SetStatus(status).
+		Save(ctx)
+}
+
+// ScheduleDeletion marks the user as pending deletion, it will be purged at the given time
+func (u UserRepo) ScheduleDeletion(ctx context.Context, id int, purgeAt int64) (*ent.User, error) {
+	return u.DB.User.UpdateOneID(id).
+		SetStatus(user.StatusPendingDeletion).
+		SetPurgeAt(purgeAt).
+		Save(ctx)
+}
+
+// CancelDeletion makes the pending deletion user active again
+func (u UserRepo) CancelDeletion(ctx context.Context, id int) (*ent.User, error) {
+	return u.DB.User.UpdateOneID(id).
+		SetStatus(user.StatusActive).
+		SetPurgeAt(0).
+		Save(ctx)
+}
+
+// ListPurgeable returns at most limit pending deletion users whose purge time is before the given time
+func (u UserRepo) ListPurgeable(ctx context.Context, before int64, limit int) ([]*ent.User, error) {
+	return u.DB.User.Query().
+		Where(
+			user.StatusEQ(user.StatusPendingDeletion),
+			user.PurgeAtLTE(before),
+		).
+		Order(user.ByPurgeAt()).
+		Limit(limit).
+		All(ctx)
+}
+
+// Anonymize erases personal data of the user and removes all of its credentials, the row is kept as soft deleted
+func (u UserRepo) Anonymize(ctx context.Context, queryUser *ent.User) error {
+	return withTx(ctx, u.DB, func(tx *ent.Tx) error {
+		if _, err := tx.Session.Delete().Where(session.UserIDEQ(queryUser.ID)).Exec(ctx); err != nil {
+			return err
+		}
+		if _, err := tx.LoginHistory.Delete().Where(loginhistory.UserIDEQ(queryUser.ID)).Exec(ctx); err != nil {
+			return err
+		}
+		if _, err := tx.RecoveryCode.Delete().Where(recoverycode.UserIDEQ(queryUser.ID)).Exec(ctx); err != nil {
+			return err
+		}
+		if _, err := tx.PasswordHistory.Delete().Where(passwordhistory.UserIDEQ(queryUser.ID)).Exec(ctx); err != nil {
+			return err
+		}
+		if _, err := tx.Identity.Delete().Where(identity.UserIDEQ(queryUser.ID)).Exec(ctx); err != nil {
+			return err
+		}
+		if _, err := tx.OAuthClient.Delete().Where(oauthclient.UserIDEQ(queryUser.ID)).Exec(ctx); err != nil {
+			return err
+		}
+		if _, err := tx.PersonalToken.Delete().Where(personaltoken.UserIDEQ(queryUser.ID)).Exec(ctx); err != nil {
+			return err
+		}
+		// username and email must stay unique, so they are derived from uid
+		return tx.User.UpdateOneID(queryUser.ID).
+			SetUsername("deleted_" + queryUser.UID).
+			SetEmail(queryUser.UID + "@deleted.invalid").
+			SetPassword("").
+			ClearTotpSecret().
+			SetTotpEnabled(false).
+			ClearNickname().
+			ClearAvatarURL().
+			ClearAvatarKey().
+			ClearBio().
+			ClearLocale().
+			ClearTimezone().
+			ClearMetadata().
+			ClearRoles().
+			SetStatus(user.StatusDeleted).
+			SetPurgeAt(0).
+			SetDeletedAt(ts.UnixMicro()).
+			Exec(ctx)
+	})
+}
+
+// Remove deletes the user permanently, rows referencing it are deleted by cascade
+func (u UserRepo) Remove(ctx context.Context, id int) error {
+	return u.DB.User.DeleteOneID(id).Exec(schema.SkipSoftDelete(ctx))
+}
+
+// UpdateProfile updates the profile fields of the user with values from the given record
+func (u UserRepo) UpdateProfile(ctx context.Context, profile *ent.User) (*ent.User, error) {
+	return u.DB.User.UpdateOneID(profile.ID).
+		SetNickname(profile.Nickname).
+		SetAvatarURL(profile.AvatarURL).
+		SetBio(profile.Bio).
+		SetLocale(profile.Locale).
+		SetTimezone(profile.Timezone).
+		SetMetadata(profile.Metadata).
+		Save(ctx)
+}
+
+// UpdateAvatar updates the uploaded avatar key and avatar url
+func (u UserRepo) UpdateAvatar(ctx context.Context, id int, avatarKey, avatarURL string) (*ent.User, error) {
+	return u.DB.User.UpdateOneID(id).
+		SetAvatarKey(avatarKey).
+		SetAvatarURL(avatarURL).
+		Save(ctx)
 }
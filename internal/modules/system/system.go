@@ -1,28 +1,78 @@
 package system
 
 import (
+	"context"
+	"github.com/ginx-contribs/ginx"
+	"github.com/ginx-contribs/ginx-server/internal/common/route"
 	"github.com/ginx-contribs/ginx-server/internal/common/types"
 	"github.com/ginx-contribs/ginx-server/internal/modules/system/api"
 	"github.com/ginx-contribs/ginx-server/internal/modules/system/cache"
 	"github.com/ginx-contribs/ginx-server/internal/modules/system/handler"
 	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
+	systype "github.com/ginx-contribs/ginx-server/internal/modules/system/types"
 	"github.com/google/wire"
+	"net/http"
+	"time"
 )
 
 var Provider = wire.NewSet(
 	cache.NewRedisCaptchaCache,
 	wire.Bind(new(cache.CaptchaCache), new(*cache.RedisCaptchaCache)),
+	cache.NewRedisTwoFACache,
+	wire.Bind(new(cache.TwoFACache), new(*cache.RedisTwoFACache)),
+	cache.NewRedisOAuthStateCache,
+	wire.Bind(new(cache.OAuthStateCache), new(*cache.RedisOAuthStateCache)),
+	cache.NewRedisAuthCodeCache,
+	wire.Bind(new(cache.AuthCodeCache), new(*cache.RedisAuthCodeCache)),
+	cache.NewRedisLockoutCache,
+	wire.Bind(new(cache.LockoutCache), new(*cache.RedisLockoutCache)),
+	cache.NewRedisEmailChangeCache,
+	wire.Bind(new(cache.EmailChangeCache), new(*cache.RedisEmailChangeCache)),
+	cache.NewRedisMagicLinkCache,
+	wire.Bind(new(cache.MagicLinkCache), new(*cache.RedisMagicLinkCache)),
 	// repo
 	wire.Struct(new(repo.UserRepo), "*"),
+	wire.Struct(new(repo.SessionRepo), "*"),
+	wire.Struct(new(repo.LoginHistoryRepo), "*"),
+	wire.Struct(new(repo.RecoveryCodeRepo), "*"),
+	wire.Struct(new(repo.IdentityRepo), "*"),
+	wire.Struct(new(repo.OAuthClientRepo), "*"),
+	wire.Struct(new(repo.PersonalTokenRepo), "*"),
+	wire.Struct(new(repo.RoleRepo), "*"),
+	wire.Struct(new(repo.AuditLogRepo), "*"),
+	wire.Struct(new(repo.PasswordHistoryRepo), "*"),
 	// handler
 	handler.NewEmailHandler,
 	wire.Struct(new(handler.AuthHandler), "*"),
 	wire.Struct(new(handler.CaptchaHandler), "*"),
+	wire.Struct(new(handler.PasswordHandler), "*"),
 	wire.Struct(new(handler.UserHandler), "*"),
+	wire.Struct(new(handler.SessionHandler), "*"),
+	wire.Struct(new(handler.TwoFAHandler), "*"),
+	wire.Struct(new(handler.OAuthHandler), "*"),
+	wire.Struct(new(handler.OAuthServerHandler), "*"),
+	wire.Struct(new(handler.PersonalTokenHandler), "*"),
+	wire.Struct(new(handler.RoleHandler), "*"),
+	wire.Struct(new(handler.LockoutHandler), "*"),
+	wire.Struct(new(handler.AdminUserHandler), "*"),
+	wire.Struct(new(handler.AvatarHandler), "*"),
+	wire.Struct(new(handler.AccountHandler), "*"),
+	wire.Struct(new(handler.ExportHandler), "*"),
+	wire.Struct(new(handler.BulkHandler), "*"),
+	wire.Struct(new(handler.EmailChangeHandler), "*"),
+	handler.NewLinkSigner,
+	wire.Struct(new(handler.MagicLinkHandler), "*"),
 	wire.Struct(new(handler.HealthHandler), "*"),
 	// api
 	wire.Struct(new(api.AuthAPI), "*"),
 	wire.Struct(new(api.UserAPI), "*"),
+	wire.Struct(new(api.SessionAPI), "*"),
+	wire.Struct(new(api.TwoFAAPI), "*"),
+	wire.Struct(new(api.OAuthAPI), "*"),
+	wire.Struct(new(api.OAuthServerAPI), "*"),
+	wire.Struct(new(api.PersonalTokenAPI), "*"),
+	wire.Struct(new(api.RoleAPI), "*"),
+	wire.Struct(new(api.AdminUserAPI), "*"),
 	wire.Struct(new(api.HealthAPI), "*"),
 
 	// module
@@ -32,19 +82,50 @@ var Provider = wire.NewSet(
 // Module is representation of system
 type Module struct {
 	// api
-	AuthAPI   api.AuthAPI
-	UserAPI   api.UserAPI
-	HealthAPI api.HealthAPI
+	AuthAPI          api.AuthAPI
+	UserAPI          api.UserAPI
+	SessionAPI       api.SessionAPI
+	TwoFAAPI         api.TwoFAAPI
+	OAuthAPI         api.OAuthAPI
+	OAuthServerAPI   api.OAuthServerAPI
+	PersonalTokenAPI api.PersonalTokenAPI
+	RoleAPI          api.RoleAPI
+	AdminUserAPI     api.AdminUserAPI
+	HealthAPI        api.HealthAPI
 
 	// handler
-	AuthHandler   handler.AuthHandler
-	CodeHandler   handler.CaptchaHandler
-	EmailHandler  handler.EmailHandler
-	UserHandler   handler.UserHandler
-	HealthHandler handler.HealthHandler
+	AuthHandler          handler.AuthHandler
+	CodeHandler          handler.CaptchaHandler
+	PasswordHandler      handler.PasswordHandler
+	EmailHandler         handler.EmailHandler
+	UserHandler          handler.UserHandler
+	SessionHandler       handler.SessionHandler
+	TwoFAHandler         handler.TwoFAHandler
+	OAuthHandler         handler.OAuthHandler
+	OAuthServerHandler   handler.OAuthServerHandler
+	PersonalTokenHandler handler.PersonalTokenHandler
+	RoleHandler          handler.RoleHandler
+	LockoutHandler       handler.LockoutHandler
+	AdminUserHandler     handler.AdminUserHandler
+	AvatarHandler        handler.AvatarHandler
+	AccountHandler       handler.AccountHandler
+	ExportHandler        handler.ExportHandler
+	BulkHandler          handler.BulkHandler
+	EmailChangeHandler   handler.EmailChangeHandler
+	MagicLinkHandler     handler.MagicLinkHandler
+	HealthHandler        handler.HealthHandler
 
 	// repo
-	UserRepo repo.UserRepo
+	UserRepo            repo.UserRepo
+	SessionRepo         repo.SessionRepo
+	LoginHistoryRepo    repo.LoginHistoryRepo
+	RecoveryCodeRepo    repo.RecoveryCodeRepo
+	IdentityRepo        repo.IdentityRepo
+	OAuthClientRepo     repo.OAuthClientRepo
+	PersonalTokenRepo   repo.PersonalTokenRepo
+	RoleRepo            repo.RoleRepo
+	AuditLogRepo        repo.AuditLogRepo
+	PasswordHistoryRepo repo.PasswordHistoryRepo
 }
 
 func (m Module) Name() string {
@@ -52,6 +133,21 @@ func (m Module) Name() string {
 }
 
 func (m Module) Init(injector types.Injector) error {
+	// seed builtin roles and permissions
+	if err := m.RoleHandler.Seed(context.Background()); err != nil {
+		return err
+	}
+	// challenge usages must be registered before sending codes
+	if err := m.CodeHandler.RegisterUsages(); err != nil {
+		return err
+	}
+	// personal data of system module is included in exports
+	if err := injector.Exporters.Register(m.Exporters()...); err != nil {
+		return err
+	}
+	if err := m.ExportHandler.Subscribe(); err != nil {
+		return err
+	}
 	m.RegisterRouter(injector)
 	return nil
 }
@@ -66,20 +162,119 @@ func (m Module) RegisterRouter(injector types.Injector) {
 	authAPI := m.AuthAPI
 	authGroup := router.Group("/auth")
 	{
-		authGroup.POST("/login", authAPI.Login)
-		authGroup.POST("/register", authAPI.Register)
-		authGroup.POST("/reset", authAPI.ResetPassword)
+		authGroup.MPOST("/login", ginx.M{route.CountLimit(20, time.Minute)}, authAPI.Login)
+		authGroup.MPOST("/login/2fa", ginx.M{route.CountLimit(20, time.Minute)}, authAPI.LoginTwoFA)
+		authGroup.MPOST("/register", ginx.M{route.CountLimit(10, time.Minute)}, authAPI.Register)
+		authGroup.MPOST("/reset", ginx.M{route.CountLimit(10, time.Minute)}, authAPI.ResetPassword)
 		authGroup.POST("/refresh", authAPI.Refresh)
-		authGroup.POST("/captcha", authAPI.Captcha)
+		authGroup.MPOST("/captcha", ginx.M{route.CountLimit(5, time.Minute)}, authAPI.Captcha)
+		authGroup.MPOST("/magic-link", ginx.M{route.CountLimit(5, time.Minute)}, authAPI.MagicLink)
+		authGroup.MGET("/magic-link/verify", ginx.M{route.CountLimit(20, time.Minute)}, authAPI.VerifyMagicLink)
+		authGroup.MDELETE("/magic-link", ginx.M{route.Private}, authAPI.RevokeMagicLinks)
+		authGroup.MPOST("/logout", ginx.M{route.Private}, authAPI.Logout)
+		authGroup.MPOST("/logout-all", ginx.M{route.Private}, authAPI.LogoutAll)
 	}
 
 	// user api
 	userAPI := m.UserAPI
 	userGroup := router.Group("")
 	{
-		userGroup.GET("/user/:uid", userAPI.Info)
-		userGroup.GET("/user/profile", userAPI.Profile)
-		userGroup.GET("/users", userAPI.List)
+		userGroup.MGET("/user/:uid", ginx.M{route.Cacheable}, userAPI.Info)
+		userGroup.MGET("/user/profile", ginx.M{route.Private, route.Scope(systype.ScopeProfile)}, userAPI.Profile)
+		userGroup.Match([]string{http.MethodPatch}, "/user/profile", ginx.M{route.Private, route.Scope(systype.ScopeProfileWrite)}, userAPI.UpdateProfile)
+		userGroup.MPUT("/user/password", ginx.M{route.Private}, userAPI.ChangePassword)
+		userGroup.MPOST("/user/email/change", ginx.M{route.Private, route.CountLimit(5, time.Minute)}, userAPI.ChangeEmail)
+		userGroup.MPOST("/user/email/confirm", ginx.M{route.Private, route.CountLimit(10, time.Minute)}, userAPI.ConfirmEmail)
+		userGroup.MGET("/user/email/revert", ginx.M{route.CountLimit(10, time.Minute)}, userAPI.RevertEmail)
+		userGroup.MDELETE("/user/me", ginx.M{route.Private}, userAPI.DeleteAccount)
+		userGroup.MPOST("/user/export", ginx.M{route.Private, route.CountLimit(3, time.Hour)}, userAPI.Export)
+		userGroup.MPOST("/user/avatar", ginx.M{route.Private, route.CountLimit(10, time.Minute)}, userAPI.UploadAvatar)
+		userGroup.GET("/user/:uid/avatar", userAPI.Avatar)
+		userGroup.MGET("/users", ginx.M{route.Private, route.Permission(systype.PermUserList)}, userAPI.List)
+		userGroup.MGET("/users/cursor", ginx.M{route.Private, route.Permission(systype.PermUserList)}, userAPI.ListByCursor)
+	}
+
+	// session api
+	sessionAPI := m.SessionAPI
+	sessionGroup := router.Group("/user/sessions")
+	{
+		sessionGroup.MGET("", ginx.M{route.Private, route.Scope(systype.ScopeSessions)}, sessionAPI.List)
+		sessionGroup.MDELETE("/:id", ginx.M{route.Private, route.Scope(systype.ScopeSessions)}, sessionAPI.Terminate)
+	}
+
+	// personal access token api
+	personalTokenAPI := m.PersonalTokenAPI
+	personalTokenGroup := router.Group("/user/tokens")
+	{
+		personalTokenGroup.MPOST("", ginx.M{route.Private}, personalTokenAPI.Create)
+		personalTokenGroup.MGET("", ginx.M{route.Private}, personalTokenAPI.List)
+		personalTokenGroup.MDELETE("/:id", ginx.M{route.Private}, personalTokenAPI.Revoke)
+	}
+
+	// 2fa api
+	twoFAAPI := m.TwoFAAPI
+	twoFAGroup := router.Group("/user/2fa")
+	{
+		twoFAGroup.MPOST("/setup", ginx.M{route.Private, route.CountLimit(5, time.Minute)}, twoFAAPI.Setup)
+		twoFAGroup.MPOST("/enable", ginx.M{route.Private, route.CountLimit(5, time.Minute)}, twoFAAPI.Enable)
+		twoFAGroup.MPOST("/disable", ginx.M{route.Private, route.CountLimit(5, time.Minute)}, twoFAAPI.Disable)
+	}
+
+	// third-party login api
+	oauthAPI := m.OAuthAPI
+	oauthGroup := router.Group("/auth/oauth/:provider")
+	{
+		oauthGroup.GET("/authorize", oauthAPI.Authorize)
+		oauthGroup.POST("/callback", oauthAPI.Callback)
+	}
+	identityGroup := router.Group("/user/identities")
+	{
+		identityGroup.MGET("", ginx.M{route.Private}, oauthAPI.Identities)
+		identityGroup.MPOST("/:provider/authorize", ginx.M{route.Private}, oauthAPI.Link)
+		identityGroup.MPOST("/:provider/callback", ginx.M{route.Private}, oauthAPI.LinkCallback)
+		identityGroup.MDELETE("/:provider", ginx.M{route.Private}, oauthAPI.Unlink)
+	}
+
+	// oauth2 authorization server api
+	oauthServerAPI := m.OAuthServerAPI
+	oauthServerGroup := router.Group("/oauth")
+	{
+		oauthServerGroup.MGET("/authorize", ginx.M{route.Private}, oauthServerAPI.Consent)
+		oauthServerGroup.MPOST("/authorize", ginx.M{route.Private}, oauthServerAPI.Approve)
+		oauthServerGroup.POST("/token", oauthServerAPI.Token)
+		oauthServerGroup.POST("/revoke", oauthServerAPI.Revoke)
+		oauthServerGroup.MPOST("/clients", ginx.M{route.Private}, oauthServerAPI.CreateClient)
+		oauthServerGroup.MGET("/clients", ginx.M{route.Private}, oauthServerAPI.ListClients)
+		oauthServerGroup.MDELETE("/clients/:id", ginx.M{route.Private}, oauthServerAPI.DeleteClient)
+	}
+
+	// role api
+	roleAPI := m.RoleAPI
+	roleMeta := ginx.M{route.Private, route.Permission(systype.PermRoleManage)}
+	roleGroup := router.Group("/admin")
+	{
+		roleGroup.MGET("/permissions", roleMeta, roleAPI.ListPermissions)
+		roleGroup.MGET("/roles", roleMeta, roleAPI.ListRoles)
+		roleGroup.MPOST("/roles", roleMeta, roleAPI.CreateRole)
+		roleGroup.MDELETE("/roles/:name", roleMeta, roleAPI.DeleteRole)
+		roleGroup.MGET("/users/:uid/roles", roleMeta, roleAPI.ListUserRoles)
+		roleGroup.MPUT("/users/:uid/roles/:role", roleMeta, roleAPI.Grant)
+		roleGroup.MDELETE("/users/:uid/roles/:role", roleMeta, roleAPI.Revoke)
+	}
+
+	// admin user api
+	adminUserAPI := m.AdminUserAPI
+	adminUserMeta := ginx.M{route.Private, route.Permission(systype.PermUserManage)}
+	adminUserGroup := router.Group("/admin/users")
+	{
+		adminUserGroup.MPOST("", adminUserMeta, adminUserAPI.Create)
+		adminUserGroup.MPOST("/import", adminUserMeta, adminUserAPI.Import)
+		adminUserGroup.MGET("/export", adminUserMeta, adminUserAPI.Export)
+		adminUserGroup.MPUT("/:uid", adminUserMeta, adminUserAPI.Update)
+		adminUserGroup.MDELETE("/:uid", adminUserMeta, adminUserAPI.Delete)
+		adminUserGroup.MPOST("/:uid/disable", adminUserMeta, adminUserAPI.Disable)
+		adminUserGroup.MPOST("/:uid/enable", adminUserMeta, adminUserAPI.Enable)
+		adminUserGroup.MPOST("/:uid/logout", adminUserMeta, adminUserAPI.Logout)
 	}
 
 	// health api
@@ -0,0 +1,23 @@
+package types
+
+import (
+	"github.com/ginx-contribs/ginx/constant/status"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+)
+
+var (
+	ErrAccountPendingDeletion = statuserr.Errorf("account is pending deletion, log in again to cancel it").SetCode(1_403_003).SetStatus(status.Forbidden)
+)
+
+// modes of purging expired accounts
+const (
+	// PurgeAnonymize erases personal data of the account, the row is kept so that references remain valid
+	PurgeAnonymize = "anonymize"
+	// PurgeRemove deletes the account permanently
+	PurgeRemove = "remove"
+)
+
+type AccountDeletionInfo struct {
+	// time when the account will be purged, in unix microseconds
+	PurgeAt int64 `json:"purgeAt"`
+}
@@ -0,0 +1,85 @@
+package types
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx/constant/status"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+)
+
+var (
+	ErrOperateSelf         = statuserr.Errorf("could not perform the operation on yourself").SetCode(1_400_144).SetStatus(status.BadRequest)
+	ErrUserPendingDeletion = statuserr.Errorf("user is pending deletion, it could not be enabled or disabled").SetCode(1_400_198).SetStatus(status.BadRequest)
+	ErrUserDisabled        = statuserr.Errorf("user has been disabled").SetCode(1_403_002).SetStatus(status.Forbidden)
+)
+
+// actions of administrative operations recorded in audit log
+const (
+	AuditUserCreate  = "user.create"
+	AuditUserUpdate  = "user.update"
+	AuditUserDisable = "user.disable"
+	AuditUserEnable  = "user.enable"
+	AuditUserDelete  = "user.delete"
+	AuditUserLogout  = "user.logout"
+	AuditUserImport  = "user.import"
+	AuditUserExport  = "user.export"
+)
+
+type AdminUserCreateOptions struct {
+	// username must be alphanumeric
+	Username string `json:"username" binding:"required,alphanum"`
+	// user email address
+	Email string `json:"email" binding:"required,email"`
+	// initial password, it must meet the password policy
+	Password string `json:"password" binding:"required,password=Username Email"`
+}
+
+type AdminUserUpdateOptions struct {
+	// new username, unchanged if empty
+	Username string `json:"username" binding:"omitempty,alphanum"`
+	// new email address, unchanged if empty
+	Email string `json:"email" binding:"omitempty,email"`
+	// new password, unchanged if empty, all sessions of the user will be logged out if reset
+	Password string `json:"password" binding:"omitempty,password=Username Email"`
+}
+
+type AdminUserInfo struct {
+	UserInfo
+	// active | disabled | pending_deletion
+	Status      string `json:"status"`
+	TotpEnabled bool   `json:"totpEnabled"`
+	UpdatedAt   int64  `json:"updatedAt"`
+}
+
+func EntToAdminUser(user *ent.User) AdminUserInfo {
+	if user == nil {
+		return AdminUserInfo{}
+	}
+	return AdminUserInfo{
+		UserInfo:    EntToUser(user),
+		Status:      user.Status.String(),
+		TotpEnabled: user.TotpEnabled,
+		UpdatedAt:   user.UpdatedAt,
+	}
+}
+
+type AuditLogInfo struct {
+	Actor     string `json:"actor"`
+	Action    string `json:"action"`
+	Target    string `json:"target"`
+	Detail    string `json:"detail"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+func EntsToAuditLogs(logs []*ent.AuditLog) []AuditLogInfo {
+	infos := []AuditLogInfo{}
+	for _, log := range logs {
+		infos = append(infos, AuditLogInfo{
+			Actor:     log.Actor,
+			Action:    log.Action,
+			Target:    log.Target,
+			Detail:    log.Detail,
+			CreatedAt: log.CreatedAt,
+		})
+	}
+	return infos
+}
@@ -1,8 +1,11 @@
 package types
 
 import (
+	"encoding/json"
+	"github.com/ginx-contribs/ginx-server/pkg/passwd"
 	"github.com/ginx-contribs/ginx/constant/status"
 	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"strings"
 )
 
 var (
@@ -10,6 +13,8 @@ var (
 	ErrUserAlreadyExists = statuserr.Errorf("user already exists").SetCode(1_400_002).SetStatus(status.BadRequest)
 	ErrPasswordMismatch  = statuserr.Errorf("password mismatch").SetCode(1_400_004).SetStatus(status.BadRequest)
 	ErrEmailAlreadyUsed  = statuserr.Errorf("email already used by other").SetCode(1_400_016).SetStatus(status.BadRequest)
+	// the message is replaced with violations of the policy, see PasswordPolicyError
+	ErrPasswordPolicy = statuserr.Errorf("password does not meet the policy").SetCode(1_400_197).SetStatus(status.BadRequest)
 
 	ErrVerifyCodeRetryLater       = statuserr.Errorf("retry applying for verify code later").SetCode(1_400_032).SetStatus(status.BadRequest)
 	ErrVerifyCodeInvalid          = statuserr.Errorf("invliad verify code").SetCode(1_400_033).SetStatus(status.BadRequest)
@@ -18,8 +23,30 @@ var (
 	ErrCredentialInvalid = statuserr.Errorf("invalid credential").SetCode(1_401_001).SetStatus(status.Unauthorized)
 	ErrCredentialExpired = statuserr.Errorf("credential expired").SetCode(1_401_002).SetStatus(status.Unauthorized)
 	ErrTokenNeedsRefresh = statuserr.Errorf("token need to refresh").SetCode(1_401_003).SetStatus(status.Unauthorized)
+	ErrTokenReused       = statuserr.Errorf("refresh token reused, the session has been revoked").SetCode(1_401_004).SetStatus(status.Unauthorized)
+	ErrLoginFailed       = statuserr.Errorf("invalid username or password").SetCode(1_401_006).SetStatus(status.Unauthorized)
+
+	ErrEmailUnverified = statuserr.Errorf("email is not verified, reset password to verify it").SetCode(1_403_004).SetStatus(status.Forbidden)
 )
 
+// PasswordPolicyError is ErrPasswordPolicy carrying violations, they are responded in data as invalid params do
+type PasswordPolicyError struct {
+	Violations []passwd.Violation
+}
+
+func (e PasswordPolicyError) Error() string {
+	return e.Unwrap().Error()
+}
+
+// Unwrap returns ErrPasswordPolicy with messages of violations
+func (e PasswordPolicyError) Unwrap() error {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.Message)
+	}
+	return ErrPasswordPolicy.SetErrorf("%s", strings.Join(messages, ", "))
+}
+
 type LoginOptions struct {
 	// username or email
 	Username string `json:"username" binding:"required"`
@@ -32,8 +59,8 @@ type LoginOptions struct {
 type RegisterOptions struct {
 	// username must be alphanumeric
 	Username string `json:"username" binding:"required,alphanum"`
-	// user password
-	Password string `json:"password" binding:"required"`
+	// user password, it must meet the password policy and not contain username or email
+	Password string `json:"password" binding:"required,password=Username Email"`
 	// user email address
 	Email string `json:"email" binding:"email"`
 	// verification code from verify email
@@ -43,8 +70,8 @@ type RegisterOptions struct {
 type ResetOptions struct {
 	// user email address
 	Email string `json:"email" binding:"email"`
-	// new password
-	Password string `json:"password" binding:"required"`
+	// new password, it must meet the password policy and not be used recently
+	Password string `json:"password" binding:"required,password=Email"`
 	// verification code from verify email
 	Code string `json:"code" binding:"required"`
 }
@@ -56,11 +83,16 @@ type RefreshTokenOptions struct {
 	RefreshToken string `json:"refreshToken" binding:"required"`
 }
 
+type LogoutOptions struct {
+	// refresh token, it will be revoked together if present
+	RefreshToken string `json:"refreshToken"`
+}
+
 type CaptchaOption struct {
 	// email receiver
 	To string `json:"to" binding:"email"`
-	// verify code usage: 1-register 2-reset password
-	Usage Usage `json:"usage" binding:"required,gte=1,lte=2"`
+	// verify code usage registered by modules, such as register and reset
+	Usage Usage `json:"usage" binding:"required"`
 }
 
 type TokenPayload struct {
@@ -69,43 +101,36 @@ type TokenPayload struct {
 }
 
 type TokenResult struct {
-	AccessToken  string `json:"accessToken"`
+	AccessToken  string `json:"accessToken,omitempty"`
 	RefreshToken string `json:"refreshToken,omitempty"`
+	// challenge token for the second factor, tokens are absent if it is present
+	Challenge string `json:"challenge,omitempty"`
 }
 
+// challenge usages of system module, they are registered in challenge registry on module init
 const (
-	UsageUnknown  Usage = 0
-	UsageRegister Usage = 1
-	UsageReset    Usage = 2
+	UsageRegister    Usage = "register"
+	UsageReset       Usage = "reset"
+	UsageChangeEmail Usage = "change_email"
 )
 
-type Usage int
-
-func (u Usage) Name() string {
-	switch u {
-	case 1:
-		return "register"
-	case 2:
-		return "reset"
-	default:
-		return "unknown"
+// Usage is the name of challenge usage registered in challenge registry
+type Usage string
+
+// UnmarshalJSON accepts legacy numeric usages 1-register and 2-reset as well
+func (u *Usage) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "1":
+		*u = UsageRegister
+		return nil
+	case "2":
+		*u = UsageReset
+		return nil
 	}
-}
-
-func (u Usage) String() string {
-	switch u {
-	case 1:
-		return "register account"
-	case 2:
-		return "reset password"
-	default:
-		return "unknown usage"
-	}
-}
-
-func CheckValidUsage(u Usage) error {
-	if u.String() == UsageUnknown.String() {
-		return ErrVerifyCodeUsageUnsupported
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
 	}
+	*u = Usage(name)
 	return nil
 }
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"github.com/ginx-contribs/ginx/constant/status"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+)
+
+var (
+	ErrAvatarRequired = statuserr.Errorf("avatar file is required").SetCode(1_400_176).SetStatus(status.BadRequest)
+	ErrAvatarNotFound = statuserr.Errorf("avatar not found").SetCode(1_404_001).SetStatus(status.NotFound)
+	ErrAvatarTooLarge = statuserr.Errorf("avatar is too large").SetCode(1_413_001).SetStatus(status.RequestEntityTooLarge)
+	ErrAvatarFormat   = statuserr.Errorf("unsupported avatar format, only jpeg, png and gif are allowed").SetCode(1_415_001).SetStatus(status.UnsupportedMediaType)
+)
+
+// AvatarField is the multipart form field name of avatar file
+const AvatarField = "file"
+
+// AvatarFormats are content types allowed to be uploaded as avatar
+var AvatarFormats = []string{"image/jpeg", "image/png", "image/gif"}
+
+type AvatarOptions struct {
+	Uid string `uri:"uid" binding:"required"`
+	// expected side length in pixels, the nearest larger one is returned, or the largest one if there is no larger
+	Size int `form:"size" binding:"omitempty,gt=0"`
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"github.com/ginx-contribs/ginx/constant/status"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+)
+
+var (
+	ErrBulkFormat     = statuserr.Errorf("unsupported format, only csv and jsonl are allowed").SetCode(1_400_192).SetStatus(status.BadRequest)
+	ErrImportFile     = statuserr.Errorf("import file is required").SetCode(1_400_193).SetStatus(status.BadRequest)
+	ErrImportHeader   = statuserr.Errorf("csv header must contain username and email").SetCode(1_400_194).SetStatus(status.BadRequest)
+	ErrImportTooLarge = statuserr.Errorf("import file is too large").SetCode(1_413_002).SetStatus(status.RequestEntityTooLarge)
+)
+
+// formats of bulk import and export
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// ImportFileField is the multipart form field name of import file
+const ImportFileField = "file"
+
+// ImportUserRow is a user to be imported, at most one of password and passwordHash could be given,
+// users without both have to reset their password before logging in.
+// In csv, columns are named as json keys except that passwordHash is password_hash, unknown columns are ignored.
+type ImportUserRow struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Nickname string `json:"nickname"`
+	// plain password, it is hashed before stored
+	Password string `json:"password"`
+	// password hash generated by supported algorithms, such as argon2id and bcrypt in PHC format
+	PasswordHash string `json:"passwordHash"`
+}
+
+type ImportOptions struct {
+	// csv | jsonl
+	Format string `form:"format" binding:"required,oneof=csv jsonl"`
+	// only validates rows without importing
+	DryRun bool `form:"dryRun"`
+	// sends invitation email to each imported user
+	Invite bool `form:"invite"`
+}
+
+// ImportRowError is the reason why a row is not imported, rows are numbered from 1 excluding csv header
+type ImportRowError struct {
+	Row      int    `json:"row"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Error    string `json:"error"`
+}
+
+// ImportedUser is a row imported as user
+type ImportedUser struct {
+	Row int    `json:"row"`
+	Uid string `json:"uid"`
+}
+
+// ImportReport is the result of import, rows either imported or failed with errors
+type ImportReport struct {
+	DryRun bool `json:"dryRun"`
+	Total  int  `json:"total"`
+	// number of imported rows, or rows which are valid to import in dry run
+	Imported int              `json:"imported"`
+	Failed   int              `json:"failed"`
+	Users    []ImportedUser   `json:"users"`
+	Errors   []ImportRowError `json:"errors"`
+}
+
+type ExportUsersOptions struct {
+	// csv | jsonl
+	Format string `form:"format" binding:"required,oneof=csv jsonl"`
+}
+
+// ExportUserRow is a user in bulk export, it could be imported again since unknown columns are ignored
+type ExportUserRow struct {
+	Uid       string `json:"uid"`
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	Nickname  string `json:"nickname"`
+	Status    string `json:"status"`
+	CreatedAt int64  `json:"createdAt"`
+}
@@ -0,0 +1,18 @@
+package types
+
+import (
+	"github.com/ginx-contribs/ginx/constant/status"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+)
+
+var (
+	ErrLoginLocked      = statuserr.Errorf("too many failed login attempts, try again later").SetCode(1_429_001).SetStatus(status.TooManyRequests)
+	ErrLoginTooFrequent = statuserr.Errorf("login attempts are too frequent, try again later").SetCode(1_429_002).SetStatus(status.TooManyRequests)
+)
+
+// LoginAttempts records the recent failed login attempts of a username or a client ip, times are unix micro.
+type LoginAttempts struct {
+	Failures    int64
+	LastFailure int64
+	LockedUntil int64
+}
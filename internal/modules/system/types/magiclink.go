@@ -0,0 +1,31 @@
+package types
+
+import (
+	"github.com/ginx-contribs/ginx/constant/status"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+)
+
+var (
+	ErrMagicLinkInvalid = statuserr.Errorf("login link invalid or expired").SetCode(1_401_007).SetStatus(status.Unauthorized)
+)
+
+// MagicLink is the pending login waiting for the link to be clicked
+type MagicLink struct {
+	Uid      string
+	Remember bool
+	// client which requested the link, only checked if links are bound to clients
+	IP        string
+	UserAgent string
+}
+
+type MagicLinkOptions struct {
+	// email address of the account
+	Email string `json:"email" binding:"required,email"`
+	// remember user or not
+	Remember bool `json:"remember"`
+}
+
+type MagicLinkVerifyOptions struct {
+	// token in the login link
+	Token string `form:"token" binding:"required"`
+}
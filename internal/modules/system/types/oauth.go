@@ -0,0 +1,78 @@
+package types
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx/constant/status"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+)
+
+var (
+	ErrOAuthProviderNotFound = statuserr.Errorf("login provider not found").SetCode(1_400_080).SetStatus(status.BadRequest)
+	ErrOAuthStateInvalid     = statuserr.Errorf("oauth state invalid or expired").SetCode(1_400_081).SetStatus(status.BadRequest)
+	ErrOAuthExchangeFailed   = statuserr.Errorf("failed to login with provider").SetCode(1_400_082).SetStatus(status.BadRequest)
+	ErrOAuthEmailRequired    = statuserr.Errorf("provider does not share a verified email").SetCode(1_400_083).SetStatus(status.BadRequest)
+	ErrIdentityAlreadyLinked = statuserr.Errorf("identity already linked").SetCode(1_400_084).SetStatus(status.BadRequest)
+	ErrIdentityNotFound      = statuserr.Errorf("identity not found").SetCode(1_400_085).SetStatus(status.BadRequest)
+)
+
+// OAuthState is the pending authorization request waiting for provider callback
+type OAuthState struct {
+	Provider string `json:"provider"`
+	// PKCE code verifier
+	Verifier string `json:"verifier"`
+	// OpenID Connect nonce
+	Nonce string `json:"nonce"`
+	// user who is linking identity, empty for login
+	Uid string `json:"uid,omitempty"`
+	// remember user or not after login
+	Remember bool `json:"remember"`
+}
+
+type OAuthProviderOptions struct {
+	// provider name
+	Provider string `uri:"provider" binding:"required"`
+}
+
+type OAuthAuthorizeOptions struct {
+	// remember user or not after login
+	Remember bool `form:"remember"`
+}
+
+type OAuthCallbackOptions struct {
+	// authorization code returned by provider
+	Code string `json:"code" binding:"required"`
+	// state returned by provider
+	State string `json:"state" binding:"required"`
+}
+
+type OAuthAuthorizeResult struct {
+	// url of provider authorization page, client should redirect user to it
+	URL string `json:"url"`
+}
+
+type IdentityInfo struct {
+	Provider    string `json:"provider"`
+	Email       string `json:"email"`
+	CreatedAt   int64  `json:"createdAt"`
+	LastLoginAt int64  `json:"lastLoginAt"`
+}
+
+func EntToIdentity(i *ent.Identity) IdentityInfo {
+	if i == nil {
+		return IdentityInfo{}
+	}
+	return IdentityInfo{
+		Provider:    i.Provider,
+		Email:       i.Email,
+		CreatedAt:   i.CreatedAt,
+		LastLoginAt: i.LastLoginAt,
+	}
+}
+
+func EntsToIdentities(identities []*ent.Identity) []IdentityInfo {
+	is := []IdentityInfo{}
+	for _, i := range identities {
+		is = append(is, EntToIdentity(i))
+	}
+	return is
+}
@@ -0,0 +1,187 @@
+package types
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx/constant/status"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+)
+
+var (
+	ErrOAuthClientNotFound  = statuserr.Errorf("oauth client not found").SetCode(1_400_096).SetStatus(status.BadRequest)
+	ErrOAuthRedirectInvalid = statuserr.Errorf("redirect uri is not registered").SetCode(1_400_097).SetStatus(status.BadRequest)
+	ErrOAuthScopeInvalid    = statuserr.Errorf("invalid scope").SetCode(1_400_098).SetStatus(status.BadRequest)
+	ErrOAuthGrantInvalid    = statuserr.Errorf("invalid grant type").SetCode(1_400_099).SetStatus(status.BadRequest)
+	ErrOAuthRequestInvalid  = statuserr.Errorf("invalid authorization request").SetCode(1_400_100).SetStatus(status.BadRequest)
+	ErrInsufficientScope    = statuserr.Errorf("token is not granted with the scope required by api").SetCode(1_403_005).SetStatus(status.Forbidden)
+)
+
+// errors responded by token endpoint and revocation endpoint, see RFC 6749 section 5.2
+var (
+	ErrInvalidRequest       = OAuthError{Status: status.BadRequest, Code: "invalid_request"}
+	ErrInvalidClient        = OAuthError{Status: status.Unauthorized, Code: "invalid_client"}
+	ErrInvalidGrant         = OAuthError{Status: status.BadRequest, Code: "invalid_grant"}
+	ErrUnauthorizedClient   = OAuthError{Status: status.BadRequest, Code: "unauthorized_client"}
+	ErrUnsupportedGrantType = OAuthError{Status: status.BadRequest, Code: "unsupported_grant_type"}
+	ErrInvalidScope         = OAuthError{Status: status.BadRequest, Code: "invalid_scope"}
+	ErrServerError          = OAuthError{Status: status.InternalServerError, Code: "server_error"}
+)
+
+const (
+	GrantAuthorizationCode = "authorization_code"
+	GrantRefreshToken      = "refresh_token"
+	GrantClientCredentials = "client_credentials"
+)
+
+// scopes required by apis which are accessible to delegated tokens
+const (
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+)
+
+// ClientPayloadKey is the key of client id in token payload
+const ClientPayloadKey = "client_id"
+
+// OAuthError is the error response of oauth2 endpoints, it is not wrapped by types.Response.
+type OAuthError struct {
+	Status      status.Status `json:"-"`
+	Code        string        `json:"error"`
+	Description string        `json:"error_description,omitempty"`
+}
+
+func (e OAuthError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+// Describe returns a copy of error with the description
+func (e OAuthError) Describe(description string) OAuthError {
+	e.Description = description
+	return e
+}
+
+// AuthCode is the authorization code waiting for being exchanged
+type AuthCode struct {
+	ClientId    string `json:"clientId"`
+	Uid         string `json:"uid"`
+	RedirectURI string `json:"redirectUri"`
+	Scope       string `json:"scope"`
+	// PKCE S256 code challenge
+	Challenge string `json:"challenge"`
+}
+
+type OAuthClientCreateOptions struct {
+	// client application name
+	Name string `json:"name" binding:"required"`
+	// redirect uris, required by authorization_code grant
+	RedirectURIs []string `json:"redirectUris" binding:"dive,url"`
+	// scopes the client is allowed to request
+	Scopes []string `json:"scopes"`
+	// grant types: authorization_code | refresh_token | client_credentials
+	GrantTypes []string `json:"grantTypes" binding:"required,min=1,dive,oneof=authorization_code refresh_token client_credentials"`
+	// public client has no secret, it must use PKCE
+	Public bool `json:"public"`
+}
+
+type OAuthClientIdOptions struct {
+	Id string `uri:"id" binding:"required"`
+}
+
+type OAuthClientInfo struct {
+	ClientId     string   `json:"clientId"`
+	Name         string   `json:"name"`
+	RedirectURIs []string `json:"redirectUris"`
+	Scopes       []string `json:"scopes"`
+	GrantTypes   []string `json:"grantTypes"`
+	Public       bool     `json:"public"`
+	CreatedAt    int64    `json:"createdAt"`
+}
+
+type OAuthClientCreateResult struct {
+	OAuthClientInfo
+	// client secret, it is only displayed once
+	Secret string `json:"secret,omitempty"`
+}
+
+func EntToOAuthClient(c *ent.OAuthClient) OAuthClientInfo {
+	if c == nil {
+		return OAuthClientInfo{}
+	}
+	return OAuthClientInfo{
+		ClientId:     c.ClientID,
+		Name:         c.Name,
+		RedirectURIs: c.RedirectUris,
+		Scopes:       c.Scopes,
+		GrantTypes:   c.GrantTypes,
+		Public:       c.Public,
+		CreatedAt:    c.CreatedAt,
+	}
+}
+
+func EntsToOAuthClients(clients []*ent.OAuthClient) []OAuthClientInfo {
+	cs := []OAuthClientInfo{}
+	for _, c := range clients {
+		cs = append(cs, EntToOAuthClient(c))
+	}
+	return cs
+}
+
+type AuthorizeOptions struct {
+	// must be code
+	ResponseType string `form:"response_type" json:"response_type" binding:"required"`
+	ClientId     string `form:"client_id" json:"client_id" binding:"required"`
+	RedirectURI  string `form:"redirect_uri" json:"redirect_uri" binding:"required"`
+	// space-delimited scopes, defaults to all scopes allowed for client
+	Scope string `form:"scope" json:"scope"`
+	// opaque value returned to client as is
+	State string `form:"state" json:"state"`
+	// PKCE code challenge
+	CodeChallenge string `form:"code_challenge" json:"code_challenge" binding:"required"`
+	// must be S256
+	CodeChallengeMethod string `form:"code_challenge_method" json:"code_challenge_method" binding:"required"`
+}
+
+type ApproveOptions struct {
+	AuthorizeOptions
+	// whether user approves the authorization
+	Approve bool `json:"approve"`
+}
+
+type ConsentInfo struct {
+	ClientId string   `json:"clientId"`
+	Name     string   `json:"name"`
+	Scopes   []string `json:"scopes"`
+}
+
+type ApproveResult struct {
+	// client redirect uri with code or error, user should be redirected to it
+	RedirectURI string `json:"redirectUri"`
+}
+
+type OAuthTokenOptions struct {
+	GrantType    string `form:"grant_type"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+	RefreshToken string `form:"refresh_token"`
+	Scope        string `form:"scope"`
+	ClientId     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+}
+
+type OAuthRevokeOptions struct {
+	Token         string `form:"token"`
+	TokenTypeHint string `form:"token_type_hint"`
+	ClientId      string `form:"client_id"`
+	ClientSecret  string `form:"client_secret"`
+}
+
+// OAuthTokenResult is the token response defined in RFC 6749 section 5.1
+type OAuthTokenResult struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx/constant/status"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+)
+
+var (
+	ErrPersonalTokenNotFound      = statuserr.Errorf("access token not found").SetCode(1_400_112).SetStatus(status.BadRequest)
+	ErrPersonalTokenLimit         = statuserr.Errorf("too many access tokens").SetCode(1_400_113).SetStatus(status.BadRequest)
+	ErrPersonalTokenExpireInvalid = statuserr.Errorf("access token lifetime exceeds the limit").SetCode(1_400_114).SetStatus(status.BadRequest)
+	ErrPersonalTokenForbidden     = statuserr.Errorf("personal access tokens could not be managed by personal access token").SetCode(1_403_006).SetStatus(status.Forbidden)
+)
+
+// PersonalTokenPayloadKey is the key of personal access token id in token payload
+const PersonalTokenPayloadKey = "pat"
+
+// scopes of self-service apis which are only grantable to personal access tokens, besides them
+// tokens could be granted with oauth2 scopes and permission names, the latter allow apis requiring
+// the permission as long as the owner still has it.
+const (
+	ScopeProfileWrite = "profile:write"
+	ScopeSessions     = "sessions"
+)
+
+// PersonalTokenScopes is the catalog of scopes only grantable to personal access tokens
+var PersonalTokenScopes = []string{ScopeProfileWrite, ScopeSessions}
+
+type PersonalTokenCreateOptions struct {
+	// token name, it helps to recognize the usage
+	Name string `json:"name" binding:"required"`
+	// scopes granted to the token, they are oauth2 scopes, profile:write, sessions or permission names,
+	// it could only access apis which declare one of them
+	Scopes []string `json:"scopes"`
+	// lifetime in seconds, 0 means never expire
+	ExpiresIn int64 `json:"expiresIn" binding:"gte=0"`
+}
+
+type PersonalTokenIdOptions struct {
+	Id string `uri:"id" binding:"required"`
+}
+
+type PersonalTokenInfo struct {
+	Id         string   `json:"id"`
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  int64    `json:"expiresAt"`
+	LastUsedAt int64    `json:"lastUsedAt"`
+	CreatedAt  int64    `json:"createdAt"`
+}
+
+type PersonalTokenCreateResult struct {
+	PersonalTokenInfo
+	// the token, it is only displayed once
+	Token string `json:"token"`
+}
+
+func EntToPersonalToken(p *ent.PersonalToken) PersonalTokenInfo {
+	if p == nil {
+		return PersonalTokenInfo{}
+	}
+	return PersonalTokenInfo{
+		Id:         p.KeyID,
+		Name:       p.Name,
+		Scopes:     p.Scopes,
+		ExpiresAt:  p.ExpiresAt,
+		LastUsedAt: p.LastUsedAt,
+		CreatedAt:  p.CreatedAt,
+	}
+}
+
+func EntsToPersonalTokens(tokens []*ent.PersonalToken) []PersonalTokenInfo {
+	ts := []PersonalTokenInfo{}
+	for _, p := range tokens {
+		ts = append(ts, EntToPersonalToken(p))
+	}
+	return ts
+}
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx/constant/status"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+)
+
+var (
+	ErrRoleNotFound       = statuserr.Errorf("role not found").SetCode(1_400_128).SetStatus(status.BadRequest)
+	ErrRoleAlreadyExists  = statuserr.Errorf("role already exists").SetCode(1_400_129).SetStatus(status.BadRequest)
+	ErrRoleBuiltin        = statuserr.Errorf("builtin role could not be modified").SetCode(1_400_130).SetStatus(status.BadRequest)
+	ErrPermissionNotFound = statuserr.Errorf("permission not found").SetCode(1_400_131).SetStatus(status.BadRequest)
+	ErrPermissionDenied   = statuserr.Errorf("permission denied").SetCode(1_403_001).SetStatus(status.Forbidden)
+)
+
+// AdminRole is the builtin role which is granted with all permissions
+const AdminRole = "admin"
+
+// permissions used by routes
+const (
+	PermUserList   = "user:list"
+	PermUserManage = "user:manage"
+	PermRoleManage = "role:manage"
+)
+
+// Permissions is the catalog of all permissions, it is synchronized into database on startup
+var Permissions = []PermissionInfo{
+	{Name: PermUserList, Description: "list all users"},
+	{Name: PermUserManage, Description: "create, update, disable and delete users"},
+	{Name: PermRoleManage, Description: "manage roles and role assignments"},
+}
+
+type RoleCreateOptions struct {
+	Name        string   `json:"name" binding:"required,max=64"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type RoleNameOptions struct {
+	Name string `uri:"name" binding:"required"`
+}
+
+type UserRoleOptions struct {
+	Uid  string `uri:"uid" binding:"required"`
+	Role string `uri:"role" binding:"required"`
+}
+
+type PermissionInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type RoleInfo struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Builtin     bool     `json:"builtin"`
+	Permissions []string `json:"permissions"`
+	CreatedAt   int64    `json:"createdAt"`
+}
+
+func EntToRole(r *ent.Role) RoleInfo {
+	if r == nil {
+		return RoleInfo{}
+	}
+	perms := []string{}
+	for _, p := range r.Edges.Permissions {
+		perms = append(perms, p.Name)
+	}
+	return RoleInfo{
+		Name:        r.Name,
+		Description: r.Description,
+		Builtin:     r.Builtin,
+		Permissions: perms,
+		CreatedAt:   r.CreatedAt,
+	}
+}
+
+func EntsToRoles(roles []*ent.Role) []RoleInfo {
+	rs := []RoleInfo{}
+	for _, r := range roles {
+		rs = append(rs, EntToRole(r))
+	}
+	return rs
+}
+
+func EntsToPermissions(perms []*ent.Permission) []PermissionInfo {
+	ps := []PermissionInfo{}
+	for _, p := range perms {
+		ps = append(ps, PermissionInfo{Name: p.Name, Description: p.Description})
+	}
+	return ps
+}
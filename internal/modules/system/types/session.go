@@ -0,0 +1,86 @@
+package types
+
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx/constant/status"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+)
+
+var (
+	ErrSessionNotFound = statuserr.Errorf("session not found").SetCode(1_400_048).SetStatus(status.BadRequest)
+)
+
+// SessionPayloadKey is the key of session id in token payload
+const SessionPayloadKey = "sid"
+
+// ClientInfo describes the device which requests for login
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+type SessionIdOptions struct {
+	Id string `uri:"id" binding:"required"`
+}
+
+type SessionInfo struct {
+	Id         string `json:"id"`
+	UserAgent  string `json:"userAgent"`
+	IP         string `json:"ip"`
+	Remember   bool   `json:"remember"`
+	Current    bool   `json:"current"`
+	CreatedAt  int64  `json:"createdAt"`
+	LastSeenAt int64  `json:"lastSeenAt"`
+	ExpiresAt  int64  `json:"expiresAt"`
+}
+
+func EntToSession(s *ent.Session) SessionInfo {
+	if s == nil {
+		return SessionInfo{}
+	}
+	return SessionInfo{
+		Id:         s.Sid,
+		UserAgent:  s.UserAgent,
+		IP:         s.IP,
+		Remember:   s.Remember,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+		ExpiresAt:  s.ExpiresAt,
+	}
+}
+
+func EntsToSessions(sessions []*ent.Session) []SessionInfo {
+	ss := []SessionInfo{}
+	for _, s := range sessions {
+		ss = append(ss, EntToSession(s))
+	}
+	return ss
+}
+
+// LoginHistoryInfo is a successful login, it is kept after the session ended
+type LoginHistoryInfo struct {
+	SessionId string `json:"sessionId"`
+	UserAgent string `json:"userAgent"`
+	IP        string `json:"ip"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+func EntToLoginHistory(l *ent.LoginHistory) LoginHistoryInfo {
+	if l == nil {
+		return LoginHistoryInfo{}
+	}
+	return LoginHistoryInfo{
+		SessionId: l.Sid,
+		UserAgent: l.UserAgent,
+		IP:        l.IP,
+		CreatedAt: l.CreatedAt,
+	}
+}
+
+func EntsToLoginHistories(histories []*ent.LoginHistory) []LoginHistoryInfo {
+	hs := []LoginHistoryInfo{}
+	for _, l := range histories {
+		hs = append(hs, EntToLoginHistory(l))
+	}
+	return hs
+}
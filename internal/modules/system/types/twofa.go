@@ -0,0 +1,47 @@
+package types
+
+import (
+	"github.com/ginx-contribs/ginx/constant/status"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+)
+
+var (
+	ErrTwoFAAlreadyEnabled = statuserr.Errorf("2fa already enabled").SetCode(1_400_064).SetStatus(status.BadRequest)
+	ErrTwoFANotEnabled     = statuserr.Errorf("2fa not enabled").SetCode(1_400_065).SetStatus(status.BadRequest)
+	ErrTwoFANotSetup       = statuserr.Errorf("2fa has not been setup").SetCode(1_400_066).SetStatus(status.BadRequest)
+	ErrTwoFACodeInvalid    = statuserr.Errorf("invalid 2fa code").SetCode(1_400_067).SetStatus(status.BadRequest)
+
+	ErrTwoFAChallengeInvalid = statuserr.Errorf("2fa challenge invalid or expired").SetCode(1_401_005).SetStatus(status.Unauthorized)
+
+	ErrTwoFATooManyAttempts = statuserr.Errorf("too many failed 2fa attempts, try again later").SetCode(1_429_003).SetStatus(status.TooManyRequests)
+)
+
+// TwoFAChallenge is the pending login waiting for the second factor
+type TwoFAChallenge struct {
+	Uid      string
+	Remember bool
+}
+
+type TwoFACodeOptions struct {
+	// current code from authenticator app
+	Code string `json:"code" binding:"required,numeric"`
+}
+
+type TwoFALoginOptions struct {
+	// challenge token returned by login
+	Challenge string `json:"challenge" binding:"required"`
+	// current code from authenticator app, or an unused recovery code
+	Code string `json:"code" binding:"required"`
+}
+
+type TwoFASetupResult struct {
+	// base32 encoded secret for manual entry
+	Secret string `json:"secret"`
+	// otpauth uri for qrcode
+	URI string `json:"uri"`
+}
+
+type TwoFAEnableResult struct {
+	// one-time recovery codes, they are only displayed once
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
@@ -1,27 +1,134 @@
 package types
 
-import "github.com/ginx-contribs/ginx-server/ent"
+import (
+	"github.com/ginx-contribs/ginx-server/ent"
+	"github.com/ginx-contribs/ginx-server/internal/common/types"
+	"github.com/ginx-contribs/ginx/constant/status"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+)
+
+var (
+	ErrMetadataTooLarge   = statuserr.Errorf("metadata is too large").SetCode(1_400_160).SetStatus(status.BadRequest)
+	ErrNoEmailChange      = statuserr.Errorf("no pending email change, apply for it again").SetCode(1_400_195).SetStatus(status.BadRequest)
+	ErrEmailRevertInvalid = statuserr.Errorf("invalid or expired email revert link").SetCode(1_400_196).SetStatus(status.BadRequest)
+)
+
+// MaxMetadataSize is the maximum size of user metadata in json encoding
+const MaxMetadataSize = 4 << 10
 
 type SearchUserOptions struct {
-	Page   int    `form:"page" binding:"required,gt=0"`
-	Size   int    `form:"size" binding:"required,gt=0"`
+	Page int `form:"page" binding:"required,gt=0"`
+	Size int `form:"size" binding:"required,gt=0,lte=100"`
+	UserFilterOptions
+}
+
+// CursorUserOptions lists users by cursor, cursors are only valid with the same sort and order
+type CursorUserOptions struct {
+	types.CursorOptions
+	UserFilterOptions
+}
+
+// UserCursorResult is a page of users paginated by cursor
+type UserCursorResult = types.CursorResult[UserInfo]
+
+// UserFilterOptions filters and sorts users in listing
+type UserFilterOptions struct {
+	// matches username or email
 	Search string `form:"search"`
+	// field to sort by, defaults to id
+	Sort string `form:"sort" binding:"omitempty,oneof=id username email created_at updated_at"`
+	// sort direction, defaults to DESC
+	Order string `form:"order" binding:"omitempty,oneof=ASC DESC asc desc"`
+	// only users in the status
+	Status string `form:"status" binding:"omitempty,oneof=active disabled pending_deletion"`
+	// only users created at or after the time, in unix microseconds
+	CreatedAfter int64 `form:"createdAfter" binding:"omitempty,gt=0"`
+	// only users created before the time, in unix microseconds
+	CreatedBefore int64 `form:"createdBefore" binding:"omitempty,gt=0"`
+}
+
+// EmailChangeOptions applies for changing email, a verification code is sent to the new address.
+// Applying for the current address verifies it without changing.
+type EmailChangeOptions struct {
+	// new email address
+	Email string `json:"email" binding:"required,email"`
+	// current password
+	Password string `json:"password" binding:"required"`
+}
+
+type EmailConfirmOptions struct {
+	// verification code sent to the new address
+	Code string `json:"code" binding:"required,alphanum"`
+}
+
+type EmailRevertOptions struct {
+	// token in the revert link sent to the old address
+	Token string `form:"token" binding:"required"`
+}
+
+// EmailRevert is the change which could be reverted by the link sent to the old address
+type EmailRevert struct {
+	Uid      string
+	OldEmail string
+	NewEmail string
 }
 
 type UidOptions struct {
 	Uid string `form:"uid" uri:"uid" binding:"required"`
 }
 
+// UserInfo is the full profile of user, it should only be visible to the user itself and administrators
 type UserInfo struct {
+	Uid      string `json:"uid"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	// time when the email was verified, 0 means unverified
+	EmailVerifiedAt int64          `json:"emailVerifiedAt"`
+	Nickname        string         `json:"nickname"`
+	AvatarURL       string         `json:"avatarUrl"`
+	Bio             string         `json:"bio"`
+	Locale          string         `json:"locale"`
+	Timezone        string         `json:"timezone"`
+	Metadata        map[string]any `json:"metadata"`
+	CreatedAt       int64          `json:"created_at"`
+}
+
+// PublicUserInfo is the subset of profile which is visible to everyone
+type PublicUserInfo struct {
 	Uid       string `json:"uid"`
 	Username  string `json:"username"`
-	Email     string `json:"email"`
+	Nickname  string `json:"nickname"`
+	AvatarURL string `json:"avatarUrl"`
+	Bio       string `json:"bio"`
 	CreatedAt int64  `json:"created_at"`
 }
 
+// UserProfileUpdateOptions updates profile of current user, nil fields are left unchanged, empty values clear them.
+type UserProfileUpdateOptions struct {
+	Nickname  *string `json:"nickname" binding:"omitempty,max=64"`
+	AvatarURL *string `json:"avatarUrl" binding:"omitempty,max=512,eq=|url"`
+	Bio       *string `json:"bio" binding:"omitempty,max=512"`
+	// language tag in BCP 47, such as en-US
+	Locale *string `json:"locale" binding:"omitempty,eq=|bcp47_language_tag"`
+	// IANA time zone name, such as Asia/Shanghai
+	Timezone *string `json:"timezone" binding:"omitempty,eq=|timezone"`
+	// metadata replaces the existing one as a whole, empty object clears it
+	Metadata map[string]any `json:"metadata" binding:"omitempty,max=32"`
+}
+
+type ChangePasswordOptions struct {
+	// current password
+	OldPassword string `json:"oldPassword" binding:"required"`
+	// new password, it must meet the password policy and not be used recently
+	NewPassword string `json:"newPassword" binding:"required,password"`
+}
+
 type UserSearchResult struct {
-	Total int64      `json:"total"`
-	List  []UserInfo `json:"list"`
+	Page       int        `json:"page"`
+	Size       int        `json:"size"`
+	Total      int64      `json:"total"`
+	TotalPages int64      `json:"totalPages"`
+	List       []UserInfo `json:"list"`
 }
 
 func EntToUser(user *ent.User) UserInfo {
@@ -29,10 +136,36 @@ func EntToUser(user *ent.User) UserInfo {
 		return UserInfo{}
 	}
 
+	metadata := user.Metadata
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
 	return UserInfo{
+		Uid:             user.UID,
+		Username:        user.Username,
+		Email:           user.Email,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		Nickname:        user.Nickname,
+		AvatarURL:       user.AvatarURL,
+		Bio:             user.Bio,
+		Locale:          user.Locale,
+		Timezone:        user.Timezone,
+		Metadata:        metadata,
+		CreatedAt:       user.CreatedAt,
+	}
+}
+
+func EntToPublicUser(user *ent.User) PublicUserInfo {
+	if user == nil {
+		return PublicUserInfo{}
+	}
+
+	return PublicUserInfo{
 		Uid:       user.UID,
 		Username:  user.Username,
-		Email:     user.Email,
+		Nickname:  user.Nickname,
+		AvatarURL: user.AvatarURL,
+		Bio:       user.Bio,
 		CreatedAt: user.CreatedAt,
 	}
 }
@@ -41,7 +174,7 @@ func EntsToUsers(users []*ent.User) []UserInfo {
 	if users == nil {
 		return []UserInfo{}
 	}
-	var us []UserInfo
+	us := make([]UserInfo, 0, len(users))
 	for _, u := range users {
 		us = append(us, EntToUser(u))
 	}
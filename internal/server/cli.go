@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"github.com/ginx-contribs/ginx-server/internal/conf"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/handler"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
+	"github.com/ginx-contribs/ginx-server/internal/wirex"
+	"github.com/ginx-contribs/ginx-server/pkg/mq"
+	"github.com/ginx-contribs/logx"
+)
+
+// NewBulkHandler returns the handler of bulk user import and export for command line, the returned function closes
+// its connections. Redis is only connected if invite is true, since invitation emails are published to the message
+// queue and then sent by the running server.
+func NewBulkHandler(ctx context.Context, appConf *conf.App, logger *logx.Logger, invite bool) (handler.BulkHandler, func() error, error) {
+	db, err := wirex.NewEntDB(ctx, appConf.DB, logger)
+	if err != nil {
+		return handler.BulkHandler{}, nil, err
+	}
+	closers := []func() error{db.Close}
+	closeAll := func() error {
+		var errs []error
+		for _, closer := range closers {
+			errs = append(errs, closer())
+		}
+		return errors.Join(errs...)
+	}
+
+	hasher, err := wirex.NewPasswordHasher(ctx, appConf.Password)
+	if err != nil {
+		return handler.BulkHandler{}, nil, errors.Join(err, closeAll())
+	}
+	policy, err := wirex.NewPasswordPolicy(ctx, appConf.Password)
+	if err != nil {
+		return handler.BulkHandler{}, nil, errors.Join(err, closeAll())
+	}
+	bulkHandler := handler.BulkHandler{
+		UserRepo: repo.UserRepo{DB: db},
+		Hasher:   hasher,
+		Policy:   policy,
+		Config:   appConf.Bulk,
+		Server:   appConf.Server,
+		MetaInfo: appConf.Meta,
+	}
+
+	if invite {
+		redisClient, err := wirex.NewRedisClient(ctx, appConf.Redis)
+		if err != nil {
+			return handler.BulkHandler{}, nil, errors.Join(err, closeAll())
+		}
+		queue := mq.NewStreamQueue(ctx, redisClient)
+		closers = append(closers, queue.Close, redisClient.Close)
+		bulkHandler.EmailHandler = handler.EmailHandler{Config: appConf.Email, Queue: queue}
+	}
+	return bulkHandler, closeAll, nil
+}
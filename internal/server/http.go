@@ -9,6 +9,9 @@ import (
 	"github.com/ginx-contribs/ginx-server/internal/conf"
 	"github.com/ginx-contribs/ginx-server/internal/modules"
 	"github.com/ginx-contribs/ginx-server/internal/wirex"
+	"github.com/ginx-contribs/ginx-server/pkg/challenge"
+	"github.com/ginx-contribs/ginx-server/pkg/email"
+	"github.com/ginx-contribs/ginx-server/pkg/export"
 	"github.com/ginx-contribs/ginx-server/pkg/logh"
 	"github.com/ginx-contribs/ginx-server/pkg/mq"
 	"github.com/ginx-contribs/logx"
@@ -43,16 +46,52 @@ func NewHTTPServer(ctx context.Context, appConf *conf.App, logger *logx.Logger)
 	if err != nil {
 		return nil, err
 	}
+	// initialize password hasher
+	hasher, err := wirex.NewPasswordHasher(ctx, appConf.Password)
+	if err != nil {
+		return nil, err
+	}
+	// initialize password policy
+	passwordPolicy, err := wirex.NewPasswordPolicy(ctx, appConf.Password)
+	if err != nil {
+		return nil, err
+	}
+	// initialize third-party login providers
+	oauthRegistry := wirex.NewOAuthRegistry(ctx, appConf.OAuth)
+	// initialize object storage
+	objectStorage, err := wirex.NewStorage(ctx, appConf.Storage)
+	if err != nil {
+		return nil, err
+	}
+	// initialize pagination cursor signer
+	cursorSigner, err := wirex.NewCursorSigner(ctx, appConf.Server)
+	if err != nil {
+		return nil, err
+	}
+	// initialize verification challenge registry, usages without their own settings use the code configuration
+	challenges := challenge.NewRegistry(challenge.Usage{
+		TTL:      appConf.Email.Code.TTL.Duration(),
+		Retry:    appConf.Email.Code.RetryTTL.Duration(),
+		Attempts: appConf.Email.Code.Attempts,
+		Template: email.TemplateCaptcha,
+	})
 	// initialize message queue
 	queue := mq.NewStreamQueue(ctx, redisClient)
 	// build injector
 	injector := types.Injector{
-		Config: appConf,
-		EntDB:  db,
-		Redis:  redisClient,
-		Token:  tokenResolver,
-		Email:  emailClient,
-		MQ:     queue,
+		Config:         appConf,
+		EntDB:          db,
+		Redis:          redisClient,
+		Token:          tokenResolver,
+		Email:          emailClient,
+		MQ:             queue,
+		Hasher:         hasher,
+		PasswordPolicy: passwordPolicy,
+		OAuth:          oauthRegistry,
+		Storage:        objectStorage,
+		Exporters:      export.NewRegistry(),
+		Cursor:         cursorSigner,
+		Challenges:     challenges,
 	}
 	// initialize ginx server
 	server, err := wirex.NewHttpServer(ctx, appConf, injector)
@@ -78,6 +117,8 @@ func NewHTTPServer(ctx context.Context, appConf *conf.App, logger *logx.Logger)
 	onStart := func(ctx context.Context) error {
 		queue.Start(ctx)
 		slog.Info("message queue is listening")
+		mods.System.AccountHandler.StartPurge(ctx)
+		mods.System.ExportHandler.StartSweep(ctx)
 		if appConf.Server.Swagger {
 			slog.Info(fmt.Sprintf("view server http api doc at http://127.0.0.1:8080/swagger/index.html"))
 		}
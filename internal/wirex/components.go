@@ -2,8 +2,15 @@ package wirex
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/ginx-contribs/ginx-server/ent"
 	"github.com/ginx-contribs/ginx-server/internal/conf"
 	"github.com/ginx-contribs/ginx-server/pkg/email"
+	"github.com/ginx-contribs/ginx-server/pkg/oauth"
+	"github.com/ginx-contribs/ginx-server/pkg/passwd"
+	"github.com/ginx-contribs/ginx-server/pkg/storage"
 	"github.com/ginx-contribs/ginx-server/pkg/token"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/redis/go-redis/v9"
@@ -20,15 +27,159 @@ func NewEmailSender(ctx context.Context, emailConf conf.Email) (*email.Sender, e
 }
 
 func NewTokenResolver(ctx context.Context, jwtconf conf.Jwt, client *redis.Client) (*token.Resolver, error) {
+	accessKeys, err := NewKeyRing(jwtconf.Access.Key, jwtconf.Access.Keys)
+	if err != nil {
+		return nil, fmt.Errorf("access token: %w", err)
+	}
+	refreshKeys, err := NewKeyRing(jwtconf.Refresh.Key, jwtconf.Refresh.Keys)
+	if err != nil {
+		return nil, fmt.Errorf("refresh token: %w", err)
+	}
 	return token.NewResolver(token.Options{
-		Cache:          token.NewRedisTokenCache(client),
-		Issuer:         jwtconf.Issuer,
-		AccessSecret:   jwtconf.Access.Key,
-		AccessMethod:   jwt.SigningMethodHS512,
-		AccessExpired:  jwtconf.Access.Expire.Duration(),
-		AccessDelay:    jwtconf.Access.Delay.Duration(),
-		RefreshSecret:  jwtconf.Refresh.Key,
-		RefreshMethod:  jwt.SigningMethodHS512,
-		RefreshExpired: jwtconf.Refresh.Expire.Duration(),
+		Cache:           token.NewRedisTokenCache(client),
+		Issuer:          jwtconf.Issuer,
+		AccessKeys:      accessKeys,
+		AccessExpired:   jwtconf.Access.Expire.Duration(),
+		AccessDelay:     jwtconf.Access.Delay.Duration(),
+		RefreshKeys:     refreshKeys,
+		RefreshExpired:  jwtconf.Refresh.Expire.Duration(),
+		RefreshRotation: jwtconf.Refresh.Rotate,
 	}), nil
 }
+
+// NewKeyRing builds signing key ring from configuration, the first key is the primary one.
+// If no keys configured, it falls back to HS512 with the given secret.
+func NewKeyRing(secret string, keysconf []conf.SigningKey) (*token.KeyRing, error) {
+	if len(keysconf) == 0 {
+		if secret == "" {
+			return nil, errors.New("neither signing key nor keys is configured")
+		}
+		return token.NewKeyRing(token.NewHMACKey("", jwt.SigningMethodHS512, secret)), nil
+	}
+
+	var keys []token.Key
+	for _, keyconf := range keysconf {
+		method := jwt.GetSigningMethod(keyconf.Method)
+		if method == nil {
+			return nil, fmt.Errorf("key %s: unsupported signing method %q", keyconf.Id, keyconf.Method)
+		}
+		if _, ok := method.(*jwt.SigningMethodHMAC); ok {
+			if keyconf.Secret == "" {
+				return nil, fmt.Errorf("key %s: secret is required for %s", keyconf.Id, keyconf.Method)
+			}
+			keys = append(keys, token.NewHMACKey(keyconf.Id, method, keyconf.Secret))
+			continue
+		}
+		key, err := token.LoadKey(keyconf.Id, method, keyconf.Private, keyconf.Public)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", keyconf.Id, err)
+		}
+		keys = append(keys, key)
+	}
+	if err := token.CheckKeyIds(keys...); err != nil {
+		return nil, err
+	}
+	if keys[0].Private == nil {
+		return nil, fmt.Errorf("key %s: %w", keys[0].Id, token.ErrKeyCanNotSign)
+	}
+	return token.NewKeyRing(keys[0], keys[1:]...), nil
+}
+
+// NewOAuthRegistry returns the registry of third-party login providers
+func NewOAuthRegistry(ctx context.Context, oauthconf conf.OAuth) *oauth.Registry {
+	options := make(map[string]oauth.Options, len(oauthconf.Providers))
+	for name, provider := range oauthconf.Providers {
+		options[name] = oauth.Options{
+			Issuer:       provider.Issuer,
+			ClientID:     provider.ClientID,
+			ClientSecret: provider.ClientSecret,
+			RedirectURL:  provider.RedirectURL,
+			Scopes:       provider.Scopes,
+			AuthURL:      provider.AuthURL,
+			TokenURL:     provider.TokenURL,
+			UserInfoURL:  provider.UserInfoURL,
+		}
+	}
+	return oauth.NewRegistry(options)
+}
+
+// NewPasswordHasher returns password hasher with the configured algorithm, legacy sha1 hashes are still able to be verified.
+func NewPasswordHasher(ctx context.Context, pwdconf conf.Password) (*passwd.Hasher, error) {
+	argon2id := passwd.NewArgon2idHasher(passwd.Argon2Options{
+		Time:    pwdconf.Argon2id.Time,
+		Memory:  pwdconf.Argon2id.Memory,
+		Threads: pwdconf.Argon2id.Threads,
+		KeyLen:  pwdconf.Argon2id.KeyLen,
+		SaltLen: pwdconf.Argon2id.SaltLen,
+	})
+	bcrypt := passwd.NewBcryptHasher(pwdconf.Bcrypt.Cost)
+
+	switch pwdconf.Algorithm {
+	case passwd.Argon2idId:
+		return passwd.New(argon2id, bcrypt, passwd.Sha1Hasher{}), nil
+	case "bcrypt":
+		return passwd.New(bcrypt, argon2id, passwd.Sha1Hasher{}), nil
+	default:
+		return nil, fmt.Errorf("unsupported password hashing algorithm: %s", pwdconf.Algorithm)
+	}
+}
+
+// NewPasswordPolicy returns the policy for checking new passwords, the breached password list file is opened if configured.
+func NewPasswordPolicy(ctx context.Context, pwdconf conf.Password) (*passwd.Policy, error) {
+	options := passwd.PolicyOptions{
+		MinLength:  pwdconf.Policy.MinLength,
+		MaxLength:  pwdconf.Policy.MaxLength,
+		MinClasses: pwdconf.Policy.MinClasses,
+		MinScore:   pwdconf.Policy.MinScore,
+	}
+	if pwdconf.Policy.Breached != "" {
+		source, err := passwd.OpenBreachFile(pwdconf.Policy.Breached)
+		if err != nil {
+			return nil, fmt.Errorf("open breached password list: %w", err)
+		}
+		options.Breached = source
+	}
+	return passwd.NewPolicy(options), nil
+}
+
+// NewStorage returns the object storage with the configured driver
+func NewStorage(ctx context.Context, storageconf conf.Storage) (storage.Storage, error) {
+	switch storageconf.Driver {
+	case "local":
+		secret := []byte(storageconf.Local.Secret)
+		if len(secret) == 0 {
+			secret = make([]byte, 32)
+			if _, err := rand.Read(secret); err != nil {
+				return nil, err
+			}
+		}
+		return storage.NewLocalStorage(storage.LocalOptions{
+			Root:      storageconf.Local.Root,
+			URLPrefix: storageconf.Local.URLPrefix,
+			Secret:    secret,
+		})
+	case "s3":
+		return storage.NewS3Storage(storage.S3Options{
+			Endpoint:  storageconf.S3.Endpoint,
+			Region:    storageconf.S3.Region,
+			Bucket:    storageconf.S3.Bucket,
+			AccessKey: storageconf.S3.AccessKey,
+			SecretKey: storageconf.S3.SecretKey,
+			PathStyle: storageconf.S3.PathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported storage driver: %s", storageconf.Driver)
+	}
+}
+
+// NewCursorSigner returns the signer of pagination cursors
+func NewCursorSigner(ctx context.Context, serverconf conf.Server) (*ent.CursorSigner, error) {
+	secret := []byte(serverconf.CursorSecret)
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+	}
+	return ent.NewCursorSigner(secret), nil
+}
@@ -1,11 +1,19 @@
 package wirex
 
 import (
+	"ariga.io/atlas/sql/migrate"
+	atlas "ariga.io/atlas/sql/schema"
 	"context"
+	"entgo.io/ent/dialect"
 	entsql "entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/schema"
 	"github.com/ginx-contribs/dbx"
 	"github.com/ginx-contribs/ginx-server/ent"
+	// registers hooks and interceptors defined in schema
+	_ "github.com/ginx-contribs/ginx-server/ent/runtime"
+	"github.com/ginx-contribs/ginx-server/ent/user"
 	"github.com/ginx-contribs/ginx-server/internal/conf"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
 	"github.com/ginx-contribs/logx"
 	"github.com/redis/go-redis/v9"
 	"log/slog"
@@ -33,13 +41,38 @@ func NewEntDB(ctx context.Context, dbConf conf.DB, logger *logx.Logger) (*ent.Cl
 		ent.Driver(entsql.OpenDB(dbConf.Driver, sqldb)),
 	)
 	// migrate database
-	if err := entClient.Schema.Create(ctx); err != nil {
+	if err := entClient.Schema.Create(ctx, schema.WithApplyHook(backfillEmailVerified(dbConf.Driver))); err != nil {
 		return nil, err
 	}
 
 	return entClient, err
 }
 
+// backfillEmailVerified marks emails of existing users as verified when email_verified_at is added,
+// they registered before verification was tracked, and would be locked out if unverified users are restricted.
+func backfillEmailVerified(driver string) schema.ApplyHook {
+	return func(next schema.Applier) schema.Applier {
+		return schema.ApplyFunc(func(ctx context.Context, conn dialect.ExecQuerier, plan *migrate.Plan) error {
+			var added bool
+			for _, change := range plan.Changes {
+				modify, ok := change.Source.(*atlas.ModifyTable)
+				if ok && modify.T.Name == user.Table && atlas.Changes(modify.Changes).IndexAddColumn(user.FieldEmailVerifiedAt) != -1 {
+					added = true
+				}
+			}
+			if err := next.Apply(ctx, conn, plan); err != nil || !added {
+				return err
+			}
+			query, args := entsql.Dialect(driver).
+				Update(user.Table).
+				Set(user.FieldEmailVerifiedAt, ts.UnixMicro()).
+				Where(entsql.EQ(user.FieldEmailVerifiedAt, 0)).
+				Query()
+			return conn.Exec(ctx, query, args, nil)
+		})
+	}
+}
+
 // NewRedisClient initialize redis connection
 func NewRedisClient(ctx context.Context, redisConf conf.Redis) (*redis.Client, error) {
 	redisClient := redis.NewClient(&redis.Options{
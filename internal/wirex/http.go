@@ -8,6 +8,8 @@ import (
 	"github.com/ginx-contribs/ginx-server/internal/common/types"
 	"github.com/ginx-contribs/ginx-server/internal/conf"
 	_ "github.com/ginx-contribs/ginx-server/internal/doc"
+	"github.com/ginx-contribs/ginx-server/pkg/passwd"
+	"github.com/ginx-contribs/ginx-server/pkg/storage"
 	"github.com/ginx-contribs/ginx/constant/methods"
 	"github.com/ginx-contribs/ginx/middleware"
 	"github.com/ginx-contribs/ginx/pkg/resp"
@@ -17,13 +19,20 @@ import (
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"log/slog"
+	"net/http"
 	"net/http/pprof"
+	"reflect"
 	"strings"
 	"time"
 )
 
 // NewHttpServer return new http server with given configuration
 func NewHttpServer(ctx context.Context, appConf *conf.App, injector types.Injector) (*ginx.Server, error) {
+	middlewares, err := GlobalMiddlewares(injector)
+	if err != nil {
+		return nil, err
+	}
+
 	server := ginx.New(
 		ginx.WithOptions(ginx.Options{
 			Mode:               gin.ReleaseMode,
@@ -38,17 +47,28 @@ func NewHttpServer(ctx context.Context, appConf *conf.App, injector types.Inject
 		// 404 handler
 		ginx.WithNoRoute(middleware.NoRoute()),
 		// 405 handler
-		ginx.WithNoMethod(middleware.NoMethod(methods.Get, methods.Post, methods.Put, methods.Delete, methods.Options)),
+		ginx.WithNoMethod(middleware.NoMethod(methods.Get, methods.Post, methods.Put, methods.Patch, methods.Delete, methods.Options)),
 		// global middlewares
-		ginx.WithMiddlewares(GlobalMiddlewares(injector)...),
+		ginx.WithMiddlewares(middlewares...),
 	)
 
 	// set validator for gin
-	err := setupHumanizedValidator()
+	err = setupHumanizedValidator(injector.PasswordPolicy)
 	if err != nil {
 		return nil, err
 	}
 
+	// public keys for verifying access token, symmetric keys are never exposed
+	server.Engine().GET("/.well-known/jwks.json", func(ctx *gin.Context) {
+		ctx.Header("Cache-Control", "public, max-age=300")
+		ctx.JSON(http.StatusOK, injector.Token.JWKS())
+	})
+
+	// objects in local storage are served by signed urls
+	if local, ok := injector.Storage.(*storage.LocalStorage); ok {
+		server.Engine().GET(strings.TrimSuffix(appConf.Storage.Local.URLPrefix, "/")+"/*key", gin.WrapH(local))
+	}
+
 	// whether to enable pprof program profiling
 	if appConf.Server.Pprof {
 		server.Engine().GET("/pprof/profile", gin.WrapF(pprof.Profile))
@@ -65,11 +85,20 @@ func NewHttpServer(ctx context.Context, appConf *conf.App, injector types.Inject
 	return server, nil
 }
 
-// override the default ginx validation error handler, see ginx.SetValidateHandler
-func setupHumanizedValidator() error {
+// override the default ginx validation error handler, see ginx.SetValidateHandler.
+// Fields tagged with password=Sibling... are checked by the password policy, siblings are personal information such as
+// username and email, failed rules are responded as violations.
+func setupHumanizedValidator(policy *passwd.Policy) error {
 	v := validator.New()
 	v.SetTagName("binding")
-	englishValidator, err := ginx.EnglishValidator(v, validateParams)
+	err := v.RegisterValidation("password", func(fl validator.FieldLevel) bool {
+		violations, err := policy.Check(fl.Field().String(), passwordInputs(fl.Parent(), fl.Param())...)
+		return err == nil && len(violations) == 0
+	})
+	if err != nil {
+		return err
+	}
+	englishValidator, err := ginx.EnglishValidator(v, validateParams(policy))
 	if err != nil {
 		return err
 	}
@@ -78,17 +107,55 @@ func setupHumanizedValidator() error {
 	return nil
 }
 
-func validateParams(ctx *gin.Context, val any, err error, translator ut.Translator) {
-	var validationErrors validator.ValidationErrors
-	if errors.As(err, &validationErrors) {
-		var errorMsg []string
-		for _, validateErr := range validationErrors {
-			errorMsg = append(errorMsg, validateErr.Translate(translator))
+func validateParams(policy *passwd.Policy) ginx.ValidateTranslator {
+	return func(ctx *gin.Context, val any, err error, translator ut.Translator) {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			var (
+				errorMsg   []string
+				violations []passwd.Violation
+			)
+			for _, validateErr := range validationErrors {
+				if validateErr.Tag() != "password" {
+					errorMsg = append(errorMsg, validateErr.Translate(translator))
+					continue
+				}
+				password, _ := validateErr.Value().(string)
+				failed, err := policy.Check(password, passwordInputs(reflect.ValueOf(val), validateErr.Param())...)
+				if err != nil {
+					ctx.Error(err)
+					resp.InternalError(ctx).JSON()
+					return
+				}
+				for _, violation := range failed {
+					errorMsg = append(errorMsg, violation.Message)
+				}
+				violations = append(violations, failed...)
+			}
+			verr := errors.New(strings.Join(errorMsg, ", "))
+			if len(violations) > 0 {
+				resp.Fail(ctx).Code(types.ErrBadParams.Code).Data(gin.H{"violations": violations}).Error(verr).JSON()
+				return
+			}
+			resp.Fail(ctx).Code(types.ErrBadParams.Code).Error(verr).JSON()
+			return
+		}
+		ctx.Error(err)
+		resp.Fail(ctx).Code(types.ErrBadParams.Code).ErrorMsg("bad params").JSON()
+	}
+}
+
+// passwordInputs returns values of the space separated sibling fields in parent struct, missing fields are ignored
+func passwordInputs(parent reflect.Value, param string) []string {
+	parent = reflect.Indirect(parent)
+	if parent.Kind() != reflect.Struct {
+		return nil
+	}
+	var inputs []string
+	for _, name := range strings.Fields(param) {
+		if field := parent.FieldByName(name); field.Kind() == reflect.String {
+			inputs = append(inputs, field.String())
 		}
-		verr := errors.New(strings.Join(errorMsg, ", "))
-		resp.Fail(ctx).Code(types.ErrBadParams.Code).Error(verr).JSON()
-		return
 	}
-	ctx.Error(err)
-	resp.Fail(ctx).Code(types.ErrBadParams.Code).ErrorMsg("bad params").JSON()
+	return inputs
 }
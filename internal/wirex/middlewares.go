@@ -1,9 +1,14 @@
 package wirex
 
 import (
+	"context"
 	"github.com/gin-gonic/gin"
 	"github.com/ginx-contribs/ginx-server/internal/common/types"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/handler"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
 	"github.com/ginx-contribs/ginx-server/pkg/mids"
+	"github.com/ginx-contribs/ginx-server/pkg/ratelimit"
+	"github.com/ginx-contribs/ginx-server/pkg/token"
 	"github.com/ginx-contribs/ginx/contribs/requestid"
 	"github.com/ginx-contribs/ginx/middleware"
 	"log/slog"
@@ -11,14 +16,20 @@ import (
 )
 
 // GlobalMiddlewares initialize all needed global middlewares, order is important.
-func GlobalMiddlewares(injector types.Injector) []gin.HandlerFunc {
+func GlobalMiddlewares(injector types.Injector) ([]gin.HandlerFunc, error) {
+	rateLimit, err := RateLimit(injector)
+	if err != nil {
+		return nil, err
+	}
 	return []gin.HandlerFunc{
 		Recovery(),
 		RequestID(),
 		AccessLogger(),
 		TokenVerify(injector),
+		rateLimit,
+		Authorize(injector),
 		RequestCache(injector),
-	}
+	}, nil
 }
 
 // Recovery return recovery middleware
@@ -36,12 +47,45 @@ func AccessLogger() gin.HandlerFunc {
 	return middleware.Logger(slog.Default(), "request-log")
 }
 
-// RequestCache return Cache middleware
+// RequestCache return Cache middleware, which only caches public GET apis marked with route.Cacheable
 func RequestCache(injector types.Injector) gin.HandlerFunc {
-	return middleware.CacheRedis("ginx-cache.", time.Second*2, injector.Redis)
+	return mids.RequestCacher(middleware.CacheRedis("ginx-cache.", time.Second*2, injector.Redis))
 }
 
-// TokenVerify return jwt token authenticate middleware
+// TokenVerify return jwt token and personal access token authenticate middleware
 func TokenVerify(injector types.Injector) gin.HandlerFunc {
-	return mids.TokenAuthenticator(injector.Token.VerifyAccess)
+	personalToken := handler.PersonalTokenHandler{
+		UserRepo:          repo.UserRepo{DB: injector.EntDB},
+		PersonalTokenRepo: repo.PersonalTokenRepo{DB: injector.EntDB},
+	}
+	account := handler.AccountHandler{UserRepo: repo.UserRepo{DB: injector.EntDB}}
+	session := handler.SessionHandler{SessionRepo: repo.SessionRepo{DB: injector.EntDB}}
+	// sessions are marked as seen by each authenticated request, not only by refreshing
+	verify := func(ctx context.Context, tokenString string) (token.Token, error) {
+		tokenInfo, err := injector.Token.VerifyAccess(ctx, tokenString)
+		if err == nil {
+			session.Seen(ctx, tokenInfo)
+		}
+		return tokenInfo, err
+	}
+	return mids.TokenAuthenticator(verify, personalToken.Verify, account.CheckActive)
+}
+
+// Authorize returns permission authorize middleware
+func Authorize(injector types.Injector) gin.HandlerFunc {
+	role := handler.RoleHandler{RoleRepo: repo.RoleRepo{DB: injector.EntDB}}
+	return mids.PermissionAuthorizer(role.Permissions)
+}
+
+// RateLimit returns rate limit middleware
+func RateLimit(injector types.Injector) (gin.HandlerFunc, error) {
+	rateconf := injector.Config.RateLimit
+	if rateconf.Disable {
+		return func(ctx *gin.Context) { ctx.Next() }, nil
+	}
+	limiter, err := ratelimit.New(injector.Redis, ratelimit.Algorithm(rateconf.Algorithm))
+	if err != nil {
+		return nil, err
+	}
+	return mids.RateLimiter(limiter, ratelimit.Rule{Limit: rateconf.Limit, Window: rateconf.Window.Duration()}), nil
 }
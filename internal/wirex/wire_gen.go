@@ -16,6 +16,11 @@ import (
 	"github.com/ginx-contribs/ginx-server/internal/modules/system/repo"
 )
 
+import (
+	_ "github.com/ginx-contribs/ginx-server/ent/runtime"
+	_ "github.com/ginx-contribs/ginx-server/internal/doc"
+)
+
 // Injectors from wire.go:
 
 func Inject(injector types.Injector) (modules.Modules, error) {
@@ -34,45 +39,283 @@ func Inject(injector types.Injector) (modules.Modules, error) {
 	if err != nil {
 		return modules.Modules{}, err
 	}
+	registry := injector.Challenges
 	metaInfo := app.Meta
 	captchaHandler := handler.CaptchaHandler{
 		CaptchaCache: redisCaptchaCache,
 		EmailHandler: emailHandler,
+		Challenges:   registry,
+		MetaInfo:     metaInfo,
+	}
+	sessionRepo := repo.SessionRepo{
+		DB: client,
+	}
+	loginHistoryRepo := repo.LoginHistoryRepo{
+		DB: client,
+	}
+	session := app.Session
+	sessionHandler := handler.SessionHandler{
+		SessionRepo:      sessionRepo,
+		LoginHistoryRepo: loginHistoryRepo,
+		Token:            resolver,
+		Config:           session,
+	}
+	recoveryCodeRepo := repo.RecoveryCodeRepo{
+		DB: client,
+	}
+	redisTwoFACache := cache.NewRedisTwoFACache(redisClient)
+	twoFA := app.TwoFA
+	twoFAHandler := handler.TwoFAHandler{
+		UserRepo:         userRepo,
+		RecoveryCodeRepo: recoveryCodeRepo,
+		TwoFACache:       redisTwoFACache,
+		Config:           twoFA,
+	}
+	oauthRegistry := injector.OAuth
+	identityRepo := repo.IdentityRepo{
+		DB: client,
+	}
+	redisOAuthStateCache := cache.NewRedisOAuthStateCache(redisClient)
+	hasher := injector.Hasher
+	oAuth := app.OAuth
+	oAuthHandler := handler.OAuthHandler{
+		Registry:        oauthRegistry,
+		UserRepo:        userRepo,
+		IdentityRepo:    identityRepo,
+		OAuthStateCache: redisOAuthStateCache,
+		Hasher:          hasher,
+		Config:          oAuth,
+	}
+	redisLockoutCache := cache.NewRedisLockoutCache(redisClient)
+	lockout := app.Lockout
+	lockoutHandler := handler.LockoutHandler{
+		LockoutCache: redisLockoutCache,
+		EmailHandler: emailHandler,
+		Config:       lockout,
 		MetaInfo:     metaInfo,
 	}
+	passwordHistoryRepo := repo.PasswordHistoryRepo{
+		DB: client,
+	}
+	policy := injector.PasswordPolicy
+	password := app.Password
+	passwordHandler := handler.PasswordHandler{
+		PasswordHistoryRepo: passwordHistoryRepo,
+		Hasher:              hasher,
+		Policy:              policy,
+		Config:              password,
+	}
+	account := app.Account
 	authHandler := handler.AuthHandler{
-		Token:          resolver,
+		Token:           resolver,
+		UserRepo:        userRepo,
+		CaptchaHandler:  captchaHandler,
+		SessionHandler:  sessionHandler,
+		TwoFAHandler:    twoFAHandler,
+		OAuthHandler:    oAuthHandler,
+		LockoutHandler:  lockoutHandler,
+		PasswordHandler: passwordHandler,
+		Hasher:          hasher,
+		Account:         account,
+	}
+	redisMagicLinkCache := cache.NewRedisMagicLinkCache(redisClient)
+	magicLink := app.MagicLink
+	signer, err := handler.NewLinkSigner(magicLink)
+	if err != nil {
+		return modules.Modules{}, err
+	}
+	server := app.Server
+	magicLinkHandler := handler.MagicLinkHandler{
 		UserRepo:       userRepo,
-		CaptchaHandler: captchaHandler,
+		AuthHandler:    authHandler,
+		MagicLinkCache: redisMagicLinkCache,
+		EmailHandler:   emailHandler,
+		Signer:         signer,
+		Config:         magicLink,
+		Server:         server,
+		MetaInfo:       metaInfo,
 	}
 	authAPI := api.AuthAPI{
-		TokenResolver:  resolver,
-		AuthHandler:    authHandler,
-		CaptchaHandler: captchaHandler,
+		TokenResolver:    resolver,
+		AuthHandler:      authHandler,
+		CaptchaHandler:   captchaHandler,
+		MagicLinkHandler: magicLinkHandler,
 	}
 	repoUserRepo := &repo.UserRepo{
 		DB: client,
 	}
+	cursorSigner := injector.Cursor
 	userHandler := handler.UserHandler{
 		UserRepo: repoUserRepo,
+		Hasher:   hasher,
+		Cursor:   cursorSigner,
+	}
+	storage := injector.Storage
+	confStorage := app.Storage
+	avatarHandler := handler.AvatarHandler{
+		UserRepo: userRepo,
+		Storage:  storage,
+		Config:   confStorage,
+		Server:   server,
+	}
+	exportRegistry := injector.Exporters
+	export := app.Export
+	exportHandler := handler.ExportHandler{
+		Exporters:    exportRegistry,
+		Storage:      storage,
+		EmailHandler: emailHandler,
+		UserRepo:     userRepo,
+		Queue:        queue,
+		Config:       export,
+		Server:       server,
+		MetaInfo:     metaInfo,
+	}
+	accountHandler := handler.AccountHandler{
+		UserRepo:      userRepo,
+		AuthHandler:   authHandler,
+		AvatarHandler: avatarHandler,
+		ExportHandler: exportHandler,
+		Config:        account,
+	}
+	redisEmailChangeCache := cache.NewRedisEmailChangeCache(redisClient)
+	emailChangeHandler := handler.EmailChangeHandler{
+		UserRepo:         userRepo,
+		EmailChangeCache: redisEmailChangeCache,
+		CaptchaHandler:   captchaHandler,
+		AuthHandler:      authHandler,
+		EmailHandler:     emailHandler,
+		Config:           account,
+		Server:           server,
+		MetaInfo:         metaInfo,
 	}
 	userAPI := api.UserAPI{
-		UserHandler: userHandler,
+		UserHandler:        userHandler,
+		AuthHandler:        authHandler,
+		AvatarHandler:      avatarHandler,
+		AccountHandler:     accountHandler,
+		ExportHandler:      exportHandler,
+		EmailChangeHandler: emailChangeHandler,
+	}
+	sessionAPI := api.SessionAPI{
+		SessionHandler: sessionHandler,
+	}
+	twoFAAPI := api.TwoFAAPI{
+		TwoFAHandler: twoFAHandler,
+	}
+	oAuthAPI := api.OAuthAPI{
+		AuthHandler:  authHandler,
+		OAuthHandler: oAuthHandler,
+	}
+	oAuthClientRepo := repo.OAuthClientRepo{
+		DB: client,
+	}
+	redisAuthCodeCache := cache.NewRedisAuthCodeCache(redisClient)
+	oAuthServer := app.OAuthServer
+	oAuthServerHandler := handler.OAuthServerHandler{
+		Token:           resolver,
+		UserRepo:        userRepo,
+		OAuthClientRepo: oAuthClientRepo,
+		AuthCodeCache:   redisAuthCodeCache,
+		Config:          oAuthServer,
+	}
+	oAuthServerAPI := api.OAuthServerAPI{
+		OAuthServerHandler: oAuthServerHandler,
+	}
+	personalTokenRepo := repo.PersonalTokenRepo{
+		DB: client,
+	}
+	personalToken := app.PersonalToken
+	personalTokenHandler := handler.PersonalTokenHandler{
+		UserRepo:          userRepo,
+		PersonalTokenRepo: personalTokenRepo,
+		Config:            personalToken,
+		OAuthServer:       oAuthServer,
+	}
+	personalTokenAPI := api.PersonalTokenAPI{
+		PersonalTokenHandler: personalTokenHandler,
+	}
+	roleRepo := repo.RoleRepo{
+		DB: client,
+	}
+	rbac := app.RBAC
+	roleHandler := handler.RoleHandler{
+		UserRepo: userRepo,
+		RoleRepo: roleRepo,
+		Config:   rbac,
+	}
+	roleAPI := api.RoleAPI{
+		RoleHandler: roleHandler,
+	}
+	bulk := app.Bulk
+	bulkHandler := handler.BulkHandler{
+		UserRepo:     userRepo,
+		Hasher:       hasher,
+		Policy:       policy,
+		EmailHandler: emailHandler,
+		Config:       bulk,
+		Server:       server,
+		MetaInfo:     metaInfo,
+	}
+	auditLogRepo := repo.AuditLogRepo{
+		DB: client,
+	}
+	adminUserHandler := handler.AdminUserHandler{
+		UserHandler:       userHandler,
+		AuthHandler:       authHandler,
+		AccountHandler:    accountHandler,
+		BulkHandler:       bulkHandler,
+		UserRepo:          userRepo,
+		PersonalTokenRepo: personalTokenRepo,
+		AuditLogRepo:      auditLogRepo,
+	}
+	adminUserAPI := api.AdminUserAPI{
+		AdminUserHandler: adminUserHandler,
 	}
 	healthHandler := handler.HealthHandler{}
 	healthAPI := api.HealthAPI{
 		HealthHandler: healthHandler,
 	}
 	module := system.Module{
-		AuthAPI:       authAPI,
-		UserAPI:       userAPI,
-		HealthAPI:     healthAPI,
-		AuthHandler:   authHandler,
-		CodeHandler:   captchaHandler,
-		EmailHandler:  emailHandler,
-		UserHandler:   userHandler,
-		HealthHandler: healthHandler,
-		UserRepo:      userRepo,
+		AuthAPI:              authAPI,
+		UserAPI:              userAPI,
+		SessionAPI:           sessionAPI,
+		TwoFAAPI:             twoFAAPI,
+		OAuthAPI:             oAuthAPI,
+		OAuthServerAPI:       oAuthServerAPI,
+		PersonalTokenAPI:     personalTokenAPI,
+		RoleAPI:              roleAPI,
+		AdminUserAPI:         adminUserAPI,
+		HealthAPI:            healthAPI,
+		AuthHandler:          authHandler,
+		CodeHandler:          captchaHandler,
+		PasswordHandler:      passwordHandler,
+		EmailHandler:         emailHandler,
+		UserHandler:          userHandler,
+		SessionHandler:       sessionHandler,
+		TwoFAHandler:         twoFAHandler,
+		OAuthHandler:         oAuthHandler,
+		OAuthServerHandler:   oAuthServerHandler,
+		PersonalTokenHandler: personalTokenHandler,
+		RoleHandler:          roleHandler,
+		LockoutHandler:       lockoutHandler,
+		AdminUserHandler:     adminUserHandler,
+		AvatarHandler:        avatarHandler,
+		AccountHandler:       accountHandler,
+		ExportHandler:        exportHandler,
+		BulkHandler:          bulkHandler,
+		EmailChangeHandler:   emailChangeHandler,
+		MagicLinkHandler:     magicLinkHandler,
+		HealthHandler:        healthHandler,
+		UserRepo:             userRepo,
+		SessionRepo:          sessionRepo,
+		LoginHistoryRepo:     loginHistoryRepo,
+		RecoveryCodeRepo:     recoveryCodeRepo,
+		IdentityRepo:         identityRepo,
+		OAuthClientRepo:      oAuthClientRepo,
+		PersonalTokenRepo:    personalTokenRepo,
+		RoleRepo:             roleRepo,
+		AuditLogRepo:         auditLogRepo,
+		PasswordHistoryRepo:  passwordHistoryRepo,
 	}
 	modulesModules := modules.Modules{
 		System: module,
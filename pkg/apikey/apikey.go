@@ -0,0 +1,64 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// Prefix is the leading part of all keys, it makes keys recognizable by secret scanners and middlewares.
+const Prefix = "gx_"
+
+// IdLen is the length of key id
+const IdLen = 12
+
+var idEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// New generates a new key in the format of gx_<id>_<secret>, the id is used to look up the key,
+// and only the hash of secret should be stored.
+func New() (key, id, hash string, err error) {
+	idBytes := make([]byte, 8)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", "", "", err
+	}
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", "", "", err
+	}
+	id = idEncoding.EncodeToString(idBytes)[:IdLen]
+	secret := base64.RawURLEncoding.EncodeToString(secretBytes)
+	return Prefix + id + "_" + secret, id, Hash(secret), nil
+}
+
+// Is returns whether s looks like a key
+func Is(s string) bool {
+	return strings.HasPrefix(s, Prefix)
+}
+
+// Parse splits the key into id and secret
+func Parse(key string) (id, secret string, ok bool) {
+	rest, found := strings.CutPrefix(key, Prefix)
+	if !found {
+		return "", "", false
+	}
+	id, secret, found = strings.Cut(rest, "_")
+	if !found || len(id) != IdLen || secret == "" {
+		return "", "", false
+	}
+	return id, secret, true
+}
+
+// Hash returns the hex encoded sha256 of secret, secret is random so that sha256 is sufficient.
+func Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify checks the secret against the hash in constant time
+func Verify(secret, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(Hash(secret)), []byte(hash)) == 1
+}
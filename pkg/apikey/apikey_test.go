@@ -0,0 +1,40 @@
+package apikey
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	key, id, hash, err := New()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, Is(key))
+	assert.Len(t, id, IdLen)
+
+	parsedId, secret, ok := Parse(key)
+	assert.True(t, ok)
+	assert.Equal(t, id, parsedId)
+	assert.True(t, Verify(secret, hash))
+	assert.False(t, Verify(secret+"x", hash))
+	t.Log(key)
+}
+
+func TestParse(t *testing.T) {
+	samples := []struct {
+		key string
+		ok  bool
+	}{
+		{"gx_abcdefghijkl_secret", true},
+		{"gx_abcdefghijkl_se_cr-et", true},
+		{"gx_abcdefghijkl_", false},
+		{"gx_abc_secret", false},
+		{"abcdefghijkl_secret", false},
+		{"", false},
+	}
+	for _, sample := range samples {
+		_, _, ok := Parse(sample.key)
+		assert.Equal(t, sample.ok, ok, sample.key)
+	}
+}
@@ -0,0 +1,134 @@
+package challenge
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+var (
+	ErrDuplicateUsage = errors.New("duplicate challenge usage")
+	ErrInvalidUsage   = errors.New("invalid challenge usage")
+)
+
+// DefaultAlphabet consists of digits and upper case letters
+const DefaultAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// Usage describes what a verification challenge is used for, such as register and reset password.
+// Zero fields fall back to the defaults of registry when registered.
+type Usage struct {
+	// Name identifies the usage in api and cache keys, only lower case letters, digits, - and _ are allowed
+	Name string
+	// Description is a human-readable action, such as "reset password"
+	Description string
+	// lifetime of code
+	TTL time.Duration
+	// min interval between two codes sent to the same recipient
+	Retry time.Duration
+	// max checks of a code, it is invalidated after that
+	Attempts int64
+	// length of code
+	Length int
+	// characters which code consists of
+	Alphabet string
+	// email template to render the code
+	Template string
+	// email subject
+	Subject string
+	// codes of private usage are only sent by its module, they could not be applied for through public api
+	Private bool
+}
+
+func NewRegistry(defaults Usage) *Registry {
+	if defaults.Length <= 0 {
+		defaults.Length = 8
+	}
+	if defaults.Alphabet == "" {
+		defaults.Alphabet = DefaultAlphabet
+	}
+	return &Registry{defaults: defaults, usages: make(map[string]Usage)}
+}
+
+// Registry holds challenge usages registered by modules, it is safe for concurrent use
+type Registry struct {
+	mu       sync.RWMutex
+	defaults Usage
+	usages   map[string]Usage
+}
+
+// Register adds usages into registry, names must be unique
+func (r *Registry) Register(usages ...Usage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, usage := range usages {
+		if !validName(usage.Name) {
+			return fmt.Errorf("%w: %q", ErrInvalidUsage, usage.Name)
+		}
+		if _, ok := r.usages[usage.Name]; ok {
+			return fmt.Errorf("%w: %q", ErrDuplicateUsage, usage.Name)
+		}
+		r.usages[usage.Name] = r.withDefaults(usage)
+	}
+	return nil
+}
+
+// Get returns the usage with the name
+func (r *Registry) Get(name string) (Usage, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	usage, ok := r.usages[name]
+	return usage, ok
+}
+
+// Names returns names of registered usages in order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Sorted(maps.Keys(r.usages))
+}
+
+func (r *Registry) withDefaults(usage Usage) Usage {
+	if usage.Description == "" {
+		usage.Description = usage.Name
+	}
+	if usage.TTL <= 0 {
+		usage.TTL = r.defaults.TTL
+	}
+	if usage.Retry <= 0 {
+		usage.Retry = r.defaults.Retry
+	}
+	if usage.Attempts <= 0 {
+		usage.Attempts = r.defaults.Attempts
+	}
+	if usage.Length <= 0 {
+		usage.Length = r.defaults.Length
+	}
+	if usage.Alphabet == "" {
+		usage.Alphabet = r.defaults.Alphabet
+	}
+	if usage.Template == "" {
+		usage.Template = r.defaults.Template
+	}
+	if usage.Subject == "" {
+		usage.Subject = r.defaults.Subject
+	}
+	if usage.Subject == "" {
+		usage.Subject = fmt.Sprintf("you are applying for verification code to %s.", usage.Description)
+	}
+	return usage
+}
+
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if !('a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package challenge
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRegister(t *testing.T) {
+	registry := NewRegistry(Usage{TTL: time.Minute, Retry: time.Second, Attempts: 5, Template: "captcha.tmpl"})
+	assert.NoError(t, registry.Register(Usage{Name: "register"}, Usage{Name: "reset"}))
+	assert.ErrorIs(t, registry.Register(Usage{Name: "register"}), ErrDuplicateUsage)
+	assert.ErrorIs(t, registry.Register(Usage{Name: ""}), ErrInvalidUsage)
+	assert.ErrorIs(t, registry.Register(Usage{Name: "a:b"}), ErrInvalidUsage)
+	assert.ErrorIs(t, registry.Register(Usage{Name: "Upper"}), ErrInvalidUsage)
+	assert.Equal(t, []string{"register", "reset"}, registry.Names())
+}
+
+func TestRegistry_Get(t *testing.T) {
+	registry := NewRegistry(Usage{TTL: time.Minute, Retry: time.Second, Attempts: 5, Template: "captcha.tmpl"})
+	assert.NoError(t, registry.Register(
+		Usage{Name: "register", Description: "register account"},
+		Usage{Name: "delete", TTL: time.Hour, Attempts: 3, Length: 6, Alphabet: "0123456789", Template: "delete.tmpl", Subject: "confirm deletion"},
+	))
+
+	usage, ok := registry.Get("register")
+	assert.True(t, ok)
+	assert.Equal(t, Usage{
+		Name:        "register",
+		Description: "register account",
+		TTL:         time.Minute,
+		Retry:       time.Second,
+		Attempts:    5,
+		Length:      8,
+		Alphabet:    DefaultAlphabet,
+		Template:    "captcha.tmpl",
+		Subject:     "you are applying for verification code to register account.",
+	}, usage)
+
+	usage, ok = registry.Get("delete")
+	assert.True(t, ok)
+	assert.Equal(t, Usage{
+		Name:        "delete",
+		Description: "delete",
+		TTL:         time.Hour,
+		Retry:       time.Second,
+		Attempts:    3,
+		Length:      6,
+		Alphabet:    "0123456789",
+		Template:    "delete.tmpl",
+		Subject:     "confirm deletion",
+	}, usage)
+
+	_, ok = registry.Get("unknown")
+	assert.False(t, ok)
+}
@@ -0,0 +1,23 @@
+package challenge
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+)
+
+// Hash hashes the code with usage and recipient, so that equal codes have different hashes
+func Hash(usage, to, code string) string {
+	sum := sha256.Sum256([]byte(usage + ":" + to + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify reports whether the code matches the hash on the attempt-th check, and whether the code is exhausted
+// and should be removed. The code never matches after maxAttempts checks, and it is exhausted on the last one.
+func Verify(hash, usage, to, code string, attempt, maxAttempts int64) (match, exhausted bool) {
+	exhausted = attempt >= maxAttempts
+	if attempt > maxAttempts {
+		return false, exhausted
+	}
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(Hash(usage, to, code))) == 1, exhausted
+}
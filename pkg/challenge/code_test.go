@@ -0,0 +1,65 @@
+package challenge
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	hash := Hash("register", "foo@example.com", "ABC123")
+	assert.Equal(t, hash, Hash("register", "foo@example.com", "ABC123"))
+	assert.NotEqual(t, hash, Hash("reset", "foo@example.com", "ABC123"))
+	assert.NotEqual(t, hash, Hash("register", "bar@example.com", "ABC123"))
+	assert.NotEqual(t, hash, Hash("register", "foo@example.com", "ABC124"))
+	assert.NotContains(t, hash, "ABC123")
+}
+
+func TestVerify(t *testing.T) {
+	hash := Hash("register", "foo@example.com", "ABC123")
+	samples := []struct {
+		name      string
+		usage     string
+		to        string
+		code      string
+		attempt   int64
+		match     bool
+		exhausted bool
+	}{
+		{"first attempt", "register", "foo@example.com", "ABC123", 1, true, false},
+		{"wrong code", "register", "foo@example.com", "ABC124", 1, false, false},
+		{"other usage", "reset", "foo@example.com", "ABC123", 1, false, false},
+		{"other recipient", "register", "bar@example.com", "ABC123", 1, false, false},
+		{"last attempt", "register", "foo@example.com", "ABC123", 3, true, true},
+		{"wrong code on last attempt", "register", "foo@example.com", "ABC124", 3, false, true},
+		{"exceeded", "register", "foo@example.com", "ABC123", 4, false, true},
+	}
+	for _, sample := range samples {
+		match, exhausted := Verify(hash, sample.usage, sample.to, sample.code, sample.attempt, 3)
+		assert.Equal(t, sample.match, match, sample.name)
+		assert.Equal(t, sample.exhausted, exhausted, sample.name)
+	}
+}
+
+func TestVerify_Attempts(t *testing.T) {
+	hash := Hash("register", "foo@example.com", "ABC123")
+	// wrong guesses count, then the right code is rejected once attempts are used up
+	var attempt int64
+	for range 2 {
+		attempt++
+		match, exhausted := Verify(hash, "register", "foo@example.com", "000000", attempt, 3)
+		assert.False(t, match)
+		assert.False(t, exhausted)
+	}
+	attempt++
+	match, exhausted := Verify(hash, "register", "foo@example.com", "000000", attempt, 3)
+	assert.False(t, match)
+	assert.True(t, exhausted)
+	attempt++
+	match, _ = Verify(hash, "register", "foo@example.com", "ABC123", attempt, 3)
+	assert.False(t, match)
+
+	// no attempt is allowed without limit
+	match, exhausted = Verify(hash, "register", "foo@example.com", "ABC123", 1, 0)
+	assert.False(t, match)
+	assert.True(t, exhausted)
+}
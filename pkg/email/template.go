@@ -5,7 +5,12 @@ import (
 )
 
 const (
-	TemplateCaptcha = "captcha.tmpl"
+	TemplateCaptcha      = "captcha.tmpl"
+	TemplateLockout      = "lockout.tmpl"
+	TemplateExport       = "export.tmpl"
+	TemplateInvite       = "invite.tmpl"
+	TemplateEmailChanged = "email_changed.tmpl"
+	TemplateMagicLink    = "magic_link.tmpl"
 )
 
 // ParseTemplate parse specified named template with given data
@@ -0,0 +1,135 @@
+package export
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrDuplicateName = errors.New("duplicate exporter name")
+	ErrInvalidName   = errors.New("invalid exporter name")
+)
+
+// ManifestName is the file name of manifest in archive
+const ManifestName = "manifest.json"
+
+// Exporter provides a part of personal data of the user
+type Exporter interface {
+	// Name is used as the file name in archive, it must be unique in registry
+	Name() string
+	// Export returns the data of user, which will be encoded as json
+	Export(ctx context.Context, uid string) (any, error)
+}
+
+// Func returns an Exporter with the given name and function
+func Func(name string, fn func(ctx context.Context, uid string) (any, error)) Exporter {
+	return funcExporter{name: name, fn: fn}
+}
+
+type funcExporter struct {
+	name string
+	fn   func(ctx context.Context, uid string) (any, error)
+}
+
+func (f funcExporter) Name() string {
+	return f.name
+}
+
+func (f funcExporter) Export(ctx context.Context, uid string) (any, error) {
+	return f.fn(ctx, uid)
+}
+
+// Manifest describes the archive
+type Manifest struct {
+	Uid        string   `json:"uid"`
+	ExportedAt int64    `json:"exportedAt"`
+	Files      []string `json:"files"`
+}
+
+func NewRegistry() *Registry {
+	return &Registry{exporters: make(map[string]Exporter)}
+}
+
+// Registry holds exporters registered by modules, it is safe for concurrent use
+type Registry struct {
+	mu        sync.RWMutex
+	exporters map[string]Exporter
+}
+
+// Register adds exporters into registry, names must be unique
+func (r *Registry) Register(exporters ...Exporter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, exporter := range exporters {
+		name := exporter.Name()
+		if name == "" || strings.ContainsAny(name, `/\`) || name+".json" == ManifestName {
+			return fmt.Errorf("%w: %q", ErrInvalidName, name)
+		}
+		if _, ok := r.exporters[name]; ok {
+			return fmt.Errorf("%w: %q", ErrDuplicateName, name)
+		}
+		r.exporters[name] = exporter
+	}
+	return nil
+}
+
+// Names returns names of registered exporters in order
+func (r *Registry) Names() []string {
+	var names []string
+	for _, exporter := range r.sorted() {
+		names = append(names, exporter.Name())
+	}
+	return names
+}
+
+// sorted returns registered exporters sorted by name
+func (r *Registry) sorted() []Exporter {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	exporters := make([]Exporter, 0, len(r.exporters))
+	for _, name := range slices.Sorted(maps.Keys(r.exporters)) {
+		exporters = append(exporters, r.exporters[name])
+	}
+	return exporters
+}
+
+// Archive runs all exporters for the user, and writes a zip archive to w,
+// data of each exporter is stored in <name>.json, along with a manifest.json.
+func (r *Registry) Archive(ctx context.Context, uid string, w io.Writer) error {
+	archive := zip.NewWriter(w)
+	manifest := Manifest{Uid: uid, ExportedAt: time.Now().UnixMicro()}
+	for _, exporter := range r.sorted() {
+		data, err := exporter.Export(ctx, uid)
+		if err != nil {
+			return fmt.Errorf("export %s: %w", exporter.Name(), err)
+		}
+		filename := exporter.Name() + ".json"
+		if err := writeJSON(archive, filename, data); err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, filename)
+	}
+	if err := writeJSON(archive, ManifestName, manifest); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+func writeJSON(archive *zip.Writer, name string, data any) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
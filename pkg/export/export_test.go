@@ -0,0 +1,71 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	registry := NewRegistry()
+	noop := func(ctx context.Context, uid string) (any, error) { return nil, nil }
+	assert.NoError(t, registry.Register(Func("user", noop), Func("sessions", noop)))
+	assert.ErrorIs(t, registry.Register(Func("user", noop)), ErrDuplicateName)
+	assert.ErrorIs(t, registry.Register(Func("", noop)), ErrInvalidName)
+	assert.ErrorIs(t, registry.Register(Func("a/b", noop)), ErrInvalidName)
+	assert.ErrorIs(t, registry.Register(Func("manifest", noop)), ErrInvalidName)
+	assert.Equal(t, []string{"sessions", "user"}, registry.Names())
+}
+
+func TestArchive(t *testing.T) {
+	registry := NewRegistry()
+	assert.NoError(t, registry.Register(
+		Func("user", func(ctx context.Context, uid string) (any, error) {
+			return map[string]string{"uid": uid}, nil
+		}),
+		Func("sessions", func(ctx context.Context, uid string) (any, error) {
+			return []int{1, 2}, nil
+		}),
+	))
+
+	var buf bytes.Buffer
+	assert.NoError(t, registry.Archive(context.Background(), "u1", &buf))
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+
+	files := make(map[string][]byte)
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		assert.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		assert.NoError(t, err)
+		assert.NoError(t, rc.Close())
+		files[file.Name] = content
+	}
+	assert.Len(t, files, 3)
+
+	var user map[string]string
+	assert.NoError(t, json.Unmarshal(files["user.json"], &user))
+	assert.Equal(t, "u1", user["uid"])
+	var sessions []int
+	assert.NoError(t, json.Unmarshal(files["sessions.json"], &sessions))
+	assert.Equal(t, []int{1, 2}, sessions)
+	var manifest Manifest
+	assert.NoError(t, json.Unmarshal(files[ManifestName], &manifest))
+	assert.Equal(t, "u1", manifest.Uid)
+	assert.Equal(t, []string{"sessions.json", "user.json"}, manifest.Files)
+}
+
+func TestArchiveError(t *testing.T) {
+	registry := NewRegistry()
+	failure := errors.New("db down")
+	assert.NoError(t, registry.Register(Func("user", func(ctx context.Context, uid string) (any, error) {
+		return nil, failure
+	})))
+	assert.ErrorIs(t, registry.Archive(context.Background(), "u1", io.Discard), failure)
+}
@@ -0,0 +1,102 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	// register decoders
+	_ "image/gif"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrTooManyPixels     = errors.New("image has too many pixels")
+)
+
+// Decode decodes jpeg, png or gif image, only the first frame of gif is decoded.
+// Images with more than maxPixels pixels are rejected before decoding, to avoid decompression bombs.
+func Decode(r io.Reader, maxPixels int) (image.Image, string, error) {
+	// config is decoded from header, so the content has to be buffered for decoding again
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, "", err
+	}
+	config, format, err := image.DecodeConfig(bytes.NewReader(content))
+	if errors.Is(err, image.ErrFormat) {
+		return nil, "", ErrUnsupportedFormat
+	} else if err != nil {
+		return nil, "", err
+	}
+	if maxPixels > 0 && config.Width*config.Height > maxPixels {
+		return nil, "", ErrTooManyPixels
+	}
+	img, format, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, "", err
+	}
+	return img, format, nil
+}
+
+// CropSquare crops the largest square in the center of image
+func CropSquare(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	x := bounds.Min.X + (bounds.Dx()-side)/2
+	y := bounds.Min.Y + (bounds.Dy()-side)/2
+	square := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(square, square.Bounds(), img, image.Pt(x, y), draw.Src)
+	return square
+}
+
+// Resize scales image to width x height. Each target pixel is the average of source pixels it covers,
+// which produces smooth results for downscaling, and it falls back to the nearest pixel for upscaling.
+func Resize(img image.Image, width, height int) *image.RGBA {
+	src := image.NewRGBA(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()))
+	draw.Draw(src, src.Bounds(), img, img.Bounds().Min, draw.Src)
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	if sw == 0 || sh == 0 {
+		return dst
+	}
+
+	for y := 0; y < height; y++ {
+		y0 := y * sh / height
+		y1 := max((y+1)*sh/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := x * sw / width
+			x1 := max((x+1)*sw/width, x0+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				offset := src.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint64(src.Pix[offset])
+					g += uint64(src.Pix[offset+1])
+					b += uint64(src.Pix[offset+2])
+					a += uint64(src.Pix[offset+3])
+					offset += 4
+					n++
+				}
+			}
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8(r / n)
+			dst.Pix[offset+1] = uint8(g / n)
+			dst.Pix[offset+2] = uint8(b / n)
+			dst.Pix[offset+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// Encode encodes image as jpeg if it is opaque, otherwise as png to keep transparency. It returns the content type.
+func Encode(w io.Writer, img *image.RGBA) (string, error) {
+	if img.Opaque() {
+		return "image/jpeg", jpeg.Encode(w, img, &jpeg.Options{Quality: 90})
+	}
+	return "image/png", png.Encode(w, img)
+}
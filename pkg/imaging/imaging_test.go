@@ -0,0 +1,82 @@
+package imaging
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func newTestImage(w, h int, c color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestDecode(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, newTestImage(40, 30, color.White)))
+
+	img, format, err := Decode(bytes.NewReader(buf.Bytes()), 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "png", format)
+	assert.Equal(t, image.Rect(0, 0, 40, 30), img.Bounds())
+
+	_, _, err = Decode(bytes.NewReader(buf.Bytes()), 1000)
+	assert.ErrorIs(t, err, ErrTooManyPixels)
+	_, _, err = Decode(bytes.NewReader([]byte("not an image")), 0)
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestCropSquare(t *testing.T) {
+	img := newTestImage(40, 30, color.White)
+	// mark the center column
+	for y := 0; y < 30; y++ {
+		img.Set(20, y, color.Black)
+	}
+	square := CropSquare(img)
+	assert.Equal(t, image.Rect(0, 0, 30, 30), square.Bounds())
+	assert.Equal(t, color.RGBAModel.Convert(color.Black), square.At(15, 0))
+}
+
+func TestResize(t *testing.T) {
+	// left half black, right half white
+	img := newTestImage(4, 4, color.White)
+	for y := 0; y < 4; y++ {
+		img.Set(0, y, color.Black)
+		img.Set(1, y, color.Black)
+	}
+	small := Resize(img, 2, 2)
+	assert.Equal(t, image.Rect(0, 0, 2, 2), small.Bounds())
+	assert.Equal(t, color.RGBA{A: 255}, small.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{R: 255, G: 255, B: 255, A: 255}, small.RGBAAt(1, 1))
+
+	// averaged
+	one := Resize(img, 1, 1)
+	assert.Equal(t, uint8(127), one.RGBAAt(0, 0).R)
+
+	big := Resize(img, 8, 8)
+	assert.Equal(t, image.Rect(0, 0, 8, 8), big.Bounds())
+	assert.Equal(t, color.RGBA{A: 255}, big.RGBAAt(3, 7))
+	assert.Equal(t, color.RGBA{R: 255, G: 255, B: 255, A: 255}, big.RGBAAt(4, 0))
+}
+
+func TestEncode(t *testing.T) {
+	var buf bytes.Buffer
+	contentType, err := Encode(&buf, newTestImage(4, 4, color.White))
+	assert.NoError(t, err)
+	assert.Equal(t, "image/jpeg", contentType)
+
+	buf.Reset()
+	contentType, err = Encode(&buf, newTestImage(4, 4, color.Transparent))
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", contentType)
+}
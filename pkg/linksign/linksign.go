@@ -0,0 +1,82 @@
+package linksign
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid link token")
+	ErrTokenExpired = errors.New("link token expired")
+)
+
+// New returns a Signer with the key, a random key is generated if key is empty,
+// then tokens signed before restarting become invalid.
+func New(key []byte) (*Signer, error) {
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+	}
+	return &Signer{key: key, now: time.Now}, nil
+}
+
+// Signer signs tokens carried by links sent to users with HMAC-SHA256, the token is in the format of
+// payload.expires.signature, where expires is unix seconds, so links could be verified without server side state.
+type Signer struct {
+	key []byte
+	now func() time.Time
+}
+
+// Sign returns the token of payload which expires after ttl, payload should be url safe
+func (s *Signer) Sign(payload string, ttl time.Duration) string {
+	signed := payload + "." + strconv.FormatInt(s.now().Add(ttl).Unix(), 10)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(s.mac(signed))
+}
+
+// Verify returns the payload of token, it returns ErrInvalidToken if token is malformed or the signature does not match,
+// and ErrTokenExpired if token has expired.
+func (s *Signer) Verify(token string) (string, error) {
+	signed, signature, ok := cutLast(token)
+	if !ok {
+		return "", ErrInvalidToken
+	}
+	sum, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sum, s.mac(signed)) {
+		return "", ErrInvalidToken
+	}
+	payload, expires, ok := cutLast(signed)
+	if !ok {
+		return "", ErrInvalidToken
+	}
+	expireAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	if s.now().Unix() > expireAt {
+		return "", ErrTokenExpired
+	}
+	return payload, nil
+}
+
+func (s *Signer) mac(signed string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(signed))
+	return mac.Sum(nil)
+}
+
+// cutLast slices s around the last dot
+func cutLast(s string) (string, string, bool) {
+	i := strings.LastIndex(s, ".")
+	if i < 0 {
+		return "", "", false
+	}
+	return s[:i], s[i+1:], true
+}
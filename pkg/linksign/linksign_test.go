@@ -0,0 +1,87 @@
+package linksign
+
+import (
+	"encoding/base64"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestSigner(t *testing.T, key string) *Signer {
+	signer, err := New([]byte(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer.now = func() time.Time { return time.Unix(1_700_000_000, 0) }
+	return signer
+}
+
+func TestSigner_Sign(t *testing.T) {
+	signer := newTestSigner(t, "secret")
+	token := signer.Sign("abc", time.Minute)
+	assert.True(t, strings.HasPrefix(token, "abc.1700000060."))
+
+	payload, err := signer.Verify(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", payload)
+
+	// payload with dots
+	payload, err = signer.Verify(signer.Sign("a.b.c", time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, "a.b.c", payload)
+}
+
+func TestSigner_Verify(t *testing.T) {
+	signer := newTestSigner(t, "secret")
+	token := signer.Sign("abc", time.Minute)
+	signed, signature, _ := cutLast(token)
+	other := newTestSigner(t, "other")
+
+	samples := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{"valid", token, nil},
+		{"empty", "", ErrInvalidToken},
+		{"without signature", signed, ErrInvalidToken},
+		{"tampered payload", "abd" + strings.TrimPrefix(token, "abc"), ErrInvalidToken},
+		{"tampered expires", strings.Replace(token, "1700000060", "1800000060", 1), ErrInvalidToken},
+		{"tampered signature", signed + "." + signature + "x", ErrInvalidToken},
+		{"not base64 signature", signed + ".!!!", ErrInvalidToken},
+		{"other key", other.Sign("abc", time.Minute), ErrInvalidToken},
+		{"without expires", "abc." + base64.RawURLEncoding.EncodeToString(signer.mac("abc")), ErrInvalidToken},
+		{"invalid expires", "abc.never." + base64.RawURLEncoding.EncodeToString(signer.mac("abc.never")), ErrInvalidToken},
+		{"expired", signer.Sign("abc", -time.Second), ErrTokenExpired},
+	}
+	for _, sample := range samples {
+		_, err := signer.Verify(sample.token)
+		if sample.err == nil {
+			assert.NoError(t, err, sample.name)
+		} else {
+			assert.ErrorIs(t, err, sample.err, sample.name)
+		}
+	}
+
+	// token expires after ttl
+	signer.now = func() time.Time { return time.Unix(1_700_000_060, 0) }
+	_, err := signer.Verify(token)
+	assert.NoError(t, err)
+	signer.now = func() time.Time { return time.Unix(1_700_000_061, 0) }
+	_, err = signer.Verify(token)
+	assert.ErrorIs(t, err, ErrTokenExpired)
+}
+
+func TestNew_RandomKey(t *testing.T) {
+	a, err := New(nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	b, err := New(nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = b.Verify(a.Sign("abc", time.Minute))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
@@ -7,15 +7,29 @@ import (
 	"github.com/ginx-contribs/ginx"
 	"github.com/ginx-contribs/ginx-server/internal/common/route"
 	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/apikey"
 	"github.com/ginx-contribs/ginx-server/pkg/token"
+	"github.com/ginx-contribs/ginx-server/pkg/toolset/ginxutils"
 	"github.com/ginx-contribs/ginx/constant/headers"
 	"github.com/ginx-contribs/ginx/constant/status"
 	"github.com/ginx-contribs/ginx/pkg/resp"
+	"slices"
 	"strings"
 )
 
-// TokenAuthenticator authenticates each request if is valid.
-func TokenAuthenticator(verify func(ctx context.Context, token string) (token.Token, error)) gin.HandlerFunc {
+// APIKeyHeader is the header carrying api key
+const APIKeyHeader = "X-API-Key"
+
+// Verifier verifies the credential, and returns the token information
+type Verifier func(ctx context.Context, token string) (token.Token, error)
+
+// UserChecker returns error if the user is no longer allowed to access, such as disabled or deleted
+type UserChecker func(ctx context.Context, uid string) error
+
+// TokenAuthenticator authenticates each request if is valid. API keys are accepted in either X-API-Key header
+// or Authorization header with Bearer scheme, they are verified by verifyKey, which could be nil if not supported.
+// The owner of valid token is checked by check, which could be nil if not needed.
+func TokenAuthenticator(verify Verifier, verifyKey Verifier, check UserChecker) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		// check if is public api
 		metadata := ginx.MetaFromCtx(ctx)
@@ -24,19 +38,44 @@ func TokenAuthenticator(verify func(ctx context.Context, token string) (token.To
 			return
 		}
 
-		// parse token string from header
-		header := ctx.Request.Header.Get(headers.Authorization)
-		pair := strings.Split(header, " ")
-		if len(pair) != 2 || pair[0] != "Bearer" {
-			resp.Fail(ctx).Status(status.Unauthorized).Error(types.ErrCredentialInvalid).JSON()
-			ctx.Abort()
-			return
+		var (
+			tokenString string
+			verifier    = verify
+		)
+		if key := ctx.Request.Header.Get(APIKeyHeader); key != "" && verifyKey != nil {
+			tokenString, verifier = key, verifyKey
+		} else {
+			// parse token string from header
+			header := ctx.Request.Header.Get(headers.Authorization)
+			pair := strings.Split(header, " ")
+			if len(pair) != 2 || pair[0] != "Bearer" {
+				resp.Fail(ctx).Status(status.Unauthorized).Error(types.ErrCredentialInvalid).JSON()
+				ctx.Abort()
+				return
+			}
+			tokenString = pair[1]
+			if apikey.Is(tokenString) && verifyKey != nil {
+				verifier = verifyKey
+			}
 		}
-		tokenString := pair[1]
 
 		// verify token if is valid
-		tokenInfo, err := verify(ctx, tokenString)
+		tokenInfo, err := verifier(ctx, tokenString)
 		if err == nil {
+			// delegated tokens could only access apis within granted scopes
+			if err := checkScope(metadata, tokenInfo); err != nil {
+				ctx.Abort()
+				resp.Fail(ctx).Error(err).JSON()
+				return
+			}
+			// owner of the token might have been disabled or deleted since it was issued
+			if check != nil {
+				if err := check(ctx, tokenInfo.Claims.Subject); err != nil {
+					ctx.Abort()
+					resp.Fail(ctx).Error(err).JSON()
+					return
+				}
+			}
 			// stores token info into context
 			route.SetTokenInfo(ctx, &tokenInfo)
 			ctx.Next()
@@ -54,3 +93,30 @@ func TokenAuthenticator(verify func(ctx context.Context, token string) (token.To
 		}
 	}
 }
+
+// checkScope checks if the token is allowed to access the api by its audience and scopes,
+// first-party tokens are issued to no audience, and they are not restricted by scopes.
+// Personal access tokens are also allowed if granted with the permission name required by api.
+func checkScope(metadata ginx.MetaData, tokenInfo token.Token) error {
+	claims := tokenInfo.Claims
+	if !ginxutils.IsDelegated(&tokenInfo) {
+		if len(claims.Audience) > 0 {
+			return types.ErrCredentialInvalid
+		}
+		return nil
+	}
+	// tokens issued to oauth2 clients must be used by the client itself
+	if clientId, ok := claims.Payload[types.ClientPayloadKey].(string); ok && !slices.Contains(claims.Audience, clientId) {
+		return types.ErrCredentialInvalid
+	}
+	if scope, ok := metadata.Get(route.ScopeKey); ok && claims.HasScope(scope.Val.(string)) {
+		return nil
+	}
+	// personal access tokens could be granted with the permission required by api, the owner is checked later
+	if _, pat := claims.Payload[types.PersonalTokenPayloadKey]; pat {
+		if permission, ok := metadata.Get(route.PermissionKey); ok && claims.HasScope(permission.Val.(string)) {
+			return nil
+		}
+	}
+	return types.ErrInsufficientScope
+}
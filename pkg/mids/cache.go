@@ -0,0 +1,21 @@
+package mids
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ginx-contribs/ginx"
+	"github.com/ginx-contribs/ginx-server/internal/common/route"
+	"net/http"
+)
+
+// RequestCacher applies the cache middleware only to public GET apis which opt in with route.Cacheable.
+// The cache is keyed by uri, so responses of private apis and other methods must never be cached.
+func RequestCacher(cache gin.HandlerFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		metadata := ginx.MetaFromCtx(ctx)
+		if ctx.Request.Method != http.MethodGet || metadata.Contains(route.Private) || !metadata.Contains(route.Cacheable) {
+			ctx.Next()
+			return
+		}
+		cache(ctx)
+	}
+}
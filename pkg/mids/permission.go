@@ -0,0 +1,64 @@
+package mids
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/ginx-contribs/ginx"
+	"github.com/ginx-contribs/ginx-server/internal/common/route"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx/constant/status"
+	"github.com/ginx-contribs/ginx/pkg/resp"
+	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
+	"slices"
+)
+
+// PermissionResolver returns names of permissions granted to the user
+type PermissionResolver func(ctx context.Context, uid string) ([]string, error)
+
+// PermissionAuthorizer checks if the login user is granted with the permission required by api,
+// it must be placed after TokenAuthenticator. Tokens issued to oauth2 clients never carry user permissions,
+// and personal access tokens are rejected unless they are granted with the permission as scope.
+func PermissionAuthorizer(resolve PermissionResolver) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		metadata := ginx.MetaFromCtx(ctx)
+		required, ok := metadata.Get(route.PermissionKey)
+		if !ok {
+			ctx.Next()
+			return
+		}
+
+		tokenInfo, exist, err := route.GetTokenInfo(ctx)
+		if !exist || err != nil {
+			ctx.Abort()
+			resp.Fail(ctx).Status(status.Unauthorized).Error(types.ErrCredentialInvalid).JSON()
+			return
+		}
+
+		if _, client := tokenInfo.Claims.Payload[types.ClientPayloadKey]; client {
+			ctx.Abort()
+			resp.Fail(ctx).Error(types.ErrPermissionDenied).JSON()
+			return
+		}
+		// personal access tokens act as the owner only on apis whose permission is granted to them
+		if _, pat := tokenInfo.Claims.Payload[types.PersonalTokenPayloadKey]; pat {
+			if !tokenInfo.Claims.HasScope(required.Val.(string)) {
+				ctx.Abort()
+				resp.Fail(ctx).Error(types.ErrPermissionDenied).JSON()
+				return
+			}
+		}
+
+		permissions, err := resolve(ctx, tokenInfo.Claims.Subject)
+		if err != nil {
+			ctx.Abort()
+			resp.Fail(ctx).Error(statuserr.InternalError(err)).JSON()
+			return
+		}
+		if !slices.Contains(permissions, required.Val.(string)) {
+			ctx.Abort()
+			resp.Fail(ctx).Error(types.ErrPermissionDenied).JSON()
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,81 @@
+package mids
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/ginx-contribs/ginx"
+	"github.com/ginx-contribs/ginx-server/internal/common/route"
+	"github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/token"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPermissionAuthorizer_PersonalToken(t *testing.T) {
+	tokens := map[string]token.Token{
+		"first-party": {Claims: token.Claims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "admin"},
+		}},
+		"pat-permission": {Claims: token.Claims{
+			Payload:          map[string]any{types.PersonalTokenPayloadKey: "a"},
+			Scope:            "profile user:list",
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "admin"},
+		}},
+		"pat-no-permission": {Claims: token.Claims{
+			Payload:          map[string]any{types.PersonalTokenPayloadKey: "b"},
+			Scope:            "profile",
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "admin"},
+		}},
+		"pat-owner-not-granted": {Claims: token.Claims{
+			Payload:          map[string]any{types.PersonalTokenPayloadKey: "c"},
+			Scope:            "user:list",
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "guest"},
+		}},
+		"client-permission": {Claims: token.Claims{
+			Payload:          map[string]any{types.ClientPayloadKey: "client"},
+			Scope:            "user:list",
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "admin", Audience: jwt.ClaimStrings{"client"}},
+		}},
+	}
+	verify := func(ctx context.Context, raw string) (token.Token, error) {
+		tokenInfo, ok := tokens[raw]
+		if !ok {
+			return token.Token{}, types.ErrCredentialInvalid
+		}
+		tokenInfo.Raw = raw
+		return tokenInfo, nil
+	}
+	resolve := func(ctx context.Context, uid string) ([]string, error) {
+		if uid == "admin" {
+			return []string{"user:list"}, nil
+		}
+		return nil, nil
+	}
+
+	server := ginx.New(ginx.WithMiddlewares(TokenAuthenticator(verify, nil, nil), PermissionAuthorizer(resolve)))
+	server.RouterGroup().MGET("/users", ginx.M{route.Private, route.Permission("user:list")}, func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	samples := []struct {
+		token  string
+		status int
+	}{
+		{"first-party", http.StatusOK},
+		{"pat-permission", http.StatusOK},
+		{"pat-no-permission", http.StatusForbidden},
+		{"pat-owner-not-granted", http.StatusForbidden},
+		{"client-permission", http.StatusForbidden},
+		{"unknown", http.StatusUnauthorized},
+	}
+	for _, sample := range samples {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/users", nil)
+		request.Header.Set("Authorization", "Bearer "+sample.token)
+		server.Engine().ServeHTTP(recorder, request)
+		assert.Equal(t, sample.status, recorder.Code, sample.token)
+	}
+}
@@ -0,0 +1,77 @@
+package mids
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ginx-contribs/ginx"
+	"github.com/ginx-contribs/ginx-server/internal/common/route"
+	"github.com/ginx-contribs/ginx-server/internal/common/types"
+	systype "github.com/ginx-contribs/ginx-server/internal/modules/system/types"
+	"github.com/ginx-contribs/ginx-server/pkg/ratelimit"
+	"github.com/ginx-contribs/ginx/pkg/resp"
+	"math"
+	"strconv"
+	"time"
+)
+
+// rate limit headers defined in draft-ietf-httpapi-ratelimit-headers
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RetryAfterHeader         = "Retry-After"
+)
+
+// RateLimiter limits request rate of each client, it must be placed after TokenAuthenticator so that clients could be
+// identified by api key or login user, anonymous clients are identified by ip. Apis with route.CountLimit metadata are
+// counted separately with their own rule, the others share the default rule.
+func RateLimiter(limiter ratelimit.Limiter, defaultRule ratelimit.Rule) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		rule, scope := defaultRule, "global"
+		if v, ok := ginx.MetaFromCtx(ctx).Get(route.CountKey); ok {
+			rule, scope = v.Val.(ratelimit.Rule), ctx.Request.Method+":"+ctx.FullPath()
+		}
+		if rule.Limit <= 0 || rule.Window <= 0 {
+			ctx.Next()
+			return
+		}
+
+		result, err := limiter.Allow(ctx, "ratelimit:"+scope+":"+clientKey(ctx), rule)
+		if err != nil {
+			// rate limiting should not bring down the service
+			ctx.Error(err)
+			ctx.Next()
+			return
+		}
+
+		ctx.Header(RateLimitLimitHeader, strconv.Itoa(result.Limit))
+		ctx.Header(RateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+		ctx.Header(RateLimitResetHeader, seconds(result.Reset))
+		if !result.Allowed {
+			ctx.Header(RetryAfterHeader, seconds(result.RetryAfter))
+			ctx.Abort()
+			resp.Fail(ctx).Error(types.ErrTooManyRequests).JSON()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// clientKey identifies the client by api key, oauth client, login user or ip in order
+func clientKey(ctx *gin.Context) string {
+	tokenInfo, ok, _ := route.GetTokenInfo(ctx)
+	if !ok {
+		return "ip:" + ctx.ClientIP()
+	}
+	if keyId, ok := tokenInfo.Claims.Payload[systype.PersonalTokenPayloadKey].(string); ok {
+		return "key:" + keyId
+	}
+	if clientId, ok := tokenInfo.Claims.Payload[systype.ClientPayloadKey].(string); ok {
+		return "client:" + clientId
+	}
+	return "uid:" + tokenInfo.Claims.Subject
+}
+
+// seconds rounds the duration up to seconds
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,252 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrProviderNotFound = errors.New("oauth provider not found")
+	ErrMissingIdToken   = errors.New("id_token missing in token response")
+	ErrNonceMismatch    = errors.New("id_token nonce mismatch")
+	ErrMissingSubject   = errors.New("subject missing in user info")
+)
+
+// Options is configuration for an OAuth2 provider
+type Options struct {
+	// OpenID Connect issuer, endpoints will be discovered from issuer if present
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+
+	// endpoints for plain OAuth2 providers without discovery
+	AuthURL     string
+	TokenURL    string
+	UserInfoURL string
+
+	// http client used to request provider, http.DefaultClient if nil
+	Client *http.Client
+}
+
+// Identity is the external user information from provider
+type Identity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+}
+
+// Provider is an OAuth2 or OpenID Connect provider supporting authorization code flow with PKCE
+type Provider struct {
+	name     string
+	opt      Options
+	config   oauth2.Config
+	verifier *oidc.IDTokenVerifier
+	oidc     *oidc.Provider
+}
+
+// NewProvider returns a provider, it discovers endpoints if issuer is configured.
+func NewProvider(ctx context.Context, name string, options Options) (*Provider, error) {
+	if options.Client == nil {
+		options.Client = http.DefaultClient
+	}
+	provider := &Provider{
+		name: name,
+		opt:  options,
+		config: oauth2.Config{
+			ClientID:     options.ClientID,
+			ClientSecret: options.ClientSecret,
+			RedirectURL:  options.RedirectURL,
+			Scopes:       options.Scopes,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  options.AuthURL,
+				TokenURL: options.TokenURL,
+			},
+		},
+	}
+
+	if options.Issuer != "" {
+		discovered, err := oidc.NewProvider(provider.clientContext(ctx), options.Issuer)
+		if err != nil {
+			return nil, err
+		}
+		provider.oidc = discovered
+		provider.config.Endpoint = discovered.Endpoint()
+		provider.verifier = discovered.Verifier(&oidc.Config{ClientID: options.ClientID})
+		if len(provider.config.Scopes) == 0 {
+			provider.config.Scopes = []string{oidc.ScopeOpenID, "profile", "email"}
+		}
+	}
+	return provider, nil
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// AuthCodeURL returns the url to redirect user to for consent, verifier is the PKCE code verifier,
+// nonce is used to bind the id_token with this request, it is ignored for plain OAuth2 providers.
+func (p *Provider) AuthCodeURL(state, nonce, verifier string) string {
+	opts := []oauth2.AuthCodeOption{oauth2.S256ChallengeOption(verifier)}
+	if p.verifier != nil {
+		opts = append(opts, oidc.Nonce(nonce))
+	}
+	return p.config.AuthCodeURL(state, opts...)
+}
+
+// Exchange exchanges the authorization code for user identity
+func (p *Provider) Exchange(ctx context.Context, code, nonce, verifier string) (Identity, error) {
+	ctx = p.clientContext(ctx)
+	token, err := p.config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return Identity{}, err
+	}
+	if p.verifier != nil {
+		return p.identityFromIdToken(ctx, token, nonce)
+	}
+	return p.identityFromUserInfo(ctx, token)
+}
+
+func (p *Provider) identityFromIdToken(ctx context.Context, token *oauth2.Token, nonce string) (Identity, error) {
+	rawIdToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIdToken == "" {
+		return Identity{}, ErrMissingIdToken
+	}
+	idToken, err := p.verifier.Verify(ctx, rawIdToken)
+	if err != nil {
+		return Identity{}, err
+	}
+	if idToken.Nonce != nonce {
+		return Identity{}, ErrNonceMismatch
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+		Picture       string `json:"picture"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return Identity{}, err
+	}
+	return Identity{
+		Provider:      p.name,
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+		Picture:       claims.Picture,
+	}, nil
+}
+
+// identityFromUserInfo requests user info endpoint, it supports the common fields of OIDC and GitHub-like providers.
+func (p *Provider) identityFromUserInfo(ctx context.Context, token *oauth2.Token) (Identity, error) {
+	if p.opt.UserInfoURL == "" {
+		return Identity{}, fmt.Errorf("%s: user info url is not configured", p.name)
+	}
+	response, err := p.config.Client(ctx, token).Get(p.opt.UserInfoURL)
+	if err != nil {
+		return Identity{}, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return Identity{}, fmt.Errorf("%s: user info: %s: %s", p.name, response.Status, body)
+	}
+
+	var info struct {
+		Sub           string      `json:"sub"`
+		Id            json.Number `json:"id"`
+		Email         string      `json:"email"`
+		EmailVerified bool        `json:"email_verified"`
+		Name          string      `json:"name"`
+		Login         string      `json:"login"`
+		Picture       string      `json:"picture"`
+		AvatarURL     string      `json:"avatar_url"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&info); err != nil {
+		return Identity{}, err
+	}
+
+	identity := Identity{
+		Provider:      p.name,
+		Subject:       info.Sub,
+		Email:         info.Email,
+		EmailVerified: info.EmailVerified,
+		Name:          info.Name,
+		Picture:       info.Picture,
+	}
+	if identity.Subject == "" {
+		identity.Subject = info.Id.String()
+	}
+	if identity.Name == "" {
+		identity.Name = info.Login
+	}
+	if identity.Picture == "" {
+		identity.Picture = info.AvatarURL
+	}
+	if identity.Subject == "" {
+		return Identity{}, ErrMissingSubject
+	}
+	return identity, nil
+}
+
+func (p *Provider) clientContext(ctx context.Context) context.Context {
+	return oidc.ClientContext(ctx, p.opt.Client)
+}
+
+// NewRegistry returns a provider registry, providers are initialized lazily on the first use,
+// so that an unavailable provider does not block the application from starting.
+func NewRegistry(options map[string]Options) *Registry {
+	return &Registry{options: options, providers: make(map[string]*Provider)}
+}
+
+// Registry holds configured providers
+type Registry struct {
+	options map[string]Options
+
+	mu        sync.Mutex
+	providers map[string]*Provider
+}
+
+// Get returns the provider with the given name
+func (r *Registry) Get(ctx context.Context, name string) (*Provider, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if provider, ok := r.providers[name]; ok {
+		return provider, nil
+	}
+	options, ok := r.options[name]
+	if !ok {
+		return nil, ErrProviderNotFound
+	}
+	provider, err := NewProvider(ctx, name, options)
+	if err != nil {
+		return nil, err
+	}
+	r.providers[name] = provider
+	return provider, nil
+}
+
+// Names returns names of all configured providers
+func (r *Registry) Names() []string {
+	var names []string
+	for name := range r.options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
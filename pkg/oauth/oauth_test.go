@@ -0,0 +1,186 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/ginx-contribs/ginx-server/pkg/token"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockProvider is a minimal OpenID Connect provider for testing
+type mockProvider struct {
+	server *httptest.Server
+	keys   *token.KeyRing
+
+	mu sync.Mutex
+	// code -> authorize request
+	codes map[string]url.Values
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock := &mockProvider{
+		keys:  token.NewKeyRing(token.Key{Id: "mock", Method: jwt.SigningMethodRS256, Private: rsaKey, Public: rsaKey.Public()}),
+		codes: make(map[string]url.Values),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{
+			"issuer":                                mock.server.URL,
+			"authorization_endpoint":                mock.server.URL + "/authorize",
+			"token_endpoint":                        mock.server.URL + "/token",
+			"userinfo_endpoint":                     mock.server.URL + "/userinfo",
+			"jwks_uri":                              mock.server.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, mock.keys.JWKS())
+	})
+	mux.HandleFunc("/token", mock.token)
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer mock-access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, map[string]any{"id": 1024, "login": "octocat", "email": "octocat@example.com", "avatar_url": "https://example.com/a.png"})
+	})
+	mock.server = httptest.NewServer(mux)
+	t.Cleanup(mock.server.Close)
+	return mock
+}
+
+// authorize simulates user consent, then returns the authorization code
+func (m *mockProvider) authorize(t *testing.T, authURL string) string {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	code := base64.RawURLEncoding.EncodeToString([]byte(time.Now().String()))
+	m.codes[code] = u.Query()
+	return code
+}
+
+func (m *mockProvider) token(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	code := r.FormValue("code")
+	authorize, ok := m.codes[code]
+	delete(m.codes, code)
+
+	// check PKCE
+	sum := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+	if !ok || authorize.Get("code_challenge_method") != "S256" ||
+		authorize.Get("code_challenge") != base64.RawURLEncoding.EncodeToString(sum[:]) {
+		w.WriteHeader(http.StatusBadRequest)
+		writeJSON(w, map[string]any{"error": "invalid_grant"})
+		return
+	}
+
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            m.server.URL,
+		"aud":            authorize.Get("client_id"),
+		"sub":            "mock-subject",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          authorize.Get("nonce"),
+		"email":          "jack@example.com",
+		"email_verified": true,
+		"name":           "jack",
+	})
+	idToken.Header["kid"] = "mock"
+	signed, err := idToken.SignedString(m.keys.Primary().Private)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]any{
+		"access_token": "mock-access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     signed,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestProvider_OIDC(t *testing.T) {
+	ctx := context.Background()
+	mock := newMockProvider(t)
+	registry := NewRegistry(map[string]Options{
+		"mock": {Issuer: mock.server.URL, ClientID: "client", ClientSecret: "secret", RedirectURL: "http://localhost/callback"},
+	})
+	provider, err := registry.Get(ctx, "mock")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	verifier := "verifier-0123456789-0123456789-0123456789-0123456789"
+	authURL := provider.AuthCodeURL("state", "nonce", verifier)
+	assert.Contains(t, authURL, "code_challenge_method=S256")
+	assert.Contains(t, authURL, "nonce=nonce")
+
+	identity, err := provider.Exchange(ctx, mock.authorize(t, authURL), "nonce", verifier)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "mock", identity.Provider)
+	assert.Equal(t, "mock-subject", identity.Subject)
+	assert.Equal(t, "jack@example.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+
+	// nonce is bound to the authorization request
+	_, err = provider.Exchange(ctx, mock.authorize(t, authURL), "other", verifier)
+	assert.ErrorIs(t, err, ErrNonceMismatch)
+
+	// code verifier must match the challenge
+	_, err = provider.Exchange(ctx, mock.authorize(t, authURL), "nonce", verifier+"x")
+	assert.Error(t, err)
+
+	_, err = registry.Get(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrProviderNotFound)
+}
+
+func TestProvider_OAuth2(t *testing.T) {
+	ctx := context.Background()
+	mock := newMockProvider(t)
+	provider, err := NewProvider(ctx, "github", Options{
+		ClientID:    "client",
+		AuthURL:     mock.server.URL + "/authorize",
+		TokenURL:    mock.server.URL + "/token",
+		UserInfoURL: mock.server.URL + "/userinfo",
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	verifier := "verifier-0123456789-0123456789-0123456789-0123456789"
+	authURL := provider.AuthCodeURL("state", "", verifier)
+	identity, err := provider.Exchange(ctx, mock.authorize(t, authURL), "", verifier)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "1024", identity.Subject)
+	assert.Equal(t, "octocat", identity.Name)
+	assert.Equal(t, "https://example.com/a.png", identity.Picture)
+}
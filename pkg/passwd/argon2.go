@@ -0,0 +1,123 @@
+package passwd
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"strings"
+)
+
+const Argon2idId = "argon2id"
+
+// Argon2Options is cost parameters for argon2id
+type Argon2Options struct {
+	// number of passes over the memory
+	Time uint32
+	// memory size in KiB
+	Memory uint32
+	// degree of parallelism
+	Threads uint8
+	// length of the derived key
+	KeyLen uint32
+	// length of the random salt
+	SaltLen uint32
+}
+
+// NewArgon2idHasher returns an Argon2idHasher, zero options will be replaced by the recommended values.
+func NewArgon2idHasher(options Argon2Options) *Argon2idHasher {
+	if options.Time == 0 {
+		options.Time = 3
+	}
+	if options.Memory == 0 {
+		options.Memory = 64 * 1024
+	}
+	if options.Threads == 0 {
+		options.Threads = 2
+	}
+	if options.KeyLen == 0 {
+		options.KeyLen = 32
+	}
+	if options.SaltLen == 0 {
+		options.SaltLen = 16
+	}
+	return &Argon2idHasher{opt: options}
+}
+
+// Argon2idHasher implements PasswordHasher with argon2id,
+// hashes are encoded as $argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>
+type Argon2idHasher struct {
+	opt Argon2Options
+}
+
+func (a *Argon2idHasher) Id() []string {
+	return []string{Argon2idId}
+}
+
+func (a *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, a.opt.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, a.opt.Time, a.opt.Memory, a.opt.Threads, a.opt.KeyLen)
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		Argon2idId, argon2.Version, a.opt.Memory, a.opt.Time, a.opt.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (a *Argon2idHasher) Verify(password, encoded string) (bool, error) {
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+	derived := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, uint32(len(key)))
+	return subtle.ConstantTimeCompare(derived, key) == 1, nil
+}
+
+func (a *Argon2idHasher) NeedsRehash(encoded string) bool {
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return true
+	}
+	return params.Time != a.opt.Time ||
+		params.Memory != a.opt.Memory ||
+		params.Threads != a.opt.Threads ||
+		uint32(len(key)) != a.opt.KeyLen ||
+		uint32(len(salt)) != a.opt.SaltLen
+}
+
+func (a *Argon2idHasher) Valid(encoded string) bool {
+	_, _, _, err := decodeArgon2id(encoded)
+	return err == nil
+}
+
+// decode params, salt and key from the PHC string
+func decodeArgon2id(encoded string) (Argon2Options, []byte, []byte, error) {
+	var params Argon2Options
+	// "", argon2id, v=19, m=65536,t=3,p=2, salt, key
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != Argon2idId {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrInvalidHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads); err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrInvalidHash
+	}
+	return params, salt, key, nil
+}
@@ -0,0 +1,52 @@
+package passwd
+
+import (
+	"errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// NewBcryptHasher returns a BcryptHasher, cost will be bcrypt.DefaultCost if it is out of range.
+func NewBcryptHasher(cost int) *BcryptHasher {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+	return &BcryptHasher{cost: cost}
+}
+
+// BcryptHasher implements PasswordHasher with bcrypt, hashes are encoded as $2a$<cost>$<salt+key>
+type BcryptHasher struct {
+	cost int
+}
+
+func (b *BcryptHasher) Id() []string {
+	return []string{"2a", "2b", "2y"}
+}
+
+func (b *BcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (b *BcryptHasher) Verify(password, encoded string) (bool, error) {
+	// bcrypt compares in constant time
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	} else if err != nil {
+		return false, ErrInvalidHash
+	}
+	return true, nil
+}
+
+func (b *BcryptHasher) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost != b.cost
+}
+
+func (b *BcryptHasher) Valid(encoded string) bool {
+	_, err := bcrypt.Cost([]byte(encoded))
+	return err == nil && len(encoded) == 60
+}
@@ -0,0 +1,123 @@
+package passwd
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// BreachPrefixLen is the length of hash prefix used to query breached passwords, the rest of hash is never sent
+const BreachPrefixLen = 5
+
+// BreachSource queries breached passwords by k-anonymity, such as the range api of haveibeenpwned.
+type BreachSource interface {
+	// Range returns upper case suffixes of sha1 hashes of breached passwords which start with the prefix
+	Range(prefix string) ([]string, error)
+}
+
+// IsBreached reports whether the password appears in the breached passwords of source,
+// only the first BreachPrefixLen characters of its sha1 hash are passed to source.
+func IsBreached(source BreachSource, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	suffixes, err := source.Range(hash[:BreachPrefixLen])
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(suffixes, hash[BreachPrefixLen:]), nil
+}
+
+var _ BreachSource = (*FileBreachSource)(nil)
+
+// OpenBreachFile opens the breached password list file, it consists of lines of sha1 hashes sorted in ascending order,
+// each line may be followed by a count separated by colon, which is the format of the haveibeenpwned downloader.
+func OpenBreachFile(name string) (*FileBreachSource, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return nil, errors.Join(err, file.Close())
+	}
+	return &FileBreachSource{file: file, size: info.Size()}, nil
+}
+
+// FileBreachSource looks up the range of prefix in a sorted hash list file by binary search,
+// so that the file, which is usually dozens of gigabytes, is never loaded into memory.
+type FileBreachSource struct {
+	file *os.File
+	size int64
+}
+
+func (f *FileBreachSource) Range(prefix string) ([]string, error) {
+	prefix = strings.ToUpper(prefix)
+	// find the smallest offset whose line is not less than prefix
+	lo, hi := int64(0), f.size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		key, _, err := f.keyAt(mid)
+		if err != nil {
+			return nil, err
+		}
+		if key != "" && key < prefix {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	_, start, err := f.keyAt(lo)
+	if err != nil {
+		return nil, err
+	}
+
+	var suffixes []string
+	scanner := bufio.NewScanner(io.NewSectionReader(f.file, start, f.size-start))
+	for scanner.Scan() {
+		hash, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		hash = strings.ToUpper(hash)
+		if !strings.HasPrefix(hash, prefix) {
+			break
+		}
+		suffixes = append(suffixes, hash[len(prefix):])
+	}
+	return suffixes, scanner.Err()
+}
+
+// keyAt returns the hash prefix and offset of the first line starting at or after off, key is empty at the end of file
+func (f *FileBreachSource) keyAt(off int64) (string, int64, error) {
+	start := off
+	if off > 0 {
+		// the line starts at off only if the previous byte is a line break
+		buf := make([]byte, 128)
+		n, err := f.file.ReadAt(buf, off-1)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", 0, err
+		}
+		i := bytes.IndexByte(buf[:n], '\n')
+		if i < 0 {
+			return "", f.size, nil
+		}
+		start = off + int64(i)
+	}
+
+	key := make([]byte, BreachPrefixLen)
+	n, err := f.file.ReadAt(key, start)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", 0, err
+	} else if n < BreachPrefixLen {
+		return "", f.size, nil
+	}
+	return strings.ToUpper(string(key)), start, nil
+}
+
+// Close closes the underlying file
+func (f *FileBreachSource) Close() error {
+	return f.file.Close()
+}
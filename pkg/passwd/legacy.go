@@ -0,0 +1,36 @@
+package passwd
+
+import (
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+)
+
+const Sha1Id = "sha1"
+
+// Sha1Hasher is the legacy unsalted sha1 hasher, it only exists for verifying old hashes,
+// and all hashes generated by it should be upgraded on next verification.
+type Sha1Hasher struct{}
+
+func (s Sha1Hasher) Id() []string {
+	return []string{Sha1Id}
+}
+
+func (s Sha1Hasher) Hash(password string) (string, error) {
+	sum := sha1.Sum([]byte(password))
+	return base64.StdEncoding.EncodeToString(sum[:]), nil
+}
+
+func (s Sha1Hasher) Verify(password, encoded string) (bool, error) {
+	hash, _ := s.Hash(password)
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(encoded)) == 1, nil
+}
+
+func (s Sha1Hasher) NeedsRehash(encoded string) bool {
+	return true
+}
+
+func (s Sha1Hasher) Valid(encoded string) bool {
+	sum, err := base64.StdEncoding.DecodeString(encoded)
+	return err == nil && len(sum) == sha1.Size
+}
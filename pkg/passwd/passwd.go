@@ -0,0 +1,93 @@
+package passwd
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalidHash      = errors.New("invalid password hash")
+	ErrUnknownAlgorithm = errors.New("unknown password hash algorithm")
+)
+
+// PasswordHasher is responsible for hashing and verifying passwords, hashes are encoded in PHC string format.
+type PasswordHasher interface {
+	// Id returns the algorithm identifiers in PHC string, e.g. argon2id
+	Id() []string
+	// Hash returns the encoded hash of the given password
+	Hash(password string) (string, error)
+	// Verify reports whether the password matches the encoded hash
+	Verify(password, encoded string) (bool, error)
+	// NeedsRehash reports whether the encoded hash was generated with outdated parameters
+	NeedsRehash(encoded string) bool
+	// Valid reports whether the encoded hash is well-formed, it is cheap since nothing is derived
+	Valid(encoded string) bool
+}
+
+// New returns a Hasher which hashes password with preferred, and is still able to verify hashes generated by legacy.
+func New(preferred PasswordHasher, legacy ...PasswordHasher) *Hasher {
+	hasher := &Hasher{preferred: preferred, hashers: make(map[string]PasswordHasher)}
+	hasher.dummy = sync.OnceValues(func() (string, error) {
+		return preferred.Hash("dummy password for timing equalization")
+	})
+	for _, h := range append(legacy, preferred) {
+		for _, id := range h.Id() {
+			hasher.hashers[id] = h
+		}
+	}
+	return hasher
+}
+
+// Hasher dispatches verification to the corresponding PasswordHasher by identifier of the encoded hash.
+type Hasher struct {
+	preferred PasswordHasher
+	hashers   map[string]PasswordHasher
+	dummy     func() (string, error)
+}
+
+// Hash returns the encoded hash of the given password with the preferred hasher
+func (h *Hasher) Hash(password string) (string, error) {
+	return h.preferred.Hash(password)
+}
+
+// Verify reports whether the password matches the encoded hash, and whether the hash should be upgraded to the preferred one.
+func (h *Hasher) Verify(password, encoded string) (match bool, rehash bool, err error) {
+	id := Identify(encoded)
+	hasher, ok := h.hashers[id]
+	if !ok {
+		return false, false, ErrUnknownAlgorithm
+	}
+	match, err = hasher.Verify(password, encoded)
+	if err != nil || !match {
+		return false, false, err
+	}
+	return true, hasher != h.preferred || hasher.NeedsRehash(encoded), nil
+}
+
+// Valid reports whether the encoded hash is well-formed and generated by a supported algorithm, such as pre-hashed
+// passwords of imported users.
+func (h *Hasher) Valid(encoded string) bool {
+	hasher, ok := h.hashers[Identify(encoded)]
+	return ok && hasher.Valid(encoded)
+}
+
+// VerifyDummy verifies the password against a dummy hash, it costs the same time as Verify, so that the caller
+// could equalize response time when the user does not exist. It always reports mismatch.
+func (h *Hasher) VerifyDummy(password string) error {
+	encoded, err := h.dummy()
+	if err != nil {
+		return err
+	}
+	_, err = h.preferred.Verify(password, encoded)
+	return err
+}
+
+// Identify returns the algorithm identifier of the encoded hash, hashes that are not in PHC format are considered as legacy sha1.
+func Identify(encoded string) string {
+	if !strings.HasPrefix(encoded, "$") {
+		return Sha1Id
+	}
+	id, _, _ := strings.Cut(encoded[1:], "$")
+	return id
+}
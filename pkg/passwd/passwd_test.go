@@ -0,0 +1,112 @@
+package passwd
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestArgon2id(t *testing.T) {
+	hasher := NewArgon2idHasher(Argon2Options{Memory: 1024})
+	hash, err := hasher.Hash("123456")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=3,p=2$"))
+
+	match, err := hasher.Verify("123456", hash)
+	assert.NoError(t, err)
+	assert.True(t, match)
+
+	match, err = hasher.Verify("1234567", hash)
+	assert.NoError(t, err)
+	assert.False(t, match)
+
+	assert.False(t, hasher.NeedsRehash(hash))
+	assert.True(t, NewArgon2idHasher(Argon2Options{Memory: 2048}).NeedsRehash(hash))
+	t.Log(hash)
+}
+
+func TestArgon2id_Invalid(t *testing.T) {
+	hasher := NewArgon2idHasher(Argon2Options{Memory: 1024})
+	_, err := hasher.Verify("123456", "$argon2id$v=19$m=1024$abc$abc")
+	assert.ErrorIs(t, err, ErrInvalidHash)
+}
+
+func TestBcrypt(t *testing.T) {
+	hasher := NewBcryptHasher(4)
+	hash, err := hasher.Hash("123456")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "2a", Identify(hash))
+
+	match, err := hasher.Verify("123456", hash)
+	assert.NoError(t, err)
+	assert.True(t, match)
+
+	match, err = hasher.Verify("1234567", hash)
+	assert.NoError(t, err)
+	assert.False(t, match)
+
+	assert.False(t, hasher.NeedsRehash(hash))
+	assert.True(t, NewBcryptHasher(5).NeedsRehash(hash))
+	t.Log(hash)
+}
+
+func TestHasher_Rehash(t *testing.T) {
+	hasher := New(NewArgon2idHasher(Argon2Options{Memory: 1024}), NewBcryptHasher(4), Sha1Hasher{})
+
+	samples := []struct {
+		hasher PasswordHasher
+		rehash bool
+	}{
+		{Sha1Hasher{}, true},
+		{NewBcryptHasher(4), true},
+		{NewArgon2idHasher(Argon2Options{Memory: 2048}), true},
+		{NewArgon2idHasher(Argon2Options{Memory: 1024}), false},
+	}
+
+	for _, sample := range samples {
+		hash, err := sample.hasher.Hash("123456")
+		if !assert.NoError(t, err) {
+			return
+		}
+		match, rehash, err := hasher.Verify("123456", hash)
+		assert.NoError(t, err)
+		assert.True(t, match)
+		assert.Equal(t, sample.rehash, rehash)
+
+		match, rehash, err = hasher.Verify("654321", hash)
+		assert.NoError(t, err)
+		assert.False(t, match)
+		assert.False(t, rehash)
+	}
+}
+
+func TestHasher_Unknown(t *testing.T) {
+	hasher := New(NewArgon2idHasher(Argon2Options{Memory: 1024}))
+	_, _, err := hasher.Verify("123456", "$scrypt$ln=16,r=8,p=1$aM15713r3Xsvxbi31lqr1Q$nFNh2CVHVjNldFVKDHDlm4CbdRSCdEBsjjJxD+iCs5E")
+	assert.ErrorIs(t, err, ErrUnknownAlgorithm)
+}
+
+func TestHasher_VerifyDummy(t *testing.T) {
+	hasher := New(NewArgon2idHasher(Argon2Options{Memory: 1024}))
+	assert.NoError(t, hasher.VerifyDummy("123456"))
+	assert.NoError(t, hasher.VerifyDummy("654321"))
+}
+
+func TestHasher_Valid(t *testing.T) {
+	hasher := New(NewArgon2idHasher(Argon2Options{Memory: 1024}), NewBcryptHasher(4), Sha1Hasher{})
+	for _, h := range []PasswordHasher{NewArgon2idHasher(Argon2Options{Memory: 1024}), NewBcryptHasher(4), Sha1Hasher{}} {
+		hash, err := h.Hash("123456")
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.True(t, hasher.Valid(hash))
+		assert.False(t, hasher.Valid(hash[:len(hash)-2]))
+	}
+	assert.False(t, hasher.Valid(""))
+	assert.False(t, hasher.Valid("123456"))
+	assert.False(t, hasher.Valid("$scrypt$ln=16,r=8,p=1$aM15713r3Xsvxbi31lqr1Q$nFNh2CVHVjNldFVKDHDlm4CbdRSCdEBsjjJxD+iCs5E"))
+}
@@ -0,0 +1,134 @@
+package passwd
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// rules of password policy
+const (
+	RuleMinLength    = "min_length"
+	RuleMaxLength    = "max_length"
+	RuleCharClasses  = "char_classes"
+	RuleStrength     = "strength"
+	RulePersonalInfo = "personal_info"
+	RuleBreached     = "breached"
+	RuleHistory      = "history"
+)
+
+// Violation is a rule which the password fails to meet
+type Violation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// PolicyOptions holds rules of password policy, zero values disable the corresponding rules
+type PolicyOptions struct {
+	// min number of characters
+	MinLength int
+	// max number of characters
+	MaxLength int
+	// min number of character classes in lower case letters, upper case letters, digits and symbols
+	MinClasses int
+	// min strength score, see Strength
+	MinScore int
+	// source of breached passwords
+	Breached BreachSource
+}
+
+func NewPolicy(options PolicyOptions) *Policy {
+	return &Policy{options: options}
+}
+
+// Policy checks whether passwords are strong enough, it is safe for concurrent use
+type Policy struct {
+	options PolicyOptions
+}
+
+// Check returns violations of the password, nil if it meets all rules. Inputs are personal information of user, such as
+// username and email, the password must not contain any of them, local part of email is checked as well.
+// The error is only returned if failed to query breached passwords.
+func (p *Policy) Check(password string, inputs ...string) ([]Violation, error) {
+	var violations []Violation
+	length := utf8.RuneCountInString(password)
+	if p.options.MinLength > 0 && length < p.options.MinLength {
+		violations = append(violations, Violation{Rule: RuleMinLength, Message: fmt.Sprintf("password must be at least %d characters", p.options.MinLength)})
+	}
+	if p.options.MaxLength > 0 && length > p.options.MaxLength {
+		violations = append(violations, Violation{Rule: RuleMaxLength, Message: fmt.Sprintf("password must be at most %d characters", p.options.MaxLength)})
+	}
+	if p.options.MinClasses > 0 && charClasses(password) < p.options.MinClasses {
+		violations = append(violations, Violation{Rule: RuleCharClasses, Message: fmt.Sprintf("password must contain at least %d of lower case letters, upper case letters, digits and symbols", p.options.MinClasses)})
+	}
+
+	violations = append(violations, p.CheckPersonal(password, inputs...)...)
+
+	if p.options.Breached != nil {
+		breached, err := IsBreached(p.options.Breached, password)
+		if err != nil {
+			return nil, err
+		} else if breached {
+			violations = append(violations, Violation{Rule: RuleBreached, Message: "password has appeared in a data breach"})
+		}
+	}
+	return violations, nil
+}
+
+// CheckPersonal returns violations of the rules depending on inputs, they are personal information and strength.
+// It is for the password which has passed Check without some inputs, so that the rest rules are not checked again.
+func (p *Policy) CheckPersonal(password string, inputs ...string) []Violation {
+	var violations []Violation
+	inputs = personalInputs(inputs)
+	if containsAny(strings.ToLower(password), inputs) {
+		violations = append(violations, Violation{Rule: RulePersonalInfo, Message: "password must not contain username or email"})
+	}
+	if p.options.MinScore > 0 && Strength(password, inputs...) < p.options.MinScore {
+		violations = append(violations, Violation{Rule: RuleStrength, Message: "password is too easy to guess"})
+	}
+	return violations
+}
+
+// charClasses returns the number of character classes in password
+func charClasses(password string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
+
+// personalInputs returns lower case inputs along with local parts of emails, short ones are ignored since they are
+// likely to be contained by chance.
+func personalInputs(inputs []string) []string {
+	var result []string
+	for _, input := range inputs {
+		input = strings.ToLower(input)
+		if local, _, found := strings.Cut(input, "@"); found && utf8.RuneCountInString(local) >= 3 {
+			result = append(result, local)
+		}
+		if utf8.RuneCountInString(input) >= 3 {
+			result = append(result, input)
+		}
+	}
+	return result
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+	return false
+}
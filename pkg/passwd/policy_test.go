@@ -0,0 +1,131 @@
+package passwd
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestStrength(t *testing.T) {
+	samples := []struct {
+		password string
+		score    int
+	}{
+		{"1", 0},
+		{"password", 0},
+		{"Password1", 0},
+		{"p@ssw0rd", 0},
+		{"drowssap", 0},
+		{"qwerty123", 0},
+		{"abcdefgh", 0},
+		{"aaaaaaaaaaaa", 0},
+		{"zxcvbnm", 0},
+		{"john2024", 2},
+		{"xK9#mQ2$vL7p", 4},
+		{"correcthorsebatterystaple", 4},
+	}
+	for _, sample := range samples {
+		assert.Equal(t, sample.score, Strength(sample.password), sample.password)
+	}
+
+	// personal information is as guessable as common passwords
+	assert.Less(t, Strength("jackson1990", "jackson"), Strength("jackson1990"))
+}
+
+func TestPolicy_Check(t *testing.T) {
+	policy := NewPolicy(PolicyOptions{MinLength: 8, MaxLength: 16, MinClasses: 3, MinScore: 2})
+
+	rules := func(password string, inputs ...string) []string {
+		violations, err := policy.Check(password, inputs...)
+		assert.NoError(t, err)
+		var names []string
+		for _, violation := range violations {
+			names = append(names, violation.Rule)
+		}
+		return names
+	}
+
+	assert.Empty(t, rules("xK9#mQ2$vL7p"))
+	assert.Equal(t, []string{RuleMinLength, RuleCharClasses, RuleStrength}, rules("1"))
+	assert.Equal(t, []string{RuleMaxLength}, rules("xK9#mQ2$vL7p-xK9#mQ2$vL7p"))
+	assert.Equal(t, []string{RuleCharClasses}, rules("xkqmvlpwzrtn"))
+	assert.Equal(t, []string{RulePersonalInfo, RuleStrength}, rules("Jackson#1990", "jackson", "foo@example.com"))
+	assert.Equal(t, []string{RulePersonalInfo}, rules("xK9#Jackson$vL7p", "foo", "jackson@example.com"))
+	// short inputs are ignored
+	assert.Empty(t, rules("xK9#mQ2$vL7p", "xk"))
+}
+
+func TestPolicy_CheckPersonal(t *testing.T) {
+	policy := NewPolicy(PolicyOptions{MinLength: 8, MinClasses: 3, MinScore: 2})
+
+	rules := func(password string, inputs ...string) []string {
+		var names []string
+		for _, violation := range policy.CheckPersonal(password, inputs...) {
+			names = append(names, violation.Rule)
+		}
+		return names
+	}
+
+	assert.Empty(t, rules("xK9#mQ2$vL7p", "jackson", "foo@example.com"))
+	// length and character classes are left to Check
+	assert.Empty(t, rules("xkqmvlpwzrtn"))
+	assert.Equal(t, []string{RulePersonalInfo, RuleStrength}, rules("Jackson#1990", "jackson", "foo@example.com"))
+	assert.Equal(t, []string{RulePersonalInfo}, rules("xK9#Jackson$vL7p", "foo", "jackson@example.com"))
+}
+
+func TestFileBreachSource(t *testing.T) {
+	breached := []string{"password", "123456", "qwerty", "letmein", "monkey", "dragon"}
+	var lines []string
+	for i, password := range breached {
+		sum := sha1.Sum([]byte(password))
+		lines = append(lines, strings.ToUpper(hex.EncodeToString(sum[:]))+":"+strings.Repeat("1", i+1))
+	}
+	// pad with hashes around, so that binary search does not hit the targets directly
+	for i := 0; i < 500; i++ {
+		sum := sha1.Sum([]byte{byte(i), byte(i >> 8), 'x'})
+		lines = append(lines, strings.ToUpper(hex.EncodeToString(sum[:]))+":1")
+	}
+	slices.Sort(lines)
+
+	name := filepath.Join(t.TempDir(), "breached.txt")
+	if !assert.NoError(t, os.WriteFile(name, []byte(strings.Join(lines, "\r\n")+"\r\n"), 0o600)) {
+		return
+	}
+	source, err := OpenBreachFile(name)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer source.Close()
+
+	for _, password := range breached {
+		found, err := IsBreached(source, password)
+		assert.NoError(t, err)
+		assert.True(t, found, password)
+	}
+	for _, password := range []string{"xK9#mQ2$vL7p", "", "password1"} {
+		found, err := IsBreached(source, password)
+		assert.NoError(t, err)
+		assert.False(t, found, password)
+	}
+
+	// boundaries of the file
+	suffixes, err := source.Range(lines[0][:BreachPrefixLen])
+	assert.NoError(t, err)
+	assert.Contains(t, suffixes, lines[0][BreachPrefixLen:strings.Index(lines[0], ":")])
+	suffixes, err = source.Range(lines[len(lines)-1][:BreachPrefixLen])
+	assert.NoError(t, err)
+	assert.NotEmpty(t, suffixes)
+	suffixes, err = source.Range("00000")
+	assert.NoError(t, err)
+	assert.Empty(t, suffixes)
+
+	policy := NewPolicy(PolicyOptions{Breached: source})
+	violations, err := policy.Check("letmein")
+	assert.NoError(t, err)
+	assert.Equal(t, []Violation{{Rule: RuleBreached, Message: "password has appeared in a data breach"}}, violations)
+}
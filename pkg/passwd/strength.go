@@ -0,0 +1,293 @@
+package passwd
+
+import (
+	_ "embed"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// commonPasswords is a list of frequently used passwords and words, one per line ordered by popularity
+//
+//go:embed common.txt
+var commonPasswords string
+
+// commonRanks maps common passwords to their 1-based rank
+var commonRanks = func() map[string]int {
+	ranks := make(map[string]int)
+	for i, word := range strings.Fields(commonPasswords) {
+		if _, ok := ranks[word]; !ok {
+			ranks[word] = i + 1
+		}
+	}
+	return ranks
+}()
+
+// keyboardRows are rows of qwerty keyboard, runs of adjacent keys are easy to guess
+var keyboardRows = []string{"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./"}
+
+// l33tTable maps common substitutions to the letters they stand for
+var l33tTable = map[rune]rune{
+	'4': 'a', '@': 'a', '8': 'b', '(': 'c', '3': 'e', '6': 'g', '1': 'i', '!': 'i',
+	'0': 'o', '$': 's', '5': 's', '7': 't', '+': 't', '2': 'z',
+}
+
+const (
+	// guesses of each character which is not matched by any pattern
+	bruteforceCardinality = 10
+	// min guesses of a matched pattern, so that it is never cheaper than a single character
+	minPatternGuesses = 10
+)
+
+// Strength estimates how hard the password is to guess in the way of zxcvbn, returns a score from 0 to 4:
+//
+//	0: too guessable, fewer than 10^3 guesses
+//	1: very guessable, fewer than 10^6 guesses
+//	2: somewhat guessable, fewer than 10^8 guesses
+//	3: safely unguessable, fewer than 10^10 guesses
+//	4: very unguessable
+//
+// Inputs are user specific words, such as username and email, they are considered as the most common passwords.
+func Strength(password string, inputs ...string) int {
+	guesses := Guesses(password, inputs...)
+	switch {
+	case guesses < 3:
+		return 0
+	case guesses < 6:
+		return 1
+	case guesses < 8:
+		return 2
+	case guesses < 10:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// Guesses returns log10 of the estimated number of guesses to crack the password. The password is split into
+// the sequence of patterns which is the cheapest to guess, such as common words, repeats, sequences and keyboard runs,
+// characters not in any pattern are guessed by brute force.
+func Guesses(password string, inputs ...string) float64 {
+	runes := []rune(password)
+	if len(runes) == 0 {
+		return 0
+	}
+	lower := []rune(strings.ToLower(password))
+	userRanks := make(map[string]int)
+	for i, input := range inputs {
+		input = strings.ToLower(input)
+		if utf8.RuneCountInString(input) >= 3 {
+			userRanks[input] = i + 1
+		}
+	}
+
+	// best[j] is log10 guesses of the cheapest split of runes[:j]
+	best := make([]float64, len(runes)+1)
+	for j := 1; j <= len(runes); j++ {
+		best[j] = best[j-1] + math.Log10(bruteforceCardinality)
+		for i := 0; i < j; i++ {
+			if guesses := patternGuesses(runes[i:j], lower[i:j], userRanks); guesses > 0 {
+				best[j] = min(best[j], best[i]+math.Log10(max(guesses, minPatternGuesses)))
+			}
+		}
+	}
+	return best[len(runes)]
+}
+
+// patternGuesses returns guesses of the token by the cheapest pattern it matches, or 0 if it matches nothing
+func patternGuesses(token, lower []rune, userRanks map[string]int) float64 {
+	var guesses []float64
+	if g := dictionaryGuesses(token, lower, userRanks); g > 0 {
+		guesses = append(guesses, g)
+	}
+	if g := repeatGuesses(lower); g > 0 {
+		guesses = append(guesses, g)
+	}
+	if g := sequenceGuesses(lower); g > 0 {
+		guesses = append(guesses, g)
+	}
+	if g := keyboardGuesses(lower); g > 0 {
+		guesses = append(guesses, g)
+	}
+	if g := yearGuesses(lower); g > 0 {
+		guesses = append(guesses, g)
+	}
+	if len(guesses) == 0 {
+		return 0
+	}
+	return slices.Min(guesses)
+}
+
+// dictionaryGuesses matches the token against common passwords and user inputs, also in reverse and l33t forms.
+// Guesses are the rank of the word, multiplied by variations of upper case letters and substitutions.
+func dictionaryGuesses(token, lower []rune, userRanks map[string]int) float64 {
+	if len(lower) < 3 {
+		return 0
+	}
+	word := string(lower)
+	rank := lookupRank(word, userRanks)
+	variations := 1.0
+	if rank == 0 {
+		if rank = lookupRank(reverse(word), userRanks); rank > 0 {
+			variations *= 2
+		}
+	}
+	if rank == 0 {
+		unl33t, subs := unleet(lower)
+		if subs > 0 {
+			if rank = lookupRank(unl33t, userRanks); rank > 0 {
+				variations *= math.Pow(2, float64(subs))
+			}
+		}
+	}
+	if rank == 0 {
+		return 0
+	}
+	return float64(rank) * variations * caseVariations(token)
+}
+
+func lookupRank(word string, userRanks map[string]int) int {
+	if rank, ok := userRanks[word]; ok {
+		return rank
+	}
+	return commonRanks[word]
+}
+
+// caseVariations returns the number of ways upper case letters are placed, capitalized words are cheap
+func caseVariations(token []rune) float64 {
+	var upper, lower int
+	for _, r := range token {
+		if unicode.IsUpper(r) {
+			upper++
+		} else if unicode.IsLower(r) {
+			lower++
+		}
+	}
+	if upper == 0 {
+		return 1
+	}
+	// all upper case, capitalized or only the last one is upper
+	if lower == 0 || upper == 1 && (unicode.IsUpper(token[0]) || unicode.IsUpper(token[len(token)-1])) {
+		return 2
+	}
+	// sum of C(n, i) for i in [1, min(upper, lower)]
+	var variations float64
+	for i := 1; i <= min(upper, lower); i++ {
+		variations += binomial(upper+lower, i)
+	}
+	return variations
+}
+
+// repeatGuesses matches tokens consist of a repeated unit, such as aaaa and abcabc
+func repeatGuesses(lower []rune) float64 {
+	if len(lower) < 3 {
+		return 0
+	}
+	for size := 1; size <= len(lower)/2; size++ {
+		if len(lower)%size != 0 {
+			continue
+		}
+		repeated := true
+		for i := size; i < len(lower) && repeated; i++ {
+			repeated = lower[i] == lower[i-size]
+		}
+		if repeated {
+			return math.Pow(bruteforceCardinality, float64(size)) * float64(len(lower)/size)
+		}
+	}
+	return 0
+}
+
+// sequenceGuesses matches tokens of characters with the same step in code point, such as abcd, 1357 and 9876
+func sequenceGuesses(lower []rune) float64 {
+	if len(lower) < 3 {
+		return 0
+	}
+	step := lower[1] - lower[0]
+	if step == 0 || step < -5 || step > 5 {
+		return 0
+	}
+	for i := 2; i < len(lower); i++ {
+		if lower[i]-lower[i-1] != step {
+			return 0
+		}
+	}
+	// obvious starts are guessed first
+	var base float64
+	switch first := lower[0]; {
+	case strings.ContainsRune("az019", first):
+		base = 4
+	case unicode.IsDigit(first):
+		base = 10
+	default:
+		base = 26
+	}
+	if step < 0 {
+		base *= 2
+	}
+	if step != 1 && step != -1 {
+		base *= 4
+	}
+	return base * float64(len(lower))
+}
+
+// keyboardGuesses matches runs of adjacent keys in a keyboard row in either direction, such as qwerty and lkjh
+func keyboardGuesses(lower []rune) float64 {
+	if len(lower) < 3 {
+		return 0
+	}
+	word := string(lower)
+	for _, row := range keyboardRows {
+		if strings.Contains(row, word) {
+			return float64(len(row) * len(lower))
+		} else if strings.Contains(row, reverse(word)) {
+			return float64(len(row)*len(lower)) * 2
+		}
+	}
+	return 0
+}
+
+// yearGuesses matches recent years from 1900 to 2099
+func yearGuesses(lower []rune) float64 {
+	if len(lower) != 4 || !(string(lower[:2]) == "19" || string(lower[:2]) == "20") {
+		return 0
+	}
+	for _, r := range lower[2:] {
+		if !unicode.IsDigit(r) {
+			return 0
+		}
+	}
+	return 200
+}
+
+// unleet replaces l33t substitutions with letters, returns the number of substitutions
+func unleet(lower []rune) (string, int) {
+	var (
+		builder strings.Builder
+		subs    int
+	)
+	for _, r := range lower {
+		if letter, ok := l33tTable[r]; ok {
+			r = letter
+			subs++
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String(), subs
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	slices.Reverse(runes)
+	return string(runes)
+}
+
+func binomial(n, k int) float64 {
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
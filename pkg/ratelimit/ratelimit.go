@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// Algorithm is the name of rate limiting algorithm
+type Algorithm string
+
+const (
+	// FixedWindow counts requests in consecutive windows, bursts are allowed at the window boundary
+	FixedWindow Algorithm = "fixed"
+	// SlidingWindow records timestamps of requests, so that there are no more than limit requests in any window
+	SlidingWindow Algorithm = "sliding"
+	// TokenBucket refills limit tokens evenly in window, and each request takes one token
+	TokenBucket Algorithm = "bucket"
+)
+
+// Rule allows at most Limit requests in Window
+type Rule struct {
+	Limit  int
+	Window time.Duration
+}
+
+// Result is the decision of a request
+type Result struct {
+	Allowed bool
+	// maximum requests of the rule
+	Limit int
+	// remaining requests in current window
+	Remaining int
+	// duration until quota is fully restored
+	Reset time.Duration
+	// duration to wait before next request is allowed, it is zero if allowed
+	RetryAfter time.Duration
+}
+
+// Limiter decides whether the request identified by key is allowed under the rule
+type Limiter interface {
+	Allow(ctx context.Context, key string, rule Rule) (Result, error)
+}
+
+// New returns a redis-backed limiter with the given algorithm
+func New(client *redis.Client, algorithm Algorithm) (Limiter, error) {
+	switch algorithm {
+	case FixedWindow:
+		return &FixedWindowLimiter{client: client}, nil
+	case SlidingWindow:
+		return &SlidingWindowLimiter{client: client}, nil
+	case TokenBucket:
+		return &TokenBucketLimiter{client: client}, nil
+	default:
+		return nil, fmt.Errorf("unsupported rate limit algorithm: %q", algorithm)
+	}
+}
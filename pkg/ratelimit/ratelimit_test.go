@@ -0,0 +1,34 @@
+package ratelimit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	for _, algorithm := range []Algorithm{FixedWindow, SlidingWindow, TokenBucket} {
+		limiter, err := New(nil, algorithm)
+		assert.NoError(t, err)
+		assert.NotNil(t, limiter)
+	}
+	_, err := New(nil, "leaky")
+	assert.Error(t, err)
+}
+
+func TestBucketResult(t *testing.T) {
+	// 10 tokens per second
+	rate := 10 / float64(time.Second.Microseconds())
+
+	result := bucketResult(true, 9, 10, rate)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 9, result.Remaining)
+	assert.Equal(t, 100*time.Millisecond, result.Reset)
+	assert.Zero(t, result.RetryAfter)
+
+	result = bucketResult(false, 0.5, 10, rate)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.Equal(t, 950*time.Millisecond, result.Reset)
+	assert.Equal(t, 50*time.Millisecond, result.RetryAfter)
+}
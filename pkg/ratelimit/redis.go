@@ -0,0 +1,157 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/redis/go-redis/v9"
+	"math"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// KEYS[1] counter key, ARGV[1] window in milliseconds, returns count and remaining ttl in milliseconds
+var fixedWindowScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+    redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl < 0 then
+    redis.call('PEXPIRE', KEYS[1], ARGV[1])
+    ttl = tonumber(ARGV[1])
+end
+return {count, ttl}
+`)
+
+// FixedWindowLimiter implements Limiter with a counter which expires at the end of window
+type FixedWindowLimiter struct {
+	client *redis.Client
+}
+
+func (f *FixedWindowLimiter) Allow(ctx context.Context, key string, rule Rule) (Result, error) {
+	values, err := fixedWindowScript.Run(ctx, f.client, []string{key}, rule.Window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	count, ttl := int(values[0]), time.Duration(values[1])*time.Millisecond
+	result := Result{
+		Allowed:   count <= rule.Limit,
+		Limit:     rule.Limit,
+		Remaining: max(rule.Limit-count, 0),
+		Reset:     ttl,
+	}
+	if !result.Allowed {
+		result.RetryAfter = ttl
+	}
+	return result, nil
+}
+
+// KEYS[1] sorted set key, ARGV[1] now in microseconds, ARGV[2] window in microseconds, ARGV[3] limit, ARGV[4] unique member.
+// returns whether allowed, count in window, and timestamp of the oldest request in window.
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+    redis.call('ZADD', KEYS[1], now, ARGV[4])
+    count = count + 1
+    allowed = 1
+end
+redis.call('PEXPIRE', KEYS[1], math.ceil(window / 1000))
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+local first = now
+if oldest[2] then
+    first = tonumber(oldest[2])
+end
+return {allowed, count, first}
+`)
+
+// SlidingWindowLimiter implements Limiter with a log of request timestamps in sorted set
+type SlidingWindowLimiter struct {
+	client *redis.Client
+	seq    atomic.Uint64
+}
+
+func (s *SlidingWindowLimiter) Allow(ctx context.Context, key string, rule Rule) (Result, error) {
+	now := time.Now().UnixMicro()
+	// member must be unique even if requests come in the same microsecond
+	member := strconv.FormatInt(now, 10) + "-" + strconv.FormatUint(s.seq.Add(1), 10)
+	values, err := slidingWindowScript.Run(ctx, s.client, []string{key}, now, rule.Window.Microseconds(), rule.Limit, member).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	allowed, count, first := values[0] == 1, int(values[1]), values[2]
+	// quota of the oldest request is restored when it slides out of window
+	reset := time.Duration(first+rule.Window.Microseconds()-now) * time.Microsecond
+	result := Result{
+		Allowed:   allowed,
+		Limit:     rule.Limit,
+		Remaining: max(rule.Limit-count, 0),
+		Reset:     reset,
+	}
+	if !allowed {
+		result.RetryAfter = reset
+	}
+	return result, nil
+}
+
+// KEYS[1] bucket key, ARGV[1] capacity, ARGV[2] refill rate in tokens per microsecond, ARGV[3] now in microseconds.
+// returns whether allowed, and tokens left in string since lua numbers are truncated to integer in reply.
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local last = tonumber(state[2])
+if tokens == nil or last == nil then
+    tokens = capacity
+    last = now
+end
+tokens = math.min(capacity, tokens + math.max(now - last, 0) * rate)
+local allowed = 0
+if tokens >= 1 then
+    tokens = tokens - 1
+    allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.max(1, math.ceil((capacity - tokens) / rate / 1000)))
+return {allowed, tostring(tokens)}
+`)
+
+// TokenBucketLimiter implements Limiter with a bucket of capacity limit, which is refilled evenly in window
+type TokenBucketLimiter struct {
+	client *redis.Client
+}
+
+func (t *TokenBucketLimiter) Allow(ctx context.Context, key string, rule Rule) (Result, error) {
+	now := time.Now().UnixMicro()
+	rate := float64(rule.Limit) / float64(rule.Window.Microseconds())
+	values, err := tokenBucketScript.Run(ctx, t.client, []string{key}, rule.Limit, strconv.FormatFloat(rate, 'g', -1, 64), now).Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	allowed, _ := values[0].(int64)
+	tokens, err := strconv.ParseFloat(values[1].(string), 64)
+	if err != nil {
+		return Result{}, err
+	}
+	return bucketResult(allowed == 1, tokens, rule.Limit, rate), nil
+}
+
+// bucketResult computes result from the tokens left in bucket
+func bucketResult(allowed bool, tokens float64, limit int, rate float64) Result {
+	result := Result{
+		Allowed:   allowed,
+		Limit:     limit,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     time.Duration(math.Ceil((float64(limit)-tokens)/rate)) * time.Microsecond,
+	}
+	if !allowed {
+		result.RetryAfter = time.Duration(math.Ceil((1-tokens)/rate)) * time.Microsecond
+	}
+	return result
+}
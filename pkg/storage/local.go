@@ -0,0 +1,231 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var _ Storage = (*LocalStorage)(nil)
+
+// LocalOptions is options of LocalStorage
+type LocalOptions struct {
+	// directory where objects are stored
+	Root string
+	// url prefix where LocalStorage is served as http.Handler, such as /storage
+	URLPrefix string
+	// secret to sign download urls
+	Secret []byte
+}
+
+// NewLocalStorage returns a storage which stores objects in local filesystem
+func NewLocalStorage(options LocalOptions) (*LocalStorage, error) {
+	if err := os.MkdirAll(options.Root, 0755); err != nil {
+		return nil, err
+	}
+	return &LocalStorage{
+		root:   options.Root,
+		prefix: strings.TrimSuffix(options.URLPrefix, "/"),
+		secret: options.Secret,
+		now:    time.Now,
+	}, nil
+}
+
+// LocalStorage implements Storage with local filesystem, it serves signed urls by itself as http.Handler.
+type LocalStorage struct {
+	root   string
+	prefix string
+	secret []byte
+	now    func() time.Time
+}
+
+func (l *LocalStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	filename, err := l.filename(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+	// write to temp file first, so that readers never see partial content
+	tmp, err := os.CreateTemp(filepath.Dir(filename), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
+
+func (l *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, Object, error) {
+	filename, err := l.filename(key)
+	if err != nil {
+		return nil, Object{}, err
+	}
+	file, err := os.Open(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, Object{}, ErrNotFound
+	} else if err != nil {
+		return nil, Object{}, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, Object{}, err
+	}
+	return file, localObject(key, info), nil
+}
+
+func (l *LocalStorage) Stat(ctx context.Context, key string) (Object, error) {
+	filename, err := l.filename(key)
+	if err != nil {
+		return Object{}, err
+	}
+	info, err := os.Stat(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Object{}, ErrNotFound
+	} else if err != nil {
+		return Object{}, err
+	}
+	return localObject(key, info), nil
+}
+
+func (l *LocalStorage) Delete(ctx context.Context, key string) error {
+	filename, err := l.filename(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filename); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (l *LocalStorage) List(ctx context.Context, prefix string) ([]Object, error) {
+	if err := CheckPrefix(prefix); err != nil {
+		return nil, err
+	}
+	var objects []Object
+	err := filepath.WalkDir(filepath.Join(l.root, filepath.FromSlash(prefix)), func(filename string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		// skip files being uploaded
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".upload-") {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(l.root, filename)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, localObject(filepath.ToSlash(rel), info))
+		return nil
+	})
+	return objects, err
+}
+
+// SignURL returns an url relative to the host, in the format of <prefix>/<key>?expires=<unix>&signature=<sign>
+func (l *LocalStorage) SignURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if err := CheckKey(key); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(l.now().Add(ttl).Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", l.sign(key, expires))
+	return l.prefix + "/" + (&url.URL{Path: key}).EscapedPath() + "?" + query.Encode(), nil
+}
+
+// Verify checks the signature of the key from signed url
+func (l *LocalStorage) Verify(key, expires, signature string) error {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSign
+	}
+	if subtle.ConstantTimeCompare([]byte(l.sign(key, expires)), []byte(signature)) != 1 {
+		return ErrInvalidSign
+	}
+	if l.now().Unix() > unix {
+		return ErrSignExpired
+	}
+	return nil
+}
+
+// ServeHTTP serves objects by signed urls, the request path should be the signed url path.
+func (l *LocalStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key, found := strings.CutPrefix(r.URL.Path, l.prefix+"/")
+	if !found || CheckKey(key) != nil {
+		http.NotFound(w, r)
+		return
+	}
+	query := r.URL.Query()
+	if err := l.Verify(key, query.Get("expires"), query.Get("signature")); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	content, object, err := l.Get(r.Context(), key)
+	if errors.Is(err, ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	defer content.Close()
+	w.Header().Set("Content-Type", object.ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, "", object.ModTime, content.(io.ReadSeeker))
+}
+
+func (l *LocalStorage) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, l.secret)
+	mac.Write([]byte(key + "\n" + expires))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (l *LocalStorage) filename(key string) (string, error) {
+	if err := CheckKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(l.root, filepath.FromSlash(key)), nil
+}
+
+// localObject returns object metadata from file info, content type is guessed by extension since it is not stored.
+func localObject(key string, info fs.FileInfo) Object {
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return Object{
+		Key:         key,
+		Size:        info.Size(),
+		ContentType: contentType,
+		ModTime:     info.ModTime(),
+	}
+}
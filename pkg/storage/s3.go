@@ -0,0 +1,355 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var _ Storage = (*S3Storage)(nil)
+
+const (
+	amzDateFormat    = "20060102T150405Z"
+	unsignedPayload  = "UNSIGNED-PAYLOAD"
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// S3Options is options of S3Storage
+type S3Options struct {
+	// service endpoint with scheme, such as https://s3.us-east-1.amazonaws.com or http://127.0.0.1:9000
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// use path-style urls like <endpoint>/<bucket>/<key> instead of virtual-hosted style, most self-hosted services require it
+	PathStyle bool
+	// http client to send requests, http.DefaultClient is used if nil
+	Client *http.Client
+}
+
+// NewS3Storage returns a storage backed by Amazon S3 or any S3-compatible service, requests are signed with AWS Signature Version 4.
+func NewS3Storage(options S3Options) (*S3Storage, error) {
+	endpoint, err := url.Parse(options.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint: %q", options.Endpoint)
+	}
+	if options.Bucket == "" {
+		return nil, errors.New("s3 bucket is required")
+	}
+	if options.Region == "" {
+		options.Region = "us-east-1"
+	}
+	if options.Client == nil {
+		options.Client = http.DefaultClient
+	}
+	return &S3Storage{options: options, endpoint: endpoint, now: time.Now}, nil
+}
+
+// S3Storage implements Storage with S3 REST api
+type S3Storage struct {
+	options  S3Options
+	endpoint *url.URL
+	now      func() time.Time
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	// S3 does not accept chunked body without content length
+	if size < 0 {
+		buf, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		r, size = bytes.NewReader(buf), int64(len(buf))
+	}
+	req, err := s.newRequest(ctx, http.MethodPut, key, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	res, err := s.do(req, unsignedPayload)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, Object, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, Object{}, err
+	}
+	res, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return nil, Object{}, err
+	}
+	return res.Body, s3Object(key, res), nil
+}
+
+func (s *S3Storage) Stat(ctx context.Context, key string) (Object, error) {
+	req, err := s.newRequest(ctx, http.MethodHead, key, nil)
+	if err != nil {
+		return Object{}, err
+	}
+	res, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return Object{}, err
+	}
+	res.Body.Close()
+	return s3Object(key, res), nil
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	res, err := s.do(req, emptyPayloadHash)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+// SignURL returns a presigned url to get the object, ttl should be no longer than 7 days.
+func (s *S3Storage) SignURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if err := CheckKey(key); err != nil {
+		return "", err
+	}
+	now := s.now().UTC()
+	objectURL := s.objectURL(key)
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	query.Set("X-Amz-Credential", s.options.AccessKey+"/"+s.scope(now))
+	query.Set("X-Amz-Date", now.Format(amzDateFormat))
+	query.Set("X-Amz-Expires", strconv.FormatInt(int64(ttl/time.Second), 10))
+	query.Set("X-Amz-SignedHeaders", "host")
+	objectURL.RawQuery = canonicalQuery(query)
+
+	header := http.Header{}
+	header.Set("Host", objectURL.Host)
+	signature, _ := s.signature(http.MethodGet, objectURL, header, unsignedPayload, now)
+	objectURL.RawQuery += "&X-Amz-Signature=" + signature
+	return objectURL.String(), nil
+}
+
+// List lists objects by ListObjectsV2 api page by page, content types are not returned by S3.
+func (s *S3Storage) List(ctx context.Context, prefix string) ([]Object, error) {
+	if err := CheckPrefix(prefix); err != nil {
+		return nil, err
+	}
+	var (
+		objects []Object
+		token   string
+	)
+	for {
+		bucketURL := s.bucketURL()
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", prefix)
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		bucketURL.RawQuery = canonicalQuery(query)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, bucketURL.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		res, err := s.do(req, emptyPayloadHash)
+		if err != nil {
+			return nil, err
+		}
+		var result listBucketResult
+		err = xml.NewDecoder(res.Body).Decode(&result)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, content := range result.Contents {
+			objects = append(objects, Object{Key: content.Key, Size: content.Size, ModTime: content.LastModified})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// listBucketResult is the response of ListObjectsV2 api
+type listBucketResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+}
+
+func (s *S3Storage) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	if err := CheckKey(key); err != nil {
+		return nil, err
+	}
+	return http.NewRequestWithContext(ctx, method, s.objectURL(key).String(), body)
+}
+
+// do signs and sends the request, non-2xx responses are converted to error.
+func (s *S3Storage) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	s.sign(req, payloadHash, s.now().UTC())
+	res, err := s.options.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return res, nil
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	return nil, fmt.Errorf("s3 %s %s: %s: %s", req.Method, req.URL.Path, res.Status, bytes.TrimSpace(msg))
+}
+
+// objectURL returns the url of the object in path-style or virtual-hosted style
+func (s *S3Storage) objectURL(key string) *url.URL {
+	objectURL := s.bucketURL()
+	objectURL.Path += key
+	objectURL.RawPath = awsEscape(objectURL.Path, false)
+	return objectURL
+}
+
+// bucketURL returns the url of the bucket with trailing slash in path-style or virtual-hosted style
+func (s *S3Storage) bucketURL() *url.URL {
+	bucketURL := *s.endpoint
+	basePath := strings.TrimSuffix(bucketURL.Path, "/")
+	if s.options.PathStyle {
+		bucketURL.Path = basePath + "/" + s.options.Bucket + "/"
+	} else {
+		bucketURL.Host = s.options.Bucket + "." + bucketURL.Host
+		bucketURL.Path = basePath + "/"
+	}
+	bucketURL.RawPath = awsEscape(bucketURL.Path, false)
+	return &bucketURL
+}
+
+// sign adds the Authorization header to request
+func (s *S3Storage) sign(req *http.Request, payloadHash string, now time.Time) {
+	req.Header.Set("X-Amz-Date", now.Format(amzDateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	header := req.Header.Clone()
+	header.Set("Host", req.URL.Host)
+	signature, signedHeaders := s.signature(req.Method, req.URL, header, payloadHash, now)
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.options.AccessKey, s.scope(now), signedHeaders, signature))
+}
+
+// signature computes the request signature, see https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func (s *S3Storage) signature(method string, u *url.URL, header http.Header, payloadHash string, now time.Time) (string, string) {
+	var names []string
+	for name := range header {
+		name = strings.ToLower(name)
+		if name == "host" || name == "content-type" || name == "content-md5" || name == "range" || strings.HasPrefix(name, "x-amz-") {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(header.Get(name)) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		awsEscape(u.Path, false),
+		canonicalQuery(u.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		now.Format(amzDateFormat),
+		s.scope(now),
+		hexSHA256(canonicalRequest),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.options.SecretKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.options.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign)), signedHeaders
+}
+
+func (s *S3Storage) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + s.options.Region + "/s3/aws4_request"
+}
+
+// canonicalQuery encodes query sorted by key with AWS escaping rules
+func canonicalQuery(query url.Values) string {
+	var pairs []string
+	for key, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, awsEscape(key, true)+"="+awsEscape(value, true))
+		}
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// awsEscape escapes all bytes except unreserved characters, slash is also escaped if escapeSlash.
+func awsEscape(s string, escapeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '.' || c == '_' || c == '~' || (c == '/' && !escapeSlash) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func s3Object(key string, res *http.Response) Object {
+	modTime, _ := http.ParseTime(res.Header.Get("Last-Modified"))
+	return Object{
+		Key:         key,
+		Size:        res.ContentLength,
+		ContentType: res.Header.Get("Content-Type"),
+		ModTime:     modTime,
+	}
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hexSHA256(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound        = errors.New("object not found")
+	ErrTooLarge        = errors.New("object too large")
+	ErrInvalidKey      = errors.New("invalid object key")
+	ErrInvalidSign     = errors.New("invalid signature")
+	ErrSignExpired     = errors.New("signature expired")
+	ErrUnsupportedType = errors.New("unsupported content type")
+)
+
+// Object is the metadata of stored object
+type Object struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+// Storage stores objects by key, key is a slash separated path without leading slash, such as avatars/1/64.png
+type Storage interface {
+	// Put stores the object, size could be -1 if unknown
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get returns the object content, caller should close it
+	Get(ctx context.Context, key string) (io.ReadCloser, Object, error)
+	// Stat returns the object metadata
+	Stat(ctx context.Context, key string) (Object, error)
+	// Delete removes the object, it is not an error if the object does not exist
+	Delete(ctx context.Context, key string) error
+	// SignURL returns an url to download the object without credentials, it expires after ttl
+	SignURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+	// List returns metadata of all objects under the prefix recursively, prefix is a key ending with slash.
+	// Content type of listed objects might be absent.
+	List(ctx context.Context, prefix string) ([]Object, error)
+}
+
+// CheckKey returns error if key is empty, absolute, or escapes from root
+func CheckKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	for _, elem := range strings.Split(key, "/") {
+		if elem == "" || elem == "." || elem == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// CheckPrefix returns error if prefix is not a valid key ending with slash
+func CheckPrefix(prefix string) error {
+	if !strings.HasSuffix(prefix, "/") {
+		return ErrInvalidKey
+	}
+	return CheckKey(strings.TrimSuffix(prefix, "/"))
+}
+
+// Sniff detects content type from the leading bytes of r, the returned reader reads the whole content including the sniffed bytes.
+// If allowed is not empty, content types out of them are rejected with ErrUnsupportedType.
+func Sniff(r io.Reader, allowed ...string) (string, io.Reader, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", nil, err
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+	// strip parameters such as charset
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	if len(allowed) > 0 && !slices.Contains(allowed, mediaType) {
+		return mediaType, nil, ErrUnsupportedType
+	}
+	return contentType, io.MultiReader(bytes.NewReader(head), r), nil
+}
+
+// LimitReader returns a reader which reads at most n bytes from r, it returns ErrTooLarge if r has more.
+func LimitReader(r io.Reader, n int64) io.Reader {
+	return &limitedReader{r: r, n: n}
+}
+
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, ErrTooLarge
+	}
+	// read one more byte to know whether there is more content
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return n + int(l.n), ErrTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckKey(t *testing.T) {
+	samples := []struct {
+		key string
+		ok  bool
+	}{
+		{"a.png", true},
+		{"avatars/1/64.png", true},
+		{"", false},
+		{"/a.png", false},
+		{"a//b", false},
+		{"a/../b", false},
+		{"../a", false},
+		{"a\\b", false},
+	}
+	for _, sample := range samples {
+		assert.Equal(t, sample.ok, CheckKey(sample.key) == nil, sample.key)
+	}
+}
+
+func TestCheckPrefix(t *testing.T) {
+	samples := []struct {
+		prefix string
+		ok     bool
+	}{
+		{"exports/", true},
+		{"exports/1/", true},
+		{"exports", false},
+		{"/", false},
+		{"", false},
+		{"exports//", false},
+		{"../", false},
+	}
+	for _, sample := range samples {
+		assert.Equal(t, sample.ok, CheckPrefix(sample.prefix) == nil, sample.prefix)
+	}
+}
+
+func TestSniff(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n" + strings.Repeat("x", 1024))
+	contentType, r, err := Sniff(bytes.NewReader(png), "image/png")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "image/png", contentType)
+	content, _ := io.ReadAll(r)
+	assert.Equal(t, png, content)
+
+	contentType, _, err = Sniff(strings.NewReader("hello"), "image/png", "image/jpeg")
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.Equal(t, "text/plain", contentType)
+}
+
+func TestLimitReader(t *testing.T) {
+	content, err := io.ReadAll(LimitReader(strings.NewReader("hello"), 5))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+
+	content, err = io.ReadAll(LimitReader(strings.NewReader("hello!"), 5))
+	assert.ErrorIs(t, err, ErrTooLarge)
+	assert.Equal(t, "hello", string(content))
+}
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	local, err := NewLocalStorage(LocalOptions{Root: t.TempDir(), URLPrefix: "/storage/", Secret: []byte("secret")})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	key := "avatars/1/a b.png"
+	assert.NoError(t, local.Put(ctx, key, strings.NewReader("image"), -1, "image/png"))
+	object, err := local.Stat(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), object.Size)
+	assert.Equal(t, "image/png", object.ContentType)
+	_, err = local.Stat(ctx, "avatars/2.png")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, local.Put(ctx, "../a.png", strings.NewReader("x"), 1, ""), ErrInvalidKey)
+
+	// download by signed url
+	signed, err := local.SignURL(ctx, key, time.Minute)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, strings.HasPrefix(signed, "/storage/avatars/1/a%20b.png?"))
+	rec := httptest.NewRecorder()
+	local.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, signed, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image", rec.Body.String())
+	assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+
+	// tampered url
+	rec = httptest.NewRecorder()
+	local.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, strings.Replace(signed, "a%20b", "c", 1), nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// expired url
+	local.now = func() time.Time { return time.Now().Add(time.Hour) }
+	rec = httptest.NewRecorder()
+	local.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, signed, nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// list recursively
+	assert.NoError(t, local.Put(ctx, "avatars/2/a.png", strings.NewReader("image2"), -1, "image/png"))
+	assert.NoError(t, local.Put(ctx, "exports/1/a.zip", strings.NewReader("zip"), -1, "application/zip"))
+	objects, err := local.List(ctx, "avatars/")
+	if assert.NoError(t, err) && assert.Len(t, objects, 2) {
+		assert.Equal(t, "avatars/1/a b.png", objects[0].Key)
+		assert.Equal(t, "avatars/2/a.png", objects[1].Key)
+		assert.Equal(t, int64(6), objects[1].Size)
+	}
+	objects, err = local.List(ctx, "avatars/3/")
+	assert.NoError(t, err)
+	assert.Empty(t, objects)
+	_, err = local.List(ctx, "../")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	assert.NoError(t, local.Delete(ctx, key))
+	assert.NoError(t, local.Delete(ctx, key))
+	_, _, err = local.Get(ctx, key)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -5,6 +5,8 @@ import (
 	"github.com/jellydator/ttlcache/v2"
 	"github.com/pkg/errors"
 	"github.com/redis/go-redis/v9"
+	"strconv"
+	"sync"
 	"time"
 )
 
@@ -18,6 +20,15 @@ type Cache interface {
 	Del(ctx context.Context, prefix, tokenId string) error
 	// Set sets the specified tokenId to the cache
 	Set(ctx context.Context, prefix, tokenId, value string, expire time.Duration) error
+	// SetNX sets the specified tokenId to the cache only if it does not exist, returns false if it already exists
+	SetNX(ctx context.Context, prefix, tokenId, value string, expire time.Duration) (bool, error)
+
+	// Index associates the member with the index key until it expires
+	Index(ctx context.Context, prefix, key, member string, expire time.Duration) error
+	// Members returns all unexpired members of the index key
+	Members(ctx context.Context, prefix, key string) ([]string, error)
+	// Unindex removes the members from the index key, it removes the whole index if no members given
+	Unindex(ctx context.Context, prefix, key string, members ...string) error
 }
 
 func prefixKey(prefix, key string) string {
@@ -54,6 +65,10 @@ func (r *RedisTokenCache) Set(ctx context.Context, prefix, tokenId, value string
 	return result.Err()
 }
 
+func (r *RedisTokenCache) SetNX(ctx context.Context, prefix, tokenId, value string, expire time.Duration) (bool, error) {
+	return r.redis.SetNX(ctx, prefixKey(prefix, tokenId), value, expire).Result()
+}
+
 func (r *RedisTokenCache) Del(ctx context.Context, prefix, tokenId string) error {
 	// del tokenId from string
 	result := r.redis.Del(ctx, prefixKey(prefix, tokenId))
@@ -63,15 +78,61 @@ func (r *RedisTokenCache) Del(ctx context.Context, prefix, tokenId string) error
 	return result.Err()
 }
 
+// Index stores members into sorted set whose score is expiration unix timestamp,
+// and the whole set expires with the member which lives longest.
+func (r *RedisTokenCache) Index(ctx context.Context, prefix, key, member string, expire time.Duration) error {
+	indexKey := prefixKey(prefix, key)
+	expireAt := time.Now().Add(expire)
+	if err := r.redis.ZAdd(ctx, indexKey, redis.Z{Score: float64(expireAt.Unix()), Member: member}).Err(); err != nil {
+		return err
+	}
+	// extend set lifetime to the latest member
+	latest, err := r.redis.ZRangeWithScores(ctx, indexKey, -1, -1).Result()
+	if err != nil {
+		return err
+	} else if len(latest) > 0 {
+		expireAt = time.Unix(int64(latest[0].Score), 0)
+	}
+	return r.redis.ExpireAt(ctx, indexKey, expireAt).Err()
+}
+
+func (r *RedisTokenCache) Members(ctx context.Context, prefix, key string) ([]string, error) {
+	indexKey := prefixKey(prefix, key)
+	// clean expired members
+	err := r.redis.ZRemRangeByScore(ctx, indexKey, "-inf", strconv.FormatInt(time.Now().Unix(), 10)).Err()
+	if err != nil {
+		return nil, err
+	}
+	return r.redis.ZRange(ctx, indexKey, 0, -1).Result()
+}
+
+func (r *RedisTokenCache) Unindex(ctx context.Context, prefix, key string, members ...string) error {
+	indexKey := prefixKey(prefix, key)
+	if len(members) == 0 {
+		return r.redis.Del(ctx, indexKey).Err()
+	}
+	var ms []any
+	for _, member := range members {
+		ms = append(ms, member)
+	}
+	return r.redis.ZRem(ctx, indexKey, ms...).Err()
+}
+
 func NewMemoryCache() *MemoryCache {
 	return &MemoryCache{
 		memStore: ttlcache.NewCache(),
+		indexes:  make(map[string]map[string]time.Time),
 	}
 }
 
 // MemoryCache implement Cache by ttlcache.Cache in memory
 type MemoryCache struct {
 	memStore *ttlcache.Cache
+
+	// guards indexes and SetNX
+	mu sync.Mutex
+	// index key -> member -> expiration
+	indexes map[string]map[string]time.Time
 }
 
 func (m *MemoryCache) Get(ctx context.Context, prefix, tokenId string) (string, bool, error) {
@@ -105,3 +166,63 @@ func (m *MemoryCache) Set(ctx context.Context, prefix, tokenId, value string, ex
 	key := prefixKey(prefix, tokenId)
 	return m.memStore.SetWithTTL(key, value, expire)
 }
+
+func (m *MemoryCache) SetNX(ctx context.Context, prefix, tokenId, value string, expire time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := prefixKey(prefix, tokenId)
+	if _, err := m.memStore.Get(key); err == nil {
+		return false, nil
+	} else if !errors.Is(err, ttlcache.ErrNotFound) {
+		return false, err
+	}
+	return true, m.memStore.SetWithTTL(key, value, expire)
+}
+
+func (m *MemoryCache) Index(ctx context.Context, prefix, key, member string, expire time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	indexKey := prefixKey(prefix, key)
+	if m.indexes[indexKey] == nil {
+		m.indexes[indexKey] = make(map[string]time.Time)
+	}
+	m.indexes[indexKey][member] = time.Now().Add(expire)
+	return nil
+}
+
+func (m *MemoryCache) Members(ctx context.Context, prefix, key string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	indexKey := prefixKey(prefix, key)
+	var members []string
+	now := time.Now()
+	for member, expireAt := range m.indexes[indexKey] {
+		// clean expired members
+		if expireAt.Before(now) {
+			delete(m.indexes[indexKey], member)
+			continue
+		}
+		members = append(members, member)
+	}
+	if len(m.indexes[indexKey]) == 0 {
+		delete(m.indexes, indexKey)
+	}
+	return members, nil
+}
+
+func (m *MemoryCache) Unindex(ctx context.Context, prefix, key string, members ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	indexKey := prefixKey(prefix, key)
+	if len(members) == 0 {
+		delete(m.indexes, indexKey)
+		return nil
+	}
+	for _, member := range members {
+		delete(m.indexes[indexKey], member)
+	}
+	if len(m.indexes[indexKey]) == 0 {
+		delete(m.indexes, indexKey)
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"os"
+	"strings"
+)
+
+var (
+	ErrKeyNotFound      = errors.New("signing key not found")
+	ErrKeyCanNotSign    = errors.New("signing key has no private key")
+	ErrUnsupportedKey   = errors.New("unsupported signing key")
+	ErrUnexpectedMethod = errors.New("unexpected signing method")
+	ErrInvalidKeyId     = errors.New("invalid signing key id")
+)
+
+// Key is a jwt signing key identified by kid.
+type Key struct {
+	// key id in jwt header
+	Id     string
+	Method jwt.SigningMethod
+	// key for signing, []byte for HMAC methods, crypto.Signer for others.
+	// it could be nil if the key is only used to verify.
+	Private any
+	// key for verifying, []byte for HMAC methods, crypto.PublicKey for others.
+	Public any
+}
+
+// NewHMACKey returns a HMAC key, the secret is used for both signing and verifying.
+func NewHMACKey(id string, method jwt.SigningMethod, secret string) Key {
+	return Key{Id: id, Method: method, Private: []byte(secret), Public: []byte(secret)}
+}
+
+// LoadKey loads key from pem files, public key will be derived from private key if publicFile is empty,
+// and private key could be empty if the key is only used to verify.
+func LoadKey(id string, method jwt.SigningMethod, privateFile, publicFile string) (Key, error) {
+	key := Key{Id: id, Method: method}
+	if privateFile != "" {
+		pemBytes, err := os.ReadFile(privateFile)
+		if err != nil {
+			return Key{}, err
+		}
+		key.Private, err = parsePrivateKey(method, pemBytes)
+		if err != nil {
+			return Key{}, err
+		}
+		key.Public = key.Private.(crypto.Signer).Public()
+	}
+	if publicFile != "" {
+		pemBytes, err := os.ReadFile(publicFile)
+		if err != nil {
+			return Key{}, err
+		}
+		key.Public, err = parsePublicKey(method, pemBytes)
+		if err != nil {
+			return Key{}, err
+		}
+	}
+	if key.Public == nil {
+		return Key{}, fmt.Errorf("%w: key %s has neither private nor public key", ErrUnsupportedKey, id)
+	}
+	return key, nil
+}
+
+func parsePrivateKey(method jwt.SigningMethod, pemBytes []byte) (any, error) {
+	switch method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		return jwt.ParseRSAPrivateKeyFromPEM(pemBytes)
+	case *jwt.SigningMethodECDSA:
+		return jwt.ParseECPrivateKeyFromPEM(pemBytes)
+	case *jwt.SigningMethodEd25519:
+		return jwt.ParseEdPrivateKeyFromPEM(pemBytes)
+	default:
+		return nil, fmt.Errorf("%w: %s could not be loaded from pem", ErrUnsupportedKey, method.Alg())
+	}
+}
+
+func parsePublicKey(method jwt.SigningMethod, pemBytes []byte) (any, error) {
+	switch method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		return jwt.ParseRSAPublicKeyFromPEM(pemBytes)
+	case *jwt.SigningMethodECDSA:
+		return jwt.ParseECPublicKeyFromPEM(pemBytes)
+	case *jwt.SigningMethodEd25519:
+		return jwt.ParseEdPublicKeyFromPEM(pemBytes)
+	default:
+		return nil, fmt.Errorf("%w: %s could not be loaded from pem", ErrUnsupportedKey, method.Alg())
+	}
+}
+
+// NewKeyRing returns a key ring, tokens are signed by the primary key, and could be verified by any key in ring.
+// It is useful for key rotation, the retired keys should be kept in ring until tokens signed by them are expired.
+func NewKeyRing(primary Key, others ...Key) *KeyRing {
+	ring := &KeyRing{primary: primary, keys: make(map[string]Key)}
+	for _, key := range append(others, primary) {
+		ring.keys[key.Id] = key
+	}
+	return ring
+}
+
+// CheckKeyIds returns error if any kid of keys is empty or duplicated, keys in a ring with multiple keys
+// must be identified by kid, otherwise tokens could be verified by a wrong key.
+func CheckKeyIds(keys ...Key) error {
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if key.Id == "" {
+			return fmt.Errorf("%w: kid is empty", ErrInvalidKeyId)
+		}
+		if _, ok := seen[key.Id]; ok {
+			return fmt.Errorf("%w: kid %s is duplicated", ErrInvalidKeyId, key.Id)
+		}
+		seen[key.Id] = struct{}{}
+	}
+	return nil
+}
+
+// KeyRing holds multiple signing keys
+type KeyRing struct {
+	primary Key
+	keys    map[string]Key
+}
+
+// Primary returns the key used to sign
+func (k *KeyRing) Primary() Key {
+	return k.primary
+}
+
+// Get returns the key matching the given kid
+func (k *KeyRing) Get(kid string) (Key, bool) {
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+// Methods returns algorithms of all keys in ring
+func (k *KeyRing) Methods() []string {
+	var methods []string
+	for _, key := range k.keys {
+		methods = append(methods, key.Method.Alg())
+	}
+	return methods
+}
+
+// sign signs the token with primary key, and sets kid in header
+func (k *KeyRing) sign(claims jwt.Claims) (*jwt.Token, string, error) {
+	if k.primary.Private == nil {
+		return nil, "", ErrKeyCanNotSign
+	}
+	token := jwt.NewWithClaims(k.primary.Method, claims)
+	if k.primary.Id != "" {
+		token.Header["kid"] = k.primary.Id
+	}
+	signed, err := token.SignedString(k.primary.Private)
+	if err != nil {
+		return nil, "", err
+	}
+	return token, signed, nil
+}
+
+// keyFunc finds the verifying key by kid in header
+func (k *KeyRing) keyFunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := k.Get(kid)
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	if key.Method.Alg() != token.Method.Alg() {
+		return nil, ErrUnexpectedMethod
+	}
+	return key.Public, nil
+}
+
+// JWK is json web key, see RFC 7517
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	// rsa
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// ecdsa and eddsa
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKSet is a set of json web key
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys in ring, symmetric keys will never be exposed.
+func (k *KeyRing) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	for _, key := range k.keys {
+		jwk, ok := toJWK(key)
+		if ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
+
+func toJWK(key Key) (JWK, bool) {
+	jwk := JWK{Use: "sig", Kid: key.Id, Alg: key.Method.Alg()}
+	switch pub := key.Public.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = b64(pub.N.Bytes())
+		jwk.E = b64(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = pub.Curve.Params().Name
+		jwk.X = b64(pub.X.FillBytes(make([]byte, size)))
+		jwk.Y = b64(pub.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = b64(pub)
+	default:
+		return JWK{}, false
+	}
+	return jwk, true
+}
+
+func b64(b []byte) string {
+	return strings.TrimRight(base64.URLEncoding.EncodeToString(b), "=")
+}
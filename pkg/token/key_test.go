@@ -0,0 +1,152 @@
+package token
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeKeyPair(t *testing.T, name string, private crypto.Signer) (string, string) {
+	dir := t.TempDir()
+	privateDer, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicDer, err := x509.MarshalPKIXPublicKey(private.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateFile := filepath.Join(dir, name+".pem")
+	publicFile := filepath.Join(dir, name+".pub.pem")
+	err = os.WriteFile(privateFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDer}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(publicFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDer}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return privateFile, publicFile
+}
+
+func TestResolver_Asymmetric(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	samples := []struct {
+		method  jwt.SigningMethod
+		private crypto.Signer
+		kty     string
+	}{
+		{jwt.SigningMethodRS256, rsaKey, "RSA"},
+		{jwt.SigningMethodES256, ecKey, "EC"},
+		{jwt.SigningMethodEdDSA, edKey, "OKP"},
+	}
+
+	ctx := context.Background()
+	for _, sample := range samples {
+		privateFile, _ := writeKeyPair(t, sample.method.Alg(), sample.private)
+		key, err := LoadKey(sample.method.Alg(), sample.method, privateFile, "")
+		if !assert.NoError(t, err) {
+			return
+		}
+		resolver := NewResolver(Options{AccessKeys: NewKeyRing(key)})
+		pair, err := resolver.Issue(ctx, map[string]any{"uid": "a"}, true)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, sample.method.Alg(), pair.Access.Token.Header["kid"])
+		access, err := resolver.VerifyAccess(ctx, pair.Access.Raw)
+		assert.NoError(t, err)
+		assert.Equal(t, "a", access.Claims.Subject)
+
+		jwks := resolver.JWKS()
+		if assert.Len(t, jwks.Keys, 1) {
+			assert.Equal(t, sample.kty, jwks.Keys[0].Kty)
+			assert.Equal(t, sample.method.Alg(), jwks.Keys[0].Alg)
+		}
+	}
+}
+
+func TestResolver_KeyRotation(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCache()
+
+	oldKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	newKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	oldPrivate, oldPublic := writeKeyPair(t, "old", oldKey)
+	newPrivate, _ := writeKeyPair(t, "new", newKey)
+
+	old, err := LoadKey("old", jwt.SigningMethodES256, oldPrivate, "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	oldResolver := NewResolver(Options{Cache: cache, AccessKeys: NewKeyRing(old)})
+	oldPair, err := oldResolver.Issue(ctx, map[string]any{"uid": "a"}, false)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// old key is retired, only public key is kept for verifying
+	retired, err := LoadKey("old", jwt.SigningMethodES256, "", oldPublic)
+	if !assert.NoError(t, err) {
+		return
+	}
+	current, err := LoadKey("new", jwt.SigningMethodES256, newPrivate, "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	resolver := NewResolver(Options{Cache: cache, AccessKeys: NewKeyRing(current, retired)})
+	_, err = resolver.VerifyAccess(ctx, oldPair.Access.Raw)
+	assert.NoError(t, err)
+
+	newPair, err := resolver.Issue(ctx, map[string]any{"uid": "a"}, false)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "new", newPair.Access.Token.Header["kid"])
+	_, err = oldResolver.VerifyAccess(ctx, newPair.Access.Raw)
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	assert.Len(t, resolver.JWKS().Keys, 2)
+
+	_, err = NewResolver(Options{AccessKeys: NewKeyRing(retired)}).Issue(ctx, map[string]any{"uid": "a"}, false)
+	assert.ErrorIs(t, err, ErrKeyCanNotSign)
+}
+
+func TestCheckKeyIds(t *testing.T) {
+	samples := []struct {
+		name string
+		keys []Key
+		err  error
+	}{
+		{"unique", []Key{NewHMACKey("a", jwt.SigningMethodHS512, "a"), NewHMACKey("b", jwt.SigningMethodHS512, "b")}, nil},
+		{"empty", []Key{NewHMACKey("a", jwt.SigningMethodHS512, "a"), NewHMACKey("", jwt.SigningMethodHS512, "b")}, ErrInvalidKeyId},
+		{"single empty", []Key{NewHMACKey("", jwt.SigningMethodHS512, "a")}, ErrInvalidKeyId},
+		{"duplicated", []Key{NewHMACKey("a", jwt.SigningMethodHS512, "a"), NewHMACKey("a", jwt.SigningMethodHS512, "b")}, ErrInvalidKeyId},
+	}
+	for _, sample := range samples {
+		err := CheckKeyIds(sample.keys...)
+		if sample.err == nil {
+			assert.NoError(t, err, sample.name)
+		} else {
+			assert.ErrorIs(t, err, sample.err, sample.name)
+		}
+	}
+}
+
+func TestKeyRing_HMACNotExposed(t *testing.T) {
+	ring := NewKeyRing(NewHMACKey("hs", jwt.SigningMethodHS512, "secret"))
+	assert.Empty(t, ring.JWKS().Keys)
+}
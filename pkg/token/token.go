@@ -6,9 +6,9 @@ import (
 	"github.com/ginx-contribs/ginx-server/pkg/toolset/idx"
 	"github.com/ginx-contribs/ginx-server/pkg/toolset/ts"
 	"github.com/ginx-contribs/ginx/pkg/resp/statuserr"
-	"github.com/ginx-contribs/jwtx"
 	"github.com/golang-jwt/jwt/v5"
-	"reflect"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -18,6 +18,7 @@ var (
 	ErrAccessTokenExpired     = errors.New("access token expired")
 	ErrTokenNotSupportRefresh = errors.New("token not support refresh")
 	ErrMisMatchTokenPair      = errors.New("mismatch token pair")
+	ErrRefreshTokenReused     = errors.New("refresh token reused")
 )
 
 // Options is configuration for token resolver
@@ -28,11 +29,19 @@ type Options struct {
 	Cache         Cache
 	AccessPrefix  string
 	RefreshPrefix string
+	SubjectPrefix string
+	FamilyPrefix  string
+	UsedPrefix    string
+
+	// key of subject in payload, tokens are indexed by subject so that they could be revoked together
+	SubjectKey string
 
 	// token issuer name
 	Issuer string
-	// access token signing key
 
+	// access token signing keys, it will be built from AccessSecret and AccessMethod if nil.
+	AccessKeys *KeyRing
+	// access token signing key
 	AccessSecret string
 	AccessMethod jwt.SigningMethod
 	// duration to expire access token
@@ -40,11 +49,16 @@ type Options struct {
 	// delay duration after expiration
 	AccessDelay time.Duration
 
-	// duration to expire refresh token
+	// refresh token signing keys, it will be built from RefreshSecret and RefreshMethod if nil.
+	RefreshKeys *KeyRing
+	// refresh token signing key
 	RefreshSecret string
 	RefreshMethod jwt.SigningMethod
-	// refresh token signing key
+	// duration to expire refresh token
 	RefreshExpired time.Duration
+	// whether to issue a new refresh token on every refresh, the old one is marked as used,
+	// and presenting a used refresh token revokes all tokens in its family.
+	RefreshRotation bool
 }
 
 // Claims consisted of jwt.RegisteredClaims and custom Payload.
@@ -53,9 +67,31 @@ type Claims struct {
 	Payload map[string]any
 	// whether to need refresh
 	Remember bool
+	// refresh tokens rotated from the same login are in a family
+	Family string `json:"fam,omitempty"`
+	// space-delimited scopes granted to the token, empty for first-party tokens
+	Scope string `json:"scope,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// Scopes returns the granted scopes
+func (c Claims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// HasScope returns whether the scope is granted to the token
+func (c Claims) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes(), scope)
+}
+
+// Grant describes to whom and for what the token is issued, zero value means a first-party token.
+type Grant struct {
+	// space-delimited scopes
+	Scope string
+	// clients which the token is issued to
+	Audience []string
+}
+
 // Token is Token Information, it could be from issued, parsed.
 type Token struct {
 	// raw token string
@@ -85,6 +121,18 @@ func NewResolver(options Options) *Resolver {
 	if options.RefreshPrefix == "" {
 		options.RefreshPrefix = "refresh"
 	}
+	if options.SubjectPrefix == "" {
+		options.SubjectPrefix = "subject"
+	}
+	if options.FamilyPrefix == "" {
+		options.FamilyPrefix = "family"
+	}
+	if options.UsedPrefix == "" {
+		options.UsedPrefix = "used"
+	}
+	if options.SubjectKey == "" {
+		options.SubjectKey = "uid"
+	}
 	if options.Issuer == "" {
 		options.Issuer = "ginx-server"
 	}
@@ -109,6 +157,12 @@ func NewResolver(options Options) *Resolver {
 	if options.RefreshExpired == 0 {
 		options.RefreshExpired = 144 * time.Hour
 	}
+	if options.AccessKeys == nil {
+		options.AccessKeys = NewKeyRing(NewHMACKey("", options.AccessMethod, options.AccessSecret))
+	}
+	if options.RefreshKeys == nil {
+		options.RefreshKeys = NewKeyRing(NewHMACKey("", options.RefreshMethod, options.RefreshSecret))
+	}
 	return &Resolver{opt: options}
 }
 
@@ -117,8 +171,18 @@ type Resolver struct {
 	opt Options
 }
 
+// JWKS returns the public keys for verifying access tokens
+func (r *Resolver) JWKS() JWKSet {
+	return r.opt.AccessKeys.JWKS()
+}
+
 // Issue return a new issued token pair with given payload, it will return refresh token if refresh is true.
 func (r *Resolver) Issue(ctx context.Context, payload map[string]any, refresh bool) (Pair, error) {
+	return r.IssueFor(ctx, payload, refresh, Grant{})
+}
+
+// IssueFor is same as Issue, but the tokens carry the given grant, it is used to issue tokens for third-party clients.
+func (r *Resolver) IssueFor(ctx context.Context, payload map[string]any, refresh bool, grant Grant) (Pair, error) {
 	var (
 		tokenCache = r.opt.Cache
 		issuedAt   = ts.Now()
@@ -127,8 +191,14 @@ func (r *Resolver) Issue(ctx context.Context, payload map[string]any, refresh bo
 		pair    Pair
 	)
 
+	// tokens rotated from this pair are in the same family
+	var family string
+	if refresh && r.opt.RefreshRotation {
+		family = r.opt.IdGen()
+	}
+
 	// issued access token
-	accessToken, err := r.createToken(r.opt.AccessSecret, refresh, payload, r.opt.AccessMethod, issuedAt, r.opt.AccessExpired)
+	accessToken, err := r.createToken(r.opt.AccessKeys, refresh, payload, "", grant, issuedAt, r.opt.AccessExpired)
 	if err != nil {
 		return Pair{}, err
 	}
@@ -141,6 +211,14 @@ func (r *Resolver) Issue(ctx context.Context, payload map[string]any, refresh bo
 	if err != nil {
 		return Pair{}, statuserr.InternalError(err)
 	}
+	err = r.index(ctx, accessToken.Claims.Subject, r.opt.AccessPrefix, accessToken.Claims.ID, accessTTL)
+	if err != nil {
+		return Pair{}, statuserr.InternalError(err)
+	}
+	err = r.indexFamily(ctx, family, r.opt.AccessPrefix, accessToken.Claims.ID, accessTTL)
+	if err != nil {
+		return Pair{}, statuserr.InternalError(err)
+	}
 	pair.Access = accessToken
 
 	// just return if no need to issue refresh-token
@@ -149,7 +227,7 @@ func (r *Resolver) Issue(ctx context.Context, payload map[string]any, refresh bo
 	}
 
 	// issue refresh-token
-	refreshToken, err := r.createToken(r.opt.RefreshSecret, refresh, payload, r.opt.RefreshMethod, issuedAt, r.opt.RefreshExpired)
+	refreshToken, err := r.createToken(r.opt.RefreshKeys, refresh, payload, family, grant, issuedAt, r.opt.RefreshExpired)
 	if err != nil {
 		return Pair{}, err
 	}
@@ -158,6 +236,14 @@ func (r *Resolver) Issue(ctx context.Context, payload map[string]any, refresh bo
 	if err != nil {
 		return Pair{}, statuserr.InternalError(err)
 	}
+	err = r.index(ctx, refreshToken.Claims.Subject, r.opt.RefreshPrefix, refreshToken.Claims.ID, r.opt.RefreshExpired)
+	if err != nil {
+		return Pair{}, statuserr.InternalError(err)
+	}
+	err = r.indexFamily(ctx, family, r.opt.RefreshPrefix, refreshToken.Claims.ID, r.opt.RefreshExpired)
+	if err != nil {
+		return Pair{}, statuserr.InternalError(err)
+	}
 	pair.Refresh = refreshToken
 
 	return pair, nil
@@ -167,17 +253,16 @@ func (r *Resolver) Issue(ctx context.Context, payload map[string]any, refresh bo
 func (r *Resolver) Refresh(ctx context.Context, accessTokenStr, refreshTokenStr string) (Pair, error) {
 	var (
 		now        = ts.Now()
-		pair       = Pair{}
 		tokenCache = r.opt.Cache
 	)
-	// parse access-token
-	refreshToken, err := r.VerifyRefresh(ctx, refreshTokenStr)
+	// parse refresh-token
+	pair, err := r.verifyRefreshForRenew(ctx, refreshTokenStr)
 	if err != nil {
 		return pair, err
 	}
-	pair.Refresh = refreshToken
+	refreshToken := pair.Refresh
 
-	// parse refresh-token
+	// parse access-token
 	accessToken, err := r.VerifyAccess(ctx, accessTokenStr)
 	if errors.Is(err, ErrAccessTokenExpired) {
 		if !accessToken.Claims.Remember {
@@ -204,13 +289,6 @@ func (r *Resolver) Refresh(ctx context.Context, accessTokenStr, refreshTokenStr
 		return pair, ErrMisMatchTokenPair
 	}
 
-	// issue a new access-token
-	newAccessToken, err := r.createToken(r.opt.AccessSecret, true, accessToken.Claims.Payload, r.opt.AccessMethod, now, r.opt.AccessExpired)
-	if err != nil {
-		return pair, err
-	}
-	pair.Access = newAccessToken
-
 	ttl, e, err := tokenCache.TTL(ctx, r.opt.AccessPrefix, accessToken.Claims.ID)
 	if !e {
 		return pair, ErrAccessTokenExpired
@@ -223,10 +301,86 @@ func (r *Resolver) Refresh(ctx context.Context, accessTokenStr, refreshTokenStr
 	if ttl >= 2*r.opt.AccessExpired {
 		ttl = 2 * r.opt.AccessExpired
 	}
+
+	return r.renew(ctx, pair, accessToken.Claims, ttl, now)
+}
+
+// RefreshWithoutAccess issues a new access token with the refresh token only, it is used by the clients
+// which do not keep access token, such as OAuth2 clients. The previous access token stays valid until it expires.
+func (r *Resolver) RefreshWithoutAccess(ctx context.Context, refreshTokenStr string) (Pair, error) {
+	pair, err := r.verifyRefreshForRenew(ctx, refreshTokenStr)
+	if err != nil {
+		return pair, err
+	}
+	return r.renew(ctx, pair, pair.Refresh.Claims, r.opt.AccessExpired, ts.Now())
+}
+
+// verifyRefreshForRenew verifies the refresh-token to be renewed, if a rotated refresh-token is presented again,
+// its family will be revoked.
+func (r *Resolver) verifyRefreshForRenew(ctx context.Context, refreshTokenStr string) (Pair, error) {
+	var pair Pair
+	refreshToken, err := r.VerifyRefresh(ctx, refreshTokenStr)
+	if errors.Is(err, ErrRefreshTokenExpired) && r.opt.RefreshRotation && refreshToken.Claims.ID != "" {
+		// the rotated refresh-token is presented again, it might be stolen
+		family, used, err := r.opt.Cache.Get(ctx, r.opt.UsedPrefix, refreshToken.Claims.ID)
+		if err != nil {
+			return pair, statuserr.InternalError(err)
+		} else if used {
+			pair.Refresh = refreshToken
+			return pair, r.revokeFamily(ctx, refreshToken.Claims.Subject, family)
+		}
+		return pair, ErrRefreshTokenExpired
+	} else if err != nil {
+		return pair, err
+	}
+	pair.Refresh = refreshToken
+	return pair, nil
+}
+
+// renew issues a new access-token which carries the payload and grant of the given claims, and associates it
+// with the refresh-token of pair.
+func (r *Resolver) renew(ctx context.Context, pair Pair, claims Claims, ttl time.Duration, now time.Time) (Pair, error) {
+	tokenCache := r.opt.Cache
+	refreshToken := pair.Refresh
+
+	// the rotated refresh-token keeps the rest life-time of the old one
+	refreshTTL := refreshToken.Claims.ExpiresAt.Sub(now)
+	if refreshTTL <= 0 {
+		return pair, ErrRefreshTokenExpired
+	}
+	family := refreshToken.Claims.Family
+	if family == "" {
+		family = refreshToken.Claims.ID
+	}
+	if r.opt.RefreshRotation {
+		// mark as used, only one of the concurrent refreshing could succeed
+		ok, err := tokenCache.SetNX(ctx, r.opt.UsedPrefix, refreshToken.Claims.ID, family, refreshTTL)
+		if err != nil {
+			return pair, statuserr.InternalError(err)
+		} else if !ok {
+			return pair, r.revokeFamily(ctx, refreshToken.Claims.Subject, family)
+		}
+	}
+
+	// issue a new access-token
+	newAccessToken, err := r.createToken(r.opt.AccessKeys, true, claims.Payload, "", grantOf(claims), now, r.opt.AccessExpired)
+	if err != nil {
+		return pair, err
+	}
+	pair.Access = newAccessToken
+
 	err = tokenCache.Set(ctx, r.opt.AccessPrefix, newAccessToken.Claims.ID, newAccessToken.Claims.ID, ttl)
 	if err != nil {
 		return pair, statuserr.InternalError(err)
 	}
+	err = r.index(ctx, newAccessToken.Claims.Subject, r.opt.AccessPrefix, newAccessToken.Claims.ID, ttl)
+	if err != nil {
+		return pair, statuserr.InternalError(err)
+	}
+
+	if r.opt.RefreshRotation {
+		return r.rotate(ctx, pair, family, refreshTTL, now)
+	}
 
 	// update token pair association
 	err = tokenCache.Set(ctx, r.opt.RefreshPrefix, refreshToken.Claims.ID, newAccessToken.Claims.ID, -1)
@@ -236,10 +390,151 @@ func (r *Resolver) Refresh(ctx context.Context, accessTokenStr, refreshTokenStr
 	return pair, nil
 }
 
+// rotate replaces the refresh-token of pair with a new one which is associated with the new access-token
+func (r *Resolver) rotate(ctx context.Context, pair Pair, family string, ttl time.Duration, now time.Time) (Pair, error) {
+	tokenCache := r.opt.Cache
+	oldRefresh := pair.Refresh
+
+	err := r.indexFamily(ctx, family, r.opt.AccessPrefix, pair.Access.Claims.ID, ttl)
+	if err != nil {
+		return pair, statuserr.InternalError(err)
+	}
+
+	newRefresh, err := r.createToken(r.opt.RefreshKeys, true, oldRefresh.Claims.Payload, family, grantOf(oldRefresh.Claims), now, ttl)
+	if err != nil {
+		return pair, err
+	}
+	err = tokenCache.Set(ctx, r.opt.RefreshPrefix, newRefresh.Claims.ID, pair.Access.Claims.ID, ttl)
+	if err != nil {
+		return pair, statuserr.InternalError(err)
+	}
+	err = r.index(ctx, newRefresh.Claims.Subject, r.opt.RefreshPrefix, newRefresh.Claims.ID, ttl)
+	if err != nil {
+		return pair, statuserr.InternalError(err)
+	}
+	err = r.indexFamily(ctx, family, r.opt.RefreshPrefix, newRefresh.Claims.ID, ttl)
+	if err != nil {
+		return pair, statuserr.InternalError(err)
+	}
+
+	// the old one is unable to refresh anymore
+	if err := tokenCache.Del(ctx, r.opt.RefreshPrefix, oldRefresh.Claims.ID); err != nil {
+		return pair, statuserr.InternalError(err)
+	}
+	member := prefixKey(r.opt.RefreshPrefix, oldRefresh.Claims.ID)
+	if oldRefresh.Claims.Subject != "" {
+		if err := tokenCache.Unindex(ctx, r.opt.SubjectPrefix, oldRefresh.Claims.Subject, member); err != nil {
+			return pair, statuserr.InternalError(err)
+		}
+	}
+	if err := tokenCache.Unindex(ctx, r.opt.FamilyPrefix, family, member); err != nil {
+		return pair, statuserr.InternalError(err)
+	}
+
+	pair.Refresh = newRefresh
+	return pair, nil
+}
+
+// revokeFamily revokes all tokens in the family when refresh-token reuse is detected, it always returns ErrRefreshTokenReused if succeeds.
+func (r *Resolver) revokeFamily(ctx context.Context, subject, family string) error {
+	tokenCache := r.opt.Cache
+	members, err := tokenCache.Members(ctx, r.opt.FamilyPrefix, family)
+	if err != nil {
+		return statuserr.InternalError(err)
+	}
+	for _, member := range members {
+		prefix, tokenId := splitKey(member)
+		if err := tokenCache.Del(ctx, prefix, tokenId); err != nil {
+			return statuserr.InternalError(err)
+		}
+	}
+	if err := tokenCache.Unindex(ctx, r.opt.FamilyPrefix, family); err != nil {
+		return statuserr.InternalError(err)
+	}
+	if subject != "" && len(members) > 0 {
+		if err := tokenCache.Unindex(ctx, r.opt.SubjectPrefix, subject, members...); err != nil {
+			return statuserr.InternalError(err)
+		}
+	}
+	return ErrRefreshTokenReused
+}
+
+// Revoke revokes the given token pair before it expires, refresh token could be zero value if it was not issued.
+func (r *Resolver) Revoke(ctx context.Context, access Token, refresh Token) error {
+	var (
+		tokenCache = r.opt.Cache
+		subject    = access.Claims.Subject
+		members    []string
+	)
+
+	if access.Claims.ID != "" {
+		if err := tokenCache.Del(ctx, r.opt.AccessPrefix, access.Claims.ID); err != nil {
+			return statuserr.InternalError(err)
+		}
+		members = append(members, prefixKey(r.opt.AccessPrefix, access.Claims.ID))
+	}
+
+	if refresh.Claims.ID != "" {
+		if subject != "" && refresh.Claims.Subject != subject {
+			return ErrMisMatchTokenPair
+		}
+		subject = refresh.Claims.Subject
+
+		// the access-token currently associated with refresh-token might be different after refreshing
+		accessId, found, err := tokenCache.Get(ctx, r.opt.RefreshPrefix, refresh.Claims.ID)
+		if err != nil {
+			return statuserr.InternalError(err)
+		} else if found && accessId != access.Claims.ID {
+			if err := tokenCache.Del(ctx, r.opt.AccessPrefix, accessId); err != nil {
+				return statuserr.InternalError(err)
+			}
+			members = append(members, prefixKey(r.opt.AccessPrefix, accessId))
+		}
+
+		if err := tokenCache.Del(ctx, r.opt.RefreshPrefix, refresh.Claims.ID); err != nil {
+			return statuserr.InternalError(err)
+		}
+		members = append(members, prefixKey(r.opt.RefreshPrefix, refresh.Claims.ID))
+
+		if refresh.Claims.Family != "" {
+			if err := tokenCache.Unindex(ctx, r.opt.FamilyPrefix, refresh.Claims.Family); err != nil {
+				return statuserr.InternalError(err)
+			}
+		}
+	}
+
+	if subject == "" || len(members) == 0 {
+		return nil
+	}
+	if err := tokenCache.Unindex(ctx, r.opt.SubjectPrefix, subject, members...); err != nil {
+		return statuserr.InternalError(err)
+	}
+	return nil
+}
+
+// RevokeAllForSubject revokes all tokens issued for the given subject.
+func (r *Resolver) RevokeAllForSubject(ctx context.Context, subject string) error {
+	tokenCache := r.opt.Cache
+	members, err := tokenCache.Members(ctx, r.opt.SubjectPrefix, subject)
+	if err != nil {
+		return statuserr.InternalError(err)
+	}
+	for _, member := range members {
+		prefix, tokenId := splitKey(member)
+		if err := tokenCache.Del(ctx, prefix, tokenId); err != nil {
+			return statuserr.InternalError(err)
+		}
+	}
+	if err := tokenCache.Unindex(ctx, r.opt.SubjectPrefix, subject); err != nil {
+		return statuserr.InternalError(err)
+	}
+	return nil
+}
+
 // VerifyAccess verify the access-token if is valid.
 func (r *Resolver) VerifyAccess(ctx context.Context, tokenString string) (Token, error) {
 	// check if is valid
-	accessToken, err := r.parseToken(tokenString, r.opt.AccessSecret, r.opt.AccessMethod)
+	accessToken, err := r.parseToken(tokenString, r.opt.AccessKeys)
 	// if it is expired
 	if errors.Is(err, jwt.ErrTokenExpired) {
 		// token need to refresh
@@ -262,7 +557,7 @@ func (r *Resolver) VerifyAccess(ctx context.Context, tokenString string) (Token,
 
 func (r *Resolver) VerifyRefresh(ctx context.Context, tokenString string) (Token, error) {
 	// check if is valid
-	refreshToken, err := r.parseToken(tokenString, r.opt.RefreshSecret, r.opt.RefreshMethod)
+	refreshToken, err := r.parseToken(tokenString, r.opt.RefreshKeys)
 	if errors.Is(err, jwt.ErrTokenExpired) {
 		return refreshToken, ErrRefreshTokenExpired
 	} else if err != nil {
@@ -277,43 +572,77 @@ func (r *Resolver) VerifyRefresh(ctx context.Context, tokenString string) (Token
 	return refreshToken, nil
 }
 
-// issue a new token with given args
-func (r *Resolver) createToken(secret string, refresh bool, payload map[string]any, method jwt.SigningMethod, at time.Time, ttl time.Duration) (Token, error) {
+// index associates token with its subject
+func (r *Resolver) index(ctx context.Context, subject, prefix, tokenId string, ttl time.Duration) error {
+	if subject == "" {
+		return nil
+	}
+	return r.opt.Cache.Index(ctx, r.opt.SubjectPrefix, subject, prefixKey(prefix, tokenId), ttl)
+}
+
+// indexFamily associates token with its family, it does nothing if family is empty
+func (r *Resolver) indexFamily(ctx context.Context, family, prefix, tokenId string, ttl time.Duration) error {
+	if family == "" {
+		return nil
+	}
+	return r.opt.Cache.Index(ctx, r.opt.FamilyPrefix, family, prefixKey(prefix, tokenId), ttl)
+}
+
+// splitKey is the reverse of prefixKey
+func splitKey(key string) (string, string) {
+	i := strings.LastIndex(key, ":")
+	if i < 0 {
+		return "", key
+	}
+	return key[:i], key[i+1:]
+}
+
+// issue a new token with given args, it is signed by the primary key of ring
+func (r *Resolver) createToken(keys *KeyRing, refresh bool, payload map[string]any, family string, grant Grant, at time.Time, ttl time.Duration) (Token, error) {
 	// generate unique id
 	id := r.opt.IdGen()
+	subject, _ := payload[r.opt.SubjectKey].(string)
 	claims := Claims{
 		Remember: refresh,
 		Payload:  payload,
+		Family:   family,
+		Scope:    grant.Scope,
 		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
 			Issuer:    r.opt.Issuer,
+			Audience:  grant.Audience,
 			IssuedAt:  jwt.NewNumericDate(at),
 			ExpiresAt: jwt.NewNumericDate(at.Add(ttl)),
 			ID:        id,
 		},
 	}
 	// issue token
-	issuedToken, err := jwtx.IssueWithClaims([]byte(secret), method, claims)
+	issuedToken, signedString, err := keys.sign(claims)
 	if err != nil {
 		return Token{}, err
 	}
 	return Token{
-		Raw:    issuedToken.SignedString,
+		Raw:    signedString,
 		Claims: claims,
-		Token:  issuedToken.Token,
+		Token:  issuedToken,
 	}, nil
 }
 
+// grantOf returns the grant carried by claims
+func grantOf(claims Claims) Grant {
+	return Grant{Scope: claims.Scope, Audience: claims.Audience}
+}
+
 // check a token if is valid, then return token info.
-func (r *Resolver) parseToken(tokenString, secret string, method jwt.SigningMethod) (Token, error) {
+func (r *Resolver) parseToken(tokenString string, keys *KeyRing) (Token, error) {
 	var token Token
-	verifiedToken, err := jwtx.VerifyWithClaims(tokenString, []byte(secret), method, &Claims{})
+	claims := &Claims{}
+	verifiedToken, err := jwt.ParseWithClaims(tokenString, claims, keys.keyFunc, jwt.WithValidMethods(keys.Methods()))
 	if verifiedToken != nil {
 		token = Token{
-			Raw:   verifiedToken.SignedString,
-			Token: verifiedToken.Token,
-		}
-		if !reflect.ValueOf(verifiedToken.Claims).IsNil() {
-			token.Claims = *verifiedToken.Claims.(*Claims)
+			Raw:    tokenString,
+			Token:  verifiedToken,
+			Claims: *claims,
 		}
 	}
 	return token, err
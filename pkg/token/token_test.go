@@ -131,3 +131,165 @@ func TestResolver_Refresh(t *testing.T) {
 		refresh = newRefresh
 	}
 }
+
+func TestResolver_Revoke(t *testing.T) {
+	ctx := context.Background()
+	resolver := NewResolver(Options{})
+	pair, err := resolver.Issue(ctx, map[string]any{"uid": "a"}, true)
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.EqualValues(t, "a", pair.Access.Claims.Subject) {
+		return
+	}
+	err = resolver.Revoke(ctx, pair.Access, pair.Refresh)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = resolver.VerifyAccess(ctx, pair.Access.Raw)
+	assert.ErrorIs(t, err, ErrAccessTokenExpired)
+	_, err = resolver.VerifyRefresh(ctx, pair.Refresh.Raw)
+	assert.ErrorIs(t, err, ErrRefreshTokenExpired)
+}
+
+func TestResolver_Revoke_AfterRefresh(t *testing.T) {
+	ctx := context.Background()
+	resolver := NewResolver(Options{})
+	pair, err := resolver.Issue(ctx, map[string]any{"uid": "a"}, true)
+	if !assert.NoError(t, err) {
+		return
+	}
+	newPair, err := resolver.Refresh(ctx, pair.Access.Raw, pair.Refresh.Raw)
+	if !assert.NoError(t, err) {
+		return
+	}
+	// revoke with the stale access token, the refreshed one should be revoked too
+	err = resolver.Revoke(ctx, pair.Access, pair.Refresh)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = resolver.VerifyAccess(ctx, newPair.Access.Raw)
+	assert.ErrorIs(t, err, ErrAccessTokenExpired)
+}
+
+func TestResolver_RevokeAllForSubject(t *testing.T) {
+	ctx := context.Background()
+	resolver := NewResolver(Options{})
+
+	var pairs []Pair
+	for range 3 {
+		pair, err := resolver.Issue(ctx, map[string]any{"uid": "a"}, true)
+		if !assert.NoError(t, err) {
+			return
+		}
+		pairs = append(pairs, pair)
+	}
+	other, err := resolver.Issue(ctx, map[string]any{"uid": "b"}, false)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	err = resolver.RevokeAllForSubject(ctx, "a")
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, pair := range pairs {
+		_, err = resolver.VerifyAccess(ctx, pair.Access.Raw)
+		assert.ErrorIs(t, err, ErrAccessTokenExpired)
+		_, err = resolver.VerifyRefresh(ctx, pair.Refresh.Raw)
+		assert.ErrorIs(t, err, ErrRefreshTokenExpired)
+	}
+	_, err = resolver.VerifyAccess(ctx, other.Access.Raw)
+	assert.NoError(t, err)
+}
+
+func TestResolver_RefreshRotation(t *testing.T) {
+	ctx := context.Background()
+	resolver := NewResolver(Options{RefreshRotation: true})
+	pair, err := resolver.Issue(ctx, map[string]any{"uid": "a"}, true)
+	if !assert.NoError(t, err) {
+		return
+	}
+	newPair, err := resolver.Refresh(ctx, pair.Access.Raw, pair.Refresh.Raw)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NotEqual(t, pair.Refresh.Claims.ID, newPair.Refresh.Claims.ID)
+	assert.Equal(t, pair.Refresh.Claims.Family, newPair.Refresh.Claims.Family)
+	assert.Equal(t, pair.Refresh.Claims.ExpiresAt, newPair.Refresh.Claims.ExpiresAt)
+
+	_, err = resolver.VerifyRefresh(ctx, pair.Refresh.Raw)
+	assert.ErrorIs(t, err, ErrRefreshTokenExpired)
+
+	newPair, err = resolver.Refresh(ctx, newPair.Access.Raw, newPair.Refresh.Raw)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = resolver.VerifyAccess(ctx, newPair.Access.Raw)
+	assert.NoError(t, err)
+}
+
+func TestResolver_RefreshReuse(t *testing.T) {
+	ctx := context.Background()
+	resolver := NewResolver(Options{RefreshRotation: true})
+	pair, err := resolver.Issue(ctx, map[string]any{"uid": "a"}, true)
+	if !assert.NoError(t, err) {
+		return
+	}
+	other, err := resolver.Issue(ctx, map[string]any{"uid": "a"}, true)
+	if !assert.NoError(t, err) {
+		return
+	}
+	newPair, err := resolver.Refresh(ctx, pair.Access.Raw, pair.Refresh.Raw)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// present the rotated refresh-token again
+	_, err = resolver.Refresh(ctx, pair.Access.Raw, pair.Refresh.Raw)
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	// the whole family is revoked
+	_, err = resolver.VerifyAccess(ctx, newPair.Access.Raw)
+	assert.ErrorIs(t, err, ErrAccessTokenExpired)
+	_, err = resolver.VerifyRefresh(ctx, newPair.Refresh.Raw)
+	assert.ErrorIs(t, err, ErrRefreshTokenExpired)
+
+	// tokens in other family are still valid
+	_, err = resolver.VerifyAccess(ctx, other.Access.Raw)
+	assert.NoError(t, err)
+	_, err = resolver.VerifyRefresh(ctx, other.Refresh.Raw)
+	assert.NoError(t, err)
+}
+
+func TestResolver_Grant(t *testing.T) {
+	ctx := context.Background()
+	resolver := NewResolver(Options{RefreshRotation: true})
+	grant := Grant{Scope: "profile email", Audience: []string{"client"}}
+	pair, err := resolver.IssueFor(ctx, map[string]any{"uid": "a"}, true, grant)
+	if !assert.NoError(t, err) {
+		return
+	}
+	access, err := resolver.VerifyAccess(ctx, pair.Access.Raw)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, access.Claims.HasScope("email"))
+	assert.False(t, access.Claims.HasScope("admin"))
+	assert.EqualValues(t, grant.Audience, access.Claims.Audience)
+
+	// grant is kept after refreshing
+	newPair, err := resolver.RefreshWithoutAccess(ctx, pair.Refresh.Raw)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, grant.Scope, newPair.Access.Claims.Scope)
+	assert.Equal(t, grant.Scope, newPair.Refresh.Claims.Scope)
+	assert.EqualValues(t, grant.Audience, newPair.Refresh.Claims.Audience)
+	_, err = resolver.VerifyAccess(ctx, newPair.Access.Raw)
+	assert.NoError(t, err)
+
+	// the rotated refresh-token could not be used again
+	_, err = resolver.RefreshWithoutAccess(ctx, pair.Refresh.Raw)
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+}
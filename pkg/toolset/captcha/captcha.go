@@ -17,3 +17,12 @@ func GenCaptcha(n int) string {
 	}
 	return w.String()
 }
+
+// GenCode generates code with given length, each character is picked from alphabet randomly.
+func GenCode(n int, alphabet string) string {
+	var w strings.Builder
+	for range n {
+		w.WriteByte(alphabet[rand.IntN(len(alphabet))])
+	}
+	return w.String()
+}
@@ -41,3 +41,15 @@ func TestGenCaptcha_Conflict(t *testing.T) {
 		t.Logf("len: %d\tn: %10d\tconflicts: %10d", sample.l, sample.n, conflicts)
 	}
 }
+
+func TestGenCode(t *testing.T) {
+	code := GenCode(6, "0123456789")
+	if len(code) != 6 {
+		t.Fatalf("expected length 6, got %q", code)
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			t.Fatalf("unexpected character in %q", code)
+		}
+	}
+}
@@ -22,3 +22,11 @@ func GetLoginUserToken(ctx *gin.Context) (*token.Token, bool) {
 	}
 	return tokenInfo, true
 }
+
+// IsDelegated returns whether the token is delegated to oauth2 clients or is a personal access token,
+// which is restricted by granted scopes
+func IsDelegated(tokenInfo *token.Token) bool {
+	_, client := tokenInfo.Claims.Payload[systype.ClientPayloadKey]
+	_, pat := tokenInfo.Claims.Payload[systype.PersonalTokenPayloadKey]
+	return client || pat
+}
@@ -1,6 +1,8 @@
 package idx
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"github.com/google/uuid"
 	"github.com/oklog/ulid/v2"
 )
@@ -15,3 +17,13 @@ func UUID() string {
 func ULID() string {
 	return ulid.Make().String()
 }
+
+// Secret returns n bytes from crypto/rand encoded in url-safe base64 without padding, or panics.
+// It is suitable for unguessable tokens.
+func Secret(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -42,3 +42,11 @@ func TestULID(t *testing.T) {
 	ulid := ULID()
 	t.Log(ulid)
 }
+
+func TestSecret(t *testing.T) {
+	secret := Secret(32)
+	if len(secret) != 43 {
+		t.Fatalf("expected length 43, got %d", len(secret))
+	}
+	t.Log(secret)
+}
@@ -0,0 +1,139 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSecret = errors.New("invalid totp secret")
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Options is configuration for TOTP, see RFC 6238
+type Options struct {
+	// number of digits of code
+	Digits int
+	// time step of code
+	Period time.Duration
+	// number of steps allowed before and after the current one, to tolerate clock drift
+	Skew int
+	// length of the random secret in bytes
+	SecretLen int
+}
+
+// New returns a TOTP with HMAC-SHA1, zero options will be replaced by the values which are compatible with most authenticator apps.
+func New(options Options) *TOTP {
+	if options.Digits == 0 {
+		options.Digits = 6
+	}
+	if options.Period == 0 {
+		options.Period = 30 * time.Second
+	}
+	if options.Skew == 0 {
+		options.Skew = 1
+	}
+	if options.SecretLen == 0 {
+		options.SecretLen = 20
+	}
+	return &TOTP{opt: options}
+}
+
+// TOTP generates and validates time-based one-time passwords
+type TOTP struct {
+	opt Options
+}
+
+// Secret returns a new random secret in base32 without padding
+func (t *TOTP) Secret() (string, error) {
+	secret := make([]byte, t.opt.SecretLen)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(secret), nil
+}
+
+// URI returns the otpauth uri which could be encoded as qrcode for authenticator apps
+func (t *TOTP) URI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(t.opt.Digits))
+	query.Set("period", fmt.Sprint(int(t.opt.Period.Seconds())))
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+// Step returns the time step counter of the given time
+func (t *TOTP) Step(at time.Time) int64 {
+	return at.Unix() / int64(t.opt.Period.Seconds())
+}
+
+// Code returns the code of the given time
+func (t *TOTP) Code(secret string, at time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return t.code(key, t.Step(at)), nil
+}
+
+// Validate checks the code is valid at the given time, and returns the step it matches,
+// callers should reject the steps that have been used to prevent replay.
+func (t *TOTP) Validate(secret, code string, at time.Time) (int64, bool, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(code) != t.opt.Digits {
+		return 0, false, nil
+	}
+	current := t.Step(at)
+	for i := -t.opt.Skew; i <= t.opt.Skew; i++ {
+		step := current + int64(i)
+		if subtle.ConstantTimeCompare([]byte(t.code(key, step)), []byte(code)) == 1 {
+			return step, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// code implements HOTP in RFC 4226
+func (t *TOTP) code(key []byte, counter int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for range t.opt.Digits {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", t.opt.Digits, value%mod)
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	return key, nil
+}
@@ -0,0 +1,70 @@
+package totp
+
+import (
+	"encoding/base32"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+// test vectors from RFC 6238 Appendix B
+func TestTOTP_RFC6238(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	totp := New(Options{Digits: 8})
+
+	samples := []struct {
+		unix int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+	for _, sample := range samples {
+		code, err := totp.Code(secret, time.Unix(sample.unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, sample.code, code)
+	}
+}
+
+func TestTOTP_Validate(t *testing.T) {
+	totp := New(Options{})
+	secret, err := totp.Secret()
+	if !assert.NoError(t, err) {
+		return
+	}
+	now := time.Now()
+	code, err := totp.Code(secret, now)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	step, ok, err := totp.Validate(secret, code, now.Add(-30*time.Second))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, totp.Step(now), step)
+
+	_, ok, err = totp.Validate(secret, code, now.Add(90*time.Second))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = totp.Validate(secret, "12345", now)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = totp.Validate("!!!", code, now)
+	assert.ErrorIs(t, err, ErrInvalidSecret)
+}
+
+func TestTOTP_URI(t *testing.T) {
+	totp := New(Options{})
+	uri := totp.URI("ginx", "jack", "JBSWY3DPEHPK3PXP")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/ginx:jack?"))
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "period=30")
+	t.Log(uri)
+}